@@ -32,6 +32,9 @@ func initDS() (*dataSources, error) {
 		return nil, fmt.Errorf("error opening db: %w", err)
 	}
 
+	// Accounts created before email verification existed count as verified
+	backfillEmailVerified := !db.Migrator().HasColumn(&model.User{}, "EmailVerified")
+
 	// Migrate models and setup join tables
 	if err := db.AutoMigrate(
 		&model.User{},
@@ -41,10 +44,23 @@ func initDS() (*dataSources, error) {
 		&model.DMMember{},
 		&model.Message{},
 		&model.Attachment{},
+		&model.AccessToken{},
+		&model.Webhook{},
+		&model.EventWebhook{},
+		&model.EventDelivery{},
+		&model.Command{},
+		&model.UserIdentity{},
+		&model.DataExport{},
 	); err != nil {
 		return nil, fmt.Errorf("error migrating models: %w", err)
 	}
 
+	if backfillEmailVerified {
+		if err := db.Exec("UPDATE users SET email_verified = true").Error; err != nil {
+			return nil, fmt.Errorf("error verifying existing users: %w", err)
+		}
+	}
+
 	if err := db.SetupJoinTable(&model.Guild{}, "Members", &model.Member{}); err != nil {
 		return nil, fmt.Errorf("error creating join table: %w", err)
 	}
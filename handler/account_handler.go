@@ -5,11 +5,13 @@ import (
 	"github.com/gin-gonic/gin"
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/go-ozzo/ozzo-validation/v4/is"
+	"github.com/sentrionic/valkyrie/model"
 	"github.com/sentrionic/valkyrie/model/apperrors"
 	"log"
 	"mime/multipart"
 	"net/http"
 	"strings"
+	"time"
 )
 
 /*
@@ -65,7 +67,8 @@ func (r *editReq) sanitize() {
 	r.Email = strings.ToLower(r.Email)
 }
 
-// Edit handler edits the users account details
+// Edit handler edits the users account details.
+// A new email only takes effect once it got confirmed.
 // Edit godoc
 // @Tags Account
 // @Summary Update Current User
@@ -102,15 +105,16 @@ func (h *Handler) Edit(c *gin.Context) {
 
 	authUser.Username = req.Username
 
-	// New email, check if it's unique
-	if authUser.Email != req.Email {
+	// New email, check if it's unique. The email only changes
+	// after the user confirmed it from the new address
+	emailChanged := authUser.Email != req.Email
+	if emailChanged {
 		inUse := h.userService.IsEmailAlreadyInUse(req.Email)
 
 		if inUse {
 			toFieldErrorResponse(c, "Email", apperrors.DuplicateEmail)
 			return
 		}
-		authUser.Email = req.Email
 	}
 
 	if req.Image != nil {
@@ -149,15 +153,120 @@ func (h *Handler) Edit(c *gin.Context) {
 		return
 	}
 
+	// Send the confirmation link to the new email
+	if emailChanged {
+		if err = h.userService.RequestEmailChange(c.Request.Context(), authUser, req.Email); err != nil {
+			log.Printf("Failed to request email change for user: %v\n%v", authUser.ID, err)
+			e := apperrors.NewInternal()
+			c.JSON(e.Status(), gin.H{
+				"error": e,
+			})
+			return
+		}
+	}
+
 	c.JSON(http.StatusOK, authUser)
 }
 
+type emailChangeReq struct {
+	// The token the user got from the email.
+	Token string `json:"token"`
+} //@name EmailChangeRequest
+
+func (r emailChangeReq) validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Token, validation.Required),
+	)
+}
+
+func (r *emailChangeReq) sanitize() {
+	r.Token = strings.TrimSpace(r.Token)
+}
+
+// ConfirmEmail changes the user's email to the new address with the token
+// sent to it. The previous address gets a link to revert the change.
+// ConfirmEmail godoc
+// @Tags Account
+// @Summary Confirm Email Change
+// @Accept  json
+// @Produce  json
+// @Param request body emailChangeReq true "Confirm Email"
+// @Success 200 {object} model.User
+// @Failure 400 {object} model.ErrorsResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /account/confirm-email [post]
+func (h *Handler) ConfirmEmail(c *gin.Context) {
+	var req emailChangeReq
+
+	if valid := bindData(c, &req); !valid {
+		return
+	}
+
+	req.sanitize()
+
+	user, err := h.userService.ConfirmEmailChange(c.Request.Context(), req.Token)
+
+	if err != nil {
+		emailChangeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// RevertEmail restores the previous email of the user with the token
+// sent to the previous address
+// RevertEmail godoc
+// @Tags Account
+// @Summary Revert Email Change
+// @Accept  json
+// @Produce  json
+// @Param request body emailChangeReq true "Revert Email"
+// @Success 200 {object} model.User
+// @Failure 400 {object} model.ErrorsResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /account/revert-email [post]
+func (h *Handler) RevertEmail(c *gin.Context) {
+	var req emailChangeReq
+
+	if valid := bindData(c, &req); !valid {
+		return
+	}
+
+	req.sanitize()
+
+	user, err := h.userService.RevertEmailChange(c.Request.Context(), req.Token)
+
+	if err != nil {
+		emailChangeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// emailChangeError writes the response for a failed email change
+func emailChangeError(c *gin.Context, err error) {
+	switch err.Error() {
+	case apperrors.NewBadRequest(apperrors.InvalidEmailChangeToken).Error():
+		toFieldErrorResponse(c, "Token", apperrors.InvalidEmailChangeToken)
+	case apperrors.NewBadRequest(apperrors.DuplicateEmail).Error():
+		toFieldErrorResponse(c, "Email", apperrors.DuplicateEmail)
+	default:
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+	}
+}
+
 type changeRequest struct {
 	CurrentPassword string `json:"currentPassword"`
 	// Min 6, max 150 characters.
 	NewPassword string `json:"newPassword"`
 	// Must be the same as the newPassword value.
 	ConfirmNewPassword string `json:"confirmNewPassword"`
+	// Authenticator or recovery code. Required if two-factor authentication is enabled.
+	Code string `json:"code"`
 } //@name ChangePasswordRequest
 
 func (r changeRequest) validate() error {
@@ -172,6 +281,7 @@ func (r *changeRequest) sanitize() {
 	r.CurrentPassword = strings.TrimSpace(r.CurrentPassword)
 	r.NewPassword = strings.TrimSpace(r.NewPassword)
 	r.ConfirmNewPassword = strings.TrimSpace(r.ConfirmNewPassword)
+	r.Code = strings.TrimSpace(r.Code)
 }
 
 // ChangePassword handler changes the user's password
@@ -213,14 +323,267 @@ func (h *Handler) ChangePassword(c *gin.Context) {
 		return
 	}
 
-	err = h.userService.ChangePassword(req.CurrentPassword, req.NewPassword, authUser)
+	err = h.userService.ChangePassword(c.Request.Context(), req.CurrentPassword, req.NewPassword, req.Code, authUser)
+
+	if err != nil {
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
+	// Log out all other devices
+	if err = h.userService.RevokeOtherLoginSessions(c.Request.Context(), userId, c.GetString("sessionId")); err != nil {
+		log.Printf("Failed to revoke the sessions of user: %v\n%v", userId, err)
+	}
+
+	c.JSON(http.StatusOK, true)
+}
+
+type deleteAccountReq struct {
+	Password string `json:"password"`
+	// Authenticator or recovery code. Required if two-factor authentication is enabled.
+	Code string `json:"code"`
+} //@name DeleteAccountRequest
+
+func (r deleteAccountReq) validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Password, validation.Required),
+	)
+}
+
+func (r *deleteAccountReq) sanitize() {
+	r.Password = strings.TrimSpace(r.Password)
+	r.Code = strings.TrimSpace(r.Code)
+}
+
+// DeleteAccount schedules the deletion of the current user's account and logs them out
+// on all devices. Logging in during the grace period cancels the deletion.
+// Afterwards the user leaves all guilds, owned guilds get passed on to the longest
+// standing member or deleted, and their messages are shown as from a deleted user.
+// DeleteAccount godoc
+// @Tags Account
+// @Summary Delete Current User's Account
+// @Accept json
+// @Produce  json
+// @Param request body deleteAccountReq true "Delete Account"
+// @Success 200 {object} model.Success
+// @Failure 400 {object} model.ErrorsResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /account [delete]
+func (h *Handler) DeleteAccount(c *gin.Context) {
+	userId := c.MustGet("userId").(string)
+	var req deleteAccountReq
+
+	if ok := bindData(c, &req); !ok {
+		return
+	}
+
+	req.sanitize()
+
+	authUser, err := h.userService.Get(userId)
 
 	if err != nil {
+		e := apperrors.NewAuthorization(apperrors.InvalidSession)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	if err = h.userService.ScheduleDeletion(c.Request.Context(), authUser, req.Password, req.Code); err != nil {
 		c.JSON(apperrors.Status(err), gin.H{
 			"error": err,
 		})
 		return
 	}
 
+	clearUserSession(c)
+
 	c.JSON(http.StatusOK, true)
 }
+
+type presenceReq struct {
+	// online, idle, dnd or invisible
+	Status string `json:"status"`
+	// Max 128 characters. Null removes the custom status
+	CustomStatus *string `json:"customStatus"`
+	// Max 32 characters
+	Emoji *string `json:"emoji"`
+	// The date the custom status gets removed. Optional
+	ExpiresAt *time.Time `json:"expiresAt"`
+} //@name PresenceRequest
+
+func (r presenceReq) validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Status, validation.Required, validation.In(
+			model.OnlineStatus,
+			model.IdleStatus,
+			model.DoNotDisturbStatus,
+			model.InvisibleStatus,
+		)),
+		validation.Field(&r.CustomStatus, validation.NilOrNotEmpty, validation.Length(1, 128)),
+		validation.Field(&r.Emoji, validation.NilOrNotEmpty, validation.Length(1, 32)),
+		validation.Field(&r.ExpiresAt, validation.Min(time.Now())),
+	)
+}
+
+func (r *presenceReq) sanitize() {
+	r.Status = strings.TrimSpace(r.Status)
+	r.Status = strings.ToLower(r.Status)
+
+	if r.CustomStatus != nil {
+		text := strings.TrimSpace(*r.CustomStatus)
+		r.CustomStatus = &text
+	}
+}
+
+// UpdatePresence handler changes the user's presence status and custom status
+// UpdatePresence godoc
+// @Tags Account
+// @Summary Update Current User's Presence
+// @Accept json
+// @Produce  json
+// @Param request body presenceReq true "Update Presence"
+// @Success 200 {object} model.User
+// @Failure 400 {object} model.ErrorsResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /account/presence [put]
+func (h *Handler) UpdatePresence(c *gin.Context) {
+	userId := c.MustGet("userId").(string)
+	var req presenceReq
+
+	if ok := bindData(c, &req); !ok {
+		return
+	}
+
+	req.sanitize()
+
+	authUser, err := h.userService.Get(userId)
+
+	if err != nil {
+		e := apperrors.NewAuthorization(apperrors.InvalidSession)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	authUser.Status = req.Status
+	authUser.CustomStatus = req.CustomStatus
+	authUser.CustomStatusEmoji = req.Emoji
+	authUser.CustomStatusExpiresAt = req.ExpiresAt
+
+	// Without any text or emoji there is no custom status to expire
+	if req.CustomStatus == nil && req.Emoji == nil {
+		authUser.CustomStatusExpiresAt = nil
+	}
+
+	// Whether the user appears online depends on their live sessions
+	if err = h.userService.UpdatePresence(c.Request.Context(), authUser); err != nil {
+		e := apperrors.NewInternal()
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	// Emit the new presence to the user's friends and guilds
+	h.socketService.EmitPresenceUpdate(authUser)
+
+	c.JSON(http.StatusOK, authUser)
+}
+
+type privacyReq struct {
+	// everyone, guild_members or friends
+	DMPrivacy string `json:"dmPrivacy"`
+} //@name PrivacyRequest
+
+func (r privacyReq) validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.DMPrivacy, validation.Required, validation.In(
+			model.DMPrivacyEveryone,
+			model.DMPrivacyGuildMembers,
+			model.DMPrivacyFriends,
+		)),
+	)
+}
+
+func (r *privacyReq) sanitize() {
+	r.DMPrivacy = strings.TrimSpace(r.DMPrivacy)
+	r.DMPrivacy = strings.ToLower(r.DMPrivacy)
+}
+
+// UpdatePrivacy handler changes who can start a DM with the current user.
+// Friends can always start a DM.
+// UpdatePrivacy godoc
+// @Tags Account
+// @Summary Update Current User's Privacy Settings
+// @Accept json
+// @Produce  json
+// @Param request body privacyReq true "Update Privacy"
+// @Success 200 {object} model.User
+// @Failure 400 {object} model.ErrorsResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /account/privacy [put]
+func (h *Handler) UpdatePrivacy(c *gin.Context) {
+	userId := c.MustGet("userId").(string)
+	var req privacyReq
+
+	if ok := bindData(c, &req); !ok {
+		return
+	}
+
+	req.sanitize()
+
+	authUser, err := h.userService.Get(userId)
+
+	if err != nil {
+		e := apperrors.NewAuthorization(apperrors.InvalidSession)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	authUser.DMPrivacy = req.DMPrivacy
+
+	if err = h.userService.UpdateAccount(authUser); err != nil {
+		log.Printf("Failed to update privacy settings: %v\n", err.Error())
+		e := apperrors.NewInternal()
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, authUser)
+}
+
+// CreateWSTicket handler returns a single use ticket to connect to the
+// websocket without the session cookie
+// CreateWSTicket godoc
+// @Tags Account
+// @Summary Create Websocket Ticket
+// @Produce  json
+// @Success 201 {object} model.WSTicket
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /account/ws-ticket [post]
+func (h *Handler) CreateWSTicket(c *gin.Context) {
+	userId := c.MustGet("userId").(string)
+
+	ticket, err := h.userService.CreateWSTicket(c.Request.Context(), userId)
+
+	if err != nil {
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, model.WSTicket{Ticket: ticket})
+}
@@ -18,6 +18,7 @@ import (
 	"net/url"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestHandler_GetCurrent(t *testing.T) {
@@ -40,7 +41,7 @@ func TestHandler_GetCurrent(t *testing.T) {
 
 		NewHandler(&Config{
 			R:           router,
-			UserService: mockUserService,
+			UserService: allowSession(mockUserService),
 		})
 
 		request, err := http.NewRequest(http.MethodGet, "/api/account", nil)
@@ -68,7 +69,7 @@ func TestHandler_GetCurrent(t *testing.T) {
 
 		NewHandler(&Config{
 			R:           router,
-			UserService: mockUserService,
+			UserService: allowSession(mockUserService),
 		})
 
 		request, err := http.NewRequest(http.MethodGet, "/api/account", nil)
@@ -156,7 +157,7 @@ func TestHandler_EditAccount(t *testing.T) {
 
 		NewHandler(&Config{
 			R:            router,
-			UserService:  mockUserService,
+			UserService:  allowSession(mockUserService),
 			MaxBodyBytes: 4 * 1024 * 1024,
 		})
 
@@ -210,7 +211,7 @@ func TestHandler_EditAccount(t *testing.T) {
 
 		NewHandler(&Config{
 			R:            router,
-			UserService:  mockUserService,
+			UserService:  allowSession(mockUserService),
 			MaxBodyBytes: 4 * 1024 * 1024,
 		})
 
@@ -248,7 +249,7 @@ func TestHandler_EditAccount(t *testing.T) {
 
 		NewHandler(&Config{
 			R:            router,
-			UserService:  mockUserService,
+			UserService:  allowSession(mockUserService),
 			MaxBodyBytes: 4 * 1024 * 1024,
 		})
 
@@ -274,7 +275,7 @@ func TestHandler_EditAccount(t *testing.T) {
 
 		NewHandler(&Config{
 			R:            router,
-			UserService:  mockUserService,
+			UserService:  allowSession(mockUserService),
 			MaxBodyBytes: 4 * 1024 * 1024,
 		})
 
@@ -301,6 +302,44 @@ func TestHandler_EditAccount(t *testing.T) {
 		assert.Equal(t, respBody, rr.Body.Bytes())
 		mockUserService.AssertNotCalled(t, "UpdateAccount", mockUser)
 	})
+
+	t.Run("Email change needs confirmation", func(t *testing.T) {
+		current := fixture.GetMockUser()
+		oldEmail := current.Email
+		newEmail := fixture.Email()
+
+		router := getAuthenticatedTestRouter(current.ID)
+
+		mockUserService := new(mocks.UserService)
+		mockUserService.On("Get", current.ID).Return(current, nil)
+		mockUserService.On("IsEmailAlreadyInUse", newEmail).Return(false)
+		mockUserService.On("UpdateAccount", current).Return(nil)
+		mockUserService.On("RequestEmailChange", mock.Anything, current, newEmail).Return(nil)
+
+		NewHandler(&Config{
+			R:            router,
+			UserService:  allowSession(mockUserService),
+			MaxBodyBytes: 4 * 1024 * 1024,
+		})
+
+		rr := httptest.NewRecorder()
+
+		form := url.Values{}
+		form.Add("username", current.Username)
+		form.Add("email", newEmail)
+
+		request, _ := http.NewRequest(http.MethodPut, "/api/account", strings.NewReader(form.Encode()))
+		request.Form = form
+
+		router.ServeHTTP(rr, request)
+
+		respBody, _ := json.Marshal(current)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		assert.Equal(t, oldEmail, current.Email)
+		mockUserService.AssertExpectations(t)
+	})
 }
 
 func TestHandler_ChangePassword(t *testing.T) {
@@ -349,18 +388,23 @@ func TestHandler_ChangePassword(t *testing.T) {
 		newPassword := "password!"
 
 		ChangePasswordArgs := mock.Arguments{
+			mock.Anything,
 			currentPassword,
 			newPassword,
+			"",
 			mockUser,
 		}
 
 		mockUserService.
 			On("ChangePassword", ChangePasswordArgs...).
 			Return(nil)
+		mockUserService.
+			On("RevokeOtherLoginSessions", mock.Anything, uid, testSessionId).
+			Return(nil)
 
 		NewHandler(&Config{
 			R:            router,
-			UserService:  mockUserService,
+			UserService:  allowSession(mockUserService),
 			MaxBodyBytes: 4 * 1024 * 1024,
 		})
 
@@ -395,8 +439,10 @@ func TestHandler_ChangePassword(t *testing.T) {
 		newPassword := "password!"
 
 		ChangePasswordArgs := mock.Arguments{
+			mock.Anything,
 			currentPassword,
 			newPassword,
+			"",
 			mockUser,
 		}
 
@@ -407,7 +453,7 @@ func TestHandler_ChangePassword(t *testing.T) {
 
 		NewHandler(&Config{
 			R:            router,
-			UserService:  mockUserService,
+			UserService:  allowSession(mockUserService),
 			MaxBodyBytes: 4 * 1024 * 1024,
 		})
 
@@ -433,6 +479,90 @@ func TestHandler_ChangePassword(t *testing.T) {
 		assert.Equal(t, respBody, rr.Body.Bytes())
 		mockUserService.AssertCalled(t, "ChangePassword", ChangePasswordArgs...)
 	})
+
+	t.Run("Invalid two-factor code", func(t *testing.T) {
+		twoFactorUser := fixture.GetMockUser()
+		twoFactorUser.TwoFactorEnabled = true
+		router := getAuthenticatedTestRouter(twoFactorUser.ID)
+
+		code := "000000"
+		mockError := apperrors.NewAuthorization(apperrors.InvalidTwoFactorCode)
+
+		mockUserService := new(mocks.UserService)
+		mockUserService.On("Get", twoFactorUser.ID).Return(twoFactorUser, nil)
+		mockUserService.On("ChangePassword", mock.Anything, twoFactorUser.Password, "password!", code, twoFactorUser).Return(mockError)
+
+		NewHandler(&Config{
+			R:            router,
+			UserService:  allowSession(mockUserService),
+			MaxBodyBytes: 4 * 1024 * 1024,
+		})
+
+		rr := httptest.NewRecorder()
+
+		reqBody, err := json.Marshal(gin.H{
+			"currentPassword":    twoFactorUser.Password,
+			"newPassword":        "password!",
+			"confirmNewPassword": "password!",
+			"code":               code,
+		})
+		assert.NoError(t, err)
+
+		request, _ := http.NewRequest(http.MethodPut, "/api/account/change-password", bytes.NewBuffer(reqBody))
+		request.Header.Set("Content-Type", "application/json")
+
+		router.ServeHTTP(rr, request)
+
+		respBody, _ := json.Marshal(gin.H{
+			"error": mockError,
+		})
+
+		assert.Equal(t, mockError.Status(), rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockUserService.AssertExpectations(t)
+		mockUserService.AssertNotCalled(t, "RevokeOtherLoginSessions", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("ChangePassword with two-factor code", func(t *testing.T) {
+		twoFactorUser := fixture.GetMockUser()
+		twoFactorUser.TwoFactorEnabled = true
+		router := getAuthenticatedTestRouter(twoFactorUser.ID)
+
+		code := "123456"
+		newPassword := "password!"
+
+		mockUserService := new(mocks.UserService)
+		mockUserService.On("Get", twoFactorUser.ID).Return(twoFactorUser, nil)
+		mockUserService.On("ChangePassword", mock.Anything, twoFactorUser.Password, newPassword, code, twoFactorUser).Return(nil)
+		mockUserService.On("RevokeOtherLoginSessions", mock.Anything, twoFactorUser.ID, testSessionId).Return(nil)
+
+		NewHandler(&Config{
+			R:            router,
+			UserService:  allowSession(mockUserService),
+			MaxBodyBytes: 4 * 1024 * 1024,
+		})
+
+		rr := httptest.NewRecorder()
+
+		reqBody, err := json.Marshal(gin.H{
+			"currentPassword":    twoFactorUser.Password,
+			"newPassword":        newPassword,
+			"confirmNewPassword": newPassword,
+			"code":               code,
+		})
+		assert.NoError(t, err)
+
+		request, _ := http.NewRequest(http.MethodPut, "/api/account/change-password", bytes.NewBuffer(reqBody))
+		request.Header.Set("Content-Type", "application/json")
+
+		router.ServeHTTP(rr, request)
+
+		respBody, _ := json.Marshal(true)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockUserService.AssertExpectations(t)
+	})
 }
 
 func TestHandler_ChangePassword_BadRequest(t *testing.T) {
@@ -450,7 +580,7 @@ func TestHandler_ChangePassword_BadRequest(t *testing.T) {
 
 	NewHandler(&Config{
 		R:            router,
-		UserService:  mockUserService,
+		UserService:  allowSession(mockUserService),
 		MaxBodyBytes: 4 * 1024 * 1024,
 	})
 
@@ -546,3 +676,652 @@ func TestHandler_ChangePassword_BadRequest(t *testing.T) {
 		})
 	}
 }
+
+func TestHandler_UpdatePresence(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+
+	uid, _ := service.GenerateId()
+
+	t.Run("Successful update", func(t *testing.T) {
+		mockUser := fixture.GetMockUser()
+		mockUser.ID = uid
+
+		router := getAuthenticatedTestRouter(uid)
+
+		mockUserService := new(mocks.UserService)
+		mockUserService.On("Get", uid).Return(mockUser, nil)
+		mockUserService.On("UpdatePresence", mock.Anything, mockUser).Return(nil)
+
+		mockSocketService := new(mocks.SocketService)
+		mockSocketService.On("EmitPresenceUpdate", mockUser).Return()
+
+		NewHandler(&Config{
+			R:             router,
+			UserService:   allowSession(mockUserService),
+			SocketService: mockSocketService,
+		})
+
+		rr := httptest.NewRecorder()
+
+		text := fixture.RandStr(20)
+		reqBody, err := json.Marshal(gin.H{
+			"status":       model.DoNotDisturbStatus,
+			"customStatus": text,
+		})
+		assert.NoError(t, err)
+
+		request, _ := http.NewRequest(http.MethodPut, "/api/account/presence", bytes.NewBuffer(reqBody))
+		request.Header.Set("Content-Type", "application/json")
+
+		router.ServeHTTP(rr, request)
+
+		respBody, _ := json.Marshal(mockUser)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		assert.Equal(t, model.DoNotDisturbStatus, mockUser.Status)
+		assert.Equal(t, text, *mockUser.GetPresence().CustomStatus.Text)
+		mockUserService.AssertExpectations(t)
+		mockSocketService.AssertExpectations(t)
+	})
+
+	t.Run("Invisible appears offline", func(t *testing.T) {
+		mockUser := fixture.GetMockUser()
+		mockUser.ID = uid
+		mockUser.IsOnline = true
+
+		router := getAuthenticatedTestRouter(uid)
+
+		mockUserService := new(mocks.UserService)
+		mockUserService.On("Get", uid).Return(mockUser, nil)
+		mockUserService.On("UpdatePresence", mock.Anything, mockUser).Return(nil)
+
+		mockSocketService := new(mocks.SocketService)
+		mockSocketService.On("EmitPresenceUpdate", mockUser).Return()
+
+		NewHandler(&Config{
+			R:             router,
+			UserService:   allowSession(mockUserService),
+			SocketService: mockSocketService,
+		})
+
+		rr := httptest.NewRecorder()
+
+		reqBody, err := json.Marshal(gin.H{
+			"status": model.InvisibleStatus,
+		})
+		assert.NoError(t, err)
+
+		request, _ := http.NewRequest(http.MethodPut, "/api/account/presence", bytes.NewBuffer(reqBody))
+		request.Header.Set("Content-Type", "application/json")
+
+		router.ServeHTTP(rr, request)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, model.OfflineStatus, mockUser.GetPresence().Status)
+		assert.Nil(t, mockUser.GetPresence().CustomStatus)
+		mockSocketService.AssertExpectations(t)
+	})
+
+	t.Run("Update Failure", func(t *testing.T) {
+		mockUser := fixture.GetMockUser()
+		mockUser.ID = uid
+
+		router := getAuthenticatedTestRouter(uid)
+
+		mockError := apperrors.NewInternal()
+		mockUserService := new(mocks.UserService)
+		mockUserService.On("Get", uid).Return(mockUser, nil)
+		mockUserService.On("UpdatePresence", mock.Anything, mockUser).Return(mockError)
+
+		mockSocketService := new(mocks.SocketService)
+
+		NewHandler(&Config{
+			R:             router,
+			UserService:   allowSession(mockUserService),
+			SocketService: mockSocketService,
+		})
+
+		rr := httptest.NewRecorder()
+
+		reqBody, err := json.Marshal(gin.H{
+			"status": model.IdleStatus,
+		})
+		assert.NoError(t, err)
+
+		request, _ := http.NewRequest(http.MethodPut, "/api/account/presence", bytes.NewBuffer(reqBody))
+		request.Header.Set("Content-Type", "application/json")
+
+		router.ServeHTTP(rr, request)
+
+		respBody, _ := json.Marshal(gin.H{
+			"error": mockError,
+		})
+
+		assert.Equal(t, mockError.Status(), rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockSocketService.AssertNotCalled(t, "EmitPresenceUpdate", mock.Anything)
+	})
+}
+
+func TestHandler_UpdatePresence_BadRequest(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+
+	uid, _ := service.GenerateId()
+
+	router := getAuthenticatedTestRouter(uid)
+
+	mockUserService := new(mocks.UserService)
+
+	NewHandler(&Config{
+		R:           router,
+		UserService: allowSession(mockUserService),
+	})
+
+	testCases := []struct {
+		name string
+		body gin.H
+	}{
+		{
+			name: "Status required",
+			body: gin.H{
+				"customStatus": fixture.RandStr(10),
+			},
+		},
+		{
+			name: "Invalid status",
+			body: gin.H{
+				"status": "away",
+			},
+		},
+		{
+			name: "CustomStatus too long",
+			body: gin.H{
+				"status":       model.OnlineStatus,
+				"customStatus": fixture.RandStr(129),
+			},
+		},
+		{
+			name: "Emoji too long",
+			body: gin.H{
+				"status": model.OnlineStatus,
+				"emoji":  fixture.RandStr(33),
+			},
+		},
+		{
+			name: "ExpiresAt in the past",
+			body: gin.H{
+				"status":       model.OnlineStatus,
+				"customStatus": fixture.RandStr(10),
+				"expiresAt":    time.Now().Add(-time.Hour),
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+
+			reqBody, err := json.Marshal(tc.body)
+			assert.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPut, "/api/account/presence", bytes.NewBuffer(reqBody))
+			assert.NoError(t, err)
+
+			request.Header.Set("Content-Type", "application/json")
+
+			router.ServeHTTP(rr, request)
+
+			assert.Equal(t, http.StatusBadRequest, rr.Code)
+			mockUserService.AssertNotCalled(t, "Get", uid)
+		})
+	}
+}
+
+func TestHandler_CreateWSTicket(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+
+	uid, _ := service.GenerateId()
+
+	t.Run("Success", func(t *testing.T) {
+		ticket := fixture.RandStr(32)
+
+		mockUserService := new(mocks.UserService)
+		mockUserService.On("CreateWSTicket", mock.Anything, uid).Return(ticket, nil)
+
+		router := getAuthenticatedTestRouter(uid)
+
+		NewHandler(&Config{
+			R:           router,
+			UserService: allowSession(mockUserService),
+		})
+
+		rr := httptest.NewRecorder()
+
+		request, _ := http.NewRequest(http.MethodPost, "/api/account/ws-ticket", nil)
+		router.ServeHTTP(rr, request)
+
+		respBody, _ := json.Marshal(model.WSTicket{Ticket: ticket})
+
+		assert.Equal(t, http.StatusCreated, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockUserService.AssertExpectations(t)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		mockError := apperrors.NewInternal()
+
+		mockUserService := new(mocks.UserService)
+		mockUserService.On("CreateWSTicket", mock.Anything, uid).Return("", mockError)
+
+		router := getAuthenticatedTestRouter(uid)
+
+		NewHandler(&Config{
+			R:           router,
+			UserService: allowSession(mockUserService),
+		})
+
+		rr := httptest.NewRecorder()
+
+		request, _ := http.NewRequest(http.MethodPost, "/api/account/ws-ticket", nil)
+		router.ServeHTTP(rr, request)
+
+		respBody, _ := json.Marshal(gin.H{
+			"error": mockError,
+		})
+
+		assert.Equal(t, mockError.Status(), rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+	})
+
+	t.Run("Unauthorized", func(t *testing.T) {
+		mockUserService := new(mocks.UserService)
+
+		router := getTestRouter()
+
+		NewHandler(&Config{
+			R:           router,
+			UserService: mockUserService,
+		})
+
+		rr := httptest.NewRecorder()
+
+		request, _ := http.NewRequest(http.MethodPost, "/api/account/ws-ticket", nil)
+		router.ServeHTTP(rr, request)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		mockUserService.AssertNotCalled(t, "CreateWSTicket", mock.Anything, mock.Anything)
+	})
+}
+
+func TestHandler_UpdatePrivacy(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+
+	uid, _ := service.GenerateId()
+
+	t.Run("Successful update", func(t *testing.T) {
+		mockUser := fixture.GetMockUser()
+		mockUser.ID = uid
+
+		router := getAuthenticatedTestRouter(uid)
+
+		mockUserService := new(mocks.UserService)
+		mockUserService.On("Get", uid).Return(mockUser, nil)
+		mockUserService.On("UpdateAccount", mockUser).Return(nil)
+
+		NewHandler(&Config{
+			R:           router,
+			UserService: allowSession(mockUserService),
+		})
+
+		rr := httptest.NewRecorder()
+
+		reqBody, err := json.Marshal(gin.H{
+			"dmPrivacy": model.DMPrivacyFriends,
+		})
+		assert.NoError(t, err)
+
+		request, _ := http.NewRequest(http.MethodPut, "/api/account/privacy", bytes.NewBuffer(reqBody))
+		request.Header.Set("Content-Type", "application/json")
+
+		router.ServeHTTP(rr, request)
+
+		respBody, _ := json.Marshal(mockUser)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		assert.Equal(t, model.DMPrivacyFriends, mockUser.DMPrivacy)
+		mockUserService.AssertExpectations(t)
+	})
+
+	t.Run("Invalid setting", func(t *testing.T) {
+		router := getAuthenticatedTestRouter(uid)
+
+		mockUserService := new(mocks.UserService)
+
+		NewHandler(&Config{
+			R:           router,
+			UserService: allowSession(mockUserService),
+		})
+
+		rr := httptest.NewRecorder()
+
+		reqBody, err := json.Marshal(gin.H{
+			"dmPrivacy": "nobody",
+		})
+		assert.NoError(t, err)
+
+		request, _ := http.NewRequest(http.MethodPut, "/api/account/privacy", bytes.NewBuffer(reqBody))
+		request.Header.Set("Content-Type", "application/json")
+
+		router.ServeHTTP(rr, request)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockUserService.AssertNotCalled(t, "UpdateAccount", mock.Anything)
+	})
+}
+
+func TestHandler_ConfirmEmail(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+
+	t.Run("Successfully confirmed", func(t *testing.T) {
+		mockUser := fixture.GetMockUser()
+		token := fixture.RandStr(21)
+
+		mockUserService := new(mocks.UserService)
+		mockUserService.On("ConfirmEmailChange", mock.Anything, token).Return(mockUser, nil)
+
+		rr := httptest.NewRecorder()
+
+		router := getTestRouter()
+
+		NewHandler(&Config{
+			R:           router,
+			UserService: mockUserService,
+		})
+
+		reqBody, err := json.Marshal(gin.H{
+			"token": token,
+		})
+		assert.NoError(t, err)
+
+		request, err := http.NewRequest(http.MethodPost, "/api/account/confirm-email", bytes.NewBuffer(reqBody))
+		assert.NoError(t, err)
+
+		request.Header.Set("Content-Type", "application/json")
+
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal(mockUser)
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockUserService.AssertExpectations(t)
+	})
+
+	t.Run("Invalid token", func(t *testing.T) {
+		token := fixture.RandStr(21)
+
+		mockUserService := new(mocks.UserService)
+		mockUserService.
+			On("ConfirmEmailChange", mock.Anything, token).
+			Return(nil, apperrors.NewBadRequest(apperrors.InvalidEmailChangeToken))
+
+		rr := httptest.NewRecorder()
+
+		router := getTestRouter()
+
+		NewHandler(&Config{
+			R:           router,
+			UserService: mockUserService,
+		})
+
+		reqBody, err := json.Marshal(gin.H{
+			"token": token,
+		})
+		assert.NoError(t, err)
+
+		request, err := http.NewRequest(http.MethodPost, "/api/account/confirm-email", bytes.NewBuffer(reqBody))
+		assert.NoError(t, err)
+
+		request.Header.Set("Content-Type", "application/json")
+
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal(getTestFieldErrorResponse("Token", apperrors.InvalidEmailChangeToken))
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockUserService.AssertExpectations(t)
+	})
+
+	t.Run("Email got taken", func(t *testing.T) {
+		token := fixture.RandStr(21)
+
+		mockUserService := new(mocks.UserService)
+		mockUserService.
+			On("ConfirmEmailChange", mock.Anything, token).
+			Return(nil, apperrors.NewBadRequest(apperrors.DuplicateEmail))
+
+		rr := httptest.NewRecorder()
+
+		router := getTestRouter()
+
+		NewHandler(&Config{
+			R:           router,
+			UserService: mockUserService,
+		})
+
+		reqBody, err := json.Marshal(gin.H{
+			"token": token,
+		})
+		assert.NoError(t, err)
+
+		request, err := http.NewRequest(http.MethodPost, "/api/account/confirm-email", bytes.NewBuffer(reqBody))
+		assert.NoError(t, err)
+
+		request.Header.Set("Content-Type", "application/json")
+
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal(getTestFieldErrorResponse("Email", apperrors.DuplicateEmail))
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockUserService.AssertExpectations(t)
+	})
+}
+
+func TestHandler_RevertEmail(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+
+	t.Run("Successfully reverted", func(t *testing.T) {
+		mockUser := fixture.GetMockUser()
+		token := fixture.RandStr(21)
+
+		mockUserService := new(mocks.UserService)
+		mockUserService.On("RevertEmailChange", mock.Anything, token).Return(mockUser, nil)
+
+		rr := httptest.NewRecorder()
+
+		router := getTestRouter()
+
+		NewHandler(&Config{
+			R:           router,
+			UserService: mockUserService,
+		})
+
+		reqBody, err := json.Marshal(gin.H{
+			"token": token,
+		})
+		assert.NoError(t, err)
+
+		request, err := http.NewRequest(http.MethodPost, "/api/account/revert-email", bytes.NewBuffer(reqBody))
+		assert.NoError(t, err)
+
+		request.Header.Set("Content-Type", "application/json")
+
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal(mockUser)
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockUserService.AssertExpectations(t)
+	})
+
+	t.Run("Token required", func(t *testing.T) {
+		mockUserService := new(mocks.UserService)
+
+		rr := httptest.NewRecorder()
+
+		router := getTestRouter()
+
+		NewHandler(&Config{
+			R:           router,
+			UserService: mockUserService,
+		})
+
+		reqBody, err := json.Marshal(gin.H{})
+		assert.NoError(t, err)
+
+		request, err := http.NewRequest(http.MethodPost, "/api/account/revert-email", bytes.NewBuffer(reqBody))
+		assert.NoError(t, err)
+
+		request.Header.Set("Content-Type", "application/json")
+
+		router.ServeHTTP(rr, request)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockUserService.AssertNotCalled(t, "RevertEmailChange", mock.Anything, mock.Anything)
+	})
+}
+
+func TestHandler_DeleteAccount(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+
+	uid, _ := service.GenerateId()
+	mockUser := fixture.GetMockUser()
+	mockUser.ID = uid
+
+	t.Run("Success", func(t *testing.T) {
+		mockUserService := new(mocks.UserService)
+		mockUserService.On("Get", uid).Return(mockUser, nil)
+		mockUserService.On("ScheduleDeletion", mock.Anything, mockUser, "password", "").Return(nil)
+
+		router := getAuthenticatedTestRouter(uid)
+
+		NewHandler(&Config{
+			R:           router,
+			UserService: allowSession(mockUserService),
+		})
+
+		rr := httptest.NewRecorder()
+
+		reqBody, _ := json.Marshal(gin.H{
+			"password": "password",
+		})
+
+		request, _ := http.NewRequest(http.MethodDelete, "/api/account", bytes.NewBuffer(reqBody))
+		request.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(rr, request)
+
+		respBody, _ := json.Marshal(true)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockUserService.AssertExpectations(t)
+	})
+
+	t.Run("Password required", func(t *testing.T) {
+		mockUserService := new(mocks.UserService)
+
+		router := getAuthenticatedTestRouter(uid)
+
+		NewHandler(&Config{
+			R:           router,
+			UserService: allowSession(mockUserService),
+		})
+
+		rr := httptest.NewRecorder()
+
+		reqBody, _ := json.Marshal(gin.H{})
+
+		request, _ := http.NewRequest(http.MethodDelete, "/api/account", bytes.NewBuffer(reqBody))
+		request.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(rr, request)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockUserService.AssertNotCalled(t, "ScheduleDeletion", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Invalid password", func(t *testing.T) {
+		mockError := apperrors.NewAuthorization(apperrors.InvalidPassword)
+
+		mockUserService := new(mocks.UserService)
+		mockUserService.On("Get", uid).Return(mockUser, nil)
+		mockUserService.On("ScheduleDeletion", mock.Anything, mockUser, "wrongpassword", "").Return(mockError)
+
+		router := getAuthenticatedTestRouter(uid)
+
+		NewHandler(&Config{
+			R:           router,
+			UserService: allowSession(mockUserService),
+		})
+
+		rr := httptest.NewRecorder()
+
+		reqBody, _ := json.Marshal(gin.H{
+			"password": "wrongpassword",
+		})
+
+		request, _ := http.NewRequest(http.MethodDelete, "/api/account", bytes.NewBuffer(reqBody))
+		request.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(rr, request)
+
+		respBody, _ := json.Marshal(gin.H{
+			"error": mockError,
+		})
+
+		assert.Equal(t, mockError.Status(), rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockUserService.AssertExpectations(t)
+	})
+
+	t.Run("Unauthorized", func(t *testing.T) {
+		mockUserService := new(mocks.UserService)
+
+		router := getTestRouter()
+
+		NewHandler(&Config{
+			R:           router,
+			UserService: mockUserService,
+		})
+
+		rr := httptest.NewRecorder()
+
+		reqBody, _ := json.Marshal(gin.H{
+			"password": "password",
+		})
+
+		request, _ := http.NewRequest(http.MethodDelete, "/api/account", bytes.NewBuffer(reqBody))
+		request.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(rr, request)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		mockUserService.AssertNotCalled(t, "ScheduleDeletion", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"github.com/gin-contrib/sessions"
 	"github.com/gin-gonic/gin"
 	validation "github.com/go-ozzo/ozzo-validation/v4"
@@ -9,6 +10,7 @@ import (
 	"github.com/sentrionic/valkyrie/model/apperrors"
 	"log"
 	"net/http"
+	"strconv"
 	"strings"
 )
 
@@ -81,7 +83,14 @@ func (h *Handler) Register(c *gin.Context) {
 		return
 	}
 
-	setUserSession(c, user.ID)
+	// The account stays restricted until the email is verified
+	if err = h.userService.SendVerificationMail(c.Request.Context(), user); err != nil {
+		log.Printf("Failed to send verification mail to user: %v\n%v", user.ID, err)
+	}
+
+	if ok := h.setUserSession(c, user); !ok {
+		return
+	}
 
 	c.JSON(http.StatusCreated, user)
 }
@@ -113,9 +122,10 @@ func (r *loginReq) sanitize() {
 // @Accept  json
 // @Produce  json
 // @Param account body loginReq true "Login account"
-// @Success 200 {object} model.User
+// @Success 200 {object} model.User "Or a TwoFactorChallenge if two-factor authentication is enabled"
 // @Failure 400 {object} model.ErrorsResponse
 // @Failure 401 {object} model.ErrorResponse
+// @Failure 429 {object} model.ErrorResponse "Too many failed attempts. Contains retryAfter and the Retry-After header"
 // @Failure 500 {object} model.ErrorResponse
 // @Router /account/login [post]
 func (h *Handler) Login(c *gin.Context) {
@@ -127,7 +137,92 @@ func (h *Handler) Login(c *gin.Context) {
 
 	req.sanitize()
 
-	user, err := h.userService.Login(req.Email, req.Password)
+	user, err := h.userService.Login(c.Request.Context(), req.Email, req.Password, c.ClientIP())
+
+	if err != nil {
+		// Tell the client when it can try again
+		var e *apperrors.Error
+		if errors.As(err, &e) && e.RetryAfter > 0 {
+			c.Header("Retry-After", strconv.Itoa(e.RetryAfter))
+		}
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
+	h.loginUser(c, user)
+}
+
+// loginUser issues the session for the authenticated user or
+// asks for their second factor if they enabled it
+func (h *Handler) loginUser(c *gin.Context, user *model.User) {
+	// The session only gets issued once the user provided their second factor
+	if user.TwoFactorEnabled {
+		ticket, err := h.userService.CreateTwoFactorTicket(c.Request.Context(), user.ID)
+
+		if err != nil {
+			c.JSON(apperrors.Status(err), gin.H{
+				"error": err,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, model.TwoFactorChallenge{
+			TwoFactorRequired: true,
+			Ticket:            ticket,
+		})
+		return
+	}
+
+	if ok := h.setUserSession(c, user); !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+type twoFactorLoginReq struct {
+	// The ticket returned by the login.
+	Ticket string `json:"ticket"`
+	// Authenticator or recovery code.
+	Code string `json:"code"`
+} //@name TwoFactorLoginRequest
+
+func (r twoFactorLoginReq) validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Ticket, validation.Required),
+		validation.Field(&r.Code, validation.Required),
+	)
+}
+
+func (r *twoFactorLoginReq) sanitize() {
+	r.Ticket = strings.TrimSpace(r.Ticket)
+	r.Code = strings.TrimSpace(r.Code)
+}
+
+// LoginTwoFactor completes the login of a user with two-factor authentication
+// LoginTwoFactor godoc
+// @Tags Account
+// @Summary Two-Factor Login
+// @Accept  json
+// @Produce  json
+// @Param request body twoFactorLoginReq true "Two-Factor Login"
+// @Success 200 {object} model.User
+// @Failure 400 {object} model.ErrorsResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /account/login/2fa [post]
+func (h *Handler) LoginTwoFactor(c *gin.Context) {
+	var req twoFactorLoginReq
+
+	if ok := bindData(c, &req); !ok {
+		return
+	}
+
+	req.sanitize()
+
+	user, err := h.userService.VerifyTwoFactorLogin(c.Request.Context(), req.Ticket, req.Code, c.ClientIP())
 
 	if err != nil {
 		c.JSON(apperrors.Status(err), gin.H{
@@ -136,7 +231,9 @@ func (h *Handler) Login(c *gin.Context) {
 		return
 	}
 
-	setUserSession(c, user.ID)
+	if ok := h.setUserSession(c, user); !ok {
+		return
+	}
 
 	c.JSON(http.StatusOK, user)
 }
@@ -154,6 +251,24 @@ func (h *Handler) Logout(c *gin.Context) {
 	c.Set("user", nil)
 
 	session := sessions.Default(c)
+
+	userId, hasUser := session.Get("userId").(string)
+	sessionId, isTracked := session.Get("sessionId").(string)
+	if hasUser && isTracked {
+		if err := h.userService.RevokeLoginSession(c.Request.Context(), userId, sessionId); err != nil {
+			log.Printf("error revoking session: %v\n", err.Error())
+		}
+	}
+
+	clearUserSession(c)
+
+	c.JSON(http.StatusOK, true)
+}
+
+// clearUserSession removes the user from the session and expires the cookie
+func clearUserSession(c *gin.Context) {
+	session := sessions.Default(c)
+
 	session.Set("userId", "")
 	session.Clear()
 	session.Options(sessions.Options{Path: "/", MaxAge: -1})
@@ -162,8 +277,6 @@ func (h *Handler) Logout(c *gin.Context) {
 	if err != nil {
 		log.Printf("error clearing session: %v\n", err.Error())
 	}
-
-	c.JSON(http.StatusOK, true)
 }
 
 type forgotRequest struct {
@@ -260,7 +373,7 @@ func (r *resetRequest) sanitize() {
 // @Accept  json
 // @Produce  json
 // @Param request body resetRequest true "Reset Password"
-// @Success 200 {object} model.User
+// @Success 200 {object} model.User "Or a TwoFactorChallenge if two-factor authentication is enabled"
 // @Failure 400 {object} model.ErrorsResponse
 // @Failure 500 {object} model.ErrorResponse
 // @Router /account/reset-password [post]
@@ -293,7 +406,125 @@ func (h *Handler) ResetPassword(c *gin.Context) {
 		return
 	}
 
-	setUserSession(c, user.ID)
+	// Whoever knew the old password must not stay logged in
+	if err = h.userService.RevokeOtherLoginSessions(ctx, user.ID, ""); err != nil {
+		log.Printf("Failed to revoke the sessions of user: %v\n%v", user.ID, err)
+	}
+
+	// Users with two-factor authentication still need their second factor
+	h.loginUser(c, user)
+}
+
+type verifyEmailRequest struct {
+	// The token the user got from the email.
+	Token string `json:"token"`
+} //@name VerifyEmailRequest
+
+func (r verifyEmailRequest) validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Token, validation.Required),
+	)
+}
+
+func (r *verifyEmailRequest) sanitize() {
+	r.Token = strings.TrimSpace(r.Token)
+}
+
+// VerifyEmail confirms the user's email with the provided token
+// VerifyEmail godoc
+// @Tags Account
+// @Summary Verify Email
+// @Accept  json
+// @Produce  json
+// @Param request body verifyEmailRequest true "Verify Email"
+// @Success 200 {object} model.User
+// @Failure 400 {object} model.ErrorsResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /account/verify-email [post]
+func (h *Handler) VerifyEmail(c *gin.Context) {
+	var req verifyEmailRequest
+
+	if valid := bindData(c, &req); !valid {
+		return
+	}
+
+	req.sanitize()
+
+	ctx := c.Request.Context()
+	user, err := h.userService.VerifyEmail(ctx, req.Token)
+
+	if err != nil {
+		if err.Error() == apperrors.NewBadRequest(apperrors.InvalidVerificationToken).Error() {
+			toFieldErrorResponse(c, "Token", apperrors.InvalidVerificationToken)
+			return
+		}
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
 
 	c.JSON(http.StatusOK, user)
 }
+
+// ResendVerification sends a new verification email to the current user
+// ResendVerification godoc
+// @Tags Account
+// @Summary Resend Verification Email
+// @Produce  json
+// @Success 200 {object} model.Success
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /account/resend-verification [post]
+func (h *Handler) ResendVerification(c *gin.Context) {
+	userId := c.MustGet("userId").(string)
+
+	user, err := h.userService.Get(userId)
+
+	if err != nil {
+		e := apperrors.NewAuthorization(apperrors.InvalidSession)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	if err = h.userService.SendVerificationMail(c.Request.Context(), user); err != nil {
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, true)
+}
+
+// requireVerifiedEmail writes an error response and returns false
+// if the user did not verify their email yet
+func requireVerifiedEmail(c *gin.Context, user *model.User) bool {
+	if !user.EmailVerified {
+		e := apperrors.NewAuthorization(apperrors.EmailNotVerified)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return false
+	}
+	return true
+}
+
+// requireTwoFactor writes an error response and returns false
+// if the user enabled two-factor authentication and the code is invalid
+func (h *Handler) requireTwoFactor(c *gin.Context, user *model.User, code string) bool {
+	if !user.TwoFactorEnabled {
+		return true
+	}
+
+	if err := h.userService.VerifyTwoFactor(c.Request.Context(), user, code); err != nil {
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return false
+	}
+	return true
+}
@@ -15,6 +15,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 )
 
 func TestHandler_Register(t *testing.T) {
@@ -260,6 +261,12 @@ func TestHandler_Register(t *testing.T) {
 		mockUserService.
 			On("Register", u).
 			Return(reqUser, nil)
+		mockUserService.
+			On("SendVerificationMail", mock.Anything, reqUser).
+			Return(nil)
+		mockUserService.
+			On("CreateLoginSession", mock.Anything, reqUser.ID, mock.Anything, mock.Anything).
+			Return(fixture.RandID(), nil)
 
 		// a response recorder for getting written http response
 		rr := httptest.NewRecorder()
@@ -329,7 +336,7 @@ func TestHandler_Login(t *testing.T) {
 		router.ServeHTTP(rr, request)
 
 		assert.Equal(t, http.StatusBadRequest, rr.Code)
-		mockUserService.AssertNotCalled(t, "Login")
+		mockUserService.AssertNotCalled(t, "Login", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
 	})
 
 	t.Run("Error Returned from UserService.Login", func(t *testing.T) {
@@ -337,7 +344,7 @@ func TestHandler_Login(t *testing.T) {
 		password := "pwdoesnotmatch123"
 
 		mockUSArgs := mock.Arguments{
-			email, password,
+			mock.Anything, email, password, mock.Anything,
 		}
 
 		// so we can check for a known status code
@@ -369,11 +376,14 @@ func TestHandler_Login(t *testing.T) {
 		user := fixture.GetMockUser()
 
 		mockUSArgs := mock.Arguments{
+			mock.Anything,
 			user.Email,
 			user.Password,
+			mock.Anything,
 		}
 
 		mockUserService.On("Login", mockUSArgs...).Return(user, nil)
+		mockUserService.On("CreateLoginSession", mock.Anything, user.ID, mock.Anything, mock.Anything).Return(fixture.RandID(), nil)
 
 		// a response recorder for getting written http response
 		rr := httptest.NewRecorder()
@@ -399,6 +409,213 @@ func TestHandler_Login(t *testing.T) {
 
 		mockUserService.AssertCalled(t, "Login", mockUSArgs...)
 	})
+
+	t.Run("Session cannot be tracked", func(t *testing.T) {
+		user := fixture.GetMockUser()
+		mockError := apperrors.NewInternal()
+
+		mockUserService.On("Login", mock.Anything, user.Email, user.Password, mock.Anything).Return(user, nil)
+		mockUserService.On("CreateLoginSession", mock.Anything, user.ID, mock.Anything, mock.Anything).Return("", mockError)
+
+		rr := httptest.NewRecorder()
+
+		reqBody, err := json.Marshal(gin.H{
+			"email":    user.Email,
+			"password": user.Password,
+		})
+		assert.NoError(t, err)
+
+		request, err := http.NewRequest(http.MethodPost, "/api/account/login", bytes.NewBuffer(reqBody))
+		assert.NoError(t, err)
+
+		request.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal(gin.H{
+			"error": mockError,
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		assert.Empty(t, rr.Header().Get("Set-Cookie"))
+	})
+
+	t.Run("Login requires second factor", func(t *testing.T) {
+		user := fixture.GetMockUser()
+		user.TwoFactorEnabled = true
+		ticket := fixture.RandStr(32)
+
+		mockUserService.On("Login", mock.Anything, user.Email, user.Password, mock.Anything).Return(user, nil)
+		mockUserService.On("CreateTwoFactorTicket", mock.Anything, user.ID).Return(ticket, nil)
+
+		rr := httptest.NewRecorder()
+
+		reqBody, err := json.Marshal(gin.H{
+			"email":    user.Email,
+			"password": user.Password,
+		})
+		assert.NoError(t, err)
+
+		request, err := http.NewRequest(http.MethodPost, "/api/account/login", bytes.NewBuffer(reqBody))
+		assert.NoError(t, err)
+
+		request.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal(model.TwoFactorChallenge{
+			TwoFactorRequired: true,
+			Ticket:            ticket,
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		assert.Empty(t, rr.Header().Get("Set-Cookie"))
+
+		mockUserService.AssertCalled(t, "CreateTwoFactorTicket", mock.Anything, user.ID)
+	})
+
+	t.Run("Locked out", func(t *testing.T) {
+		email := fixture.Email()
+		password := "password123"
+
+		mockError := apperrors.NewTooManyRequests(apperrors.LoginLocked, 90*time.Second)
+		mockUserService.On("Login", mock.Anything, email, password, mock.Anything).Return(nil, mockError)
+
+		rr := httptest.NewRecorder()
+
+		reqBody, err := json.Marshal(gin.H{
+			"email":    email,
+			"password": password,
+		})
+		assert.NoError(t, err)
+
+		request, err := http.NewRequest(http.MethodPost, "/api/account/login", bytes.NewBuffer(reqBody))
+		assert.NoError(t, err)
+
+		request.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal(gin.H{
+			"error": mockError,
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusTooManyRequests, rr.Code)
+		assert.Equal(t, "90", rr.Header().Get("Retry-After"))
+		assert.Equal(t, respBody, rr.Body.Bytes())
+	})
+}
+
+func TestHandler_LoginTwoFactor(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+
+	t.Run("Successful Login", func(t *testing.T) {
+		user := fixture.GetMockUser()
+		user.TwoFactorEnabled = true
+		ticket := fixture.RandStr(32)
+		code := "123456"
+
+		mockUserService := new(mocks.UserService)
+		mockUserService.On("VerifyTwoFactorLogin", mock.Anything, ticket, code, mock.Anything).Return(user, nil)
+		mockUserService.On("CreateLoginSession", mock.Anything, user.ID, mock.Anything, mock.Anything).Return(fixture.RandID(), nil)
+
+		rr := httptest.NewRecorder()
+
+		router := getTestRouter()
+
+		NewHandler(&Config{
+			R:           router,
+			UserService: mockUserService,
+		})
+
+		reqBody, err := json.Marshal(gin.H{
+			"ticket": ticket,
+			"code":   code,
+		})
+		assert.NoError(t, err)
+
+		request, err := http.NewRequest(http.MethodPost, "/api/account/login/2fa", bytes.NewBuffer(reqBody))
+		assert.NoError(t, err)
+
+		request.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal(user)
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockUserService.AssertExpectations(t)
+	})
+
+	t.Run("Invalid code", func(t *testing.T) {
+		ticket := fixture.RandStr(32)
+		code := "000000"
+
+		mockError := apperrors.NewAuthorization(apperrors.InvalidTwoFactorCode)
+		mockUserService := new(mocks.UserService)
+		mockUserService.On("VerifyTwoFactorLogin", mock.Anything, ticket, code, mock.Anything).Return(nil, mockError)
+
+		rr := httptest.NewRecorder()
+
+		router := getTestRouter()
+
+		NewHandler(&Config{
+			R:           router,
+			UserService: mockUserService,
+		})
+
+		reqBody, err := json.Marshal(gin.H{
+			"ticket": ticket,
+			"code":   code,
+		})
+		assert.NoError(t, err)
+
+		request, err := http.NewRequest(http.MethodPost, "/api/account/login/2fa", bytes.NewBuffer(reqBody))
+		assert.NoError(t, err)
+
+		request.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal(gin.H{
+			"error": mockError,
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, mockError.Status(), rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockUserService.AssertExpectations(t)
+	})
+
+	t.Run("Bad request data", func(t *testing.T) {
+		mockUserService := new(mocks.UserService)
+
+		rr := httptest.NewRecorder()
+
+		router := getTestRouter()
+
+		NewHandler(&Config{
+			R:           router,
+			UserService: mockUserService,
+		})
+
+		reqBody, err := json.Marshal(gin.H{
+			"ticket": fixture.RandStr(32),
+		})
+		assert.NoError(t, err)
+
+		request, err := http.NewRequest(http.MethodPost, "/api/account/login/2fa", bytes.NewBuffer(reqBody))
+		assert.NoError(t, err)
+
+		request.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(rr, request)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockUserService.AssertNotCalled(t, "VerifyTwoFactorLogin", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
 }
 
 func TestHandler_Logout(t *testing.T) {
@@ -409,11 +626,15 @@ func TestHandler_Logout(t *testing.T) {
 
 		rr := httptest.NewRecorder()
 
+		mockUserService := new(mocks.UserService)
+		mockUserService.On("RevokeLoginSession", mock.Anything, uid, testSessionId).Return(nil)
+
 		// creates a test context for setting a user
 		router := getAuthenticatedTestRouter(uid)
 
 		NewHandler(&Config{
-			R: router,
+			R:           router,
+			UserService: allowSession(mockUserService),
 		})
 
 		request, _ := http.NewRequest(http.MethodPost, "/api/account/logout", nil)
@@ -459,7 +680,7 @@ func TestHandler_ForgotPassword(t *testing.T) {
 
 		NewHandler(&Config{
 			R:            router,
-			UserService:  mockUserService,
+			UserService:  allowSession(mockUserService),
 			MaxBodyBytes: 4 * 1024 * 1024,
 		})
 
@@ -500,7 +721,7 @@ func TestHandler_ForgotPassword(t *testing.T) {
 
 		NewHandler(&Config{
 			R:            router,
-			UserService:  mockUserService,
+			UserService:  allowSession(mockUserService),
 			MaxBodyBytes: 4 * 1024 * 1024,
 		})
 
@@ -544,7 +765,7 @@ func TestHandler_ForgotPassword(t *testing.T) {
 
 		NewHandler(&Config{
 			R:            router,
-			UserService:  mockUserService,
+			UserService:  allowSession(mockUserService),
 			MaxBodyBytes: 4 * 1024 * 1024,
 		})
 
@@ -581,7 +802,7 @@ func TestHandler_ForgotPassword_BadRequest(t *testing.T) {
 
 	NewHandler(&Config{
 		R:            router,
-		UserService:  mockUserService,
+		UserService:  allowSession(mockUserService),
 		MaxBodyBytes: 4 * 1024 * 1024,
 	})
 
@@ -645,6 +866,12 @@ func TestHandler_ResetPassword(t *testing.T) {
 		mockUserService.
 			On("ResetPassword", ResetPasswordArgs...).
 			Return(mockUser, nil)
+		mockUserService.
+			On("RevokeOtherLoginSessions", mock.Anything, mockUser.ID, "").
+			Return(nil)
+		mockUserService.
+			On("CreateLoginSession", mock.Anything, mockUser.ID, mock.Anything, mock.Anything).
+			Return(fixture.RandID(), nil)
 
 		NewHandler(&Config{
 			R:            router,
@@ -824,3 +1051,195 @@ func TestHandler_ResetPassword_BadRequest(t *testing.T) {
 		})
 	}
 }
+
+func TestHandler_VerifyEmail(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+
+	t.Run("Successfully verified", func(t *testing.T) {
+		mockUser := fixture.GetMockUser()
+		token := fixture.RandStr(21)
+
+		mockUserService := new(mocks.UserService)
+		mockUserService.On("VerifyEmail", mock.Anything, token).Return(mockUser, nil)
+
+		rr := httptest.NewRecorder()
+
+		router := getTestRouter()
+
+		NewHandler(&Config{
+			R:           router,
+			UserService: mockUserService,
+		})
+
+		reqBody, err := json.Marshal(gin.H{
+			"token": token,
+		})
+		assert.NoError(t, err)
+
+		request, err := http.NewRequest(http.MethodPost, "/api/account/verify-email", bytes.NewBuffer(reqBody))
+		assert.NoError(t, err)
+
+		request.Header.Set("Content-Type", "application/json")
+
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal(mockUser)
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockUserService.AssertExpectations(t)
+	})
+
+	t.Run("Invalid token", func(t *testing.T) {
+		token := fixture.RandStr(21)
+
+		mockUserService := new(mocks.UserService)
+		mockUserService.
+			On("VerifyEmail", mock.Anything, token).
+			Return(nil, apperrors.NewBadRequest(apperrors.InvalidVerificationToken))
+
+		rr := httptest.NewRecorder()
+
+		router := getTestRouter()
+
+		NewHandler(&Config{
+			R:           router,
+			UserService: mockUserService,
+		})
+
+		reqBody, err := json.Marshal(gin.H{
+			"token": token,
+		})
+		assert.NoError(t, err)
+
+		request, err := http.NewRequest(http.MethodPost, "/api/account/verify-email", bytes.NewBuffer(reqBody))
+		assert.NoError(t, err)
+
+		request.Header.Set("Content-Type", "application/json")
+
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal(getTestFieldErrorResponse("Token", apperrors.InvalidVerificationToken))
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockUserService.AssertExpectations(t)
+	})
+
+	t.Run("Token required", func(t *testing.T) {
+		mockUserService := new(mocks.UserService)
+
+		rr := httptest.NewRecorder()
+
+		router := getTestRouter()
+
+		NewHandler(&Config{
+			R:           router,
+			UserService: mockUserService,
+		})
+
+		reqBody, err := json.Marshal(gin.H{})
+		assert.NoError(t, err)
+
+		request, err := http.NewRequest(http.MethodPost, "/api/account/verify-email", bytes.NewBuffer(reqBody))
+		assert.NoError(t, err)
+
+		request.Header.Set("Content-Type", "application/json")
+
+		router.ServeHTTP(rr, request)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockUserService.AssertNotCalled(t, "VerifyEmail", mock.Anything, mock.Anything)
+	})
+}
+
+func TestHandler_ResendVerification(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+
+	t.Run("Successfully sent mail", func(t *testing.T) {
+		mockUser := fixture.GetMockUser()
+		mockUser.EmailVerified = false
+
+		mockUserService := new(mocks.UserService)
+		mockUserService.On("Get", mockUser.ID).Return(mockUser, nil)
+		mockUserService.On("SendVerificationMail", mock.Anything, mockUser).Return(nil)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(mockUser.ID)
+
+		NewHandler(&Config{
+			R:           router,
+			UserService: allowSession(mockUserService),
+		})
+
+		request, err := http.NewRequest(http.MethodPost, "/api/account/resend-verification", nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal(true)
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockUserService.AssertExpectations(t)
+	})
+
+	t.Run("Already verified", func(t *testing.T) {
+		mockUser := fixture.GetMockUser()
+		mockError := apperrors.NewBadRequest(apperrors.EmailAlreadyVerified)
+
+		mockUserService := new(mocks.UserService)
+		mockUserService.On("Get", mockUser.ID).Return(mockUser, nil)
+		mockUserService.On("SendVerificationMail", mock.Anything, mockUser).Return(mockError)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(mockUser.ID)
+
+		NewHandler(&Config{
+			R:           router,
+			UserService: allowSession(mockUserService),
+		})
+
+		request, err := http.NewRequest(http.MethodPost, "/api/account/resend-verification", nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal(gin.H{
+			"error": mockError,
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, mockError.Status(), rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockUserService.AssertExpectations(t)
+	})
+
+	t.Run("Unauthorized", func(t *testing.T) {
+		mockUserService := new(mocks.UserService)
+
+		rr := httptest.NewRecorder()
+
+		router := getTestRouter()
+
+		NewHandler(&Config{
+			R:           router,
+			UserService: mockUserService,
+		})
+
+		request, err := http.NewRequest(http.MethodPost, "/api/account/resend-verification", nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		mockUserService.AssertNotCalled(t, "SendVerificationMail", mock.Anything, mock.Anything)
+	})
+}
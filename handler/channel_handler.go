@@ -75,11 +75,17 @@ type channelReq struct {
 	IsPublic *bool `json:"isPublic"`
 	// Array of memberIds
 	Members []string `json:"members"`
+	// text or voice. Default is text. Can only be set on creation
+	Type *string `json:"type"`
 } //@name ChannelRequest
 
 func (r channelReq) validate() error {
 	return validation.ValidateStruct(&r,
 		validation.Field(&r.Name, validation.Required, validation.Length(3, 30)),
+		validation.Field(&r.Type, validation.NilOrNotEmpty, validation.In(
+			model.TextChannel,
+			model.VoiceChannel,
+		)),
 	)
 }
 
@@ -147,6 +153,11 @@ func (h *Handler) CreateChannel(c *gin.Context) {
 		Name:     req.Name,
 		IsPublic: true,
 		GuildID:  &guildId,
+		Type:     model.TextChannel,
+	}
+
+	if req.Type != nil {
+		channelParams.Type = *req.Type
 	}
 
 	// Channel is private
@@ -315,7 +326,8 @@ func (h *Handler) DirectMessages(c *gin.Context) {
 }
 
 // GetOrCreateDM gets the DM with the given member and creates it
-// if it does not already exist
+// if it does not already exist. DMs started by non friends need to
+// be accepted by the member in their message requests.
 // DirectMessages godoc
 // @Tags Channels
 // @Summary Get or Create DM
@@ -338,6 +350,22 @@ func (h *Handler) GetOrCreateDM(c *gin.Context) {
 		return
 	}
 
+	authUser, err := h.friendService.GetMemberById(userId)
+
+	if err != nil {
+		log.Printf("Unable to find user for id: %v\n%v", userId, err)
+		e := apperrors.NewNotFound("user", userId)
+
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	if !requireVerifiedEmail(c, authUser) {
+		return
+	}
+
 	member, err := h.friendService.GetMemberById(memberId)
 
 	if err != nil {
@@ -350,6 +378,25 @@ func (h *Handler) GetOrCreateDM(c *gin.Context) {
 		return
 	}
 
+	// Users cannot DM each other if one blocked the other
+	blocked, err := h.friendService.IsBlocked(userId, memberId)
+
+	if err != nil {
+		e := apperrors.NewInternal()
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	if blocked {
+		e := apperrors.NewBadRequest(apperrors.BlockedUserError)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
 	// check if dm channel already exists with these members
 	dmId, err := h.channelService.GetDirectMessageChannel(userId, memberId)
 
@@ -365,11 +412,33 @@ func (h *Handler) GetOrCreateDM(c *gin.Context) {
 
 	// dm already exists
 	if dmId != nil && *dmId != "" {
+		// Opening the DM accepts a pending message request
+		_ = h.channelService.SetDirectMessageRequest(*dmId, userId, false)
 		_ = h.channelService.SetDirectMessageStatus(*dmId, userId, true)
 		c.JSON(http.StatusOK, toDMChannel(member, *dmId, userId))
 		return
 	}
 
+	// Check if the member accepts DMs from the current user
+	allowed, err := h.channelService.AllowsDirectMessage(member, userId)
+
+	if err != nil {
+		log.Printf("Unable to get dm settings for user id: %v\n%v", memberId, err)
+		e := apperrors.NewInternal()
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	if !allowed {
+		e := apperrors.NewBadRequest(apperrors.DMPrivacyError)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
 	// Create the dm channel between the current user and the member
 	id := fmt.Sprintf("%s-%s", userId, memberId)
 	channelParams := model.Channel{
@@ -401,20 +470,37 @@ func (h *Handler) GetOrCreateDM(c *gin.Context) {
 		return
 	}
 
+	// DMs from non friends need to be accepted by the member first
+	if !isFriend(member, userId) {
+		err = h.channelService.SetDirectMessageRequest(channel.ID, memberId, true)
+
+		if err != nil {
+			log.Printf("Failed to create message request: %v\n", err.Error())
+			e := apperrors.NewInternal()
+			c.JSON(e.Status(), gin.H{
+				"error": e,
+			})
+			return
+		}
+	}
+
 	c.JSON(http.StatusOK, toDMChannel(member, channel.ID, userId))
 }
 
 // toDMChannel returns the DM response for the given channel and member
 func toDMChannel(member *model.User, channelId string, userId string) model.DirectMessage {
+	user := model.DMUser{
+		Id:       member.ID,
+		Username: member.Username,
+		Image:    member.Image,
+		IsOnline: member.IsOnline,
+		IsFriend: isFriend(member, userId),
+	}
+
 	return model.DirectMessage{
-		Id: channelId,
-		User: model.DMUser{
-			Id:       member.ID,
-			Username: member.Username,
-			Image:    member.Image,
-			IsOnline: member.IsOnline,
-			IsFriend: isFriend(member, userId),
-		},
+		Id:      channelId,
+		User:    &user,
+		Members: []model.DMUser{user},
 	}
 }
 
@@ -663,3 +749,140 @@ func containsUser(members []string, userId string) bool {
 	}
 	return false
 }
+
+// StartTyping emits that the current user is typing in the given channel.
+// Used by clients that receive events over SSE instead of websockets.
+// StartTyping godoc
+// @Tags Channels
+// @Summary Start Typing
+// @Produce  json
+// @Param id path string true "Channel ID"
+// @Success 200 {object} model.Success
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Router /channels/{id}/typing [post]
+func (h *Handler) StartTyping(c *gin.Context) {
+	userId := c.MustGet("userId").(string)
+	channelId := c.Param("id")
+
+	channel, err := h.channelService.Get(channelId)
+
+	if err != nil {
+		e := apperrors.NewNotFound("channel", channelId)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	// Check if the user has access to said channel
+	if err = h.channelService.IsChannelMember(channel, userId); err != nil {
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
+	user, err := h.userService.Get(userId)
+
+	if err != nil {
+		e := apperrors.NewNotFound("user", userId)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	// Show the nickname in guild channels if the user set one
+	username := user.Username
+	if channel.GuildID != nil {
+		settings, err := h.guildService.GetMemberSettings(userId, *channel.GuildID)
+
+		if err == nil && settings.Nickname != nil {
+			username = *settings.Nickname
+		}
+	}
+
+	h.socketService.EmitStartTyping(&model.TypingUser{
+		ChannelId: channel.ID,
+		UserId:    userId,
+		Username:  username,
+	})
+
+	c.JSON(http.StatusOK, true)
+}
+
+// StopTyping emits that the current user stopped typing in the given channel
+// StopTyping godoc
+// @Tags Channels
+// @Summary Stop Typing
+// @Produce  json
+// @Param id path string true "Channel ID"
+// @Success 200 {object} model.Success
+// @Failure 401 {object} model.ErrorResponse
+// @Router /channels/{id}/typing [delete]
+func (h *Handler) StopTyping(c *gin.Context) {
+	userId := c.MustGet("userId").(string)
+	channelId := c.Param("id")
+
+	// Only removes the user if they started typing in the channel
+	h.socketService.EmitStopTyping(channelId, userId)
+
+	c.JSON(http.StatusOK, true)
+}
+
+// VoiceParticipants returns the users connected to the given voice channel
+// VoiceParticipants godoc
+// @Tags Channels
+// @Summary Get Voice Channel Participants
+// @Produce  json
+// @Param id path string true "Channel ID"
+// @Success 200 {object} model.VoiceChannelState
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Router /channels/{id}/voice [get]
+func (h *Handler) VoiceParticipants(c *gin.Context) {
+	userId := c.MustGet("userId").(string)
+	channelId := c.Param("id")
+
+	channel, err := h.channelService.Get(channelId)
+
+	if err != nil {
+		e := apperrors.NewNotFound("channel", channelId)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	// Check if the user has access to said channel
+	if err = h.channelService.IsChannelMember(channel, userId); err != nil {
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
+	if channel.Type != model.VoiceChannel {
+		e := apperrors.NewBadRequest(apperrors.NotAVoiceChannel)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	participants, err := h.channelService.GetVoiceParticipants(c.Request.Context(), channel.ID)
+
+	if err != nil {
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, model.VoiceChannelState{
+		ChannelId:    channel.ID,
+		Participants: *participants,
+	})
+}
@@ -44,6 +44,7 @@ func TestHandler_GuildChannels(t *testing.T) {
 
 		NewHandler(&Config{
 			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
 			GuildService:   mockGuildService,
 			ChannelService: mockChannelService,
 		})
@@ -77,6 +78,7 @@ func TestHandler_GuildChannels(t *testing.T) {
 
 		NewHandler(&Config{
 			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
 			GuildService:   mockGuildService,
 			ChannelService: mockChannelService,
 		})
@@ -114,6 +116,7 @@ func TestHandler_GuildChannels(t *testing.T) {
 
 		NewHandler(&Config{
 			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
 			GuildService:   mockGuildService,
 			ChannelService: mockChannelService,
 		})
@@ -189,6 +192,7 @@ func TestHandler_GuildChannels(t *testing.T) {
 
 		NewHandler(&Config{
 			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
 			GuildService:   mockGuildService,
 			ChannelService: mockChannelService,
 		})
@@ -228,6 +232,7 @@ func TestHandler_CreateChannel(t *testing.T) {
 			Name:     mockChannel.Name,
 			IsPublic: true,
 			GuildID:  &mockGuild.ID,
+			Type:     model.TextChannel,
 		}
 		mockChannelService.On("CreateChannel", channelParams).Return(mockChannel, nil)
 
@@ -239,6 +244,7 @@ func TestHandler_CreateChannel(t *testing.T) {
 
 		NewHandler(&Config{
 			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
 			GuildService:   mockGuildService,
 			ChannelService: mockChannelService,
 			SocketService:  mockSocketService,
@@ -285,6 +291,7 @@ func TestHandler_CreateChannel(t *testing.T) {
 
 		NewHandler(&Config{
 			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
 			GuildService:   mockGuildService,
 			ChannelService: mockChannelService,
 			SocketService:  mockSocketService,
@@ -338,6 +345,7 @@ func TestHandler_CreateChannel(t *testing.T) {
 
 		NewHandler(&Config{
 			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
 			GuildService:   mockGuildService,
 			ChannelService: mockChannelService,
 			SocketService:  mockSocketService,
@@ -386,6 +394,7 @@ func TestHandler_CreateChannel(t *testing.T) {
 
 		NewHandler(&Config{
 			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
 			GuildService:   mockGuildService,
 			ChannelService: mockChannelService,
 			SocketService:  mockSocketService,
@@ -481,6 +490,7 @@ func TestHandler_CreateChannel(t *testing.T) {
 			Name:     mockChannel.Name,
 			IsPublic: true,
 			GuildID:  &mockGuild.ID,
+			Type:     model.TextChannel,
 		}
 		mockError := apperrors.NewInternal()
 		mockChannelService.On("CreateChannel", channelParams).Return(nil, mockError)
@@ -491,6 +501,7 @@ func TestHandler_CreateChannel(t *testing.T) {
 
 		NewHandler(&Config{
 			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
 			GuildService:   mockGuildService,
 			ChannelService: mockChannelService,
 			SocketService:  mockSocketService,
@@ -543,6 +554,7 @@ func TestHandler_CreateChannel(t *testing.T) {
 			Name:     mockChannel.Name,
 			IsPublic: false,
 			GuildID:  &mockGuild.ID,
+			Type:     model.TextChannel,
 		}
 
 		channelParams.PCMembers = append(channelParams.PCMembers, *authUser)
@@ -556,6 +568,7 @@ func TestHandler_CreateChannel(t *testing.T) {
 
 		NewHandler(&Config{
 			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
 			GuildService:   mockGuildService,
 			ChannelService: mockChannelService,
 			SocketService:  mockSocketService,
@@ -600,6 +613,7 @@ func TestHandler_CreateChannel_BadRequest(t *testing.T) {
 
 	NewHandler(&Config{
 		R:              router,
+		UserService:    allowSession(new(mocks.UserService)),
 		GuildService:   mockGuildService,
 		ChannelService: mockChannelService,
 	})
@@ -682,6 +696,7 @@ func TestHandler_PrivateChannelMembers(t *testing.T) {
 
 		NewHandler(&Config{
 			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
 			GuildService:   mockGuildService,
 			ChannelService: mockChannelService,
 		})
@@ -716,6 +731,7 @@ func TestHandler_PrivateChannelMembers(t *testing.T) {
 
 		NewHandler(&Config{
 			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
 			GuildService:   mockGuildService,
 			ChannelService: mockChannelService,
 		})
@@ -754,6 +770,7 @@ func TestHandler_PrivateChannelMembers(t *testing.T) {
 
 		NewHandler(&Config{
 			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
 			GuildService:   mockGuildService,
 			ChannelService: mockChannelService,
 		})
@@ -794,6 +811,7 @@ func TestHandler_PrivateChannelMembers(t *testing.T) {
 
 		NewHandler(&Config{
 			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
 			GuildService:   mockGuildService,
 			ChannelService: mockChannelService,
 		})
@@ -876,6 +894,7 @@ func TestHandler_PrivateChannelMembers(t *testing.T) {
 
 		NewHandler(&Config{
 			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
 			GuildService:   mockGuildService,
 			ChannelService: mockChannelService,
 		})
@@ -916,6 +935,7 @@ func TestHandler_DirectMessages(t *testing.T) {
 
 		NewHandler(&Config{
 			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
 			ChannelService: mockChannelService,
 		})
 
@@ -976,6 +996,7 @@ func TestHandler_DirectMessages(t *testing.T) {
 
 		NewHandler(&Config{
 			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
 			ChannelService: mockChannelService,
 		})
 
@@ -1007,16 +1028,20 @@ func TestHandler_GetOrCreateDM(t *testing.T) {
 		dmId := fixture.RandID()
 
 		mockFriendService := new(mocks.FriendService)
+		mockFriendService.On("GetMemberById", authUser.ID).Return(authUser, nil)
 		mockFriendService.On("GetMemberById", mockUser.ID).Return(mockUser, nil)
+		mockFriendService.On("IsBlocked", authUser.ID, mockUser.ID).Return(false, nil)
 
 		mockChannelService := new(mocks.ChannelService)
 		mockChannelService.On("GetDirectMessageChannel", authUser.ID, mockUser.ID).Return(&dmId, nil)
+		mockChannelService.On("SetDirectMessageRequest", dmId, authUser.ID, false).Return(nil)
 		mockChannelService.On("SetDirectMessageStatus", dmId, authUser.ID, true).Return(nil)
 
 		router := getAuthenticatedTestRouter(authUser.ID)
 
 		NewHandler(&Config{
 			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
 			FriendService:  mockFriendService,
 			ChannelService: mockChannelService,
 		})
@@ -1045,10 +1070,13 @@ func TestHandler_GetOrCreateDM(t *testing.T) {
 		mockUser := fixture.GetMockUser()
 
 		mockFriendService := new(mocks.FriendService)
+		mockFriendService.On("GetMemberById", authUser.ID).Return(authUser, nil)
 		mockFriendService.On("GetMemberById", mockUser.ID).Return(mockUser, nil)
+		mockFriendService.On("IsBlocked", authUser.ID, mockUser.ID).Return(false, nil)
 
 		mockChannelService := new(mocks.ChannelService)
 		mockChannelService.On("GetDirectMessageChannel", authUser.ID, mockUser.ID).Return(nil, nil)
+		mockChannelService.On("AllowsDirectMessage", mockUser, authUser.ID).Return(true, nil)
 
 		mockDM := fixture.GetMockDMChannel()
 		channelParams := &model.Channel{
@@ -1065,11 +1093,13 @@ func TestHandler_GetOrCreateDM(t *testing.T) {
 			authUser.ID,
 		}
 		mockChannelService.On("AddDMChannelMembers", mockArgs...).Return(nil)
+		mockChannelService.On("SetDirectMessageRequest", mockDM.ID, mockUser.ID, true).Return(nil)
 
 		router := getAuthenticatedTestRouter(authUser.ID)
 
 		NewHandler(&Config{
 			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
 			FriendService:  mockFriendService,
 			ChannelService: mockChannelService,
 		})
@@ -1093,11 +1123,142 @@ func TestHandler_GetOrCreateDM(t *testing.T) {
 		mockChannelService.AssertExpectations(t)
 	})
 
+	t.Run("Successfully returned a new DM with a friend", func(t *testing.T) {
+		mockUser := fixture.GetMockUser()
+		mockUser.Friends = append(mockUser.Friends, *authUser)
+
+		mockFriendService := new(mocks.FriendService)
+		mockFriendService.On("GetMemberById", authUser.ID).Return(authUser, nil)
+		mockFriendService.On("GetMemberById", mockUser.ID).Return(mockUser, nil)
+		mockFriendService.On("IsBlocked", authUser.ID, mockUser.ID).Return(false, nil)
+
+		mockChannelService := new(mocks.ChannelService)
+		mockChannelService.On("GetDirectMessageChannel", authUser.ID, mockUser.ID).Return(nil, nil)
+		mockChannelService.On("AllowsDirectMessage", mockUser, authUser.ID).Return(true, nil)
+
+		mockDM := fixture.GetMockDMChannel()
+		channelParams := &model.Channel{
+			Name:     fmt.Sprintf("%s-%s", authUser.ID, mockUser.ID),
+			IsPublic: false,
+			IsDM:     true,
+		}
+		mockChannelService.On("CreateChannel", channelParams).Return(mockDM, nil)
+
+		ids := []string{authUser.ID, mockUser.ID}
+		mockChannelService.On("AddDMChannelMembers", ids, mockDM.ID, authUser.ID).Return(nil)
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
+			FriendService:  mockFriendService,
+			ChannelService: mockChannelService,
+		})
+
+		rr := httptest.NewRecorder()
+
+		url := fmt.Sprintf("/api/channels/%s/dm", mockUser.ID)
+		request, err := http.NewRequest(http.MethodPost, url, nil)
+		assert.NoError(t, err)
+
+		respBody, _ := json.Marshal(toDMChannel(mockUser, mockDM.ID, authUser.ID))
+		router.ServeHTTP(rr, request)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+
+		mockFriendService.AssertExpectations(t)
+		mockChannelService.AssertExpectations(t)
+		mockChannelService.AssertNotCalled(t, "SetDirectMessageRequest", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Member does not accept DMs from the user", func(t *testing.T) {
+		mockUser := fixture.GetMockUser()
+
+		mockFriendService := new(mocks.FriendService)
+		mockFriendService.On("GetMemberById", authUser.ID).Return(authUser, nil)
+		mockFriendService.On("GetMemberById", mockUser.ID).Return(mockUser, nil)
+		mockFriendService.On("IsBlocked", authUser.ID, mockUser.ID).Return(false, nil)
+
+		mockChannelService := new(mocks.ChannelService)
+		mockChannelService.On("GetDirectMessageChannel", authUser.ID, mockUser.ID).Return(nil, nil)
+		mockChannelService.On("AllowsDirectMessage", mockUser, authUser.ID).Return(false, nil)
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
+			FriendService:  mockFriendService,
+			ChannelService: mockChannelService,
+		})
+
+		rr := httptest.NewRecorder()
+
+		url := fmt.Sprintf("/api/channels/%s/dm", mockUser.ID)
+		request, err := http.NewRequest(http.MethodPost, url, nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		mockError := apperrors.NewBadRequest(apperrors.DMPrivacyError)
+		respBody, _ := json.Marshal(gin.H{
+			"error": mockError,
+		})
+
+		assert.Equal(t, mockError.Status(), rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+
+		mockChannelService.AssertExpectations(t)
+		mockChannelService.AssertNotCalled(t, "CreateChannel", mock.Anything)
+	})
+
+	t.Run("Unverified email", func(t *testing.T) {
+		mockUser := fixture.GetMockUser()
+		current := fixture.GetMockUser()
+		current.EmailVerified = false
+
+		mockFriendService := new(mocks.FriendService)
+		mockFriendService.On("GetMemberById", current.ID).Return(current, nil)
+
+		mockChannelService := new(mocks.ChannelService)
+
+		router := getAuthenticatedTestRouter(current.ID)
+
+		NewHandler(&Config{
+			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
+			FriendService:  mockFriendService,
+			ChannelService: mockChannelService,
+		})
+
+		rr := httptest.NewRecorder()
+
+		url := fmt.Sprintf("/api/channels/%s/dm", mockUser.ID)
+		request, err := http.NewRequest(http.MethodPost, url, nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		mockError := apperrors.NewAuthorization(apperrors.EmailNotVerified)
+		respBody, _ := json.Marshal(gin.H{
+			"error": mockError,
+		})
+
+		assert.Equal(t, mockError.Status(), rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+
+		mockFriendService.AssertNotCalled(t, "GetMemberById", mockUser.ID)
+		mockChannelService.AssertNotCalled(t, "CreateChannel", mock.Anything)
+	})
+
 	t.Run("Member not found", func(t *testing.T) {
 		id := fixture.RandID()
 		mockError := apperrors.NewNotFound("member", id)
 
 		mockFriendService := new(mocks.FriendService)
+		mockFriendService.On("GetMemberById", authUser.ID).Return(authUser, nil)
 		mockFriendService.On("GetMemberById", id).Return(nil, mockError)
 
 		mockChannelService := new(mocks.ChannelService)
@@ -1106,6 +1267,7 @@ func TestHandler_GetOrCreateDM(t *testing.T) {
 
 		NewHandler(&Config{
 			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
 			FriendService:  mockFriendService,
 			ChannelService: mockChannelService,
 		})
@@ -1140,6 +1302,7 @@ func TestHandler_GetOrCreateDM(t *testing.T) {
 
 		NewHandler(&Config{
 			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
 			FriendService:  mockFriendService,
 			ChannelService: mockChannelService,
 		})
@@ -1208,10 +1371,13 @@ func TestHandler_GetOrCreateDM(t *testing.T) {
 		mockUser := fixture.GetMockUser()
 
 		mockFriendService := new(mocks.FriendService)
+		mockFriendService.On("GetMemberById", authUser.ID).Return(authUser, nil)
 		mockFriendService.On("GetMemberById", mockUser.ID).Return(mockUser, nil)
+		mockFriendService.On("IsBlocked", authUser.ID, mockUser.ID).Return(false, nil)
 
 		mockChannelService := new(mocks.ChannelService)
 		mockChannelService.On("GetDirectMessageChannel", authUser.ID, mockUser.ID).Return(nil, nil)
+		mockChannelService.On("AllowsDirectMessage", mockUser, authUser.ID).Return(true, nil)
 
 		mockDM := fixture.GetMockDMChannel()
 		channelParams := &model.Channel{
@@ -1234,6 +1400,7 @@ func TestHandler_GetOrCreateDM(t *testing.T) {
 
 		NewHandler(&Config{
 			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
 			FriendService:  mockFriendService,
 			ChannelService: mockChannelService,
 		})
@@ -1284,6 +1451,7 @@ func TestHandler_EditChannel(t *testing.T) {
 
 		NewHandler(&Config{
 			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
 			GuildService:   mockGuildService,
 			ChannelService: mockChannelService,
 			SocketService:  mockSocketService,
@@ -1331,6 +1499,7 @@ func TestHandler_EditChannel(t *testing.T) {
 
 		NewHandler(&Config{
 			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
 			GuildService:   mockGuildService,
 			ChannelService: mockChannelService,
 			SocketService:  mockSocketService,
@@ -1379,6 +1548,7 @@ func TestHandler_EditChannel(t *testing.T) {
 
 		NewHandler(&Config{
 			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
 			GuildService:   mockGuildService,
 			ChannelService: mockChannelService,
 			SocketService:  mockSocketService,
@@ -1428,6 +1598,7 @@ func TestHandler_EditChannel(t *testing.T) {
 
 		NewHandler(&Config{
 			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
 			GuildService:   mockGuildService,
 			ChannelService: mockChannelService,
 			SocketService:  mockSocketService,
@@ -1532,6 +1703,7 @@ func TestHandler_EditChannel(t *testing.T) {
 
 		NewHandler(&Config{
 			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
 			GuildService:   mockGuildService,
 			ChannelService: mockChannelService,
 			SocketService:  mockSocketService,
@@ -1592,6 +1764,7 @@ func TestHandler_EditChannel(t *testing.T) {
 
 		NewHandler(&Config{
 			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
 			GuildService:   mockGuildService,
 			ChannelService: mockChannelService,
 			SocketService:  mockSocketService,
@@ -1650,6 +1823,7 @@ func TestHandler_EditChannel(t *testing.T) {
 
 		NewHandler(&Config{
 			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
 			GuildService:   mockGuildService,
 			ChannelService: mockChannelService,
 			SocketService:  mockSocketService,
@@ -1694,6 +1868,7 @@ func TestHandler_EditChannel_BadRequest(t *testing.T) {
 
 	NewHandler(&Config{
 		R:              router,
+		UserService:    allowSession(new(mocks.UserService)),
 		GuildService:   mockGuildService,
 		ChannelService: mockChannelService,
 	})
@@ -1769,6 +1944,7 @@ func TestHandler_DeleteChannel(t *testing.T) {
 
 		NewHandler(&Config{
 			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
 			GuildService:   mockGuildService,
 			ChannelService: mockChannelService,
 			SocketService:  mockSocketService,
@@ -1809,6 +1985,7 @@ func TestHandler_DeleteChannel(t *testing.T) {
 
 		NewHandler(&Config{
 			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
 			GuildService:   mockGuildService,
 			ChannelService: mockChannelService,
 			SocketService:  mockSocketService,
@@ -1853,6 +2030,7 @@ func TestHandler_DeleteChannel(t *testing.T) {
 
 		NewHandler(&Config{
 			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
 			GuildService:   mockGuildService,
 			ChannelService: mockChannelService,
 			SocketService:  mockSocketService,
@@ -1897,6 +2075,7 @@ func TestHandler_DeleteChannel(t *testing.T) {
 
 		NewHandler(&Config{
 			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
 			GuildService:   mockGuildService,
 			ChannelService: mockChannelService,
 			SocketService:  mockSocketService,
@@ -1943,6 +2122,7 @@ func TestHandler_DeleteChannel(t *testing.T) {
 
 		NewHandler(&Config{
 			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
 			GuildService:   mockGuildService,
 			ChannelService: mockChannelService,
 			SocketService:  mockSocketService,
@@ -2035,6 +2215,7 @@ func TestHandler_DeleteChannel(t *testing.T) {
 
 		NewHandler(&Config{
 			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
 			GuildService:   mockGuildService,
 			ChannelService: mockChannelService,
 			SocketService:  mockSocketService,
@@ -2090,6 +2271,7 @@ func TestHandler_CloseDM(t *testing.T) {
 
 		NewHandler(&Config{
 			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
 			ChannelService: mockChannelService,
 		})
 
@@ -2126,6 +2308,7 @@ func TestHandler_CloseDM(t *testing.T) {
 
 		NewHandler(&Config{
 			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
 			ChannelService: mockChannelService,
 		})
 
@@ -2209,6 +2392,7 @@ func TestHandler_CloseDM(t *testing.T) {
 
 		NewHandler(&Config{
 			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
 			ChannelService: mockChannelService,
 		})
 
@@ -2230,3 +2414,319 @@ func TestHandler_CloseDM(t *testing.T) {
 		mockChannelService.AssertExpectations(t)
 	})
 }
+
+func TestHandler_StartTyping(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	authUser := fixture.GetMockUser()
+
+	t.Run("Emits the nickname in guild channels", func(t *testing.T) {
+		guildId := fixture.RandID()
+		channel := fixture.GetMockChannel(guildId)
+		nickname := fixture.RandStr(8)
+
+		mockChannelService := new(mocks.ChannelService)
+		mockChannelService.On("Get", channel.ID).Return(channel, nil)
+		mockChannelService.On("IsChannelMember", channel, authUser.ID).Return(nil)
+
+		mockUserService := new(mocks.UserService)
+		mockUserService.On("Get", authUser.ID).Return(authUser, nil)
+
+		mockGuildService := new(mocks.GuildService)
+		mockGuildService.On("GetMemberSettings", authUser.ID, guildId).Return(&model.MemberSettings{
+			Nickname: &nickname,
+		}, nil)
+
+		mockSocketService := new(mocks.SocketService)
+		mockSocketService.On("EmitStartTyping", &model.TypingUser{
+			ChannelId: channel.ID,
+			UserId:    authUser.ID,
+			Username:  nickname,
+		}).Return()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:              router,
+			ChannelService: mockChannelService,
+			UserService:    allowSession(mockUserService),
+			GuildService:   mockGuildService,
+			SocketService:  mockSocketService,
+		})
+
+		rr := httptest.NewRecorder()
+
+		url := fmt.Sprintf("/api/channels/%s/typing", channel.ID)
+		request, err := http.NewRequest(http.MethodPost, url, nil)
+		assert.NoError(t, err)
+
+		respBody, _ := json.Marshal(true)
+		router.ServeHTTP(rr, request)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+
+		mockChannelService.AssertExpectations(t)
+		mockGuildService.AssertExpectations(t)
+		mockSocketService.AssertExpectations(t)
+	})
+
+	t.Run("Emits the username in DMs", func(t *testing.T) {
+		channel := fixture.GetMockDMChannel()
+
+		mockChannelService := new(mocks.ChannelService)
+		mockChannelService.On("Get", channel.ID).Return(channel, nil)
+		mockChannelService.On("IsChannelMember", channel, authUser.ID).Return(nil)
+
+		mockUserService := new(mocks.UserService)
+		mockUserService.On("Get", authUser.ID).Return(authUser, nil)
+
+		mockGuildService := new(mocks.GuildService)
+
+		mockSocketService := new(mocks.SocketService)
+		mockSocketService.On("EmitStartTyping", &model.TypingUser{
+			ChannelId: channel.ID,
+			UserId:    authUser.ID,
+			Username:  authUser.Username,
+		}).Return()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:              router,
+			ChannelService: mockChannelService,
+			UserService:    allowSession(mockUserService),
+			GuildService:   mockGuildService,
+			SocketService:  mockSocketService,
+		})
+
+		rr := httptest.NewRecorder()
+
+		url := fmt.Sprintf("/api/channels/%s/typing", channel.ID)
+		request, err := http.NewRequest(http.MethodPost, url, nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		mockGuildService.AssertNotCalled(t, "GetMemberSettings", mock.Anything, mock.Anything)
+		mockSocketService.AssertExpectations(t)
+	})
+
+	t.Run("Not a member of the channel", func(t *testing.T) {
+		channel := fixture.GetMockChannel(fixture.RandID())
+
+		mockError := apperrors.NewAuthorization(apperrors.Unauthorized)
+		mockChannelService := new(mocks.ChannelService)
+		mockChannelService.On("Get", channel.ID).Return(channel, nil)
+		mockChannelService.On("IsChannelMember", channel, authUser.ID).Return(mockError)
+
+		mockSocketService := new(mocks.SocketService)
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
+			ChannelService: mockChannelService,
+			SocketService:  mockSocketService,
+		})
+
+		rr := httptest.NewRecorder()
+
+		url := fmt.Sprintf("/api/channels/%s/typing", channel.ID)
+		request, err := http.NewRequest(http.MethodPost, url, nil)
+		assert.NoError(t, err)
+
+		respBody, _ := json.Marshal(gin.H{
+			"error": mockError,
+		})
+		router.ServeHTTP(rr, request)
+
+		assert.Equal(t, mockError.Status(), rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+
+		mockSocketService.AssertNotCalled(t, "EmitStartTyping", mock.Anything)
+	})
+
+	t.Run("Channel not found", func(t *testing.T) {
+		channelId := fixture.RandID()
+
+		mockChannelService := new(mocks.ChannelService)
+		mockChannelService.On("Get", channelId).Return(nil, fmt.Errorf("some error"))
+
+		mockSocketService := new(mocks.SocketService)
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
+			ChannelService: mockChannelService,
+			SocketService:  mockSocketService,
+		})
+
+		rr := httptest.NewRecorder()
+
+		url := fmt.Sprintf("/api/channels/%s/typing", channelId)
+		request, err := http.NewRequest(http.MethodPost, url, nil)
+		assert.NoError(t, err)
+
+		mockError := apperrors.NewNotFound("channel", channelId)
+		respBody, _ := json.Marshal(gin.H{
+			"error": mockError,
+		})
+		router.ServeHTTP(rr, request)
+
+		assert.Equal(t, mockError.Status(), rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+
+		mockSocketService.AssertNotCalled(t, "EmitStartTyping", mock.Anything)
+	})
+}
+
+func TestHandler_StopTyping(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	authUser := fixture.GetMockUser()
+	channelId := fixture.RandID()
+
+	mockSocketService := new(mocks.SocketService)
+	mockSocketService.On("EmitStopTyping", channelId, authUser.ID).Return()
+
+	router := getAuthenticatedTestRouter(authUser.ID)
+
+	NewHandler(&Config{
+		R:             router,
+		UserService:   allowSession(new(mocks.UserService)),
+		SocketService: mockSocketService,
+	})
+
+	rr := httptest.NewRecorder()
+
+	url := fmt.Sprintf("/api/channels/%s/typing", channelId)
+	request, err := http.NewRequest(http.MethodDelete, url, nil)
+	assert.NoError(t, err)
+
+	respBody, _ := json.Marshal(true)
+	router.ServeHTTP(rr, request)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, respBody, rr.Body.Bytes())
+
+	mockSocketService.AssertExpectations(t)
+}
+
+func TestHandler_VoiceParticipants(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	authUser := fixture.GetMockUser()
+
+	t.Run("Returns the participants of the voice channel", func(t *testing.T) {
+		guildId := fixture.RandID()
+		channel := fixture.GetMockChannel(guildId)
+		channel.Type = model.VoiceChannel
+
+		participants := []model.VoiceState{
+			{
+				UserId:    authUser.ID,
+				ChannelId: channel.ID,
+				GuildId:   guildId,
+			},
+		}
+
+		mockChannelService := new(mocks.ChannelService)
+		mockChannelService.On("Get", channel.ID).Return(channel, nil)
+		mockChannelService.On("IsChannelMember", channel, authUser.ID).Return(nil)
+		mockChannelService.On("GetVoiceParticipants", mock.Anything, channel.ID).Return(&participants, nil)
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
+			ChannelService: mockChannelService,
+		})
+
+		rr := httptest.NewRecorder()
+
+		url := fmt.Sprintf("/api/channels/%s/voice", channel.ID)
+		request, err := http.NewRequest(http.MethodGet, url, nil)
+		assert.NoError(t, err)
+
+		respBody, _ := json.Marshal(model.VoiceChannelState{
+			ChannelId:    channel.ID,
+			Participants: participants,
+		})
+		router.ServeHTTP(rr, request)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+
+		mockChannelService.AssertExpectations(t)
+	})
+
+	t.Run("Text channels have no participants", func(t *testing.T) {
+		channel := fixture.GetMockChannel(fixture.RandID())
+
+		mockChannelService := new(mocks.ChannelService)
+		mockChannelService.On("Get", channel.ID).Return(channel, nil)
+		mockChannelService.On("IsChannelMember", channel, authUser.ID).Return(nil)
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
+			ChannelService: mockChannelService,
+		})
+
+		rr := httptest.NewRecorder()
+
+		url := fmt.Sprintf("/api/channels/%s/voice", channel.ID)
+		request, err := http.NewRequest(http.MethodGet, url, nil)
+		assert.NoError(t, err)
+
+		mockError := apperrors.NewBadRequest(apperrors.NotAVoiceChannel)
+		respBody, _ := json.Marshal(gin.H{
+			"error": mockError,
+		})
+		router.ServeHTTP(rr, request)
+
+		assert.Equal(t, mockError.Status(), rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+
+		mockChannelService.AssertExpectations(t)
+		mockChannelService.AssertNotCalled(t, "GetVoiceParticipants", mock.Anything, channel.ID)
+	})
+
+	t.Run("Not a member of the channel", func(t *testing.T) {
+		channel := fixture.GetMockChannel(fixture.RandID())
+		channel.Type = model.VoiceChannel
+
+		mockError := apperrors.NewAuthorization(apperrors.Unauthorized)
+
+		mockChannelService := new(mocks.ChannelService)
+		mockChannelService.On("Get", channel.ID).Return(channel, nil)
+		mockChannelService.On("IsChannelMember", channel, authUser.ID).Return(mockError)
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
+			ChannelService: mockChannelService,
+		})
+
+		rr := httptest.NewRecorder()
+
+		url := fmt.Sprintf("/api/channels/%s/voice", channel.ID)
+		request, err := http.NewRequest(http.MethodGet, url, nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		assert.Equal(t, mockError.Status(), rr.Code)
+
+		mockChannelService.AssertExpectations(t)
+		mockChannelService.AssertNotCalled(t, "GetVoiceParticipants", mock.Anything, channel.ID)
+	})
+}
@@ -0,0 +1,505 @@
+package handler
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"log"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+)
+
+/*
+ * CommandHandler contains all routes related to slash commands of bots and their interactions
+ */
+
+var commandNameRegex = regexp.MustCompile(`^[a-z0-9_-]+$`)
+
+type commandReq struct {
+	// Lowercase letters, numbers, - and _. Min 1, max 32 characters.
+	Name string `json:"name"`
+	// Min 1, max 100 characters.
+	Description string `json:"description"`
+	// Max 10 options. Required options must come before optional ones
+	Options []model.CommandOption `json:"options"`
+	// The http(s) URL the interactions get posted to
+	CallbackUrl string `json:"callbackUrl"`
+} //@name CommandRequest
+
+func (r commandReq) validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Name, validation.Required, validation.Length(1, 32), validation.Match(commandNameRegex)),
+		validation.Field(&r.Description, validation.Required, validation.Length(1, 100)),
+		validation.Field(&r.Options, validation.Length(0, 10), validation.By(validateCommandOptions)),
+		validation.Field(&r.CallbackUrl, validation.Required, is.URL, validation.Match(httpURLRegex).Error("must be a http(s) url")),
+	)
+}
+
+func (r *commandReq) sanitize() {
+	r.Description = strings.TrimSpace(r.Description)
+	r.CallbackUrl = strings.TrimSpace(r.CallbackUrl)
+
+	for i := range r.Options {
+		r.Options[i].Description = strings.TrimSpace(r.Options[i].Description)
+	}
+}
+
+// validateCommandOptions checks the options of a command
+func validateCommandOptions(value interface{}) error {
+	options, _ := value.([]model.CommandOption)
+	names := make(map[string]bool)
+	optional := false
+
+	for _, option := range options {
+		err := validation.ValidateStruct(&option,
+			validation.Field(&option.Name, validation.Required, validation.Length(1, 32), validation.Match(commandNameRegex)),
+			validation.Field(&option.Description, validation.Length(0, 100)),
+			validation.Field(&option.Type, validation.Required, validation.In(
+				model.StringOption,
+				model.IntegerOption,
+				model.NumberOption,
+				model.BooleanOption,
+			)),
+		)
+
+		if err != nil {
+			return err
+		}
+
+		if names[option.Name] {
+			return errors.New(apperrors.DuplicateCommandOption)
+		}
+		names[option.Name] = true
+
+		if option.Required && optional {
+			return errors.New(apperrors.CommandOptionOrder)
+		}
+		optional = !option.Required
+	}
+
+	return nil
+}
+
+// GetGuildCommands returns the commands of the given guild.
+// Filters the commands by the start of their name if a query is given
+// GetGuildCommands godoc
+// @Tags Guilds
+// @Summary Get Guild Commands
+// @Produce  json
+// @Param guildId path string true "Guild ID"
+// @Param query query string false "Start of the command name"
+// @Success 200 {array} model.CommandResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /guilds/{guildId}/commands [get]
+func (h *Handler) GetGuildCommands(c *gin.Context) {
+	guildId := c.Param("guildId")
+	userId := c.MustGet("userId").(string)
+	query := strings.TrimPrefix(strings.TrimSpace(c.Query("query")), "/")
+
+	if _, ok := h.getMemberGuild(c, guildId, userId); !ok {
+		return
+	}
+
+	commands, err := h.commandService.GetGuildCommands(guildId, query)
+
+	if err != nil {
+		log.Printf("Unable to find commands for guild: %v\n%v", guildId, err)
+		e := apperrors.NewInternal()
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	response := make([]model.CommandResponse, 0)
+	for _, command := range *commands {
+		response = append(response, command.SerializeCommand())
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// CreateCommand registers a slash command for the current bot in the given guild.
+// The bot must be a member of the guild. The signing secret is only returned once.
+// CreateCommand godoc
+// @Tags Guilds
+// @Summary Create Command
+// @Accept  json
+// @Produce  json
+// @Param guildId path string true "Guild ID"
+// @Param request body commandReq true "Create Command"
+// @Success 201 {object} model.CommandResponse
+// @Failure 400 {object} model.ErrorsResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 409 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /guilds/{guildId}/commands [post]
+func (h *Handler) CreateCommand(c *gin.Context) {
+	guildId := c.Param("guildId")
+	userId := c.MustGet("userId").(string)
+
+	var req commandReq
+	if ok := bindData(c, &req); !ok {
+		return
+	}
+
+	req.sanitize()
+
+	bot, err := h.userService.Get(userId)
+
+	if err != nil {
+		e := apperrors.NewNotFound("user", userId)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	if !bot.IsBot {
+		e := apperrors.NewBadRequest(apperrors.CommandBotsOnly)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	if _, ok := h.getMemberGuild(c, guildId, userId); !ok {
+		return
+	}
+
+	params := model.Command{
+		GuildId:     guildId,
+		BotId:       userId,
+		Name:        req.Name,
+		Description: req.Description,
+		Options:     req.Options,
+		CallbackUrl: req.CallbackUrl,
+	}
+
+	command, err := h.commandService.CreateCommand(&params)
+
+	if err != nil {
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
+	response := command.SerializeCommand()
+	response.Secret = command.Secret
+
+	c.JSON(http.StatusCreated, response)
+}
+
+// EditCommand edits the given command of the current bot
+// EditCommand godoc
+// @Tags Guilds
+// @Summary Edit Command
+// @Accept  json
+// @Produce  json
+// @Param guildId path string true "Guild ID"
+// @Param id path string true "Command ID"
+// @Param request body commandReq true "Edit Command"
+// @Success 200 {object} model.CommandResponse
+// @Failure 400 {object} model.ErrorsResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 409 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /guilds/{guildId}/commands/{id} [put]
+func (h *Handler) EditCommand(c *gin.Context) {
+	guildId := c.Param("guildId")
+	commandId := c.Param("id")
+	userId := c.MustGet("userId").(string)
+
+	var req commandReq
+	if ok := bindData(c, &req); !ok {
+		return
+	}
+
+	req.sanitize()
+
+	command, err := h.commandService.Get(commandId)
+
+	if err != nil || command.GuildId != guildId || command.BotId != userId {
+		e := apperrors.NewNotFound("command", commandId)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	command.Name = req.Name
+	command.Description = req.Description
+	command.Options = req.Options
+	command.CallbackUrl = req.CallbackUrl
+
+	if err := h.commandService.UpdateCommand(command); err != nil {
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, command.SerializeCommand())
+}
+
+// DeleteCommand deletes the given command.
+// Only the bot of the command and the guild owner can delete it
+// DeleteCommand godoc
+// @Tags Guilds
+// @Summary Delete Command
+// @Produce  json
+// @Param guildId path string true "Guild ID"
+// @Param id path string true "Command ID"
+// @Success 200 {object} model.Success
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /guilds/{guildId}/commands/{id} [delete]
+func (h *Handler) DeleteCommand(c *gin.Context) {
+	guildId := c.Param("guildId")
+	commandId := c.Param("id")
+	userId := c.MustGet("userId").(string)
+
+	command, err := h.commandService.Get(commandId)
+
+	if err != nil || command.GuildId != guildId {
+		e := apperrors.NewNotFound("command", commandId)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	if command.BotId != userId {
+		if ok := h.isWebhookGuildOwner(c, guildId, userId); !ok {
+			return
+		}
+	}
+
+	if err := h.commandService.DeleteCommand(command); err != nil {
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, true)
+}
+
+type interactionReq struct {
+	// Min 1, max 2000 characters.
+	Text string `json:"text"`
+} //@name InteractionRequest
+
+func (r interactionReq) validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Text, validation.Required, validation.Length(1, 2000)),
+	)
+}
+
+func (r *interactionReq) sanitize() {
+	r.Text = strings.TrimSpace(r.Text)
+}
+
+// RespondInteraction posts the deferred response of the current bot to the given interaction.
+// Interactions can be responded to once within 15 minutes
+// RespondInteraction godoc
+// @Tags Messages
+// @Summary Respond to Interaction
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Interaction ID"
+// @Param request body interactionReq true "Interaction Response"
+// @Success 201 {object} model.Success
+// @Failure 400 {object} model.ErrorsResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /interactions/{id} [post]
+func (h *Handler) RespondInteraction(c *gin.Context) {
+	interactionId := c.Param("id")
+	userId := c.MustGet("userId").(string)
+
+	var req interactionReq
+	if ok := bindData(c, &req); !ok {
+		return
+	}
+
+	req.sanitize()
+
+	interaction, err := h.commandService.GetInteraction(interactionId)
+
+	if err != nil || interaction.BotId != userId {
+		e := apperrors.NewNotFound("interaction", interactionId)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	channel, err := h.channelService.Get(interaction.ChannelId)
+
+	if err != nil {
+		e := apperrors.NewNotFound("channel", interaction.ChannelId)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	if err := h.postBotMessage(channel, userId, req.Text); err != nil {
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
+	_ = h.commandService.CompleteInteraction(interaction.Id)
+
+	c.JSON(http.StatusCreated, true)
+}
+
+// runCommand invokes the slash command the text starts with and posts the response of the bot.
+// Returns false if the guild has no such command, so the text gets posted as a message.
+func (h *Handler) runCommand(c *gin.Context, channel *model.Channel, author *model.User, text string) bool {
+	name := strings.Fields(strings.TrimPrefix(text, "/"))
+	if len(name) == 0 {
+		return false
+	}
+
+	command, err := h.commandService.FindCommand(*channel.GuildID, name[0])
+
+	if err != nil {
+		return false
+	}
+
+	input := strings.TrimSpace(strings.TrimPrefix(text, "/"+name[0]))
+	options, err := h.commandService.ParseOptions(command, input)
+
+	if err != nil {
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return true
+	}
+
+	response, err := h.commandService.Invoke(command, channel.ID, author, options)
+
+	if err != nil {
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return true
+	}
+
+	if response.Type == model.MessageInteractionResponse {
+		if err := h.postBotMessage(channel, command.BotId, response.Text); err != nil {
+			c.JSON(apperrors.Status(err), gin.H{
+				"error": err,
+			})
+			return true
+		}
+	}
+
+	c.JSON(http.StatusCreated, true)
+	return true
+}
+
+// postBotMessage posts the text as the given bot into the guild channel.
+// Returns an error if the bot cannot access the channel.
+func (h *Handler) postBotMessage(channel *model.Channel, botId string, text string) error {
+	bot, err := h.userService.Get(botId)
+
+	if err != nil {
+		return apperrors.NewNotFound("user", botId)
+	}
+
+	// The bot might have been removed from the guild or the channel since the command got invoked
+	settings, err := h.guildService.GetMemberSettings(bot.ID, *channel.GuildID)
+
+	if err != nil {
+		return apperrors.NewAuthorization(apperrors.NotAMember)
+	}
+
+	if err = h.channelService.IsChannelMember(channel, bot.ID); err != nil {
+		return err
+	}
+
+	message, err := h.messageService.CreateMessage(&model.Message{
+		Text:      &text,
+		UserId:    bot.ID,
+		ChannelId: channel.ID,
+	})
+
+	if err != nil {
+		log.Printf("Failed to create bot message: %v\n", err.Error())
+		return err
+	}
+
+	response := model.MessageResponse{
+		Id:         message.ID,
+		Text:       message.Text,
+		Type:       message.Type,
+		CreatedAt:  message.CreatedAt,
+		UpdatedAt:  message.UpdatedAt,
+		Attachment: message.Attachment,
+		User: model.MemberResponse{
+			Id:        bot.ID,
+			Username:  bot.Username,
+			Image:     bot.Image,
+			IsOnline:  bot.IsOnline,
+			Status:    bot.GetPresence().Status,
+			CreatedAt: bot.CreatedAt,
+			UpdatedAt: bot.UpdatedAt,
+			IsFriend:  false,
+		},
+	}
+
+	response.User.Nickname = settings.Nickname
+	response.User.Color = settings.Color
+
+	// Emit new message to the channel
+	h.socketService.EmitNewMessage(channel.ID, &response)
+
+	// Update last activity in channel
+	channel.LastActivity = time.Now()
+	_ = h.channelService.UpdateChannel(channel)
+	// Post a notification
+	h.socketService.EmitNewNotification(*channel.GuildID, channel.ID)
+
+	return nil
+}
+
+// getMemberGuild returns the given guild if the user is a member of it.
+// Writes the error response otherwise.
+func (h *Handler) getMemberGuild(c *gin.Context, guildId string, userId string) (*model.Guild, bool) {
+	guild, err := h.guildService.GetGuild(guildId)
+
+	if err != nil {
+		e := apperrors.NewNotFound("guild", guildId)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return nil, false
+	}
+
+	if !isMember(guild, userId) {
+		e := apperrors.NewAuthorization(apperrors.NotAMember)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return nil, false
+	}
+
+	return guild, true
+}
@@ -0,0 +1,724 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/sentrionic/valkyrie/mocks"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"github.com/sentrionic/valkyrie/model/fixture"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func getMockCommand(guildId string, botId string) *model.Command {
+	command := &model.Command{
+		GuildId:     guildId,
+		BotId:       botId,
+		Name:        "roll",
+		Description: "Rolls a dice",
+		Options: model.CommandOptions{
+			{Name: "sides", Type: model.IntegerOption, Required: true},
+		},
+		CallbackUrl: "https://example.com/interactions",
+		Secret:      fixture.RandStr(43),
+	}
+	command.ID = fixture.RandID()
+	command.CreatedAt = time.Now()
+	command.UpdatedAt = time.Now()
+	return command
+}
+
+func TestHandler_GetGuildCommands(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	authUser := fixture.GetMockUser()
+
+	t.Run("Autocompletes the commands", func(t *testing.T) {
+		mockGuild := fixture.GetMockGuild(fixture.RandID())
+		mockGuild.Members = append(mockGuild.Members, *authUser)
+		commands := []model.Command{*getMockCommand(mockGuild.ID, fixture.RandID())}
+
+		mockGuildService := new(mocks.GuildService)
+		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
+
+		mockCommandService := new(mocks.CommandService)
+		mockCommandService.On("GetGuildCommands", mockGuild.ID, "ro").Return(&commands, nil)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
+			GuildService:   mockGuildService,
+			CommandService: mockCommandService,
+		})
+
+		request, err := http.NewRequest(http.MethodGet, "/api/guilds/"+mockGuild.ID+"/commands?query=/ro", nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal([]model.CommandResponse{commands[0].SerializeCommand()})
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockCommandService.AssertExpectations(t)
+	})
+
+	t.Run("Not a member", func(t *testing.T) {
+		mockGuild := fixture.GetMockGuild(fixture.RandID())
+
+		mockGuildService := new(mocks.GuildService)
+		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
+
+		mockCommandService := new(mocks.CommandService)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
+			GuildService:   mockGuildService,
+			CommandService: mockCommandService,
+		})
+
+		request, err := http.NewRequest(http.MethodGet, "/api/guilds/"+mockGuild.ID+"/commands", nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		mockError := apperrors.NewAuthorization(apperrors.NotAMember)
+		respBody, err := json.Marshal(gin.H{
+			"error": mockError,
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, mockError.Status(), rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockCommandService.AssertNotCalled(t, "GetGuildCommands", mock.Anything, mock.Anything)
+	})
+}
+
+func TestHandler_CreateCommand(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+
+	t.Run("Successfully created", func(t *testing.T) {
+		bot := fixture.GetMockUser()
+		bot.IsBot = true
+		mockGuild := fixture.GetMockGuild(fixture.RandID())
+		mockGuild.Members = append(mockGuild.Members, *bot)
+		command := getMockCommand(mockGuild.ID, bot.ID)
+
+		params := &model.Command{
+			GuildId:     mockGuild.ID,
+			BotId:       bot.ID,
+			Name:        command.Name,
+			Description: command.Description,
+			Options:     command.Options,
+			CallbackUrl: command.CallbackUrl,
+		}
+
+		mockUserService := new(mocks.UserService)
+		mockUserService.On("Get", bot.ID).Return(bot, nil)
+
+		mockGuildService := new(mocks.GuildService)
+		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
+
+		mockCommandService := new(mocks.CommandService)
+		mockCommandService.On("CreateCommand", params).Return(command, nil)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(bot.ID)
+
+		NewHandler(&Config{
+			R:              router,
+			UserService:    allowSession(mockUserService),
+			GuildService:   mockGuildService,
+			CommandService: mockCommandService,
+		})
+
+		reqBody, err := json.Marshal(gin.H{
+			"name":        command.Name,
+			"description": command.Description,
+			"options":     command.Options,
+			"callbackUrl": command.CallbackUrl,
+		})
+		assert.NoError(t, err)
+
+		request, err := http.NewRequest(http.MethodPost, "/api/guilds/"+mockGuild.ID+"/commands", bytes.NewBuffer(reqBody))
+		assert.NoError(t, err)
+		request.Header.Set("Content-Type", "application/json")
+
+		router.ServeHTTP(rr, request)
+
+		response := command.SerializeCommand()
+		response.Secret = command.Secret
+		respBody, err := json.Marshal(response)
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusCreated, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockCommandService.AssertExpectations(t)
+	})
+
+	t.Run("Only bots can register commands", func(t *testing.T) {
+		authUser := fixture.GetMockUser()
+		mockGuild := fixture.GetMockGuild(authUser.ID)
+
+		mockUserService := new(mocks.UserService)
+		mockUserService.On("Get", authUser.ID).Return(authUser, nil)
+
+		mockCommandService := new(mocks.CommandService)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:              router,
+			UserService:    allowSession(mockUserService),
+			CommandService: mockCommandService,
+		})
+
+		reqBody, err := json.Marshal(gin.H{
+			"name":        "roll",
+			"description": "Rolls a dice",
+			"callbackUrl": "https://example.com",
+		})
+		assert.NoError(t, err)
+
+		request, err := http.NewRequest(http.MethodPost, "/api/guilds/"+mockGuild.ID+"/commands", bytes.NewBuffer(reqBody))
+		assert.NoError(t, err)
+		request.Header.Set("Content-Type", "application/json")
+
+		router.ServeHTTP(rr, request)
+
+		mockError := apperrors.NewBadRequest(apperrors.CommandBotsOnly)
+		respBody, err := json.Marshal(gin.H{
+			"error": mockError,
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, mockError.Status(), rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockCommandService.AssertNotCalled(t, "CreateCommand", mock.Anything)
+	})
+
+	testCases := []struct {
+		name string
+		body gin.H
+	}{
+		{
+			name: "Name must be lowercase",
+			body: gin.H{"name": "Roll", "description": "Rolls a dice", "callbackUrl": "https://example.com"},
+		},
+		{
+			name: "Unknown option type",
+			body: gin.H{
+				"name": "roll", "description": "Rolls a dice", "callbackUrl": "https://example.com",
+				"options": []gin.H{{"name": "sides", "type": "dice"}},
+			},
+		},
+		{
+			name: "Duplicate option",
+			body: gin.H{
+				"name": "roll", "description": "Rolls a dice", "callbackUrl": "https://example.com",
+				"options": []gin.H{{"name": "sides", "type": "integer"}, {"name": "sides", "type": "string"}},
+			},
+		},
+		{
+			name: "Required option after optional one",
+			body: gin.H{
+				"name": "roll", "description": "Rolls a dice", "callbackUrl": "https://example.com",
+				"options": []gin.H{{"name": "sides", "type": "integer"}, {"name": "count", "type": "integer", "required": true}},
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			mockCommandService := new(mocks.CommandService)
+
+			rr := httptest.NewRecorder()
+
+			router := getAuthenticatedTestRouter(fixture.RandID())
+
+			NewHandler(&Config{
+				R:              router,
+				UserService:    allowSession(new(mocks.UserService)),
+				CommandService: mockCommandService,
+			})
+
+			reqBody, err := json.Marshal(tc.body)
+			assert.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/api/guilds/"+fixture.RandID()+"/commands", bytes.NewBuffer(reqBody))
+			assert.NoError(t, err)
+			request.Header.Set("Content-Type", "application/json")
+
+			router.ServeHTTP(rr, request)
+
+			assert.Equal(t, http.StatusBadRequest, rr.Code)
+			mockCommandService.AssertNotCalled(t, "CreateCommand", mock.Anything)
+		})
+	}
+}
+
+func TestHandler_DeleteCommand(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	authUser := fixture.GetMockUser()
+
+	t.Run("Guild owner can delete commands", func(t *testing.T) {
+		mockGuild := fixture.GetMockGuild(authUser.ID)
+		command := getMockCommand(mockGuild.ID, fixture.RandID())
+
+		mockGuildService := new(mocks.GuildService)
+		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
+
+		mockCommandService := new(mocks.CommandService)
+		mockCommandService.On("Get", command.ID).Return(command, nil)
+		mockCommandService.On("DeleteCommand", command).Return(nil)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
+			GuildService:   mockGuildService,
+			CommandService: mockCommandService,
+		})
+
+		request, err := http.NewRequest(http.MethodDelete, "/api/guilds/"+mockGuild.ID+"/commands/"+command.ID, nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		mockCommandService.AssertExpectations(t)
+	})
+
+	t.Run("Other members cannot delete commands", func(t *testing.T) {
+		mockGuild := fixture.GetMockGuild(fixture.RandID())
+		command := getMockCommand(mockGuild.ID, fixture.RandID())
+
+		mockGuildService := new(mocks.GuildService)
+		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
+
+		mockCommandService := new(mocks.CommandService)
+		mockCommandService.On("Get", command.ID).Return(command, nil)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
+			GuildService:   mockGuildService,
+			CommandService: mockCommandService,
+		})
+
+		request, err := http.NewRequest(http.MethodDelete, "/api/guilds/"+mockGuild.ID+"/commands/"+command.ID, nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		mockCommandService.AssertNotCalled(t, "DeleteCommand", mock.Anything)
+	})
+}
+
+func TestHandler_CreateMessage_Command(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	authUser := fixture.GetMockUser()
+	bot := fixture.GetMockUser()
+	bot.IsBot = true
+
+	t.Run("Posts the response of the bot", func(t *testing.T) {
+		mockGuild := fixture.GetMockGuild("")
+		mockChannel := fixture.GetMockChannel(mockGuild.ID)
+		command := getMockCommand(mockGuild.ID, bot.ID)
+		text := "You rolled a 4"
+		options := map[string]interface{}{"sides": int64(6)}
+
+		mockChannelService := new(mocks.ChannelService)
+		mockChannelService.On("Get", mockChannel.ID).Return(mockChannel, nil)
+		mockChannelService.On("IsChannelMember", mockChannel, authUser.ID).Return(nil)
+		mockChannelService.On("IsChannelMember", mockChannel, bot.ID).Return(nil)
+		mockChannelService.On("UpdateChannel", mockChannel).Return(nil)
+
+		mockUserService := new(mocks.UserService)
+		mockUserService.On("Get", authUser.ID).Return(authUser, nil)
+		mockUserService.On("Get", bot.ID).Return(bot, nil)
+
+		mockCommandService := new(mocks.CommandService)
+		mockCommandService.On("FindCommand", mockGuild.ID, "roll").Return(command, nil)
+		mockCommandService.On("ParseOptions", command, "6").Return(options, nil)
+		mockCommandService.On("Invoke", command, mockChannel.ID, authUser, options).Return(&model.InteractionResponse{
+			Type: model.MessageInteractionResponse,
+			Text: text,
+		}, nil)
+
+		mockMessage := fixture.GetMockMessage(bot.ID, mockChannel.ID)
+		mockMessage.Text = &text
+
+		mockMessageService := new(mocks.MessageService)
+		mockMessageService.On("CreateMessage", &model.Message{
+			Text:      &text,
+			UserId:    bot.ID,
+			ChannelId: mockChannel.ID,
+		}).Return(mockMessage, nil)
+
+		mockGuildService := new(mocks.GuildService)
+		mockGuildService.On("GetMemberSettings", bot.ID, mockGuild.ID).Return(&model.MemberSettings{}, nil)
+
+		mockSocketService := new(mocks.SocketService)
+		mockSocketService.On("EmitNewMessage", mockChannel.ID, mock.MatchedBy(func(response *model.MessageResponse) bool {
+			return response.User.Id == bot.ID && *response.Text == text
+		})).Return()
+		mockSocketService.On("EmitNewNotification", mockGuild.ID, mockChannel.ID)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:              router,
+			ChannelService: mockChannelService,
+			MessageService: mockMessageService,
+			GuildService:   mockGuildService,
+			SocketService:  mockSocketService,
+			UserService:    allowSession(mockUserService),
+			CommandService: mockCommandService,
+		})
+
+		form := url.Values{}
+		form.Add("text", "/roll 6")
+
+		request, err := http.NewRequest(http.MethodPost, "/api/messages/"+mockChannel.ID, strings.NewReader(form.Encode()))
+		assert.NoError(t, err)
+		request.Form = form
+
+		router.ServeHTTP(rr, request)
+
+		assert.Equal(t, http.StatusCreated, rr.Code)
+		mockCommandService.AssertExpectations(t)
+		mockMessageService.AssertExpectations(t)
+		mockSocketService.AssertExpectations(t)
+	})
+
+	t.Run("Invalid options", func(t *testing.T) {
+		mockGuild := fixture.GetMockGuild("")
+		mockChannel := fixture.GetMockChannel(mockGuild.ID)
+		command := getMockCommand(mockGuild.ID, bot.ID)
+		mockError := apperrors.NewBadRequest("Option sides must be of type integer")
+
+		mockChannelService := new(mocks.ChannelService)
+		mockChannelService.On("Get", mockChannel.ID).Return(mockChannel, nil)
+		mockChannelService.On("IsChannelMember", mockChannel, authUser.ID).Return(nil)
+
+		mockUserService := new(mocks.UserService)
+		mockUserService.On("Get", authUser.ID).Return(authUser, nil)
+
+		mockCommandService := new(mocks.CommandService)
+		mockCommandService.On("FindCommand", mockGuild.ID, "roll").Return(command, nil)
+		mockCommandService.On("ParseOptions", command, "six").Return(nil, mockError)
+
+		mockMessageService := new(mocks.MessageService)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:              router,
+			ChannelService: mockChannelService,
+			MessageService: mockMessageService,
+			UserService:    allowSession(mockUserService),
+			CommandService: mockCommandService,
+		})
+
+		form := url.Values{}
+		form.Add("text", "/roll six")
+
+		request, err := http.NewRequest(http.MethodPost, "/api/messages/"+mockChannel.ID, strings.NewReader(form.Encode()))
+		assert.NoError(t, err)
+		request.Form = form
+
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal(gin.H{
+			"error": mockError,
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, mockError.Status(), rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockCommandService.AssertNotCalled(t, "Invoke", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		mockMessageService.AssertNotCalled(t, "CreateMessage", mock.Anything)
+	})
+
+	t.Run("Unknown commands are posted as text", func(t *testing.T) {
+		mockGuild := fixture.GetMockGuild("")
+		mockChannel := fixture.GetMockChannel(mockGuild.ID)
+		text := "/shrug"
+		mockMessage := fixture.GetMockMessage(authUser.ID, mockChannel.ID)
+		mockMessage.Text = &text
+
+		mockChannelService := new(mocks.ChannelService)
+		mockChannelService.On("Get", mockChannel.ID).Return(mockChannel, nil)
+		mockChannelService.On("IsChannelMember", mockChannel, authUser.ID).Return(nil)
+		mockChannelService.On("UpdateChannel", mockChannel).Return(nil)
+
+		mockUserService := new(mocks.UserService)
+		mockUserService.On("Get", authUser.ID).Return(authUser, nil)
+
+		mockCommandService := new(mocks.CommandService)
+		mockCommandService.On("FindCommand", mockGuild.ID, "shrug").Return(nil, apperrors.NewNotFound("command", "shrug"))
+
+		mockMessageService := new(mocks.MessageService)
+		mockMessageService.On("CreateMessage", &model.Message{
+			Text:      &text,
+			UserId:    authUser.ID,
+			ChannelId: mockChannel.ID,
+		}).Return(mockMessage, nil)
+
+		mockGuildService := new(mocks.GuildService)
+		mockGuildService.On("GetMemberSettings", authUser.ID, mockGuild.ID).Return(&model.MemberSettings{}, nil)
+
+		mockSocketService := new(mocks.SocketService)
+		mockSocketService.On("EmitNewMessage", mockChannel.ID, mock.Anything).Return()
+		mockSocketService.On("EmitNewNotification", mockGuild.ID, mockChannel.ID)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:              router,
+			ChannelService: mockChannelService,
+			MessageService: mockMessageService,
+			GuildService:   mockGuildService,
+			SocketService:  mockSocketService,
+			UserService:    allowSession(mockUserService),
+			CommandService: mockCommandService,
+		})
+
+		form := url.Values{}
+		form.Add("text", text)
+
+		request, err := http.NewRequest(http.MethodPost, "/api/messages/"+mockChannel.ID, strings.NewReader(form.Encode()))
+		assert.NoError(t, err)
+		request.Form = form
+
+		router.ServeHTTP(rr, request)
+
+		assert.Equal(t, http.StatusCreated, rr.Code)
+		mockMessageService.AssertExpectations(t)
+	})
+}
+
+func TestHandler_RespondInteraction(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	bot := fixture.GetMockUser()
+	bot.IsBot = true
+
+	t.Run("Posts the deferred response", func(t *testing.T) {
+		mockGuild := fixture.GetMockGuild("")
+		mockChannel := fixture.GetMockChannel(mockGuild.ID)
+		text := "Done!"
+		interaction := &model.Interaction{
+			Id:        fixture.RandID(),
+			BotId:     bot.ID,
+			GuildId:   mockGuild.ID,
+			ChannelId: mockChannel.ID,
+			UserId:    fixture.RandID(),
+		}
+
+		mockCommandService := new(mocks.CommandService)
+		mockCommandService.On("GetInteraction", interaction.Id).Return(interaction, nil)
+		mockCommandService.On("CompleteInteraction", interaction.Id).Return(nil)
+
+		mockChannelService := new(mocks.ChannelService)
+		mockChannelService.On("Get", mockChannel.ID).Return(mockChannel, nil)
+		mockChannelService.On("IsChannelMember", mockChannel, bot.ID).Return(nil)
+		mockChannelService.On("UpdateChannel", mockChannel).Return(nil)
+
+		mockUserService := new(mocks.UserService)
+		mockUserService.On("Get", bot.ID).Return(bot, nil)
+
+		mockMessage := fixture.GetMockMessage(bot.ID, mockChannel.ID)
+		mockMessage.Text = &text
+
+		mockMessageService := new(mocks.MessageService)
+		mockMessageService.On("CreateMessage", &model.Message{
+			Text:      &text,
+			UserId:    bot.ID,
+			ChannelId: mockChannel.ID,
+		}).Return(mockMessage, nil)
+
+		mockGuildService := new(mocks.GuildService)
+		mockGuildService.On("GetMemberSettings", bot.ID, mockGuild.ID).Return(&model.MemberSettings{}, nil)
+
+		mockSocketService := new(mocks.SocketService)
+		mockSocketService.On("EmitNewMessage", mockChannel.ID, mock.Anything).Return()
+		mockSocketService.On("EmitNewNotification", mockGuild.ID, mockChannel.ID)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(bot.ID)
+
+		NewHandler(&Config{
+			R:              router,
+			ChannelService: mockChannelService,
+			MessageService: mockMessageService,
+			GuildService:   mockGuildService,
+			SocketService:  mockSocketService,
+			UserService:    allowSession(mockUserService),
+			CommandService: mockCommandService,
+		})
+
+		reqBody, err := json.Marshal(gin.H{
+			"text": text,
+		})
+		assert.NoError(t, err)
+
+		request, err := http.NewRequest(http.MethodPost, "/api/interactions/"+interaction.Id, bytes.NewBuffer(reqBody))
+		assert.NoError(t, err)
+		request.Header.Set("Content-Type", "application/json")
+
+		router.ServeHTTP(rr, request)
+
+		assert.Equal(t, http.StatusCreated, rr.Code)
+		mockMessageService.AssertExpectations(t)
+		mockCommandService.AssertExpectations(t)
+	})
+
+	t.Run("Bot got removed from the guild", func(t *testing.T) {
+		mockGuild := fixture.GetMockGuild("")
+		mockChannel := fixture.GetMockChannel(mockGuild.ID)
+		interaction := &model.Interaction{
+			Id:        fixture.RandID(),
+			BotId:     bot.ID,
+			GuildId:   mockGuild.ID,
+			ChannelId: mockChannel.ID,
+			UserId:    fixture.RandID(),
+		}
+
+		mockCommandService := new(mocks.CommandService)
+		mockCommandService.On("GetInteraction", interaction.Id).Return(interaction, nil)
+
+		mockChannelService := new(mocks.ChannelService)
+		mockChannelService.On("Get", mockChannel.ID).Return(mockChannel, nil)
+
+		mockUserService := new(mocks.UserService)
+		mockUserService.On("Get", bot.ID).Return(bot, nil)
+
+		mockGuildService := new(mocks.GuildService)
+		mockGuildService.On("GetMemberSettings", bot.ID, mockGuild.ID).Return(nil, apperrors.NewNotFound("member", bot.ID))
+
+		mockMessageService := new(mocks.MessageService)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(bot.ID)
+
+		NewHandler(&Config{
+			R:              router,
+			ChannelService: mockChannelService,
+			MessageService: mockMessageService,
+			GuildService:   mockGuildService,
+			UserService:    allowSession(mockUserService),
+			CommandService: mockCommandService,
+		})
+
+		reqBody, err := json.Marshal(gin.H{
+			"text": "Done!",
+		})
+		assert.NoError(t, err)
+
+		request, err := http.NewRequest(http.MethodPost, "/api/interactions/"+interaction.Id, bytes.NewBuffer(reqBody))
+		assert.NoError(t, err)
+		request.Header.Set("Content-Type", "application/json")
+
+		router.ServeHTTP(rr, request)
+
+		mockError := apperrors.NewAuthorization(apperrors.NotAMember)
+		respBody, err := json.Marshal(gin.H{
+			"error": mockError,
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, mockError.Status(), rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockMessageService.AssertNotCalled(t, "CreateMessage", mock.Anything)
+		mockCommandService.AssertNotCalled(t, "CompleteInteraction", mock.Anything)
+	})
+
+	t.Run("Interaction of another bot", func(t *testing.T) {
+		interaction := &model.Interaction{
+			Id:    fixture.RandID(),
+			BotId: fixture.RandID(),
+		}
+
+		mockCommandService := new(mocks.CommandService)
+		mockCommandService.On("GetInteraction", interaction.Id).Return(interaction, nil)
+
+		mockMessageService := new(mocks.MessageService)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(bot.ID)
+
+		NewHandler(&Config{
+			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
+			MessageService: mockMessageService,
+			CommandService: mockCommandService,
+		})
+
+		reqBody, err := json.Marshal(gin.H{
+			"text": "Done!",
+		})
+		assert.NoError(t, err)
+
+		request, err := http.NewRequest(http.MethodPost, "/api/interactions/"+interaction.Id, bytes.NewBuffer(reqBody))
+		assert.NoError(t, err)
+		request.Header.Set("Content-Type", "application/json")
+
+		router.ServeHTTP(rr, request)
+
+		mockError := apperrors.NewNotFound("interaction", interaction.Id)
+		respBody, err := json.Marshal(gin.H{
+			"error": mockError,
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, mockError.Status(), rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockMessageService.AssertNotCalled(t, "CreateMessage", mock.Anything)
+		mockCommandService.AssertNotCalled(t, "CompleteInteraction", mock.Anything)
+	})
+}
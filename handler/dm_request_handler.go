@@ -0,0 +1,144 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"log"
+	"net/http"
+)
+
+/*
+ * DMRequestHandler contains all routes related to message requests (/api/channels)
+ */
+
+// DMRequests returns the DMs started by non friends that the
+// current user has not accepted yet
+// DMRequests godoc
+// @Tags Channels
+// @Summary Get User's Message Requests
+// @Produce  json
+// @Success 200 {array} model.DirectMessage
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Router /channels/me/dm/requests [get]
+func (h *Handler) DMRequests(c *gin.Context) {
+	userId := c.MustGet("userId").(string)
+
+	requests, err := h.channelService.GetDMRequests(userId)
+
+	if err != nil {
+		log.Printf("Unable to find message requests for user id: %v\n%v", userId, err)
+		e := apperrors.NewNotFound("dms", userId)
+
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, requests)
+}
+
+// AcceptDMRequest accepts the message request and opens
+// the DM on the current user's side
+// AcceptDMRequest godoc
+// @Tags Channels
+// @Summary Accept Message Request
+// @Produce  json
+// @Param id path string true "DM Channel ID"
+// @Success 200 {object} model.DirectMessage
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /channels/me/dm/requests/{id} [post]
+func (h *Handler) AcceptDMRequest(c *gin.Context) {
+	userId := c.MustGet("userId").(string)
+	channelId := c.Param("id")
+
+	dm, ok := h.getDMRequest(c, channelId, userId)
+
+	if !ok {
+		return
+	}
+
+	if err := h.channelService.SetDirectMessageRequest(channelId, userId, false); err != nil {
+		log.Printf("Unable to accept message request: %v\n%v", channelId, err)
+		e := apperrors.NewInternal()
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	_ = h.channelService.SetDirectMessageStatus(channelId, userId, true)
+
+	// Add the DM to the user's DM list
+	h.socketService.EmitAddDM([]string{userId}, channelId)
+
+	dm.IsRequest = false
+	c.JSON(http.StatusOK, dm)
+}
+
+// DeclineDMRequest declines the message request and deletes the DM
+// DeclineDMRequest godoc
+// @Tags Channels
+// @Summary Decline Message Request
+// @Produce  json
+// @Param id path string true "DM Channel ID"
+// @Success 200 {object} model.Success
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /channels/me/dm/requests/{id} [delete]
+func (h *Handler) DeclineDMRequest(c *gin.Context) {
+	userId := c.MustGet("userId").(string)
+	channelId := c.Param("id")
+
+	dm, ok := h.getDMRequest(c, channelId, userId)
+
+	if !ok {
+		return
+	}
+
+	channel, err := h.channelService.Get(channelId)
+
+	if err != nil {
+		e := apperrors.NewNotFound("channel", channelId)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	if err = h.channelService.DeleteChannel(channel); err != nil {
+		log.Printf("Unable to decline message request: %v\n%v", channelId, err)
+		e := apperrors.NewInternal()
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	// Remove the DM for both users
+	h.socketService.EmitRemoveDM(userId, channelId)
+	for _, member := range dm.Members {
+		h.socketService.EmitRemoveDM(member.Id, channelId)
+	}
+
+	c.JSON(http.StatusOK, true)
+}
+
+// getDMRequest returns the DM for the given id if it is a pending message request
+// of the user. Writes the error response and returns false otherwise.
+func (h *Handler) getDMRequest(c *gin.Context, channelId string, userId string) (*model.DirectMessage, bool) {
+	dm, err := h.channelService.GetDirectMessage(channelId, userId)
+
+	if err != nil || !dm.IsRequest {
+		e := apperrors.NewNotFound("message request", channelId)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return nil, false
+	}
+
+	return dm, true
+}
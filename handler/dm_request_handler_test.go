@@ -0,0 +1,264 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/sentrionic/valkyrie/mocks"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"github.com/sentrionic/valkyrie/model/fixture"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHandler_DMRequests(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	authUser := fixture.GetMockUser()
+
+	t.Run("Successful Fetch", func(t *testing.T) {
+		mockUser := fixture.GetMockUser()
+		member := model.DMUser{
+			Id:       mockUser.ID,
+			Username: mockUser.Username,
+			Image:    mockUser.Image,
+		}
+		requests := []model.DirectMessage{
+			{
+				Id:        fixture.RandID(),
+				User:      &member,
+				Members:   []model.DMUser{member},
+				IsRequest: true,
+			},
+		}
+
+		mockChannelService := new(mocks.ChannelService)
+		mockChannelService.On("GetDMRequests", authUser.ID).Return(&requests, nil)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
+			ChannelService: mockChannelService,
+		})
+
+		request, err := http.NewRequest(http.MethodGet, "/api/channels/me/dm/requests", nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal(requests)
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockChannelService.AssertExpectations(t)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		mockChannelService := new(mocks.ChannelService)
+		mockChannelService.On("GetDMRequests", authUser.ID).Return(nil, fmt.Errorf("some error down the call chain"))
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
+			ChannelService: mockChannelService,
+		})
+
+		request, err := http.NewRequest(http.MethodGet, "/api/channels/me/dm/requests", nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		mockError := apperrors.NewNotFound("dms", authUser.ID)
+		respBody, err := json.Marshal(gin.H{
+			"error": mockError,
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, mockError.Status(), rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockChannelService.AssertExpectations(t)
+	})
+}
+
+func TestHandler_AcceptDMRequest(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	authUser := fixture.GetMockUser()
+
+	t.Run("Successfully accepted request", func(t *testing.T) {
+		dm := &model.DirectMessage{
+			Id:        fixture.RandID(),
+			Members:   make([]model.DMUser, 0),
+			IsRequest: true,
+		}
+
+		mockChannelService := new(mocks.ChannelService)
+		mockChannelService.On("GetDirectMessage", dm.Id, authUser.ID).Return(dm, nil)
+		mockChannelService.On("SetDirectMessageRequest", dm.Id, authUser.ID, false).Return(nil)
+		mockChannelService.On("SetDirectMessageStatus", dm.Id, authUser.ID, true).Return(nil)
+
+		mockSocketService := new(mocks.SocketService)
+		mockSocketService.On("EmitAddDM", []string{authUser.ID}, dm.Id).Return()
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
+			ChannelService: mockChannelService,
+			SocketService:  mockSocketService,
+		})
+
+		url := fmt.Sprintf("/api/channels/me/dm/requests/%s", dm.Id)
+		request, err := http.NewRequest(http.MethodPost, url, nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal(model.DirectMessage{
+			Id:        dm.Id,
+			Members:   dm.Members,
+			IsRequest: false,
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockChannelService.AssertExpectations(t)
+		mockSocketService.AssertExpectations(t)
+	})
+
+	t.Run("DM is not a request", func(t *testing.T) {
+		dm := &model.DirectMessage{
+			Id:      fixture.RandID(),
+			Members: make([]model.DMUser, 0),
+		}
+
+		mockChannelService := new(mocks.ChannelService)
+		mockChannelService.On("GetDirectMessage", dm.Id, authUser.ID).Return(dm, nil)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
+			ChannelService: mockChannelService,
+		})
+
+		url := fmt.Sprintf("/api/channels/me/dm/requests/%s", dm.Id)
+		request, err := http.NewRequest(http.MethodPost, url, nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		mockError := apperrors.NewNotFound("message request", dm.Id)
+		respBody, err := json.Marshal(gin.H{
+			"error": mockError,
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, mockError.Status(), rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockChannelService.AssertNotCalled(t, "SetDirectMessageRequest", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestHandler_DeclineDMRequest(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	authUser := fixture.GetMockUser()
+
+	t.Run("Successfully declined request", func(t *testing.T) {
+		mockUser := fixture.GetMockUser()
+		channel := fixture.GetMockDMChannel()
+		dm := &model.DirectMessage{
+			Id:        channel.ID,
+			Members:   []model.DMUser{{Id: mockUser.ID, Username: mockUser.Username}},
+			IsRequest: true,
+		}
+
+		mockChannelService := new(mocks.ChannelService)
+		mockChannelService.On("GetDirectMessage", dm.Id, authUser.ID).Return(dm, nil)
+		mockChannelService.On("Get", channel.ID).Return(channel, nil)
+		mockChannelService.On("DeleteChannel", channel).Return(nil)
+
+		mockSocketService := new(mocks.SocketService)
+		mockSocketService.On("EmitRemoveDM", authUser.ID, channel.ID).Return()
+		mockSocketService.On("EmitRemoveDM", mockUser.ID, channel.ID).Return()
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
+			ChannelService: mockChannelService,
+			SocketService:  mockSocketService,
+		})
+
+		url := fmt.Sprintf("/api/channels/me/dm/requests/%s", dm.Id)
+		request, err := http.NewRequest(http.MethodDelete, url, nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal(true)
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockChannelService.AssertExpectations(t)
+		mockSocketService.AssertExpectations(t)
+	})
+
+	t.Run("Request not found", func(t *testing.T) {
+		id := fixture.RandID()
+
+		mockChannelService := new(mocks.ChannelService)
+		mockChannelService.On("GetDirectMessage", id, authUser.ID).Return(nil, apperrors.NewNotFound("dms", id))
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
+			ChannelService: mockChannelService,
+		})
+
+		url := fmt.Sprintf("/api/channels/me/dm/requests/%s", id)
+		request, err := http.NewRequest(http.MethodDelete, url, nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		mockError := apperrors.NewNotFound("message request", id)
+		respBody, err := json.Marshal(gin.H{
+			"error": mockError,
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, mockError.Status(), rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockChannelService.AssertNotCalled(t, "DeleteChannel", mock.Anything)
+	})
+}
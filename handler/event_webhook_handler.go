@@ -0,0 +1,301 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"log"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+/*
+ * EventWebhookHandler contains all routes related to outgoing guild event webhooks
+ */
+
+var httpURLRegex = regexp.MustCompile(`^https?://`)
+
+var eventsRule = validation.Each(validation.In(
+	model.MessageCreatedEvent,
+	model.MessageUpdatedEvent,
+	model.MessageDeletedEvent,
+	model.MemberJoinedEvent,
+	model.MemberLeftEvent,
+	model.ChannelCreatedEvent,
+	model.ChannelUpdatedEvent,
+	model.ChannelDeletedEvent,
+).Error("unknown event"))
+
+type createEventWebhookReq struct {
+	// The http(s) URL the events get posted to
+	Url string `json:"url"`
+	// The events the webhook receives.
+	// message.created, message.updated, message.deleted, member.joined,
+	// member.left, channel.created, channel.updated or channel.deleted
+	Events []string `json:"events"`
+} //@name CreateEventWebhookRequest
+
+func (r createEventWebhookReq) validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Url, validation.Required, is.URL, validation.Match(httpURLRegex).Error("must be a http(s) url")),
+		validation.Field(&r.Events, validation.Required, eventsRule),
+	)
+}
+
+func (r *createEventWebhookReq) sanitize() {
+	r.Url = strings.TrimSpace(r.Url)
+}
+
+type editEventWebhookReq struct {
+	// The http(s) URL the events get posted to
+	Url string `json:"url"`
+	// The events the webhook receives
+	Events []string `json:"events"`
+	// Enabling the webhook resets its failed deliveries
+	Enabled bool `json:"enabled"`
+} //@name EditEventWebhookRequest
+
+func (r editEventWebhookReq) validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Url, validation.Required, is.URL, validation.Match(httpURLRegex).Error("must be a http(s) url")),
+		validation.Field(&r.Events, validation.Required, eventsRule),
+	)
+}
+
+func (r *editEventWebhookReq) sanitize() {
+	r.Url = strings.TrimSpace(r.Url)
+}
+
+// GetEventWebhooks returns the event webhooks of the given guild
+// GetEventWebhooks godoc
+// @Tags Guilds
+// @Summary Get Guild Event Webhooks
+// @Produce  json
+// @Param guildId path string true "Guild ID"
+// @Success 200 {array} model.EventWebhookResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /guilds/{guildId}/event-webhooks [get]
+func (h *Handler) GetEventWebhooks(c *gin.Context) {
+	guildId := c.Param("guildId")
+	userId := c.MustGet("userId").(string)
+
+	if ok := h.isWebhookGuildOwner(c, guildId, userId); !ok {
+		return
+	}
+
+	webhooks, err := h.eventService.GetGuildEventWebhooks(guildId)
+
+	if err != nil {
+		log.Printf("Unable to find event webhooks for guild: %v\n%v", guildId, err)
+		e := apperrors.NewInternal()
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	response := make([]model.EventWebhookResponse, 0)
+	for _, webhook := range *webhooks {
+		response = append(response, webhook.SerializeEventWebhook())
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// CreateEventWebhook registers a callback URL for the given events of the guild.
+// The signing secret is only returned once.
+// CreateEventWebhook godoc
+// @Tags Guilds
+// @Summary Create Event Webhook
+// @Accept  json
+// @Produce  json
+// @Param guildId path string true "Guild ID"
+// @Param request body createEventWebhookReq true "Create Event Webhook"
+// @Success 201 {object} model.EventWebhookResponse
+// @Failure 400 {object} model.ErrorsResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /guilds/{guildId}/event-webhooks [post]
+func (h *Handler) CreateEventWebhook(c *gin.Context) {
+	guildId := c.Param("guildId")
+	userId := c.MustGet("userId").(string)
+
+	var req createEventWebhookReq
+	if ok := bindData(c, &req); !ok {
+		return
+	}
+
+	req.sanitize()
+
+	if ok := h.isWebhookGuildOwner(c, guildId, userId); !ok {
+		return
+	}
+
+	params := model.EventWebhook{
+		GuildId: guildId,
+		UserId:  userId,
+		Url:     req.Url,
+		Events:  req.Events,
+	}
+
+	webhook, err := h.eventService.CreateEventWebhook(&params)
+
+	if err != nil {
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
+	response := webhook.SerializeEventWebhook()
+	response.Secret = webhook.Secret
+
+	c.JSON(http.StatusCreated, response)
+}
+
+// EditEventWebhook edits the URL, events and status of the given event webhook
+// EditEventWebhook godoc
+// @Tags Guilds
+// @Summary Edit Event Webhook
+// @Accept  json
+// @Produce  json
+// @Param guildId path string true "Guild ID"
+// @Param id path string true "Event Webhook ID"
+// @Param request body editEventWebhookReq true "Edit Event Webhook"
+// @Success 200 {object} model.EventWebhookResponse
+// @Failure 400 {object} model.ErrorsResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /guilds/{guildId}/event-webhooks/{id} [put]
+func (h *Handler) EditEventWebhook(c *gin.Context) {
+	guildId := c.Param("guildId")
+	webhookId := c.Param("id")
+	userId := c.MustGet("userId").(string)
+
+	var req editEventWebhookReq
+	if ok := bindData(c, &req); !ok {
+		return
+	}
+
+	req.sanitize()
+
+	webhook, ok := h.getOwnedEventWebhook(c, guildId, webhookId, userId)
+
+	if !ok {
+		return
+	}
+
+	if req.Enabled && !webhook.Enabled {
+		webhook.FailureCount = 0
+	}
+
+	webhook.Url = req.Url
+	webhook.Events = req.Events
+	webhook.Enabled = req.Enabled
+
+	if err := h.eventService.UpdateEventWebhook(webhook); err != nil {
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, webhook.SerializeEventWebhook())
+}
+
+// DeleteEventWebhook deletes the given event webhook
+// DeleteEventWebhook godoc
+// @Tags Guilds
+// @Summary Delete Event Webhook
+// @Produce  json
+// @Param guildId path string true "Guild ID"
+// @Param id path string true "Event Webhook ID"
+// @Success 200 {object} model.Success
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /guilds/{guildId}/event-webhooks/{id} [delete]
+func (h *Handler) DeleteEventWebhook(c *gin.Context) {
+	guildId := c.Param("guildId")
+	webhookId := c.Param("id")
+	userId := c.MustGet("userId").(string)
+
+	webhook, ok := h.getOwnedEventWebhook(c, guildId, webhookId, userId)
+
+	if !ok {
+		return
+	}
+
+	if err := h.eventService.DeleteEventWebhook(webhook); err != nil {
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, true)
+}
+
+// GetEventDeliveries returns the most recent delivery attempts of the given event webhook
+// GetEventDeliveries godoc
+// @Tags Guilds
+// @Summary Get Event Webhook Deliveries
+// @Produce  json
+// @Param guildId path string true "Guild ID"
+// @Param id path string true "Event Webhook ID"
+// @Success 200 {array} model.EventDelivery
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /guilds/{guildId}/event-webhooks/{id}/deliveries [get]
+func (h *Handler) GetEventDeliveries(c *gin.Context) {
+	guildId := c.Param("guildId")
+	webhookId := c.Param("id")
+	userId := c.MustGet("userId").(string)
+
+	webhook, ok := h.getOwnedEventWebhook(c, guildId, webhookId, userId)
+
+	if !ok {
+		return
+	}
+
+	deliveries, err := h.eventService.GetDeliveries(webhook.ID)
+
+	if err != nil {
+		log.Printf("Unable to find deliveries for event webhook: %v\n%v", webhook.ID, err)
+		e := apperrors.NewInternal()
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, deliveries)
+}
+
+// getOwnedEventWebhook returns the given event webhook of the guild if the user owns the guild.
+// Writes the error response otherwise.
+func (h *Handler) getOwnedEventWebhook(c *gin.Context, guildId string, webhookId string, userId string) (*model.EventWebhook, bool) {
+	if ok := h.isWebhookGuildOwner(c, guildId, userId); !ok {
+		return nil, false
+	}
+
+	webhook, err := h.eventService.Get(webhookId)
+
+	if err != nil || webhook.GuildId != guildId {
+		e := apperrors.NewNotFound("webhook", webhookId)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return nil, false
+	}
+
+	return webhook, true
+}
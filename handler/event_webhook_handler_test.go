@@ -0,0 +1,406 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/sentrionic/valkyrie/mocks"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"github.com/sentrionic/valkyrie/model/fixture"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func getMockEventWebhook(guildId string, userId string) *model.EventWebhook {
+	webhook := &model.EventWebhook{
+		GuildId: guildId,
+		UserId:  userId,
+		Url:     "https://example.com/events",
+		Secret:  fixture.RandStr(43),
+		Events:  []string{model.MessageCreatedEvent},
+		Enabled: true,
+	}
+	webhook.ID = fixture.RandID()
+	webhook.CreatedAt = time.Now()
+	webhook.UpdatedAt = time.Now()
+	return webhook
+}
+
+func TestHandler_GetEventWebhooks(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	authUser := fixture.GetMockUser()
+
+	t.Run("Successful Fetch", func(t *testing.T) {
+		mockGuild := fixture.GetMockGuild(authUser.ID)
+		webhooks := []model.EventWebhook{*getMockEventWebhook(mockGuild.ID, authUser.ID)}
+
+		mockGuildService := new(mocks.GuildService)
+		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
+
+		mockEventService := new(mocks.EventService)
+		mockEventService.On("GetGuildEventWebhooks", mockGuild.ID).Return(&webhooks, nil)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:            router,
+			UserService:  allowSession(new(mocks.UserService)),
+			GuildService: mockGuildService,
+			EventService: mockEventService,
+		})
+
+		request, err := http.NewRequest(http.MethodGet, "/api/guilds/"+mockGuild.ID+"/event-webhooks", nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal([]model.EventWebhookResponse{webhooks[0].SerializeEventWebhook()})
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		assert.NotContains(t, rr.Body.String(), webhooks[0].Secret)
+		mockEventService.AssertExpectations(t)
+	})
+
+	t.Run("Not the owner", func(t *testing.T) {
+		mockGuild := fixture.GetMockGuild(fixture.RandID())
+
+		mockGuildService := new(mocks.GuildService)
+		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
+
+		mockEventService := new(mocks.EventService)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:            router,
+			UserService:  allowSession(new(mocks.UserService)),
+			GuildService: mockGuildService,
+			EventService: mockEventService,
+		})
+
+		request, err := http.NewRequest(http.MethodGet, "/api/guilds/"+mockGuild.ID+"/event-webhooks", nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		mockError := apperrors.NewAuthorization(apperrors.MustBeOwner)
+		respBody, err := json.Marshal(gin.H{
+			"error": mockError,
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, mockError.Status(), rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockEventService.AssertNotCalled(t, "GetGuildEventWebhooks", mock.Anything)
+	})
+}
+
+func TestHandler_CreateEventWebhook(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	authUser := fixture.GetMockUser()
+
+	t.Run("Successfully created", func(t *testing.T) {
+		mockGuild := fixture.GetMockGuild(authUser.ID)
+		webhook := getMockEventWebhook(mockGuild.ID, authUser.ID)
+
+		params := &model.EventWebhook{
+			GuildId: mockGuild.ID,
+			UserId:  authUser.ID,
+			Url:     webhook.Url,
+			Events:  webhook.Events,
+		}
+
+		mockGuildService := new(mocks.GuildService)
+		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
+
+		mockEventService := new(mocks.EventService)
+		mockEventService.On("CreateEventWebhook", params).Return(webhook, nil)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:            router,
+			UserService:  allowSession(new(mocks.UserService)),
+			GuildService: mockGuildService,
+			EventService: mockEventService,
+		})
+
+		reqBody, err := json.Marshal(gin.H{
+			"url":    webhook.Url,
+			"events": webhook.Events,
+		})
+		assert.NoError(t, err)
+
+		request, err := http.NewRequest(http.MethodPost, "/api/guilds/"+mockGuild.ID+"/event-webhooks", bytes.NewBuffer(reqBody))
+		assert.NoError(t, err)
+		request.Header.Set("Content-Type", "application/json")
+
+		router.ServeHTTP(rr, request)
+
+		response := webhook.SerializeEventWebhook()
+		response.Secret = webhook.Secret
+		respBody, err := json.Marshal(response)
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusCreated, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockEventService.AssertExpectations(t)
+	})
+
+	testCases := []struct {
+		name string
+		body gin.H
+	}{
+		{
+			name: "URL required",
+			body: gin.H{"events": []string{model.MessageCreatedEvent}},
+		},
+		{
+			name: "URL must use http(s)",
+			body: gin.H{"url": "ftp://example.com", "events": []string{model.MessageCreatedEvent}},
+		},
+		{
+			name: "Events required",
+			body: gin.H{"url": "https://example.com"},
+		},
+		{
+			name: "Unknown event",
+			body: gin.H{"url": "https://example.com", "events": []string{"guild.exploded"}},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			mockEventService := new(mocks.EventService)
+
+			rr := httptest.NewRecorder()
+
+			router := getAuthenticatedTestRouter(authUser.ID)
+
+			NewHandler(&Config{
+				R:            router,
+				UserService:  allowSession(new(mocks.UserService)),
+				EventService: mockEventService,
+			})
+
+			reqBody, err := json.Marshal(tc.body)
+			assert.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/api/guilds/"+fixture.RandID()+"/event-webhooks", bytes.NewBuffer(reqBody))
+			assert.NoError(t, err)
+			request.Header.Set("Content-Type", "application/json")
+
+			router.ServeHTTP(rr, request)
+
+			assert.Equal(t, http.StatusBadRequest, rr.Code)
+			mockEventService.AssertNotCalled(t, "CreateEventWebhook", mock.Anything)
+		})
+	}
+}
+
+func TestHandler_EditEventWebhook(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	authUser := fixture.GetMockUser()
+
+	t.Run("Re-enabling resets the failures", func(t *testing.T) {
+		mockGuild := fixture.GetMockGuild(authUser.ID)
+		webhook := getMockEventWebhook(mockGuild.ID, authUser.ID)
+		webhook.Enabled = false
+		webhook.FailureCount = model.EventWebhookFailureLimit
+
+		mockGuildService := new(mocks.GuildService)
+		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
+
+		mockEventService := new(mocks.EventService)
+		mockEventService.On("Get", webhook.ID).Return(webhook, nil)
+		mockEventService.On("UpdateEventWebhook", webhook).Return(nil)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:            router,
+			UserService:  allowSession(new(mocks.UserService)),
+			GuildService: mockGuildService,
+			EventService: mockEventService,
+		})
+
+		reqBody, err := json.Marshal(gin.H{
+			"url":     "https://example.com/hooks",
+			"events":  []string{model.MemberJoinedEvent, model.MemberLeftEvent},
+			"enabled": true,
+		})
+		assert.NoError(t, err)
+
+		request, err := http.NewRequest(http.MethodPut, "/api/guilds/"+mockGuild.ID+"/event-webhooks/"+webhook.ID, bytes.NewBuffer(reqBody))
+		assert.NoError(t, err)
+		request.Header.Set("Content-Type", "application/json")
+
+		router.ServeHTTP(rr, request)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.True(t, webhook.Enabled)
+		assert.Equal(t, 0, webhook.FailureCount)
+		assert.Equal(t, "https://example.com/hooks", webhook.Url)
+		assert.Equal(t, 2, len(webhook.Events))
+		mockEventService.AssertExpectations(t)
+	})
+
+	t.Run("Webhook of another guild", func(t *testing.T) {
+		mockGuild := fixture.GetMockGuild(authUser.ID)
+		webhook := getMockEventWebhook(fixture.RandID(), authUser.ID)
+
+		mockGuildService := new(mocks.GuildService)
+		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
+
+		mockEventService := new(mocks.EventService)
+		mockEventService.On("Get", webhook.ID).Return(webhook, nil)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:            router,
+			UserService:  allowSession(new(mocks.UserService)),
+			GuildService: mockGuildService,
+			EventService: mockEventService,
+		})
+
+		reqBody, err := json.Marshal(gin.H{
+			"url":     webhook.Url,
+			"events":  webhook.Events,
+			"enabled": true,
+		})
+		assert.NoError(t, err)
+
+		request, err := http.NewRequest(http.MethodPut, "/api/guilds/"+mockGuild.ID+"/event-webhooks/"+webhook.ID, bytes.NewBuffer(reqBody))
+		assert.NoError(t, err)
+		request.Header.Set("Content-Type", "application/json")
+
+		router.ServeHTTP(rr, request)
+
+		mockError := apperrors.NewNotFound("webhook", webhook.ID)
+		respBody, err := json.Marshal(gin.H{
+			"error": mockError,
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, mockError.Status(), rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockEventService.AssertNotCalled(t, "UpdateEventWebhook", mock.Anything)
+	})
+}
+
+func TestHandler_DeleteEventWebhook(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	authUser := fixture.GetMockUser()
+
+	t.Run("Successfully deleted", func(t *testing.T) {
+		mockGuild := fixture.GetMockGuild(authUser.ID)
+		webhook := getMockEventWebhook(mockGuild.ID, authUser.ID)
+
+		mockGuildService := new(mocks.GuildService)
+		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
+
+		mockEventService := new(mocks.EventService)
+		mockEventService.On("Get", webhook.ID).Return(webhook, nil)
+		mockEventService.On("DeleteEventWebhook", webhook).Return(nil)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:            router,
+			UserService:  allowSession(new(mocks.UserService)),
+			GuildService: mockGuildService,
+			EventService: mockEventService,
+		})
+
+		request, err := http.NewRequest(http.MethodDelete, "/api/guilds/"+mockGuild.ID+"/event-webhooks/"+webhook.ID, nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal(true)
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockEventService.AssertExpectations(t)
+	})
+}
+
+func TestHandler_GetEventDeliveries(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	authUser := fixture.GetMockUser()
+
+	t.Run("Successful Fetch", func(t *testing.T) {
+		mockGuild := fixture.GetMockGuild(authUser.ID)
+		webhook := getMockEventWebhook(mockGuild.ID, authUser.ID)
+		deliveries := []model.EventDelivery{
+			{
+				ID:             1,
+				CreatedAt:      time.Now(),
+				EventWebhookId: webhook.ID,
+				DeliveryId:     fixture.RandID(),
+				Event:          model.MessageCreatedEvent,
+				Attempt:        1,
+				StatusCode:     http.StatusOK,
+				Success:        true,
+			},
+		}
+
+		mockGuildService := new(mocks.GuildService)
+		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
+
+		mockEventService := new(mocks.EventService)
+		mockEventService.On("Get", webhook.ID).Return(webhook, nil)
+		mockEventService.On("GetDeliveries", webhook.ID).Return(&deliveries, nil)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:            router,
+			UserService:  allowSession(new(mocks.UserService)),
+			GuildService: mockGuildService,
+			EventService: mockEventService,
+		})
+
+		request, err := http.NewRequest(http.MethodGet, "/api/guilds/"+mockGuild.ID+"/event-webhooks/"+webhook.ID+"/deliveries", nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal(deliveries)
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockEventService.AssertExpectations(t)
+	})
+}
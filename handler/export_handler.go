@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"net/http"
+)
+
+/*
+ * ExportHandler contains all routes related to exporting the data of the current user
+ */
+
+// RequestExport queues an export of all data stored about the current user.
+// The user receives a mail with the download link once the archive is ready.
+// RequestExport godoc
+// @Tags Account
+// @Summary Request Data Export
+// @Description Only one export can be requested per day. The download link expires after 7 days.
+// @Produce  json
+// @Success 202 {object} model.DataExportResponse
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /account/export [post]
+func (h *Handler) RequestExport(c *gin.Context) {
+	userId := c.MustGet("userId").(string)
+
+	user, err := h.userService.Get(userId)
+
+	if err != nil {
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
+	export, err := h.exportService.RequestExport(user)
+
+	if err != nil {
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, export.SerializeExport(nil))
+}
+
+// GetExport returns the status of the latest data export of the current user
+// and its download link once it is ready
+// GetExport godoc
+// @Tags Account
+// @Summary Get Data Export
+// @Produce  json
+// @Success 200 {object} model.DataExportResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /account/export [get]
+func (h *Handler) GetExport(c *gin.Context) {
+	userId := c.MustGet("userId").(string)
+
+	export, err := h.exportService.GetLatestExport(userId)
+
+	if err != nil {
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
+	url, err := h.exportService.GetDownloadURL(export)
+
+	if err != nil {
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, export.SerializeExport(url))
+}
@@ -0,0 +1,177 @@
+package handler
+
+import (
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/sentrionic/valkyrie/mocks"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"github.com/sentrionic/valkyrie/model/fixture"
+	"github.com/sentrionic/valkyrie/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHandler_RequestExport(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+
+	uid, _ := service.GenerateId()
+	mockUser := fixture.GetMockUser()
+	mockUser.ID = uid
+
+	t.Run("Success", func(t *testing.T) {
+		export := &model.DataExport{
+			BaseModel: model.BaseModel{ID: fixture.RandID(), CreatedAt: time.Now()},
+			UserId:    uid,
+			Status:    model.ExportPending,
+		}
+
+		mockUserService := new(mocks.UserService)
+		mockUserService.On("Get", uid).Return(mockUser, nil)
+		mockExportService := new(mocks.ExportService)
+		mockExportService.On("RequestExport", mockUser).Return(export, nil)
+
+		router := getAuthenticatedTestRouter(uid)
+
+		NewHandler(&Config{
+			R:             router,
+			UserService:   allowSession(mockUserService),
+			ExportService: mockExportService,
+		})
+
+		rr := httptest.NewRecorder()
+
+		request, _ := http.NewRequest(http.MethodPost, "/api/account/export", nil)
+		router.ServeHTTP(rr, request)
+
+		respBody, _ := json.Marshal(export.SerializeExport(nil))
+
+		assert.Equal(t, http.StatusAccepted, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockExportService.AssertExpectations(t)
+	})
+
+	t.Run("One export per day", func(t *testing.T) {
+		mockError := apperrors.NewBadRequest(apperrors.ExportCooldown)
+
+		mockUserService := new(mocks.UserService)
+		mockUserService.On("Get", uid).Return(mockUser, nil)
+		mockExportService := new(mocks.ExportService)
+		mockExportService.On("RequestExport", mockUser).Return(nil, mockError)
+
+		router := getAuthenticatedTestRouter(uid)
+
+		NewHandler(&Config{
+			R:             router,
+			UserService:   allowSession(mockUserService),
+			ExportService: mockExportService,
+		})
+
+		rr := httptest.NewRecorder()
+
+		request, _ := http.NewRequest(http.MethodPost, "/api/account/export", nil)
+		router.ServeHTTP(rr, request)
+
+		respBody, _ := json.Marshal(gin.H{
+			"error": mockError,
+		})
+
+		assert.Equal(t, mockError.Status(), rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+	})
+
+	t.Run("Unauthorized", func(t *testing.T) {
+		mockExportService := new(mocks.ExportService)
+
+		router := getTestRouter()
+
+		NewHandler(&Config{
+			R:             router,
+			UserService:   new(mocks.UserService),
+			ExportService: mockExportService,
+		})
+
+		rr := httptest.NewRecorder()
+
+		request, _ := http.NewRequest(http.MethodPost, "/api/account/export", nil)
+		router.ServeHTTP(rr, request)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		mockExportService.AssertNotCalled(t, "RequestExport", mock.Anything)
+	})
+}
+
+func TestHandler_GetExport(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+
+	uid, _ := service.GenerateId()
+
+	t.Run("Ready export", func(t *testing.T) {
+		expiresAt := time.Now().Add(time.Hour)
+		export := &model.DataExport{
+			BaseModel: model.BaseModel{ID: fixture.RandID(), CreatedAt: time.Now()},
+			UserId:    uid,
+			Status:    model.ExportReady,
+			Key:       "exports/" + uid + "/archive.zip",
+			ExpiresAt: &expiresAt,
+		}
+		url := "https://bucket.s3.amazonaws.com/exports/" + uid + "/archive.zip?X-Amz-Signature=signature"
+
+		mockExportService := new(mocks.ExportService)
+		mockExportService.On("GetLatestExport", uid).Return(export, nil)
+		mockExportService.On("GetDownloadURL", export).Return(&url, nil)
+
+		router := getAuthenticatedTestRouter(uid)
+
+		NewHandler(&Config{
+			R:             router,
+			UserService:   allowSession(new(mocks.UserService)),
+			ExportService: mockExportService,
+		})
+
+		rr := httptest.NewRecorder()
+
+		request, _ := http.NewRequest(http.MethodGet, "/api/account/export", nil)
+		router.ServeHTTP(rr, request)
+
+		respBody, _ := json.Marshal(export.SerializeExport(&url))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockExportService.AssertExpectations(t)
+	})
+
+	t.Run("No export requested", func(t *testing.T) {
+		mockError := apperrors.NewNotFound("export", uid)
+
+		mockExportService := new(mocks.ExportService)
+		mockExportService.On("GetLatestExport", uid).Return(nil, mockError)
+
+		router := getAuthenticatedTestRouter(uid)
+
+		NewHandler(&Config{
+			R:             router,
+			UserService:   allowSession(new(mocks.UserService)),
+			ExportService: mockExportService,
+		})
+
+		rr := httptest.NewRecorder()
+
+		request, _ := http.NewRequest(http.MethodGet, "/api/account/export", nil)
+		router.ServeHTTP(rr, request)
+
+		respBody, _ := json.Marshal(gin.H{
+			"error": mockError,
+		})
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockExportService.AssertNotCalled(t, "GetDownloadURL", mock.Anything)
+	})
+}
@@ -111,6 +111,15 @@ func (h *Handler) SendFriendRequest(c *gin.Context) {
 		return
 	}
 
+	// Neither of them can send requests if one blocked the other
+	if hasBlocked(authUser, member.ID) || hasBlocked(member, authUser.ID) {
+		e := apperrors.NewBadRequest(apperrors.BlockedUserError)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
 	// Check if they are already friends and no request exists
 	if !isFriend(authUser, member.ID) && !containsRequest(authUser, member) {
 		authUser.Requests = append(authUser.Requests, *member)
@@ -374,6 +383,16 @@ func isFriend(user *model.User, userId string) bool {
 	return false
 }
 
+// hasBlocked checks if the given user blocked the user with the given id
+func hasBlocked(user *model.User, userId string) bool {
+	for _, v := range user.Blocks {
+		if v.ID == userId {
+			return true
+		}
+	}
+	return false
+}
+
 // containsRequest checks if the given user has a friends request from the current one
 func containsRequest(user *model.User, current *model.User) bool {
 	for _, v := range user.Requests {
@@ -383,3 +402,121 @@ func containsRequest(user *model.User, current *model.User) bool {
 	}
 	return false
 }
+
+// GetBlockedUsers returns the users the current user blocked
+// GetBlockedUsers godoc
+// @Tags Friends
+// @Summary Get Current User's Blocked Users
+// @Produce  json
+// @Success 200 {array} model.BlockedUser
+// @Failure 404 {object} model.ErrorResponse
+// @Router /account/me/blocked [get]
+func (h *Handler) GetBlockedUsers(c *gin.Context) {
+	userId := c.MustGet("userId").(string)
+
+	users, err := h.friendService.GetBlockedUsers(userId)
+
+	if err != nil {
+		log.Printf("Unable to find blocked users for id: %v\n%v", userId, err)
+		e := apperrors.NewNotFound("user", userId)
+
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, users)
+}
+
+// BlockUser blocks the given member param. Removes them from the
+// friends and deletes pending requests between them. Blocked users
+// cannot send friend requests or DMs to the current user.
+// BlockUser godoc
+// @Tags Friends
+// @Summary Block User
+// @Produce  json
+// @Param memberId path string true "User ID"
+// @Success 200 {object} model.Success
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Router /account/{memberId}/block [post]
+func (h *Handler) BlockUser(c *gin.Context) {
+	userId := c.MustGet("userId").(string)
+	memberId := c.Param("memberId")
+
+	if userId == memberId {
+		e := apperrors.NewBadRequest(apperrors.BlockYourselfError)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	authUser, err := h.friendService.GetMemberById(userId)
+
+	if err != nil {
+		log.Printf("Unable to find user: %v\n%v", userId, err)
+		e := apperrors.NewNotFound("user", userId)
+
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	member, err := h.friendService.GetMemberById(memberId)
+
+	if err != nil {
+		log.Printf("Unable to find user: %v\n%v", memberId, err)
+		e := apperrors.NewNotFound("user", memberId)
+
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	if err = h.friendService.BlockUser(authUser.ID, member.ID); err != nil {
+		log.Printf("Unable to block user: %v\n%v", memberId, err)
+		e := apperrors.NewBadRequest(apperrors.UnableBlockError)
+
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	// Emit signal to remove the person from the friends
+	if isFriend(authUser, member.ID) {
+		h.socketService.EmitRemoveFriend(userId, memberId)
+	}
+
+	c.JSON(http.StatusOK, true)
+}
+
+// UnblockUser unblocks the given member param
+// UnblockUser godoc
+// @Tags Friends
+// @Summary Unblock User
+// @Produce  json
+// @Param memberId path string true "User ID"
+// @Success 200 {object} model.Success
+// @Failure 404 {object} model.ErrorResponse
+// @Router /account/{memberId}/block [delete]
+func (h *Handler) UnblockUser(c *gin.Context) {
+	userId := c.MustGet("userId").(string)
+	memberId := c.Param("memberId")
+
+	if err := h.friendService.UnblockUser(userId, memberId); err != nil {
+		log.Printf("Unable to unblock user: %v\n%v", memberId, err)
+		e := apperrors.NewNotFound("user", memberId)
+
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, true)
+}
@@ -43,6 +43,7 @@ func TestHandler_GetUserFriends(t *testing.T) {
 
 		NewHandler(&Config{
 			R:             router,
+			UserService:   allowSession(new(mocks.UserService)),
 			FriendService: mockFriendService,
 		})
 
@@ -96,6 +97,7 @@ func TestHandler_GetUserFriends(t *testing.T) {
 
 		NewHandler(&Config{
 			R:             router,
+			UserService:   allowSession(new(mocks.UserService)),
 			FriendService: mockFriendService,
 		})
 
@@ -144,6 +146,7 @@ func TestHandler_GetUserRequests(t *testing.T) {
 
 		NewHandler(&Config{
 			R:             router,
+			UserService:   allowSession(new(mocks.UserService)),
 			FriendService: mockFriendService,
 		})
 
@@ -197,6 +200,7 @@ func TestHandler_GetUserRequests(t *testing.T) {
 
 		NewHandler(&Config{
 			R:             router,
+			UserService:   allowSession(new(mocks.UserService)),
 			FriendService: mockFriendService,
 		})
 
@@ -254,6 +258,7 @@ func TestHandler_AcceptFriendRequest(t *testing.T) {
 
 		NewHandler(&Config{
 			R:             router,
+			UserService:   allowSession(new(mocks.UserService)),
 			FriendService: mockFriendService,
 			SocketService: mockSocketService,
 		})
@@ -289,6 +294,7 @@ func TestHandler_AcceptFriendRequest(t *testing.T) {
 
 		NewHandler(&Config{
 			R:             router,
+			UserService:   allowSession(new(mocks.UserService)),
 			FriendService: mockFriendService,
 			SocketService: mockSocketService,
 		})
@@ -353,6 +359,7 @@ func TestHandler_AcceptFriendRequest(t *testing.T) {
 
 		NewHandler(&Config{
 			R:             router,
+			UserService:   allowSession(new(mocks.UserService)),
 			FriendService: mockFriendService,
 			SocketService: mockSocketService,
 		})
@@ -387,6 +394,7 @@ func TestHandler_AcceptFriendRequest(t *testing.T) {
 
 		NewHandler(&Config{
 			R:             router,
+			UserService:   allowSession(new(mocks.UserService)),
 			FriendService: mockFriendService,
 			SocketService: mockSocketService,
 		})
@@ -433,6 +441,7 @@ func TestHandler_AcceptFriendRequest(t *testing.T) {
 
 		NewHandler(&Config{
 			R:             router,
+			UserService:   allowSession(new(mocks.UserService)),
 			FriendService: mockFriendService,
 			SocketService: mockSocketService,
 		})
@@ -489,6 +498,7 @@ func TestHandler_SendFriendRequest(t *testing.T) {
 
 		NewHandler(&Config{
 			R:             router,
+			UserService:   allowSession(new(mocks.UserService)),
 			FriendService: mockFriendService,
 			SocketService: mockSocketService,
 		})
@@ -525,6 +535,7 @@ func TestHandler_SendFriendRequest(t *testing.T) {
 
 		NewHandler(&Config{
 			R:             router,
+			UserService:   allowSession(new(mocks.UserService)),
 			FriendService: mockFriendService,
 			SocketService: mockSocketService,
 		})
@@ -563,6 +574,7 @@ func TestHandler_SendFriendRequest(t *testing.T) {
 
 		NewHandler(&Config{
 			R:             router,
+			UserService:   allowSession(new(mocks.UserService)),
 			FriendService: mockFriendService,
 			SocketService: mockSocketService,
 		})
@@ -627,6 +639,7 @@ func TestHandler_SendFriendRequest(t *testing.T) {
 
 		NewHandler(&Config{
 			R:             router,
+			UserService:   allowSession(new(mocks.UserService)),
 			FriendService: mockFriendService,
 			SocketService: mockSocketService,
 		})
@@ -661,6 +674,7 @@ func TestHandler_SendFriendRequest(t *testing.T) {
 
 		NewHandler(&Config{
 			R:             router,
+			UserService:   allowSession(new(mocks.UserService)),
 			FriendService: mockFriendService,
 			SocketService: mockSocketService,
 		})
@@ -705,6 +719,7 @@ func TestHandler_SendFriendRequest(t *testing.T) {
 
 		NewHandler(&Config{
 			R:             router,
+			UserService:   allowSession(new(mocks.UserService)),
 			FriendService: mockFriendService,
 			SocketService: mockSocketService,
 		})
@@ -757,6 +772,7 @@ func TestHandler_RemoveFriend(t *testing.T) {
 
 		NewHandler(&Config{
 			R:             router,
+			UserService:   allowSession(new(mocks.UserService)),
 			FriendService: mockFriendService,
 			SocketService: mockSocketService,
 		})
@@ -792,6 +808,7 @@ func TestHandler_RemoveFriend(t *testing.T) {
 
 		NewHandler(&Config{
 			R:             router,
+			UserService:   allowSession(new(mocks.UserService)),
 			FriendService: mockFriendService,
 			SocketService: mockSocketService,
 		})
@@ -856,6 +873,7 @@ func TestHandler_RemoveFriend(t *testing.T) {
 
 		NewHandler(&Config{
 			R:             router,
+			UserService:   allowSession(new(mocks.UserService)),
 			FriendService: mockFriendService,
 			SocketService: mockSocketService,
 		})
@@ -890,6 +908,7 @@ func TestHandler_RemoveFriend(t *testing.T) {
 
 		NewHandler(&Config{
 			R:             router,
+			UserService:   allowSession(new(mocks.UserService)),
 			FriendService: mockFriendService,
 			SocketService: mockSocketService,
 		})
@@ -935,6 +954,7 @@ func TestHandler_RemoveFriend(t *testing.T) {
 
 		NewHandler(&Config{
 			R:             router,
+			UserService:   allowSession(new(mocks.UserService)),
 			FriendService: mockFriendService,
 			SocketService: mockSocketService,
 		})
@@ -984,6 +1004,7 @@ func TestHandler_CancelFriendRequest(t *testing.T) {
 
 		NewHandler(&Config{
 			R:             router,
+			UserService:   allowSession(new(mocks.UserService)),
 			FriendService: mockFriendService,
 		})
 
@@ -1016,6 +1037,7 @@ func TestHandler_CancelFriendRequest(t *testing.T) {
 
 		NewHandler(&Config{
 			R:             router,
+			UserService:   allowSession(new(mocks.UserService)),
 			FriendService: mockFriendService,
 		})
 
@@ -1076,6 +1098,7 @@ func TestHandler_CancelFriendRequest(t *testing.T) {
 
 		NewHandler(&Config{
 			R:             router,
+			UserService:   allowSession(new(mocks.UserService)),
 			FriendService: mockFriendService,
 		})
 
@@ -1107,6 +1130,7 @@ func TestHandler_CancelFriendRequest(t *testing.T) {
 
 		NewHandler(&Config{
 			R:             router,
+			UserService:   allowSession(new(mocks.UserService)),
 			FriendService: mockFriendService,
 		})
 
@@ -1148,6 +1172,7 @@ func TestHandler_CancelFriendRequest(t *testing.T) {
 
 		NewHandler(&Config{
 			R:             router,
+			UserService:   allowSession(new(mocks.UserService)),
 			FriendService: mockFriendService,
 		})
 
@@ -1168,3 +1193,302 @@ func TestHandler_CancelFriendRequest(t *testing.T) {
 		mockFriendService.AssertExpectations(t)
 	})
 }
+
+func TestHandler_SendFriendRequest_Blocked(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+
+	t.Run("Member blocked the current user", func(t *testing.T) {
+		current := fixture.GetMockUser()
+		mockUser := fixture.GetMockUser()
+		mockUser.Blocks = append(mockUser.Blocks, *current)
+
+		mockFriendService := new(mocks.FriendService)
+		mockFriendService.On("GetMemberById", current.ID).Return(current, nil)
+		mockFriendService.On("GetMemberById", mockUser.ID).Return(mockUser, nil)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(current.ID)
+
+		NewHandler(&Config{
+			R:             router,
+			UserService:   allowSession(new(mocks.UserService)),
+			FriendService: mockFriendService,
+		})
+
+		url := fmt.Sprintf("/api/account/%s/friend", mockUser.ID)
+		request, err := http.NewRequest(http.MethodPost, url, nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		mockError := apperrors.NewBadRequest(apperrors.BlockedUserError)
+		respBody, err := json.Marshal(gin.H{
+			"error": mockError,
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, mockError.Status(), rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+
+		mockFriendService.AssertNotCalled(t, "SendRequest", mock.Anything)
+		mockFriendService.AssertExpectations(t)
+	})
+}
+
+func TestHandler_GetBlockedUsers(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	uid := fixture.RandID()
+
+	t.Run("Successful Fetch", func(t *testing.T) {
+		mockUser := fixture.GetMockUser()
+		blocked := []model.BlockedUser{
+			{
+				Id:       mockUser.ID,
+				Username: mockUser.Username,
+				Image:    mockUser.Image,
+			},
+		}
+
+		mockFriendService := new(mocks.FriendService)
+		mockFriendService.On("GetBlockedUsers", uid).Return(&blocked, nil)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(uid)
+
+		NewHandler(&Config{
+			R:             router,
+			UserService:   allowSession(new(mocks.UserService)),
+			FriendService: mockFriendService,
+		})
+
+		request, err := http.NewRequest(http.MethodGet, "/api/account/me/blocked", nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal(blocked)
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockFriendService.AssertExpectations(t)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		mockFriendService := new(mocks.FriendService)
+		mockFriendService.On("GetBlockedUsers", uid).Return(nil, fmt.Errorf("some error down the call chain"))
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(uid)
+
+		NewHandler(&Config{
+			R:             router,
+			UserService:   allowSession(new(mocks.UserService)),
+			FriendService: mockFriendService,
+		})
+
+		request, err := http.NewRequest(http.MethodGet, "/api/account/me/blocked", nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		mockError := apperrors.NewNotFound("user", uid)
+		respBody, err := json.Marshal(gin.H{
+			"error": mockError,
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, mockError.Status(), rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockFriendService.AssertExpectations(t)
+	})
+}
+
+func TestHandler_BlockUser(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+
+	t.Run("Successfully blocked friend", func(t *testing.T) {
+		current := fixture.GetMockUser()
+		mockUser := fixture.GetMockUser()
+		current.Friends = append(current.Friends, *mockUser)
+
+		mockFriendService := new(mocks.FriendService)
+		mockFriendService.On("GetMemberById", current.ID).Return(current, nil)
+		mockFriendService.On("GetMemberById", mockUser.ID).Return(mockUser, nil)
+		mockFriendService.On("BlockUser", current.ID, mockUser.ID).Return(nil)
+
+		mockSocketService := new(mocks.SocketService)
+		mockSocketService.On("EmitRemoveFriend", current.ID, mockUser.ID).Return()
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(current.ID)
+
+		NewHandler(&Config{
+			R:             router,
+			UserService:   allowSession(new(mocks.UserService)),
+			FriendService: mockFriendService,
+			SocketService: mockSocketService,
+		})
+
+		url := fmt.Sprintf("/api/account/%s/block", mockUser.ID)
+		request, err := http.NewRequest(http.MethodPost, url, nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal(true)
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+
+		mockFriendService.AssertExpectations(t)
+		mockSocketService.AssertExpectations(t)
+	})
+
+	t.Run("Successfully blocked non friend", func(t *testing.T) {
+		current := fixture.GetMockUser()
+		mockUser := fixture.GetMockUser()
+
+		mockFriendService := new(mocks.FriendService)
+		mockFriendService.On("GetMemberById", current.ID).Return(current, nil)
+		mockFriendService.On("GetMemberById", mockUser.ID).Return(mockUser, nil)
+		mockFriendService.On("BlockUser", current.ID, mockUser.ID).Return(nil)
+
+		mockSocketService := new(mocks.SocketService)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(current.ID)
+
+		NewHandler(&Config{
+			R:             router,
+			UserService:   allowSession(new(mocks.UserService)),
+			FriendService: mockFriendService,
+			SocketService: mockSocketService,
+		})
+
+		url := fmt.Sprintf("/api/account/%s/block", mockUser.ID)
+		request, err := http.NewRequest(http.MethodPost, url, nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal(true)
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+
+		mockFriendService.AssertExpectations(t)
+		mockSocketService.AssertNotCalled(t, "EmitRemoveFriend", mock.Anything, mock.Anything)
+	})
+
+	t.Run("MemberId and UserId are the same", func(t *testing.T) {
+		current := fixture.GetMockUser()
+		mockFriendService := new(mocks.FriendService)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(current.ID)
+
+		NewHandler(&Config{
+			R:             router,
+			UserService:   allowSession(new(mocks.UserService)),
+			FriendService: mockFriendService,
+		})
+
+		url := fmt.Sprintf("/api/account/%s/block", current.ID)
+		request, err := http.NewRequest(http.MethodPost, url, nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		mockError := apperrors.NewBadRequest(apperrors.BlockYourselfError)
+		respBody, err := json.Marshal(gin.H{
+			"error": mockError,
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, mockError.Status(), rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockFriendService.AssertNotCalled(t, "BlockUser", mock.Anything, mock.Anything)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		current := fixture.GetMockUser()
+		memberId := fixture.RandID()
+
+		mockFriendService := new(mocks.FriendService)
+		mockFriendService.On("GetMemberById", current.ID).Return(current, nil)
+		mockFriendService.On("GetMemberById", memberId).Return(nil, apperrors.NewNotFound("user", memberId))
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(current.ID)
+
+		NewHandler(&Config{
+			R:             router,
+			UserService:   allowSession(new(mocks.UserService)),
+			FriendService: mockFriendService,
+		})
+
+		url := fmt.Sprintf("/api/account/%s/block", memberId)
+		request, err := http.NewRequest(http.MethodPost, url, nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		mockError := apperrors.NewNotFound("user", memberId)
+		respBody, err := json.Marshal(gin.H{
+			"error": mockError,
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, mockError.Status(), rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockFriendService.AssertNotCalled(t, "BlockUser", mock.Anything, mock.Anything)
+	})
+}
+
+func TestHandler_UnblockUser(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	uid := fixture.RandID()
+	memberId := fixture.RandID()
+
+	t.Run("Successfully unblocked user", func(t *testing.T) {
+		mockFriendService := new(mocks.FriendService)
+		mockFriendService.On("UnblockUser", uid, memberId).Return(nil)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(uid)
+
+		NewHandler(&Config{
+			R:             router,
+			UserService:   allowSession(new(mocks.UserService)),
+			FriendService: mockFriendService,
+		})
+
+		url := fmt.Sprintf("/api/account/%s/block", memberId)
+		request, err := http.NewRequest(http.MethodDelete, url, nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal(true)
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockFriendService.AssertExpectations(t)
+	})
+}
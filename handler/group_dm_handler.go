@@ -0,0 +1,553 @@
+package handler
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"log"
+	"mime/multipart"
+	"net/http"
+	"strings"
+)
+
+/*
+ * GroupDMHandler contains all routes related to group DMs (/api/channels)
+ */
+
+// groupDMReq specifies the input form for creating a group DM
+type groupDMReq struct {
+	// Group Name. Max 30 characters. Optional
+	Name *string `json:"name"`
+	// Ids of the friends to add. 1 to 9 members
+	Members []string `json:"members"`
+} //@name GroupDMRequest
+
+func (r groupDMReq) validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Name, validation.NilOrNotEmpty, validation.Length(1, 30)),
+		validation.Field(&r.Members, validation.Required, validation.Length(1, model.MaximumGroupDMMembers-1)),
+	)
+}
+
+func (r *groupDMReq) sanitize() {
+	if r.Name != nil {
+		name := strings.TrimSpace(*r.Name)
+		r.Name = &name
+	}
+
+	// Remove duplicate members
+	members := make([]string, 0, len(r.Members))
+	for _, m := range r.Members {
+		if !containsUser(members, m) {
+			members = append(members, m)
+		}
+	}
+	r.Members = members
+}
+
+// CreateGroupDM creates a group DM with the current user as the owner
+// and the given friends as members
+// CreateGroupDM godoc
+// @Tags Channels
+// @Summary Create Group DM
+// @Accepts json
+// @Produce  json
+// @Param request body groupDMReq true "Create Group DM"
+// @Success 201 {object} model.DirectMessage
+// @Failure 400 {object} model.ErrorsResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /channels/me/dm [post]
+func (h *Handler) CreateGroupDM(c *gin.Context) {
+	var req groupDMReq
+
+	// Bind incoming json to struct and check for validation errors
+	if ok := bindData(c, &req); !ok {
+		return
+	}
+
+	req.sanitize()
+
+	userId := c.MustGet("userId").(string)
+
+	// The owner is always part of the group
+	members := make([]string, 0, len(req.Members))
+	for _, m := range req.Members {
+		if m != userId {
+			members = append(members, m)
+		}
+	}
+
+	if len(members) == 0 {
+		e := apperrors.NewBadRequest(apperrors.DMYourselfError)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	user, err := h.friendService.GetMemberById(userId)
+
+	if err != nil {
+		e := apperrors.NewNotFound("user", userId)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	if !requireVerifiedEmail(c, user) {
+		return
+	}
+
+	// Only friends can be added to the group
+	for _, m := range members {
+		if !isFriend(user, m) {
+			e := apperrors.NewBadRequest(apperrors.GroupDMFriendsOnly)
+			c.JSON(e.Status(), gin.H{
+				"error": e,
+			})
+			return
+		}
+	}
+
+	channelParams := model.Channel{
+		IsPublic: false,
+		IsDM:     true,
+		IsGroup:  true,
+		OwnerId:  &userId,
+	}
+
+	if req.Name != nil {
+		channelParams.Name = *req.Name
+	}
+
+	channel, err := h.channelService.CreateChannel(&channelParams)
+
+	if err != nil {
+		log.Printf("Failed to create channel: %v\n", err.Error())
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
+	ids := append([]string{userId}, members...)
+	if err = h.channelService.AddDMChannelMembers(ids, channel.ID, userId); err != nil {
+		log.Printf("Failed to create channel: %v\n", err.Error())
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
+	// The group shows up for all members
+	_ = h.channelService.OpenDMForAll(channel.ID)
+
+	dm, err := h.channelService.GetDirectMessage(channel.ID, userId)
+
+	if err != nil {
+		log.Printf("Failed to get group dm: %v\n", err.Error())
+		e := apperrors.NewInternal()
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	h.socketService.EmitAddDM(members, channel.ID)
+
+	c.JSON(http.StatusCreated, dm)
+}
+
+// editGroupDMRequest specifies the form to edit the group DM.
+// If Image is not nil then the group's icon got changed.
+// If Icon is not nil then the group kept its old one.
+// If both are nil then the icon got reset.
+type editGroupDMRequest struct {
+	// Group Name. Max 30 characters. Empty to reset it
+	Name string `form:"name"`
+	// image/png or image/jpeg
+	Image *multipart.FileHeader `form:"image" swaggertype:"string" format:"binary"`
+	// The old group icon url if no new image is selected. Set to null to reset the group icon
+	Icon *string `form:"icon"`
+} //@name EditGroupDMRequest
+
+func (r editGroupDMRequest) validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Name, validation.Length(0, 30)),
+	)
+}
+
+func (r *editGroupDMRequest) sanitize() {
+	r.Name = strings.TrimSpace(r.Name)
+}
+
+// EditGroupDM edits the name and icon of the group DM.
+// Every member can edit the group.
+// EditGroupDM godoc
+// @Tags Channels
+// @Summary Edit Group DM
+// @Accepts  mpfd
+// @Produce  json
+// @Param request body editGroupDMRequest true "Edit Group DM"
+// @Param id path string true "DM Channel ID"
+// @Success 200 {object} model.Success
+// @Failure 400 {object} model.ErrorsResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /channels/{id}/group [put]
+func (h *Handler) EditGroupDM(c *gin.Context) {
+	var req editGroupDMRequest
+
+	// Bind incoming json to struct and check for validation errors
+	if ok := bindData(c, &req); !ok {
+		return
+	}
+
+	req.sanitize()
+
+	userId := c.MustGet("userId").(string)
+	channelId := c.Param("id")
+
+	channel, ok := h.getGroupDM(c, channelId, userId)
+	if !ok {
+		return
+	}
+
+	author, err := h.userService.Get(userId)
+
+	if err != nil {
+		e := apperrors.NewNotFound("user", userId)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	nameChanged := channel.Name != req.Name
+	channel.Name = req.Name
+
+	oldIcon := channel.Icon
+
+	// Group icon got changed
+	if req.Image != nil {
+		// Validate image mime-type is allowable
+		mimeType := req.Image.Header.Get("Content-Type")
+
+		if valid := isAllowedImageType(mimeType); !valid {
+			toFieldErrorResponse(c, "Image", apperrors.InvalidImageType)
+			return
+		}
+
+		directory := fmt.Sprintf("valkyrie/channels/%s", channel.ID)
+		url, err := h.userService.ChangeAvatar(req.Image, directory)
+
+		if err != nil {
+			e := apperrors.NewInternal()
+			c.JSON(e.Status(), gin.H{
+				"error": e,
+			})
+			return
+		}
+
+		if channel.Icon != nil {
+			_ = h.userService.DeleteImage(*channel.Icon)
+		}
+		channel.Icon = &url
+		// Group kept its old icon
+	} else if req.Icon != nil {
+		channel.Icon = req.Icon
+		// Group reset its icon
+	} else {
+		channel.Icon = nil
+	}
+
+	iconChanged := (oldIcon == nil) != (channel.Icon == nil) ||
+		(oldIcon != nil && *oldIcon != *channel.Icon)
+
+	if err = h.channelService.UpdateChannel(channel); err != nil {
+		log.Printf("Failed to update channel: %v\n", err.Error())
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
+	if nameChanged {
+		name := channel.Name
+		h.sendSystemMessage(channel, author, model.ChannelNameChangeMessage, &name)
+	}
+
+	if iconChanged {
+		h.sendSystemMessage(channel, author, model.ChannelIconChangeMessage, channel.Icon)
+	}
+
+	// Emit the changes to the members
+	h.socketService.EmitEditDM(channel.ID)
+
+	c.JSON(http.StatusOK, true)
+}
+
+// AddGroupDMMember adds the given friend to the group DM.
+// Every member can add their friends.
+// AddGroupDMMember godoc
+// @Tags Channels
+// @Summary Add Group DM Member
+// @Produce  json
+// @Param id path string true "DM Channel ID"
+// @Param memberId path string true "Member ID"
+// @Success 200 {object} model.Success
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /channels/{id}/members/{memberId} [post]
+func (h *Handler) AddGroupDMMember(c *gin.Context) {
+	userId := c.MustGet("userId").(string)
+	channelId := c.Param("id")
+	memberId := c.Param("memberId")
+
+	channel, ok := h.getGroupDM(c, channelId, userId)
+	if !ok {
+		return
+	}
+
+	if id, _ := h.channelService.GetDMByUserAndChannel(memberId, channel.ID); id != "" {
+		e := apperrors.NewBadRequest(apperrors.AlreadyGroupMember)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	members, err := h.channelService.GetDMMemberIds(channel.ID)
+
+	if err != nil {
+		e := apperrors.NewInternal()
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	if len(*members) >= model.MaximumGroupDMMembers {
+		e := apperrors.NewBadRequest(apperrors.GroupDMLimitError)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	user, err := h.friendService.GetMemberById(userId)
+
+	if err != nil {
+		e := apperrors.NewNotFound("user", userId)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	if !isFriend(user, memberId) {
+		e := apperrors.NewBadRequest(apperrors.GroupDMFriendsOnly)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	// The DM is open for the added member
+	if err = h.channelService.AddDMChannelMembers([]string{memberId}, channel.ID, memberId); err != nil {
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
+	h.sendSystemMessage(channel, user, model.RecipientAddMessage, &memberId)
+
+	h.socketService.EmitAddDM([]string{memberId}, channel.ID)
+	h.socketService.EmitEditDM(channel.ID)
+
+	c.JSON(http.StatusOK, true)
+}
+
+// RemoveGroupDMMember removes the member from the group DM.
+// Only the owner can remove other members, every member can remove
+// themselves to leave the group. If the owner leaves, the longest
+// standing member becomes the new owner. The group gets deleted
+// once the last member left.
+// RemoveGroupDMMember godoc
+// @Tags Channels
+// @Summary Remove Group DM Member or Leave Group DM
+// @Produce  json
+// @Param id path string true "DM Channel ID"
+// @Param memberId path string true "Member ID"
+// @Success 200 {object} model.Success
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /channels/{id}/members/{memberId} [delete]
+func (h *Handler) RemoveGroupDMMember(c *gin.Context) {
+	userId := c.MustGet("userId").(string)
+	channelId := c.Param("id")
+	memberId := c.Param("memberId")
+
+	channel, ok := h.getGroupDM(c, channelId, userId)
+	if !ok {
+		return
+	}
+
+	if memberId != userId {
+		if channel.OwnerId == nil || *channel.OwnerId != userId {
+			e := apperrors.NewAuthorization(apperrors.MustBeOwner)
+			c.JSON(e.Status(), gin.H{
+				"error": e,
+			})
+			return
+		}
+
+		if id, _ := h.channelService.GetDMByUserAndChannel(memberId, channel.ID); id == "" {
+			e := apperrors.NewBadRequest(apperrors.NotAGroupMember)
+			c.JSON(e.Status(), gin.H{
+				"error": e,
+			})
+			return
+		}
+	}
+
+	author, err := h.userService.Get(userId)
+
+	if err != nil {
+		e := apperrors.NewNotFound("user", userId)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	if err = h.channelService.RemoveDMChannelMember(channel.ID, memberId); err != nil {
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
+	h.socketService.EmitRemoveDM(memberId, channel.ID)
+
+	members, err := h.channelService.GetDMMemberIds(channel.ID)
+
+	if err != nil {
+		e := apperrors.NewInternal()
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	// Delete the group once everyone left
+	if len(*members) == 0 {
+		if err = h.channelService.DeleteChannel(channel); err != nil {
+			c.JSON(apperrors.Status(err), gin.H{
+				"error": err,
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, true)
+		return
+	}
+
+	// Transfer the ownership if the owner left
+	if channel.OwnerId != nil && *channel.OwnerId == memberId {
+		newOwner := (*members)[0]
+		channel.OwnerId = &newOwner
+
+		if err = h.channelService.UpdateChannel(channel); err != nil {
+			c.JSON(apperrors.Status(err), gin.H{
+				"error": err,
+			})
+			return
+		}
+	}
+
+	h.sendSystemMessage(channel, author, model.RecipientRemoveMessage, &memberId)
+
+	h.socketService.EmitEditDM(channel.ID)
+
+	c.JSON(http.StatusOK, true)
+}
+
+// getGroupDM returns the group DM for the given id if the user is a member of it.
+// Writes the error response and returns false otherwise.
+func (h *Handler) getGroupDM(c *gin.Context, channelId string, userId string) (*model.Channel, bool) {
+	channel, err := h.channelService.Get(channelId)
+
+	if err != nil {
+		e := apperrors.NewNotFound("channel", channelId)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return nil, false
+	}
+
+	if !channel.IsGroup {
+		e := apperrors.NewBadRequest(apperrors.NotAGroupDM)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return nil, false
+	}
+
+	// Check if the user is a member of the group
+	if err = h.channelService.IsChannelMember(channel, userId); err != nil {
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return nil, false
+	}
+
+	return channel, true
+}
+
+// sendSystemMessage posts a message of the given type in the channel
+// and emits it to the channel
+func (h *Handler) sendSystemMessage(channel *model.Channel, author *model.User, messageType string, text *string) {
+	message, err := h.messageService.CreateMessage(&model.Message{
+		UserId:    author.ID,
+		ChannelId: channel.ID,
+		Type:      messageType,
+		Text:      text,
+	})
+
+	if err != nil {
+		log.Printf("Failed to create system message: %v\n", err.Error())
+		return
+	}
+
+	response := model.MessageResponse{
+		Id:        message.ID,
+		Text:      message.Text,
+		Type:      message.Type,
+		CreatedAt: message.CreatedAt,
+		UpdatedAt: message.UpdatedAt,
+		User: model.MemberResponse{
+			Id:        author.ID,
+			Username:  author.Username,
+			Image:     author.Image,
+			IsOnline:  author.IsOnline,
+			Status:    author.GetPresence().Status,
+			CreatedAt: author.CreatedAt,
+			UpdatedAt: author.UpdatedAt,
+			IsFriend:  false,
+		},
+	}
+
+	h.socketService.EmitNewMessage(channel.ID, &response)
+}
@@ -0,0 +1,510 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/sentrionic/valkyrie/mocks"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"github.com/sentrionic/valkyrie/model/fixture"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// getMockGroupDM returns a group DM owned by the given user
+func getMockGroupDM(ownerId string) *model.Channel {
+	channel := fixture.GetMockDMChannel()
+	channel.IsGroup = true
+	channel.OwnerId = &ownerId
+	return channel
+}
+
+func TestHandler_CreateGroupDM(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("Successfully created", func(t *testing.T) {
+		authUser := fixture.GetMockUser()
+		friend := fixture.GetMockUser()
+		authUser.Friends = append(authUser.Friends, *friend)
+		name := fixture.RandStr(8)
+
+		channel := getMockGroupDM(authUser.ID)
+		channel.Name = name
+
+		channelParams := &model.Channel{
+			Name:     name,
+			IsPublic: false,
+			IsDM:     true,
+			IsGroup:  true,
+			OwnerId:  &authUser.ID,
+		}
+
+		dm := &model.DirectMessage{
+			Id:      channel.ID,
+			Name:    &name,
+			IsGroup: true,
+			OwnerId: &authUser.ID,
+			Members: []model.DMUser{{Id: friend.ID, Username: friend.Username}},
+		}
+
+		mockFriendService := new(mocks.FriendService)
+		mockFriendService.On("GetMemberById", authUser.ID).Return(authUser, nil)
+
+		mockChannelService := new(mocks.ChannelService)
+		mockChannelService.On("CreateChannel", channelParams).Return(channel, nil)
+		mockChannelService.On("AddDMChannelMembers", []string{authUser.ID, friend.ID}, channel.ID, authUser.ID).Return(nil)
+		mockChannelService.On("OpenDMForAll", channel.ID).Return(nil)
+		mockChannelService.On("GetDirectMessage", channel.ID, authUser.ID).Return(dm, nil)
+
+		mockSocketService := new(mocks.SocketService)
+		mockSocketService.On("EmitAddDM", []string{friend.ID}, channel.ID).Return()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
+			ChannelService: mockChannelService,
+			FriendService:  mockFriendService,
+			SocketService:  mockSocketService,
+		})
+
+		rr := httptest.NewRecorder()
+
+		reqBody, err := json.Marshal(gin.H{
+			"name":    name,
+			"members": []string{friend.ID, friend.ID, authUser.ID},
+		})
+		assert.NoError(t, err)
+
+		request, err := http.NewRequest(http.MethodPost, "/api/channels/me/dm", bytes.NewBuffer(reqBody))
+		assert.NoError(t, err)
+		request.Header.Set("Content-Type", "application/json")
+
+		respBody, _ := json.Marshal(dm)
+		router.ServeHTTP(rr, request)
+
+		assert.Equal(t, http.StatusCreated, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+
+		mockFriendService.AssertExpectations(t)
+		mockChannelService.AssertExpectations(t)
+		mockSocketService.AssertExpectations(t)
+	})
+
+	t.Run("Only friends can be added", func(t *testing.T) {
+		authUser := fixture.GetMockUser()
+		stranger := fixture.GetMockUser()
+
+		mockFriendService := new(mocks.FriendService)
+		mockFriendService.On("GetMemberById", authUser.ID).Return(authUser, nil)
+
+		mockChannelService := new(mocks.ChannelService)
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
+			ChannelService: mockChannelService,
+			FriendService:  mockFriendService,
+		})
+
+		rr := httptest.NewRecorder()
+
+		reqBody, err := json.Marshal(gin.H{
+			"members": []string{stranger.ID},
+		})
+		assert.NoError(t, err)
+
+		request, err := http.NewRequest(http.MethodPost, "/api/channels/me/dm", bytes.NewBuffer(reqBody))
+		assert.NoError(t, err)
+		request.Header.Set("Content-Type", "application/json")
+
+		mockError := apperrors.NewBadRequest(apperrors.GroupDMFriendsOnly)
+		respBody, _ := json.Marshal(gin.H{
+			"error": mockError,
+		})
+		router.ServeHTTP(rr, request)
+
+		assert.Equal(t, mockError.Status(), rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+
+		mockFriendService.AssertExpectations(t)
+		mockChannelService.AssertNotCalled(t, "CreateChannel", mock.Anything)
+	})
+
+	t.Run("Too many members", func(t *testing.T) {
+		authUser := fixture.GetMockUser()
+
+		members := make([]string, 0)
+		for i := 0; i < model.MaximumGroupDMMembers; i++ {
+			members = append(members, fixture.RandID())
+		}
+
+		mockFriendService := new(mocks.FriendService)
+		mockChannelService := new(mocks.ChannelService)
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
+			ChannelService: mockChannelService,
+			FriendService:  mockFriendService,
+		})
+
+		rr := httptest.NewRecorder()
+
+		reqBody, err := json.Marshal(gin.H{
+			"members": members,
+		})
+		assert.NoError(t, err)
+
+		request, err := http.NewRequest(http.MethodPost, "/api/channels/me/dm", bytes.NewBuffer(reqBody))
+		assert.NoError(t, err)
+		request.Header.Set("Content-Type", "application/json")
+
+		router.ServeHTTP(rr, request)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+		mockFriendService.AssertNotCalled(t, "GetMemberById", mock.Anything)
+		mockChannelService.AssertNotCalled(t, "CreateChannel", mock.Anything)
+	})
+}
+
+func TestHandler_AddGroupDMMember(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("Successfully added", func(t *testing.T) {
+		authUser := fixture.GetMockUser()
+		friend := fixture.GetMockUser()
+		authUser.Friends = append(authUser.Friends, *friend)
+		channel := getMockGroupDM(authUser.ID)
+		message := fixture.GetMockMessage(authUser.ID, channel.ID)
+
+		mockChannelService := new(mocks.ChannelService)
+		mockChannelService.On("Get", channel.ID).Return(channel, nil)
+		mockChannelService.On("IsChannelMember", channel, authUser.ID).Return(nil)
+		mockChannelService.On("GetDMByUserAndChannel", friend.ID, channel.ID).Return("", nil)
+		mockChannelService.On("GetDMMemberIds", channel.ID).Return(&[]string{authUser.ID}, nil)
+		mockChannelService.On("AddDMChannelMembers", []string{friend.ID}, channel.ID, friend.ID).Return(nil)
+
+		mockFriendService := new(mocks.FriendService)
+		mockFriendService.On("GetMemberById", authUser.ID).Return(authUser, nil)
+
+		mockMessageService := new(mocks.MessageService)
+		mockMessageService.On("CreateMessage", &model.Message{
+			UserId:    authUser.ID,
+			ChannelId: channel.ID,
+			Type:      model.RecipientAddMessage,
+			Text:      &friend.ID,
+		}).Return(message, nil)
+
+		mockSocketService := new(mocks.SocketService)
+		mockSocketService.On("EmitNewMessage", channel.ID, mock.AnythingOfType("*model.MessageResponse")).Return()
+		mockSocketService.On("EmitAddDM", []string{friend.ID}, channel.ID).Return()
+		mockSocketService.On("EmitEditDM", channel.ID).Return()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
+			ChannelService: mockChannelService,
+			FriendService:  mockFriendService,
+			MessageService: mockMessageService,
+			SocketService:  mockSocketService,
+		})
+
+		rr := httptest.NewRecorder()
+
+		url := fmt.Sprintf("/api/channels/%s/members/%s", channel.ID, friend.ID)
+		request, err := http.NewRequest(http.MethodPost, url, nil)
+		assert.NoError(t, err)
+
+		respBody, _ := json.Marshal(true)
+		router.ServeHTTP(rr, request)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+
+		mockChannelService.AssertExpectations(t)
+		mockFriendService.AssertExpectations(t)
+		mockMessageService.AssertExpectations(t)
+		mockSocketService.AssertExpectations(t)
+	})
+
+	t.Run("Group is full", func(t *testing.T) {
+		authUser := fixture.GetMockUser()
+		memberId := fixture.RandID()
+		channel := getMockGroupDM(authUser.ID)
+
+		members := make([]string, 0)
+		for i := 0; i < model.MaximumGroupDMMembers; i++ {
+			members = append(members, fixture.RandID())
+		}
+
+		mockChannelService := new(mocks.ChannelService)
+		mockChannelService.On("Get", channel.ID).Return(channel, nil)
+		mockChannelService.On("IsChannelMember", channel, authUser.ID).Return(nil)
+		mockChannelService.On("GetDMByUserAndChannel", memberId, channel.ID).Return("", nil)
+		mockChannelService.On("GetDMMemberIds", channel.ID).Return(&members, nil)
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
+			ChannelService: mockChannelService,
+		})
+
+		rr := httptest.NewRecorder()
+
+		url := fmt.Sprintf("/api/channels/%s/members/%s", channel.ID, memberId)
+		request, err := http.NewRequest(http.MethodPost, url, nil)
+		assert.NoError(t, err)
+
+		mockError := apperrors.NewBadRequest(apperrors.GroupDMLimitError)
+		respBody, _ := json.Marshal(gin.H{
+			"error": mockError,
+		})
+		router.ServeHTTP(rr, request)
+
+		assert.Equal(t, mockError.Status(), rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+
+		mockChannelService.AssertExpectations(t)
+		mockChannelService.AssertNotCalled(t, "AddDMChannelMembers", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Not a group DM", func(t *testing.T) {
+		authUser := fixture.GetMockUser()
+		channel := fixture.GetMockDMChannel()
+
+		mockChannelService := new(mocks.ChannelService)
+		mockChannelService.On("Get", channel.ID).Return(channel, nil)
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
+			ChannelService: mockChannelService,
+		})
+
+		rr := httptest.NewRecorder()
+
+		url := fmt.Sprintf("/api/channels/%s/members/%s", channel.ID, fixture.RandID())
+		request, err := http.NewRequest(http.MethodPost, url, nil)
+		assert.NoError(t, err)
+
+		mockError := apperrors.NewBadRequest(apperrors.NotAGroupDM)
+		respBody, _ := json.Marshal(gin.H{
+			"error": mockError,
+		})
+		router.ServeHTTP(rr, request)
+
+		assert.Equal(t, mockError.Status(), rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+
+		mockChannelService.AssertExpectations(t)
+	})
+}
+
+func TestHandler_RemoveGroupDMMember(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("Owner kicks a member", func(t *testing.T) {
+		authUser := fixture.GetMockUser()
+		memberId := fixture.RandID()
+		channel := getMockGroupDM(authUser.ID)
+		message := fixture.GetMockMessage(authUser.ID, channel.ID)
+
+		mockChannelService := new(mocks.ChannelService)
+		mockChannelService.On("Get", channel.ID).Return(channel, nil)
+		mockChannelService.On("IsChannelMember", channel, authUser.ID).Return(nil)
+		mockChannelService.On("GetDMByUserAndChannel", memberId, channel.ID).Return(fixture.RandID(), nil)
+		mockChannelService.On("RemoveDMChannelMember", channel.ID, memberId).Return(nil)
+		mockChannelService.On("GetDMMemberIds", channel.ID).Return(&[]string{authUser.ID}, nil)
+
+		mockUserService := new(mocks.UserService)
+		mockUserService.On("Get", authUser.ID).Return(authUser, nil)
+
+		mockMessageService := new(mocks.MessageService)
+		mockMessageService.On("CreateMessage", &model.Message{
+			UserId:    authUser.ID,
+			ChannelId: channel.ID,
+			Type:      model.RecipientRemoveMessage,
+			Text:      &memberId,
+		}).Return(message, nil)
+
+		mockSocketService := new(mocks.SocketService)
+		mockSocketService.On("EmitRemoveDM", memberId, channel.ID).Return()
+		mockSocketService.On("EmitNewMessage", channel.ID, mock.AnythingOfType("*model.MessageResponse")).Return()
+		mockSocketService.On("EmitEditDM", channel.ID).Return()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:              router,
+			ChannelService: mockChannelService,
+			UserService:    allowSession(mockUserService),
+			MessageService: mockMessageService,
+			SocketService:  mockSocketService,
+		})
+
+		rr := httptest.NewRecorder()
+
+		url := fmt.Sprintf("/api/channels/%s/members/%s", channel.ID, memberId)
+		request, err := http.NewRequest(http.MethodDelete, url, nil)
+		assert.NoError(t, err)
+
+		respBody, _ := json.Marshal(true)
+		router.ServeHTTP(rr, request)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+
+		mockChannelService.AssertExpectations(t)
+		mockMessageService.AssertExpectations(t)
+		mockSocketService.AssertExpectations(t)
+		mockChannelService.AssertNotCalled(t, "UpdateChannel", mock.Anything)
+	})
+
+	t.Run("Only the owner can kick", func(t *testing.T) {
+		authUser := fixture.GetMockUser()
+		memberId := fixture.RandID()
+		channel := getMockGroupDM(fixture.RandID())
+
+		mockChannelService := new(mocks.ChannelService)
+		mockChannelService.On("Get", channel.ID).Return(channel, nil)
+		mockChannelService.On("IsChannelMember", channel, authUser.ID).Return(nil)
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
+			ChannelService: mockChannelService,
+		})
+
+		rr := httptest.NewRecorder()
+
+		url := fmt.Sprintf("/api/channels/%s/members/%s", channel.ID, memberId)
+		request, err := http.NewRequest(http.MethodDelete, url, nil)
+		assert.NoError(t, err)
+
+		mockError := apperrors.NewAuthorization(apperrors.MustBeOwner)
+		respBody, _ := json.Marshal(gin.H{
+			"error": mockError,
+		})
+		router.ServeHTTP(rr, request)
+
+		assert.Equal(t, mockError.Status(), rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+
+		mockChannelService.AssertExpectations(t)
+		mockChannelService.AssertNotCalled(t, "RemoveDMChannelMember", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Owner leaves and transfers the ownership", func(t *testing.T) {
+		authUser := fixture.GetMockUser()
+		nextOwner := fixture.RandID()
+		channel := getMockGroupDM(authUser.ID)
+		message := fixture.GetMockMessage(authUser.ID, channel.ID)
+
+		mockChannelService := new(mocks.ChannelService)
+		mockChannelService.On("Get", channel.ID).Return(channel, nil)
+		mockChannelService.On("IsChannelMember", channel, authUser.ID).Return(nil)
+		mockChannelService.On("RemoveDMChannelMember", channel.ID, authUser.ID).Return(nil)
+		mockChannelService.On("GetDMMemberIds", channel.ID).Return(&[]string{nextOwner, fixture.RandID()}, nil)
+		mockChannelService.On("UpdateChannel", channel).Return(nil)
+
+		mockUserService := new(mocks.UserService)
+		mockUserService.On("Get", authUser.ID).Return(authUser, nil)
+
+		mockMessageService := new(mocks.MessageService)
+		mockMessageService.On("CreateMessage", mock.AnythingOfType("*model.Message")).Return(message, nil)
+
+		mockSocketService := new(mocks.SocketService)
+		mockSocketService.On("EmitRemoveDM", authUser.ID, channel.ID).Return()
+		mockSocketService.On("EmitNewMessage", channel.ID, mock.AnythingOfType("*model.MessageResponse")).Return()
+		mockSocketService.On("EmitEditDM", channel.ID).Return()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:              router,
+			ChannelService: mockChannelService,
+			UserService:    allowSession(mockUserService),
+			MessageService: mockMessageService,
+			SocketService:  mockSocketService,
+		})
+
+		rr := httptest.NewRecorder()
+
+		url := fmt.Sprintf("/api/channels/%s/members/%s", channel.ID, authUser.ID)
+		request, err := http.NewRequest(http.MethodDelete, url, nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, nextOwner, *channel.OwnerId)
+
+		mockChannelService.AssertExpectations(t)
+		mockSocketService.AssertExpectations(t)
+		mockChannelService.AssertNotCalled(t, "GetDMByUserAndChannel", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Last member leaves and deletes the group", func(t *testing.T) {
+		authUser := fixture.GetMockUser()
+		channel := getMockGroupDM(authUser.ID)
+
+		mockChannelService := new(mocks.ChannelService)
+		mockChannelService.On("Get", channel.ID).Return(channel, nil)
+		mockChannelService.On("IsChannelMember", channel, authUser.ID).Return(nil)
+		mockChannelService.On("RemoveDMChannelMember", channel.ID, authUser.ID).Return(nil)
+		mockChannelService.On("GetDMMemberIds", channel.ID).Return(&[]string{}, nil)
+		mockChannelService.On("DeleteChannel", channel).Return(nil)
+
+		mockUserService := new(mocks.UserService)
+		mockUserService.On("Get", authUser.ID).Return(authUser, nil)
+
+		mockMessageService := new(mocks.MessageService)
+
+		mockSocketService := new(mocks.SocketService)
+		mockSocketService.On("EmitRemoveDM", authUser.ID, channel.ID).Return()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:              router,
+			ChannelService: mockChannelService,
+			UserService:    allowSession(mockUserService),
+			MessageService: mockMessageService,
+			SocketService:  mockSocketService,
+		})
+
+		rr := httptest.NewRecorder()
+
+		url := fmt.Sprintf("/api/channels/%s/members/%s", channel.ID, authUser.ID)
+		request, err := http.NewRequest(http.MethodDelete, url, nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		mockChannelService.AssertExpectations(t)
+		mockSocketService.AssertExpectations(t)
+		mockMessageService.AssertNotCalled(t, "CreateMessage", mock.Anything)
+	})
+}
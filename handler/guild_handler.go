@@ -148,6 +148,10 @@ func (h *Handler) CreateGuild(c *gin.Context) {
 		return
 	}
 
+	if !requireVerifiedEmail(c, authUser) {
+		return
+	}
+
 	// Check if the user is already in 100 guilds
 	if len(authUser.Guilds) >= model.MaximumGuilds {
 		e := apperrors.NewBadRequest(apperrors.GuildLimitReached)
@@ -612,6 +616,7 @@ func (h *Handler) LeaveGuild(c *gin.Context) {
 // @Summary Delete Guild
 // @Produce  json
 // @Param guildId path string true "Guild ID"
+// @Param X-Two-Factor-Code header string false "Required if two-factor authentication is enabled"
 // @Success 200 {object} model.Success
 // @Failure 401 {object} model.ErrorResponse
 // @Failure 404 {object} model.ErrorResponse
@@ -640,6 +645,20 @@ func (h *Handler) DeleteGuild(c *gin.Context) {
 		return
 	}
 
+	authUser, err := h.userService.Get(userId)
+
+	if err != nil {
+		e := apperrors.NewAuthorization(apperrors.InvalidSession)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	if ok := h.requireTwoFactor(c, authUser, c.GetHeader("X-Two-Factor-Code")); !ok {
+		return
+	}
+
 	// Get the ID of all members to emit the deletion to
 	members := make([]string, 0)
 	for _, member := range guild.Members {
@@ -660,6 +679,147 @@ func (h *Handler) DeleteGuild(c *gin.Context) {
 	c.JSON(http.StatusOK, true)
 }
 
+type transferGuildReq struct {
+	// The member that becomes the new owner
+	MemberId string `json:"memberId"`
+	Password string `json:"password"`
+	// Authenticator or recovery code. Required if two-factor authentication is enabled.
+	Code string `json:"code"`
+} //@name TransferGuildRequest
+
+func (r transferGuildReq) validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.MemberId, validation.Required),
+		validation.Field(&r.Password, validation.Required),
+	)
+}
+
+func (r *transferGuildReq) sanitize() {
+	r.MemberId = strings.TrimSpace(r.MemberId)
+	r.Password = strings.TrimSpace(r.Password)
+	r.Code = strings.TrimSpace(r.Code)
+}
+
+// TransferGuild makes the given member the owner of the guild.
+// The previous owner stays a member and can leave the guild afterwards.
+// TransferGuild godoc
+// @Tags Guilds
+// @Summary Transfer Guild Ownership
+// @Accept json
+// @Produce  json
+// @Param guildId path string true "Guild ID"
+// @Param request body transferGuildReq true "Transfer Guild"
+// @Success 200 {object} model.Success
+// @Failure 400 {object} model.ErrorsResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /guilds/{guildId}/transfer [post]
+func (h *Handler) TransferGuild(c *gin.Context) {
+	var req transferGuildReq
+
+	if ok := bindData(c, &req); !ok {
+		return
+	}
+
+	req.sanitize()
+
+	userId := c.MustGet("userId").(string)
+	guildId := c.Param("guildId")
+
+	guild, err := h.guildService.GetGuild(guildId)
+
+	if err != nil {
+		e := apperrors.NewNotFound("guild", guildId)
+
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	if guild.OwnerId != userId {
+		e := apperrors.NewAuthorization(apperrors.MustBeOwner)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	if req.MemberId == userId {
+		e := apperrors.NewBadRequest(apperrors.TransferYourselfError)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	var member *model.User
+	for i := range guild.Members {
+		if guild.Members[i].ID == req.MemberId {
+			member = &guild.Members[i]
+			break
+		}
+	}
+
+	if member == nil {
+		e := apperrors.NewBadRequest(apperrors.NotAMember)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	if member.IsBot {
+		e := apperrors.NewBadRequest(apperrors.TransferToBotError)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	authUser, err := h.userService.Get(userId)
+
+	if err != nil {
+		e := apperrors.NewAuthorization(apperrors.InvalidSession)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	if err = h.userService.VerifyPassword(authUser, req.Password); err != nil {
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
+	if ok := h.requireTwoFactor(c, authUser, req.Code); !ok {
+		return
+	}
+
+	guild.OwnerId = member.ID
+
+	if err = h.guildService.UpdateGuild(guild); err != nil {
+		log.Printf("Failed to transfer guild: %v\n", err.Error())
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
+	// Emit the new owner to the guild members and notify both owners
+	h.socketService.EmitEditGuild(guild)
+	h.socketService.EmitTransferGuild(&model.GuildTransfer{
+		GuildId:    guild.ID,
+		OldOwnerId: userId,
+		NewOwnerId: member.ID,
+	})
+
+	c.JSON(http.StatusOK, true)
+}
+
 // isMember checks if the given user is a member of the guild
 func isMember(guild *model.Guild, userId string) bool {
 	for _, v := range guild.Members {
@@ -41,6 +41,7 @@ func TestHandler_GetUserGuilds(t *testing.T) {
 
 		NewHandler(&Config{
 			R:            router,
+			UserService:  allowSession(new(mocks.UserService)),
 			GuildService: mockGuildService,
 		})
 
@@ -94,6 +95,7 @@ func TestHandler_GetUserGuilds(t *testing.T) {
 
 		NewHandler(&Config{
 			R:            router,
+			UserService:  allowSession(new(mocks.UserService)),
 			GuildService: mockGuildService,
 		})
 
@@ -147,6 +149,7 @@ func TestHandler_GetGuildMembers(t *testing.T) {
 
 		NewHandler(&Config{
 			R:            router,
+			UserService:  allowSession(new(mocks.UserService)),
 			GuildService: mockGuildService,
 		})
 
@@ -208,6 +211,7 @@ func TestHandler_GetGuildMembers(t *testing.T) {
 
 		NewHandler(&Config{
 			R:            router,
+			UserService:  allowSession(new(mocks.UserService)),
 			GuildService: mockGuildService,
 		})
 
@@ -249,6 +253,7 @@ func TestHandler_GetGuildMembers(t *testing.T) {
 
 		NewHandler(&Config{
 			R:            router,
+			UserService:  allowSession(new(mocks.UserService)),
 			GuildService: mockGuildService,
 		})
 
@@ -287,6 +292,7 @@ func TestHandler_GetGuildMembers(t *testing.T) {
 
 		NewHandler(&Config{
 			R:            router,
+			UserService:  allowSession(new(mocks.UserService)),
 			GuildService: mockGuildService,
 		})
 
@@ -354,6 +360,7 @@ func TestHandler_CreateGuild(t *testing.T) {
 
 		NewHandler(&Config{
 			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
 			GuildService:   mockGuildService,
 			ChannelService: mockChannelService,
 		})
@@ -404,6 +411,7 @@ func TestHandler_CreateGuild(t *testing.T) {
 
 		NewHandler(&Config{
 			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
 			GuildService:   mockGuildService,
 			ChannelService: mockChannelService,
 		})
@@ -453,6 +461,7 @@ func TestHandler_CreateGuild(t *testing.T) {
 
 		NewHandler(&Config{
 			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
 			GuildService:   mockGuildService,
 			ChannelService: mockChannelService,
 		})
@@ -514,6 +523,45 @@ func TestHandler_CreateGuild(t *testing.T) {
 		mockGuildService.AssertNotCalled(t, "CreateGuild")
 		mockChannelService.AssertNotCalled(t, "CreateChannel")
 	})
+
+	t.Run("Unverified email", func(t *testing.T) {
+		mockUser := fixture.GetMockUser()
+		mockUser.EmailVerified = false
+
+		router := getAuthenticatedTestRouter(mockUser.ID)
+
+		mockGuildService := new(mocks.GuildService)
+		mockGuildService.On("GetUser", mockUser.ID).Return(mockUser, nil)
+
+		NewHandler(&Config{
+			R:            router,
+			UserService:  allowSession(new(mocks.UserService)),
+			GuildService: mockGuildService,
+		})
+
+		reqBody, err := json.Marshal(gin.H{
+			"name": fixture.RandStr(8),
+		})
+		assert.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+
+		request, err := http.NewRequest(http.MethodPost, "/api/guilds/create", bytes.NewBuffer(reqBody))
+		assert.NoError(t, err)
+
+		request.Header.Set("Content-Type", "application/json")
+
+		router.ServeHTTP(rr, request)
+
+		mockError := apperrors.NewAuthorization(apperrors.EmailNotVerified)
+		respBody, _ := json.Marshal(gin.H{
+			"error": mockError,
+		})
+
+		assert.Equal(t, mockError.Status(), rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockGuildService.AssertNotCalled(t, "CreateGuild", mock.Anything)
+	})
 }
 
 func TestHandler_CreateGuild_BadRequest(t *testing.T) {
@@ -528,6 +576,7 @@ func TestHandler_CreateGuild_BadRequest(t *testing.T) {
 
 	NewHandler(&Config{
 		R:            router,
+		UserService:  allowSession(new(mocks.UserService)),
 		GuildService: mockGuildService,
 	})
 
@@ -605,6 +654,7 @@ func TestHandler_UpdateGuild(t *testing.T) {
 
 		NewHandler(&Config{
 			R:             router,
+			UserService:   allowSession(new(mocks.UserService)),
 			GuildService:  mockGuildService,
 			SocketService: mockSocketService,
 		})
@@ -645,6 +695,7 @@ func TestHandler_UpdateGuild(t *testing.T) {
 
 		NewHandler(&Config{
 			R:             router,
+			UserService:   allowSession(new(mocks.UserService)),
 			GuildService:  mockGuildService,
 			SocketService: mockSocketService,
 		})
@@ -685,6 +736,7 @@ func TestHandler_UpdateGuild(t *testing.T) {
 
 		NewHandler(&Config{
 			R:             router,
+			UserService:   allowSession(new(mocks.UserService)),
 			GuildService:  mockGuildService,
 			SocketService: mockSocketService,
 		})
@@ -766,6 +818,7 @@ func TestHandler_UpdateGuild(t *testing.T) {
 
 		NewHandler(&Config{
 			R:             router,
+			UserService:   allowSession(new(mocks.UserService)),
 			GuildService:  mockGuildService,
 			SocketService: mockSocketService,
 		})
@@ -803,6 +856,7 @@ func TestHandler_UpdateGuild_BadRequest(t *testing.T) {
 
 	NewHandler(&Config{
 		R:            router,
+		UserService:  allowSession(new(mocks.UserService)),
 		GuildService: mockGuildService,
 	})
 
@@ -882,6 +936,7 @@ func TestHandler_GetInvite(t *testing.T) {
 
 		NewHandler(&Config{
 			R:            router,
+			UserService:  allowSession(new(mocks.UserService)),
 			GuildService: mockGuildService,
 		})
 
@@ -940,6 +995,7 @@ func TestHandler_GetInvite(t *testing.T) {
 
 		NewHandler(&Config{
 			R:            router,
+			UserService:  allowSession(new(mocks.UserService)),
 			GuildService: mockGuildService,
 		})
 
@@ -977,6 +1033,7 @@ func TestHandler_GetInvite(t *testing.T) {
 
 		NewHandler(&Config{
 			R:            router,
+			UserService:  allowSession(new(mocks.UserService)),
 			GuildService: mockGuildService,
 		})
 
@@ -1012,6 +1069,7 @@ func TestHandler_GetInvite(t *testing.T) {
 
 		NewHandler(&Config{
 			R:            router,
+			UserService:  allowSession(new(mocks.UserService)),
 			GuildService: mockGuildService,
 		})
 
@@ -1063,6 +1121,7 @@ func TestHandler_GetInvite(t *testing.T) {
 
 		NewHandler(&Config{
 			R:            router,
+			UserService:  allowSession(new(mocks.UserService)),
 			GuildService: mockGuildService,
 		})
 
@@ -1103,6 +1162,7 @@ func TestHandler_GetInvite(t *testing.T) {
 
 		NewHandler(&Config{
 			R:            router,
+			UserService:  allowSession(new(mocks.UserService)),
 			GuildService: mockGuildService,
 		})
 
@@ -1149,6 +1209,7 @@ func TestHandler_DeleteGuildInvites(t *testing.T) {
 
 		NewHandler(&Config{
 			R:            router,
+			UserService:  allowSession(new(mocks.UserService)),
 			GuildService: mockGuildService,
 		})
 
@@ -1217,6 +1278,7 @@ func TestHandler_DeleteGuildInvites(t *testing.T) {
 
 		NewHandler(&Config{
 			R:            router,
+			UserService:  allowSession(new(mocks.UserService)),
 			GuildService: mockGuildService,
 		})
 
@@ -1249,6 +1311,7 @@ func TestHandler_DeleteGuildInvites(t *testing.T) {
 
 		NewHandler(&Config{
 			R:            router,
+			UserService:  allowSession(new(mocks.UserService)),
 			GuildService: mockGuildService,
 		})
 
@@ -1284,6 +1347,7 @@ func TestHandler_DeleteGuildInvites(t *testing.T) {
 
 		NewHandler(&Config{
 			R:            router,
+			UserService:  allowSession(new(mocks.UserService)),
 			GuildService: mockGuildService,
 		})
 
@@ -1339,6 +1403,7 @@ func TestHandler_JoinGuild(t *testing.T) {
 
 		NewHandler(&Config{
 			R:             router,
+			UserService:   allowSession(new(mocks.UserService)),
 			GuildService:  mockGuildService,
 			SocketService: mockSocketService,
 		})
@@ -1372,6 +1437,7 @@ func TestHandler_JoinGuild(t *testing.T) {
 
 		NewHandler(&Config{
 			R:             router,
+			UserService:   allowSession(new(mocks.UserService)),
 			GuildService:  mockGuildService,
 			SocketService: mockSocketService,
 		})
@@ -1463,6 +1529,7 @@ func TestHandler_JoinGuild(t *testing.T) {
 
 		NewHandler(&Config{
 			R:             router,
+			UserService:   allowSession(new(mocks.UserService)),
 			GuildService:  mockGuildService,
 			SocketService: mockSocketService,
 		})
@@ -1508,6 +1575,7 @@ func TestHandler_JoinGuild(t *testing.T) {
 
 		NewHandler(&Config{
 			R:             router,
+			UserService:   allowSession(new(mocks.UserService)),
 			GuildService:  mockGuildService,
 			SocketService: mockSocketService,
 		})
@@ -1563,6 +1631,7 @@ func TestHandler_JoinGuild(t *testing.T) {
 
 		NewHandler(&Config{
 			R:             router,
+			UserService:   allowSession(new(mocks.UserService)),
 			GuildService:  mockGuildService,
 			SocketService: mockSocketService,
 		})
@@ -1616,6 +1685,7 @@ func TestHandler_JoinGuild(t *testing.T) {
 
 		NewHandler(&Config{
 			R:             router,
+			UserService:   allowSession(new(mocks.UserService)),
 			GuildService:  mockGuildService,
 			SocketService: mockSocketService,
 		})
@@ -1670,6 +1740,7 @@ func TestHandler_JoinGuild(t *testing.T) {
 
 		NewHandler(&Config{
 			R:             router,
+			UserService:   allowSession(new(mocks.UserService)),
 			GuildService:  mockGuildService,
 			SocketService: mockSocketService,
 		})
@@ -1722,6 +1793,7 @@ func TestHandler_LeaveGuild(t *testing.T) {
 
 		NewHandler(&Config{
 			R:             router,
+			UserService:   allowSession(new(mocks.UserService)),
 			GuildService:  mockGuildService,
 			SocketService: mockSocketService,
 		})
@@ -1756,6 +1828,7 @@ func TestHandler_LeaveGuild(t *testing.T) {
 
 		NewHandler(&Config{
 			R:             router,
+			UserService:   allowSession(new(mocks.UserService)),
 			GuildService:  mockGuildService,
 			SocketService: mockSocketService,
 		})
@@ -1834,6 +1907,7 @@ func TestHandler_LeaveGuild(t *testing.T) {
 
 		NewHandler(&Config{
 			R:             router,
+			UserService:   allowSession(new(mocks.UserService)),
 			GuildService:  mockGuildService,
 			SocketService: mockSocketService,
 		})
@@ -1875,6 +1949,7 @@ func TestHandler_LeaveGuild(t *testing.T) {
 
 		NewHandler(&Config{
 			R:             router,
+			UserService:   allowSession(new(mocks.UserService)),
 			GuildService:  mockGuildService,
 			SocketService: mockSocketService,
 		})
@@ -1909,6 +1984,9 @@ func TestHandler_DeleteGuild(t *testing.T) {
 		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
 		mockGuildService.On("DeleteGuild", mockGuild.ID).Return(nil)
 
+		mockUserService := new(mocks.UserService)
+		mockUserService.On("Get", authUser.ID).Return(authUser, nil)
+
 		mockSocketService := new(mocks.SocketService)
 
 		members := make([]string, 0)
@@ -1921,6 +1999,7 @@ func TestHandler_DeleteGuild(t *testing.T) {
 
 		NewHandler(&Config{
 			R:             router,
+			UserService:   allowSession(mockUserService),
 			GuildService:  mockGuildService,
 			SocketService: mockSocketService,
 		})
@@ -1956,6 +2035,7 @@ func TestHandler_DeleteGuild(t *testing.T) {
 
 		NewHandler(&Config{
 			R:             router,
+			UserService:   allowSession(new(mocks.UserService)),
 			GuildService:  mockGuildService,
 			SocketService: mockSocketService,
 		})
@@ -2034,6 +2114,7 @@ func TestHandler_DeleteGuild(t *testing.T) {
 
 		NewHandler(&Config{
 			R:             router,
+			UserService:   allowSession(new(mocks.UserService)),
 			GuildService:  mockGuildService,
 			SocketService: mockSocketService,
 		})
@@ -2066,6 +2147,9 @@ func TestHandler_DeleteGuild(t *testing.T) {
 		mockError := apperrors.NewInternal()
 		mockGuildService.On("DeleteGuild", mockGuild.ID).Return(mockError)
 
+		mockUserService := new(mocks.UserService)
+		mockUserService.On("Get", authUser.ID).Return(authUser, nil)
+
 		mockSocketService := new(mocks.SocketService)
 
 		// a response recorder for getting written http response
@@ -2075,6 +2159,7 @@ func TestHandler_DeleteGuild(t *testing.T) {
 
 		NewHandler(&Config{
 			R:             router,
+			UserService:   allowSession(mockUserService),
 			GuildService:  mockGuildService,
 			SocketService: mockSocketService,
 		})
@@ -2096,4 +2181,354 @@ func TestHandler_DeleteGuild(t *testing.T) {
 
 		mockSocketService.AssertNotCalled(t, "EmitDeleteGuild")
 	})
+	t.Run("Two-factor code required", func(t *testing.T) {
+		mockUser := fixture.GetMockUser()
+		mockUser.TwoFactorEnabled = true
+		mockGuild := fixture.GetMockGuild(mockUser.ID)
+
+		mockGuildService := new(mocks.GuildService)
+		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
+
+		mockError := apperrors.NewAuthorization(apperrors.TwoFactorRequired)
+		mockUserService := new(mocks.UserService)
+		mockUserService.On("Get", mockUser.ID).Return(mockUser, nil)
+		mockUserService.On("VerifyTwoFactor", mock.Anything, mockUser, "").Return(mockError)
+
+		mockSocketService := new(mocks.SocketService)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(mockUser.ID)
+
+		NewHandler(&Config{
+			R:             router,
+			UserService:   allowSession(mockUserService),
+			GuildService:  mockGuildService,
+			SocketService: mockSocketService,
+		})
+
+		reqUrl := fmt.Sprintf("/api/guilds/%s/delete", mockGuild.ID)
+		request, err := http.NewRequest(http.MethodDelete, reqUrl, nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal(gin.H{
+			"error": mockError,
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, mockError.Status(), rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockUserService.AssertExpectations(t)
+		mockGuildService.AssertNotCalled(t, "DeleteGuild", mock.Anything)
+		mockSocketService.AssertNotCalled(t, "EmitDeleteGuild", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Deleted with two-factor code", func(t *testing.T) {
+		mockUser := fixture.GetMockUser()
+		mockUser.TwoFactorEnabled = true
+		mockGuild := fixture.GetMockGuild(mockUser.ID)
+		code := "123456"
+
+		mockGuildService := new(mocks.GuildService)
+		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
+		mockGuildService.On("DeleteGuild", mockGuild.ID).Return(nil)
+
+		mockUserService := new(mocks.UserService)
+		mockUserService.On("Get", mockUser.ID).Return(mockUser, nil)
+		mockUserService.On("VerifyTwoFactor", mock.Anything, mockUser, code).Return(nil)
+
+		mockSocketService := new(mocks.SocketService)
+		mockSocketService.On("EmitDeleteGuild", mockGuild.ID, make([]string, 0)).Return()
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(mockUser.ID)
+
+		NewHandler(&Config{
+			R:             router,
+			UserService:   allowSession(mockUserService),
+			GuildService:  mockGuildService,
+			SocketService: mockSocketService,
+		})
+
+		reqUrl := fmt.Sprintf("/api/guilds/%s/delete", mockGuild.ID)
+		request, err := http.NewRequest(http.MethodDelete, reqUrl, nil)
+		assert.NoError(t, err)
+		request.Header.Set("X-Two-Factor-Code", code)
+
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal(true)
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockUserService.AssertExpectations(t)
+		mockGuildService.AssertExpectations(t)
+		mockSocketService.AssertExpectations(t)
+	})
+}
+
+func TestHandler_TransferGuild(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	authUser := fixture.GetMockUser()
+
+	t.Run("Successfully transferred", func(t *testing.T) {
+		member := fixture.GetMockUser()
+		mockGuild := fixture.GetMockGuild(authUser.ID)
+		mockGuild.Members = []model.User{*authUser, *member}
+
+		mockGuildService := new(mocks.GuildService)
+		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
+		mockGuildService.On("UpdateGuild", mock.MatchedBy(func(g *model.Guild) bool {
+			return g.ID == mockGuild.ID && g.OwnerId == member.ID
+		})).Return(nil)
+
+		mockUserService := new(mocks.UserService)
+		mockUserService.On("Get", authUser.ID).Return(authUser, nil)
+		mockUserService.On("VerifyPassword", authUser, "password").Return(nil)
+
+		mockSocketService := new(mocks.SocketService)
+		mockSocketService.On("EmitEditGuild", mockGuild).Return()
+		mockSocketService.On("EmitTransferGuild", &model.GuildTransfer{
+			GuildId:    mockGuild.ID,
+			OldOwnerId: authUser.ID,
+			NewOwnerId: member.ID,
+		}).Return()
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:             router,
+			UserService:   allowSession(mockUserService),
+			GuildService:  mockGuildService,
+			SocketService: mockSocketService,
+		})
+
+		reqBody, err := json.Marshal(gin.H{
+			"memberId": member.ID,
+			"password": "password",
+		})
+		assert.NoError(t, err)
+
+		reqUrl := fmt.Sprintf("/api/guilds/%s/transfer", mockGuild.ID)
+		request, err := http.NewRequest(http.MethodPost, reqUrl, bytes.NewBuffer(reqBody))
+		assert.NoError(t, err)
+		request.Header.Set("Content-Type", "application/json")
+
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal(true)
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		assert.Equal(t, member.ID, mockGuild.OwnerId)
+		mockGuildService.AssertExpectations(t)
+		mockUserService.AssertExpectations(t)
+		mockSocketService.AssertExpectations(t)
+	})
+
+	t.Run("Not the guild owner", func(t *testing.T) {
+		member := fixture.GetMockUser()
+		mockGuild := fixture.GetMockGuild("")
+		mockGuild.Members = []model.User{*authUser, *member}
+
+		mockGuildService := new(mocks.GuildService)
+		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:            router,
+			UserService:  allowSession(new(mocks.UserService)),
+			GuildService: mockGuildService,
+		})
+
+		reqBody, _ := json.Marshal(gin.H{
+			"memberId": member.ID,
+			"password": "password",
+		})
+
+		reqUrl := fmt.Sprintf("/api/guilds/%s/transfer", mockGuild.ID)
+		request, _ := http.NewRequest(http.MethodPost, reqUrl, bytes.NewBuffer(reqBody))
+		request.Header.Set("Content-Type", "application/json")
+
+		router.ServeHTTP(rr, request)
+
+		mockError := apperrors.NewAuthorization(apperrors.MustBeOwner)
+		respBody, _ := json.Marshal(gin.H{
+			"error": mockError,
+		})
+
+		assert.Equal(t, mockError.Status(), rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockGuildService.AssertNotCalled(t, "UpdateGuild", mock.Anything)
+	})
+
+	t.Run("Target is not a member", func(t *testing.T) {
+		mockGuild := fixture.GetMockGuild(authUser.ID)
+		mockGuild.Members = []model.User{*authUser}
+
+		mockGuildService := new(mocks.GuildService)
+		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:            router,
+			UserService:  allowSession(new(mocks.UserService)),
+			GuildService: mockGuildService,
+		})
+
+		reqBody, _ := json.Marshal(gin.H{
+			"memberId": fixture.RandID(),
+			"password": "password",
+		})
+
+		reqUrl := fmt.Sprintf("/api/guilds/%s/transfer", mockGuild.ID)
+		request, _ := http.NewRequest(http.MethodPost, reqUrl, bytes.NewBuffer(reqBody))
+		request.Header.Set("Content-Type", "application/json")
+
+		router.ServeHTTP(rr, request)
+
+		mockError := apperrors.NewBadRequest(apperrors.NotAMember)
+		respBody, _ := json.Marshal(gin.H{
+			"error": mockError,
+		})
+
+		assert.Equal(t, mockError.Status(), rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockGuildService.AssertNotCalled(t, "UpdateGuild", mock.Anything)
+	})
+
+	t.Run("Cannot transfer to yourself", func(t *testing.T) {
+		mockGuild := fixture.GetMockGuild(authUser.ID)
+		mockGuild.Members = []model.User{*authUser}
+
+		mockGuildService := new(mocks.GuildService)
+		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:            router,
+			UserService:  allowSession(new(mocks.UserService)),
+			GuildService: mockGuildService,
+		})
+
+		reqBody, _ := json.Marshal(gin.H{
+			"memberId": authUser.ID,
+			"password": "password",
+		})
+
+		reqUrl := fmt.Sprintf("/api/guilds/%s/transfer", mockGuild.ID)
+		request, _ := http.NewRequest(http.MethodPost, reqUrl, bytes.NewBuffer(reqBody))
+		request.Header.Set("Content-Type", "application/json")
+
+		router.ServeHTTP(rr, request)
+
+		mockError := apperrors.NewBadRequest(apperrors.TransferYourselfError)
+		respBody, _ := json.Marshal(gin.H{
+			"error": mockError,
+		})
+
+		assert.Equal(t, mockError.Status(), rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockGuildService.AssertNotCalled(t, "UpdateGuild", mock.Anything)
+	})
+
+	t.Run("Cannot transfer to a bot", func(t *testing.T) {
+		bot := fixture.GetMockUser()
+		bot.IsBot = true
+		mockGuild := fixture.GetMockGuild(authUser.ID)
+		mockGuild.Members = []model.User{*authUser, *bot}
+
+		mockGuildService := new(mocks.GuildService)
+		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:            router,
+			UserService:  allowSession(new(mocks.UserService)),
+			GuildService: mockGuildService,
+		})
+
+		reqBody, _ := json.Marshal(gin.H{
+			"memberId": bot.ID,
+			"password": "password",
+		})
+
+		reqUrl := fmt.Sprintf("/api/guilds/%s/transfer", mockGuild.ID)
+		request, _ := http.NewRequest(http.MethodPost, reqUrl, bytes.NewBuffer(reqBody))
+		request.Header.Set("Content-Type", "application/json")
+
+		router.ServeHTTP(rr, request)
+
+		mockError := apperrors.NewBadRequest(apperrors.TransferToBotError)
+		respBody, _ := json.Marshal(gin.H{
+			"error": mockError,
+		})
+
+		assert.Equal(t, mockError.Status(), rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockGuildService.AssertNotCalled(t, "UpdateGuild", mock.Anything)
+	})
+
+	t.Run("Invalid password", func(t *testing.T) {
+		member := fixture.GetMockUser()
+		mockGuild := fixture.GetMockGuild(authUser.ID)
+		mockGuild.Members = []model.User{*authUser, *member}
+
+		mockGuildService := new(mocks.GuildService)
+		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
+
+		mockError := apperrors.NewAuthorization(apperrors.InvalidPassword)
+		mockUserService := new(mocks.UserService)
+		mockUserService.On("Get", authUser.ID).Return(authUser, nil)
+		mockUserService.On("VerifyPassword", authUser, "wrongpassword").Return(mockError)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:            router,
+			UserService:  allowSession(mockUserService),
+			GuildService: mockGuildService,
+		})
+
+		reqBody, _ := json.Marshal(gin.H{
+			"memberId": member.ID,
+			"password": "wrongpassword",
+		})
+
+		reqUrl := fmt.Sprintf("/api/guilds/%s/transfer", mockGuild.ID)
+		request, _ := http.NewRequest(http.MethodPost, reqUrl, bytes.NewBuffer(reqBody))
+		request.Header.Set("Content-Type", "application/json")
+
+		router.ServeHTTP(rr, request)
+
+		respBody, _ := json.Marshal(gin.H{
+			"error": mockError,
+		})
+
+		assert.Equal(t, mockError.Status(), rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		assert.Equal(t, authUser.ID, mockGuild.OwnerId)
+		mockGuildService.AssertNotCalled(t, "UpdateGuild", mock.Anything)
+	})
 }
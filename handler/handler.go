@@ -19,13 +19,19 @@ import (
 
 // Handler struct holds required services for handler to function
 type Handler struct {
-	userService    model.UserService
-	friendService  model.FriendService
-	guildService   model.GuildService
-	channelService model.ChannelService
-	messageService model.MessageService
-	socketService  model.SocketService
-	MaxBodyBytes   int64
+	userService     model.UserService
+	friendService   model.FriendService
+	guildService    model.GuildService
+	channelService  model.ChannelService
+	messageService  model.MessageService
+	socketService   model.SocketService
+	tokenService    model.TokenService
+	webhookService  model.WebhookService
+	eventService    model.EventService
+	commandService  model.CommandService
+	identityService model.IdentityService
+	exportService   model.ExportService
+	MaxBodyBytes    int64
 }
 
 // Config will hold services that will eventually be injected into this
@@ -38,6 +44,12 @@ type Config struct {
 	ChannelService  model.ChannelService
 	MessageService  model.MessageService
 	SocketService   model.SocketService
+	TokenService    model.TokenService
+	WebhookService  model.WebhookService
+	EventService    model.EventService
+	CommandService  model.CommandService
+	IdentityService model.IdentityService
+	ExportService   model.ExportService
 	TimeoutDuration time.Duration
 	MaxBodyBytes    int64
 }
@@ -48,13 +60,19 @@ func NewHandler(c *Config) {
 
 	// Create a handler (which will later have injected services)
 	h := &Handler{
-		userService:    c.UserService,
-		friendService:  c.FriendService,
-		guildService:   c.GuildService,
-		channelService: c.ChannelService,
-		messageService: c.MessageService,
-		socketService:  c.SocketService,
-		MaxBodyBytes:   c.MaxBodyBytes,
+		userService:     c.UserService,
+		friendService:   c.FriendService,
+		guildService:    c.GuildService,
+		channelService:  c.ChannelService,
+		messageService:  c.MessageService,
+		socketService:   c.SocketService,
+		tokenService:    c.TokenService,
+		webhookService:  c.WebhookService,
+		eventService:    c.EventService,
+		commandService:  c.CommandService,
+		identityService: c.IdentityService,
+		exportService:   c.ExportService,
+		MaxBodyBytes:    c.MaxBodyBytes,
 	}
 
 	c.R.NoRoute(func(c *gin.Context) {
@@ -76,14 +94,47 @@ func NewHandler(c *Config) {
 
 	ag.POST("/register", h.Register)
 	ag.POST("/login", h.Login)
+	ag.POST("/login/2fa", h.LoginTwoFactor)
 	ag.POST("/logout", h.Logout)
 	ag.POST("/forgot-password", h.ForgotPassword)
 	ag.POST("/reset-password", h.ResetPassword)
+	ag.POST("/verify-email", h.VerifyEmail)
+	ag.POST("/confirm-email", h.ConfirmEmail)
+	ag.POST("/revert-email", h.RevertEmail)
+	ag.POST("/oidc/authorize", h.AuthorizeOIDC)
+	ag.POST("/oidc/callback", h.CallbackOIDC)
 
-	ag.Use(middleware.AuthUser())
+	ag.Use(middleware.AuthUser(c.UserService, c.TokenService))
 	ag.GET("", h.GetCurrent)
 	ag.PUT("", h.Edit)
-	ag.PUT("/change-password", h.ChangePassword)
+	ag.DELETE("", middleware.RequireSession(), h.DeleteAccount)
+	ag.PUT("/change-password", middleware.RequireSession(), h.ChangePassword)
+	ag.PUT("/presence", h.UpdatePresence)
+	ag.PUT("/privacy", h.UpdatePrivacy)
+	ag.POST("/ws-ticket", h.CreateWSTicket)
+	ag.POST("/resend-verification", h.ResendVerification)
+
+	ag.POST("/2fa/setup", middleware.RequireSession(), h.SetupTwoFactor)
+	ag.POST("/2fa/enable", middleware.RequireSession(), h.EnableTwoFactor)
+	ag.POST("/2fa/disable", middleware.RequireSession(), h.DisableTwoFactor)
+	ag.POST("/2fa/recovery-codes", middleware.RequireSession(), h.RegenerateRecoveryCodes)
+
+	ag.GET("/sessions", middleware.RequireSession(), h.GetSessions)
+	ag.DELETE("/sessions/:id", middleware.RequireSession(), h.RevokeSession)
+
+	// Access tokens cannot be used to manage tokens and bots
+	ag.GET("/tokens", middleware.RequireSession(), h.GetTokens)
+	ag.POST("/tokens", middleware.RequireSession(), h.CreateToken)
+	ag.DELETE("/tokens/:id", middleware.RequireSession(), h.DeleteToken)
+	ag.GET("/bots", middleware.RequireSession(), h.GetBots)
+	ag.POST("/bots", middleware.RequireSession(), h.CreateBot)
+
+	ag.GET("/identities", middleware.RequireSession(), h.GetIdentities)
+	ag.POST("/identities", middleware.RequireSession(), h.LinkIdentity)
+	ag.DELETE("/identities/:id", middleware.RequireSession(), h.UnlinkIdentity)
+
+	ag.GET("/export", middleware.RequireSession(), h.GetExport)
+	ag.POST("/export", middleware.RequireSession(), h.RequestExport)
 
 	ag.GET("/me/friends", h.GetUserFriends)
 	ag.GET("/me/pending", h.GetUserRequests)
@@ -92,9 +143,13 @@ func NewHandler(c *Config) {
 	ag.POST("/:memberId/friend/accept", h.AcceptFriendRequest)
 	ag.POST("/:memberId/friend/cancel", h.CancelFriendRequest)
 
+	ag.GET("/me/blocked", h.GetBlockedUsers)
+	ag.POST("/:memberId/block", h.BlockUser)
+	ag.DELETE("/:memberId/block", h.UnblockUser)
+
 	// Create a guild group
 	gg := c.R.Group("api/guilds")
-	gg.Use(middleware.AuthUser())
+	gg.Use(middleware.AuthUser(c.UserService, c.TokenService))
 
 	gg.GET("/:guildId/members", h.GetGuildMembers)
 	gg.GET("", h.GetUserGuilds)
@@ -107,14 +162,26 @@ func NewHandler(c *Config) {
 	gg.DELETE("/:guildId", h.LeaveGuild)
 	gg.PUT("/:guildId", h.EditGuild)
 	gg.DELETE("/:guildId/delete", h.DeleteGuild)
+	gg.POST("/:guildId/transfer", middleware.RequireSession(), h.TransferGuild)
 	gg.GET("/:guildId/bans", h.GetBanList)
 	gg.POST("/:guildId/bans", h.BanMember)
 	gg.DELETE("/:guildId/bans", h.UnbanMember)
 	gg.POST("/:guildId/kick", h.KickMember)
 
+	gg.GET("/:guildId/event-webhooks", h.GetEventWebhooks)
+	gg.POST("/:guildId/event-webhooks", h.CreateEventWebhook)
+	gg.PUT("/:guildId/event-webhooks/:id", h.EditEventWebhook)
+	gg.DELETE("/:guildId/event-webhooks/:id", h.DeleteEventWebhook)
+	gg.GET("/:guildId/event-webhooks/:id/deliveries", h.GetEventDeliveries)
+
+	gg.GET("/:guildId/commands", h.GetGuildCommands)
+	gg.POST("/:guildId/commands", h.CreateCommand)
+	gg.PUT("/:guildId/commands/:id", h.EditCommand)
+	gg.DELETE("/:guildId/commands/:id", h.DeleteCommand)
+
 	// Create a channels group
 	cg := c.R.Group("api/channels")
-	cg.Use(middleware.AuthUser())
+	cg.Use(middleware.AuthUser(c.UserService, c.TokenService))
 
 	// Route parameters cause conflicts so they have to use the same parameter name
 	cg.GET("/:id", h.GuildChannels)                 // id -> guildId
@@ -122,27 +189,86 @@ func NewHandler(c *Config) {
 	cg.GET("/:id/members", h.PrivateChannelMembers) // id -> channelId
 	cg.POST("/:id/dm", h.GetOrCreateDM)             // id -> memberId
 	cg.GET("/me/dm", h.DirectMessages)              //
+	cg.POST("/me/dm", h.CreateGroupDM)              //
 	cg.PUT("/:id", h.EditChannel)                   // id -> channelId
 	cg.DELETE("/:id", h.DeleteChannel)              // id -> channelId
 	cg.DELETE("/:id/dm", h.CloseDM)                 // id -> channelId
+	cg.POST("/:id/typing", h.StartTyping)           // id -> channelId
+	cg.DELETE("/:id/typing", h.StopTyping)          // id -> channelId
+	cg.GET("/:id/voice", h.VoiceParticipants)       // id -> channelId
+	cg.PUT("/:id/group", h.EditGroupDM)             // id -> channelId
+
+	cg.GET("/:id/webhooks", h.GetChannelWebhooks) // id -> channelId
+	cg.POST("/:id/webhooks", h.CreateWebhook)     // id -> channelId
+
+	cg.POST("/:id/members/:memberId", h.AddGroupDMMember)      // id -> channelId
+	cg.DELETE("/:id/members/:memberId", h.RemoveGroupDMMember) // id -> channelId
+
+	cg.GET("/me/dm/requests", h.DMRequests)              //
+	cg.POST("/me/dm/requests/:id", h.AcceptDMRequest)    // id -> channelId
+	cg.DELETE("/me/dm/requests/:id", h.DeclineDMRequest) // id -> channelId
 
 	// Create a messages group
 	mg := c.R.Group("api/messages")
-	mg.Use(middleware.AuthUser())
+	mg.Use(middleware.AuthUser(c.UserService, c.TokenService))
 
 	mg.GET("/:channelId", h.GetMessages)
 	mg.POST("/:channelId", h.CreateMessage)
 	mg.PUT("/:messageId", h.EditMessage)
 	mg.DELETE("/:messageId", h.DeleteMessage)
+
+	// Create a webhooks group
+	wg := c.R.Group("api/webhooks")
+
+	wg.POST("/:id/:token", h.ExecuteWebhook)
+
+	wg.Use(middleware.AuthUser(c.UserService, c.TokenService))
+	wg.PUT("/:id", h.EditWebhook)
+	wg.DELETE("/:id", h.DeleteWebhook)
+
+	// Create an interactions group
+	ig := c.R.Group("api/interactions")
+	ig.Use(middleware.AuthUser(c.UserService, c.TokenService))
+
+	ig.POST("/:id", h.RespondInteraction)
 }
 
-// setUserSession saves the users ID in the session
-func setUserSession(c *gin.Context, id string) {
+// setUserSession saves the users ID in the session and tracks
+// the session so the user can revoke it later.
+// Logging in cancels a scheduled deletion of the account.
+// Writes an error response and returns false if the session could not be tracked.
+func (h *Handler) setUserSession(c *gin.Context, user *model.User) bool {
+	if user.DeletionScheduledAt != nil {
+		if err := h.userService.CancelDeletion(user); err != nil {
+			log.Printf("error cancelling the account deletion: %v\n", err.Error())
+		}
+	}
+
+	// Sessions that are not tracked could never be revoked
+	sessionId, err := h.userService.CreateLoginSession(c.Request.Context(), user.ID, c.Request.UserAgent(), c.ClientIP())
+	if err != nil {
+		log.Printf("error tracking the session: %v\n", err.Error())
+		e := apperrors.NewInternal()
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return false
+	}
+
 	session := sessions.Default(c)
-	session.Set("userId", id)
+	session.Set("userId", user.ID)
+	session.Set("sessionId", sessionId)
+
 	if err := session.Save(); err != nil {
 		log.Printf("error setting the session: %v\n", err.Error())
+		e := apperrors.NewInternal()
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return false
 	}
+
+	return true
 }
 
 func toFieldErrorResponse(c *gin.Context, field, message string) {
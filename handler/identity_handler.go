@@ -0,0 +1,274 @@
+package handler
+
+import (
+	"crypto/subtle"
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-gonic/gin"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"log"
+	"net/http"
+	"strings"
+)
+
+/*
+ * IdentityHandler contains all routes related to logging in with an OpenID Connect provider
+ */
+
+// Session keys of a started login at the identity provider
+const (
+	oidcStateKey    = "oidcState"
+	oidcNonceKey    = "oidcNonce"
+	oidcVerifierKey = "oidcVerifier"
+)
+
+// AuthorizeOIDC starts a login at the identity provider
+// AuthorizeOIDC godoc
+// @Tags Account
+// @Summary Start Identity Provider Login
+// @Description Returns the URL of the provider. The provider redirects back with a code and state
+// @Description that have to be sent to the callback or the link route.
+// @Produce  json
+// @Success 200 {object} model.OIDCAuthorization
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Failure 503 {object} model.ErrorResponse
+// @Router /account/oidc/authorize [post]
+func (h *Handler) AuthorizeOIDC(c *gin.Context) {
+	request, err := h.identityService.CreateAuthRequest(c.Request.Context())
+
+	if err != nil {
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
+	session := sessions.Default(c)
+	session.Set(oidcStateKey, request.State)
+	session.Set(oidcNonceKey, request.Nonce)
+	session.Set(oidcVerifierKey, request.Verifier)
+
+	if err := session.Save(); err != nil {
+		log.Printf("error setting the session: %v\n", err.Error())
+		e := apperrors.NewInternal()
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, model.OIDCAuthorization{URL: request.URL})
+}
+
+type oidcCallbackReq struct {
+	// The code returned by the provider.
+	Code string `json:"code"`
+	// The state returned by the provider.
+	State string `json:"state"`
+} //@name OIDCCallbackRequest
+
+func (r oidcCallbackReq) validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Code, validation.Required),
+		validation.Field(&r.State, validation.Required),
+	)
+}
+
+func (r *oidcCallbackReq) sanitize() {
+	r.Code = strings.TrimSpace(r.Code)
+	r.State = strings.TrimSpace(r.State)
+}
+
+// CallbackOIDC logs in the user of the identity.
+// Unknown identities get linked to the account with the same verified email
+// or create a new account.
+// CallbackOIDC godoc
+// @Tags Account
+// @Summary Identity Provider Login
+// @Accept  json
+// @Produce  json
+// @Param request body oidcCallbackReq true "Identity Provider Login"
+// @Success 200 {object} model.User "Or a TwoFactorChallenge if two-factor authentication is enabled"
+// @Failure 400 {object} model.ErrorsResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /account/oidc/callback [post]
+func (h *Handler) CallbackOIDC(c *gin.Context) {
+	var req oidcCallbackReq
+
+	if ok := bindData(c, &req); !ok {
+		return
+	}
+
+	req.sanitize()
+
+	nonce, verifier, ok := consumeOIDCSession(c, req.State)
+
+	if !ok {
+		e := apperrors.NewBadRequest(apperrors.InvalidOIDCState)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	user, err := h.identityService.Login(c.Request.Context(), req.Code, verifier, nonce)
+
+	if err != nil {
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
+	h.loginUser(c, user)
+}
+
+// LinkIdentity adds the identity to the current user
+// LinkIdentity godoc
+// @Tags Account
+// @Summary Link Identity
+// @Accept  json
+// @Produce  json
+// @Param request body oidcCallbackReq true "Link Identity"
+// @Success 201 {object} model.IdentityResponse
+// @Failure 400 {object} model.ErrorsResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /account/identities [post]
+func (h *Handler) LinkIdentity(c *gin.Context) {
+	userId := c.MustGet("userId").(string)
+	var req oidcCallbackReq
+
+	if ok := bindData(c, &req); !ok {
+		return
+	}
+
+	req.sanitize()
+
+	nonce, verifier, ok := consumeOIDCSession(c, req.State)
+
+	if !ok {
+		e := apperrors.NewBadRequest(apperrors.InvalidOIDCState)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	user, err := h.userService.Get(userId)
+
+	if err != nil {
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
+	identity, err := h.identityService.Link(c.Request.Context(), user, req.Code, verifier, nonce)
+
+	if err != nil {
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, identity.SerializeIdentity())
+}
+
+// GetIdentities returns the identities linked to the current user
+// GetIdentities godoc
+// @Tags Account
+// @Summary Get Current User's Identities
+// @Produce  json
+// @Success 200 {array} model.IdentityResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /account/identities [get]
+func (h *Handler) GetIdentities(c *gin.Context) {
+	userId := c.MustGet("userId").(string)
+
+	identities, err := h.identityService.GetIdentities(userId)
+
+	if err != nil {
+		e := apperrors.NewInternal()
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	response := make([]model.IdentityResponse, 0)
+	for _, identity := range *identities {
+		response = append(response, identity.SerializeIdentity())
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// UnlinkIdentity removes the identity from the current user.
+// Users without a password cannot remove their last identity.
+// UnlinkIdentity godoc
+// @Tags Account
+// @Summary Unlink Identity
+// @Produce  json
+// @Param id path string true "Identity ID"
+// @Success 200 {object} model.Success
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /account/identities/{id} [delete]
+func (h *Handler) UnlinkIdentity(c *gin.Context) {
+	userId := c.MustGet("userId").(string)
+	identityId := c.Param("id")
+
+	user, err := h.userService.Get(userId)
+
+	if err != nil {
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
+	if err := h.identityService.Unlink(user, identityId); err != nil {
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, true)
+}
+
+// consumeOIDCSession returns the nonce and verifier of the started login if the state matches.
+// The values get removed so every login can only be completed once.
+func consumeOIDCSession(c *gin.Context, state string) (string, string, bool) {
+	session := sessions.Default(c)
+
+	expected, _ := session.Get(oidcStateKey).(string)
+	nonce, _ := session.Get(oidcNonceKey).(string)
+	verifier, _ := session.Get(oidcVerifierKey).(string)
+
+	if expected == "" {
+		return "", "", false
+	}
+
+	session.Delete(oidcStateKey)
+	session.Delete(oidcNonceKey)
+	session.Delete(oidcVerifierKey)
+
+	if err := session.Save(); err != nil {
+		log.Printf("error setting the session: %v\n", err.Error())
+	}
+
+	if subtle.ConstantTimeCompare([]byte(expected), []byte(state)) != 1 {
+		return "", "", false
+	}
+
+	return nonce, verifier, true
+}
@@ -0,0 +1,510 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/sentrionic/valkyrie/mocks"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"github.com/sentrionic/valkyrie/model/fixture"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func getMockAuthRequest() *model.OIDCAuthRequest {
+	return &model.OIDCAuthRequest{
+		URL:      "https://accounts.example.com/authorize?state=abc",
+		State:    fixture.RandStr(43),
+		Nonce:    fixture.RandStr(43),
+		Verifier: fixture.RandStr(43),
+	}
+}
+
+// startOIDCLogin calls the authorize route and returns the session cookie
+func startOIDCLogin(t *testing.T, router *gin.Engine) *http.Cookie {
+	rr := httptest.NewRecorder()
+
+	request, err := http.NewRequest(http.MethodPost, "/api/account/oidc/authorize", nil)
+	assert.NoError(t, err)
+
+	router.ServeHTTP(rr, request)
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	cookies := rr.Result().Cookies()
+	assert.NotEmpty(t, cookies)
+
+	return cookies[len(cookies)-1]
+}
+
+func TestHandler_AuthorizeOIDC(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+
+	t.Run("Success", func(t *testing.T) {
+		authRequest := getMockAuthRequest()
+
+		mockIdentityService := new(mocks.IdentityService)
+		mockIdentityService.On("CreateAuthRequest", mock.Anything).Return(authRequest, nil)
+
+		rr := httptest.NewRecorder()
+
+		router := getTestRouter()
+
+		NewHandler(&Config{
+			R:               router,
+			IdentityService: mockIdentityService,
+		})
+
+		request, err := http.NewRequest(http.MethodPost, "/api/account/oidc/authorize", nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal(model.OIDCAuthorization{URL: authRequest.URL})
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		assert.NotEmpty(t, rr.Header().Get("Set-Cookie"))
+		assert.NotContains(t, rr.Body.String(), authRequest.Verifier)
+	})
+
+	t.Run("Not configured", func(t *testing.T) {
+		mockError := apperrors.NewBadRequest(apperrors.OIDCNotConfigured)
+
+		mockIdentityService := new(mocks.IdentityService)
+		mockIdentityService.On("CreateAuthRequest", mock.Anything).Return(nil, mockError)
+
+		rr := httptest.NewRecorder()
+
+		router := getTestRouter()
+
+		NewHandler(&Config{
+			R:               router,
+			IdentityService: mockIdentityService,
+		})
+
+		request, err := http.NewRequest(http.MethodPost, "/api/account/oidc/authorize", nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal(gin.H{
+			"error": mockError,
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+	})
+}
+
+func TestHandler_CallbackOIDC(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+
+	code := fixture.RandStr(20)
+
+	t.Run("Successful Login", func(t *testing.T) {
+		user := fixture.GetMockUser()
+		authRequest := getMockAuthRequest()
+
+		mockIdentityService := new(mocks.IdentityService)
+		mockUserService := new(mocks.UserService)
+		mockIdentityService.On("CreateAuthRequest", mock.Anything).Return(authRequest, nil)
+		mockIdentityService.On("Login", mock.Anything, code, authRequest.Verifier, authRequest.Nonce).Return(user, nil)
+		mockUserService.On("CreateLoginSession", mock.Anything, user.ID, mock.Anything, mock.Anything).Return(fixture.RandID(), nil)
+
+		router := getTestRouter()
+
+		NewHandler(&Config{
+			R:               router,
+			UserService:     mockUserService,
+			IdentityService: mockIdentityService,
+		})
+
+		cookie := startOIDCLogin(t, router)
+
+		rr := httptest.NewRecorder()
+
+		reqBody, err := json.Marshal(gin.H{
+			"code":  code,
+			"state": authRequest.State,
+		})
+		assert.NoError(t, err)
+
+		request, err := http.NewRequest(http.MethodPost, "/api/account/oidc/callback", bytes.NewBuffer(reqBody))
+		assert.NoError(t, err)
+
+		request.Header.Set("Content-Type", "application/json")
+		request.AddCookie(cookie)
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal(user)
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+
+		mockIdentityService.AssertExpectations(t)
+		mockUserService.AssertExpectations(t)
+	})
+
+	t.Run("Login requires second factor", func(t *testing.T) {
+		user := fixture.GetMockUser()
+		user.TwoFactorEnabled = true
+		ticket := fixture.RandStr(32)
+		authRequest := getMockAuthRequest()
+
+		mockIdentityService := new(mocks.IdentityService)
+		mockUserService := new(mocks.UserService)
+		mockIdentityService.On("CreateAuthRequest", mock.Anything).Return(authRequest, nil)
+		mockIdentityService.On("Login", mock.Anything, code, authRequest.Verifier, authRequest.Nonce).Return(user, nil)
+		mockUserService.On("CreateTwoFactorTicket", mock.Anything, user.ID).Return(ticket, nil)
+
+		router := getTestRouter()
+
+		NewHandler(&Config{
+			R:               router,
+			UserService:     mockUserService,
+			IdentityService: mockIdentityService,
+		})
+
+		cookie := startOIDCLogin(t, router)
+
+		rr := httptest.NewRecorder()
+
+		reqBody, err := json.Marshal(gin.H{
+			"code":  code,
+			"state": authRequest.State,
+		})
+		assert.NoError(t, err)
+
+		request, err := http.NewRequest(http.MethodPost, "/api/account/oidc/callback", bytes.NewBuffer(reqBody))
+		assert.NoError(t, err)
+
+		request.Header.Set("Content-Type", "application/json")
+		request.AddCookie(cookie)
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal(model.TwoFactorChallenge{
+			TwoFactorRequired: true,
+			Ticket:            ticket,
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+
+		mockUserService.AssertNotCalled(t, "CreateLoginSession", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("State does not match", func(t *testing.T) {
+		authRequest := getMockAuthRequest()
+
+		mockIdentityService := new(mocks.IdentityService)
+		mockIdentityService.On("CreateAuthRequest", mock.Anything).Return(authRequest, nil)
+
+		router := getTestRouter()
+
+		NewHandler(&Config{
+			R:               router,
+			IdentityService: mockIdentityService,
+		})
+
+		cookie := startOIDCLogin(t, router)
+
+		rr := httptest.NewRecorder()
+
+		reqBody, err := json.Marshal(gin.H{
+			"code":  code,
+			"state": fixture.RandStr(43),
+		})
+		assert.NoError(t, err)
+
+		request, err := http.NewRequest(http.MethodPost, "/api/account/oidc/callback", bytes.NewBuffer(reqBody))
+		assert.NoError(t, err)
+
+		request.Header.Set("Content-Type", "application/json")
+		request.AddCookie(cookie)
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal(gin.H{
+			"error": apperrors.NewBadRequest(apperrors.InvalidOIDCState),
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+
+		mockIdentityService.AssertNotCalled(t, "Login", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("No login started", func(t *testing.T) {
+		mockIdentityService := new(mocks.IdentityService)
+
+		router := getTestRouter()
+
+		NewHandler(&Config{
+			R:               router,
+			IdentityService: mockIdentityService,
+		})
+
+		rr := httptest.NewRecorder()
+
+		reqBody, err := json.Marshal(gin.H{
+			"code":  code,
+			"state": fixture.RandStr(43),
+		})
+		assert.NoError(t, err)
+
+		request, err := http.NewRequest(http.MethodPost, "/api/account/oidc/callback", bytes.NewBuffer(reqBody))
+		assert.NoError(t, err)
+
+		request.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(rr, request)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockIdentityService.AssertNotCalled(t, "Login", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Missing code", func(t *testing.T) {
+		mockIdentityService := new(mocks.IdentityService)
+
+		router := getTestRouter()
+
+		NewHandler(&Config{
+			R:               router,
+			IdentityService: mockIdentityService,
+		})
+
+		rr := httptest.NewRecorder()
+
+		reqBody, err := json.Marshal(gin.H{
+			"state": fixture.RandStr(43),
+		})
+		assert.NoError(t, err)
+
+		request, err := http.NewRequest(http.MethodPost, "/api/account/oidc/callback", bytes.NewBuffer(reqBody))
+		assert.NoError(t, err)
+
+		request.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(rr, request)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockIdentityService.AssertNotCalled(t, "Login", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestHandler_LinkIdentity(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+
+	authUser := fixture.GetMockUser()
+	code := fixture.RandStr(20)
+
+	t.Run("Success", func(t *testing.T) {
+		authRequest := getMockAuthRequest()
+		identity := &model.UserIdentity{
+			UserId:  authUser.ID,
+			Issuer:  "https://accounts.example.com",
+			Subject: fixture.RandID(),
+			Email:   authUser.Email,
+		}
+		identity.ID = fixture.RandID()
+
+		mockIdentityService := new(mocks.IdentityService)
+		mockUserService := new(mocks.UserService)
+		mockUserService.On("Get", authUser.ID).Return(authUser, nil)
+		mockIdentityService.On("CreateAuthRequest", mock.Anything).Return(authRequest, nil)
+		mockIdentityService.On("Link", mock.Anything, authUser, code, authRequest.Verifier, authRequest.Nonce).Return(identity, nil)
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:               router,
+			UserService:     allowSession(mockUserService),
+			IdentityService: mockIdentityService,
+		})
+
+		cookie := startOIDCLogin(t, router)
+
+		rr := httptest.NewRecorder()
+
+		reqBody, err := json.Marshal(gin.H{
+			"code":  code,
+			"state": authRequest.State,
+		})
+		assert.NoError(t, err)
+
+		request, err := http.NewRequest(http.MethodPost, "/api/account/identities", bytes.NewBuffer(reqBody))
+		assert.NoError(t, err)
+
+		request.Header.Set("Content-Type", "application/json")
+		request.AddCookie(cookie)
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal(identity.SerializeIdentity())
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusCreated, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockIdentityService.AssertExpectations(t)
+	})
+
+	t.Run("Linked to another account", func(t *testing.T) {
+		authRequest := getMockAuthRequest()
+		mockError := apperrors.NewBadRequest(apperrors.IdentityAlreadyLinked)
+
+		mockIdentityService := new(mocks.IdentityService)
+		mockUserService := new(mocks.UserService)
+		mockUserService.On("Get", authUser.ID).Return(authUser, nil)
+		mockIdentityService.On("CreateAuthRequest", mock.Anything).Return(authRequest, nil)
+		mockIdentityService.On("Link", mock.Anything, authUser, code, authRequest.Verifier, authRequest.Nonce).Return(nil, mockError)
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:               router,
+			UserService:     allowSession(mockUserService),
+			IdentityService: mockIdentityService,
+		})
+
+		cookie := startOIDCLogin(t, router)
+
+		rr := httptest.NewRecorder()
+
+		reqBody, err := json.Marshal(gin.H{
+			"code":  code,
+			"state": authRequest.State,
+		})
+		assert.NoError(t, err)
+
+		request, err := http.NewRequest(http.MethodPost, "/api/account/identities", bytes.NewBuffer(reqBody))
+		assert.NoError(t, err)
+
+		request.Header.Set("Content-Type", "application/json")
+		request.AddCookie(cookie)
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal(gin.H{
+			"error": mockError,
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+	})
+}
+
+func TestHandler_GetIdentities(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	authUser := fixture.GetMockUser()
+
+	identity := model.UserIdentity{
+		UserId:  authUser.ID,
+		Issuer:  "https://accounts.example.com",
+		Subject: fixture.RandID(),
+		Email:   authUser.Email,
+	}
+	identity.ID = fixture.RandID()
+	identities := []model.UserIdentity{identity}
+
+	mockIdentityService := new(mocks.IdentityService)
+	mockIdentityService.On("GetIdentities", authUser.ID).Return(&identities, nil)
+
+	rr := httptest.NewRecorder()
+
+	router := getAuthenticatedTestRouter(authUser.ID)
+
+	NewHandler(&Config{
+		R:               router,
+		UserService:     allowSession(new(mocks.UserService)),
+		IdentityService: mockIdentityService,
+	})
+
+	request, err := http.NewRequest(http.MethodGet, "/api/account/identities", nil)
+	assert.NoError(t, err)
+
+	router.ServeHTTP(rr, request)
+
+	respBody, err := json.Marshal([]model.IdentityResponse{identity.SerializeIdentity()})
+	assert.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, respBody, rr.Body.Bytes())
+	assert.NotContains(t, rr.Body.String(), identity.Subject)
+}
+
+func TestHandler_UnlinkIdentity(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	authUser := fixture.GetMockUser()
+	identityId := fixture.RandID()
+
+	t.Run("Success", func(t *testing.T) {
+		mockIdentityService := new(mocks.IdentityService)
+		mockUserService := new(mocks.UserService)
+		mockUserService.On("Get", authUser.ID).Return(authUser, nil)
+		mockIdentityService.On("Unlink", authUser, identityId).Return(nil)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:               router,
+			UserService:     allowSession(mockUserService),
+			IdentityService: mockIdentityService,
+		})
+
+		request, err := http.NewRequest(http.MethodDelete, "/api/account/identities/"+identityId, nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal(true)
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockIdentityService.AssertExpectations(t)
+	})
+
+	t.Run("Last login method", func(t *testing.T) {
+		mockError := apperrors.NewBadRequest(apperrors.LastLoginMethod)
+
+		mockIdentityService := new(mocks.IdentityService)
+		mockUserService := new(mocks.UserService)
+		mockUserService.On("Get", authUser.ID).Return(authUser, nil)
+		mockIdentityService.On("Unlink", authUser, identityId).Return(mockError)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:               router,
+			UserService:     allowSession(mockUserService),
+			IdentityService: mockIdentityService,
+		})
+
+		request, err := http.NewRequest(http.MethodDelete, "/api/account/identities/"+identityId, nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal(gin.H{
+			"error": mockError,
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+	})
+}
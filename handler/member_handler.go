@@ -27,8 +27,8 @@ func (r memberReq) validate() error {
 	)
 }
 
-// GetMemberSettings gets the current user's role color and nickname
-// for the given guild
+// GetMemberSettings gets the current user's role color, nickname
+// and DM privacy override for the given guild
 // GetMemberSettings godoc
 // @Tags Members
 // @Summary Get Member Settings
@@ -69,6 +69,8 @@ func (h *Handler) GetMemberSettings(c *gin.Context) {
 type memberSettingsReq struct {
 	Nickname *string `json:"nickname"`
 	Color    *string `json:"color"`
+	// Overrides the DM privacy setting for the guild. Null uses the account setting
+	AllowDMs *bool `json:"allowDMs"`
 } //@name MemberSettingsRequest
 
 func (r memberSettingsReq) validate() error {
@@ -85,8 +87,8 @@ func (r *memberSettingsReq) sanitize() {
 	}
 }
 
-// EditMemberSettings changes the current user's role color and nickname
-// for the given guild
+// EditMemberSettings changes the current user's role color, nickname
+// and DM privacy override for the given guild
 // EditMemberSettings godoc
 // @Tags Members
 // @Summary Edit Member Settings
@@ -135,6 +137,7 @@ func (h *Handler) EditMemberSettings(c *gin.Context) {
 	settings := &model.MemberSettings{
 		Nickname: req.Nickname,
 		Color:    req.Color,
+		AllowDMs: req.AllowDMs,
 	}
 
 	err = h.guildService.UpdateMemberSettings(settings, userId, guildId)
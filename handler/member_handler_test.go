@@ -43,6 +43,7 @@ func TestHandler_GetMemberSettings(t *testing.T) {
 
 		NewHandler(&Config{
 			R:            router,
+			UserService:  allowSession(new(mocks.UserService)),
 			GuildService: mockGuildService,
 		})
 
@@ -108,6 +109,7 @@ func TestHandler_GetMemberSettings(t *testing.T) {
 
 		NewHandler(&Config{
 			R:            router,
+			UserService:  allowSession(new(mocks.UserService)),
 			GuildService: mockGuildService,
 		})
 
@@ -147,6 +149,7 @@ func TestHandler_GetMemberSettings(t *testing.T) {
 
 		NewHandler(&Config{
 			R:            router,
+			UserService:  allowSession(new(mocks.UserService)),
 			GuildService: mockGuildService,
 		})
 
@@ -199,6 +202,7 @@ func TestHandler_EditMemberSettings(t *testing.T) {
 
 		NewHandler(&Config{
 			R:            router,
+			UserService:  allowSession(new(mocks.UserService)),
 			GuildService: mockGuildService,
 		})
 
@@ -224,6 +228,54 @@ func TestHandler_EditMemberSettings(t *testing.T) {
 		mockGuildService.AssertExpectations(t)
 	})
 
+	t.Run("Successfully disabled DMs for the guild", func(t *testing.T) {
+		mockGuild := fixture.GetMockGuild("")
+		mockGuild.Members = append(mockGuild.Members, *authUser)
+
+		allowDMs := false
+		mockSettings := &model.MemberSettings{
+			Nickname: &nickname,
+			Color:    &color,
+			AllowDMs: &allowDMs,
+		}
+
+		mockGuildService := new(mocks.GuildService)
+		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
+		mockGuildService.On("UpdateMemberSettings", mockSettings, authUser.ID, mockGuild.ID).Return(nil)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:            router,
+			UserService:  allowSession(new(mocks.UserService)),
+			GuildService: mockGuildService,
+		})
+
+		reqBody, err := json.Marshal(gin.H{
+			"nickname": nickname,
+			"color":    color,
+			"allowDMs": allowDMs,
+		})
+		assert.NoError(t, err)
+
+		reqUrl := fmt.Sprintf("/api/guilds/%s/member", mockGuild.ID)
+		request, err := http.NewRequest(http.MethodPut, reqUrl, bytes.NewBuffer(reqBody))
+		assert.NoError(t, err)
+
+		request.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal(true)
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+
+		mockGuildService.AssertExpectations(t)
+	})
+
 	t.Run("Successfully reset member settings", func(t *testing.T) {
 		mockGuild := fixture.GetMockGuild("")
 		mockGuild.Members = append(mockGuild.Members, *authUser)
@@ -244,6 +296,7 @@ func TestHandler_EditMemberSettings(t *testing.T) {
 
 		NewHandler(&Config{
 			R:            router,
+			UserService:  allowSession(new(mocks.UserService)),
 			GuildService: mockGuildService,
 		})
 
@@ -281,6 +334,7 @@ func TestHandler_EditMemberSettings(t *testing.T) {
 
 		NewHandler(&Config{
 			R:            router,
+			UserService:  allowSession(new(mocks.UserService)),
 			GuildService: mockGuildService,
 		})
 
@@ -359,6 +413,7 @@ func TestHandler_EditMemberSettings(t *testing.T) {
 
 		NewHandler(&Config{
 			R:            router,
+			UserService:  allowSession(new(mocks.UserService)),
 			GuildService: mockGuildService,
 		})
 
@@ -408,6 +463,7 @@ func TestHandler_EditMemberSettings(t *testing.T) {
 
 		NewHandler(&Config{
 			R:            router,
+			UserService:  allowSession(new(mocks.UserService)),
 			GuildService: mockGuildService,
 		})
 
@@ -446,6 +502,7 @@ func TestHandler_EditMemberSettings_BadRequest(t *testing.T) {
 
 	NewHandler(&Config{
 		R:            router,
+		UserService:  allowSession(new(mocks.UserService)),
 		GuildService: mockGuildService,
 	})
 
@@ -525,6 +582,7 @@ func TestHandler_GetBanList(t *testing.T) {
 
 		NewHandler(&Config{
 			R:            router,
+			UserService:  allowSession(new(mocks.UserService)),
 			GuildService: mockGuildService,
 		})
 
@@ -554,6 +612,7 @@ func TestHandler_GetBanList(t *testing.T) {
 
 		NewHandler(&Config{
 			R:            router,
+			UserService:  allowSession(new(mocks.UserService)),
 			GuildService: mockGuildService,
 		})
 
@@ -623,6 +682,7 @@ func TestHandler_GetBanList(t *testing.T) {
 
 		NewHandler(&Config{
 			R:            router,
+			UserService:  allowSession(new(mocks.UserService)),
 			GuildService: mockGuildService,
 		})
 
@@ -673,6 +733,7 @@ func TestHandler_BanMember(t *testing.T) {
 
 		NewHandler(&Config{
 			R:             router,
+			UserService:   allowSession(new(mocks.UserService)),
 			GuildService:  mockGuildService,
 			SocketService: mockSocketService,
 		})
@@ -713,6 +774,7 @@ func TestHandler_BanMember(t *testing.T) {
 
 		NewHandler(&Config{
 			R:             router,
+			UserService:   allowSession(new(mocks.UserService)),
 			GuildService:  mockGuildService,
 			SocketService: mockSocketService,
 		})
@@ -816,6 +878,7 @@ func TestHandler_BanMember(t *testing.T) {
 
 		NewHandler(&Config{
 			R:             router,
+			UserService:   allowSession(new(mocks.UserService)),
 			GuildService:  mockGuildService,
 			SocketService: mockSocketService,
 		})
@@ -861,6 +924,7 @@ func TestHandler_BanMember(t *testing.T) {
 
 		NewHandler(&Config{
 			R:             router,
+			UserService:   allowSession(new(mocks.UserService)),
 			GuildService:  mockGuildService,
 			SocketService: mockSocketService,
 		})
@@ -910,6 +974,7 @@ func TestHandler_BanMember(t *testing.T) {
 
 		NewHandler(&Config{
 			R:             router,
+			UserService:   allowSession(new(mocks.UserService)),
 			GuildService:  mockGuildService,
 			SocketService: mockSocketService,
 		})
@@ -954,6 +1019,7 @@ func TestHandler_BanMember(t *testing.T) {
 
 		NewHandler(&Config{
 			R:             router,
+			UserService:   allowSession(new(mocks.UserService)),
 			GuildService:  mockGuildService,
 			SocketService: mockSocketService,
 		})
@@ -993,6 +1059,7 @@ func TestHandler_BanMember(t *testing.T) {
 
 		NewHandler(&Config{
 			R:             router,
+			UserService:   allowSession(new(mocks.UserService)),
 			GuildService:  mockGuildService,
 			SocketService: mockSocketService,
 		})
@@ -1055,6 +1122,7 @@ func TestHandler_KickMember(t *testing.T) {
 
 		NewHandler(&Config{
 			R:             router,
+			UserService:   allowSession(new(mocks.UserService)),
 			GuildService:  mockGuildService,
 			SocketService: mockSocketService,
 		})
@@ -1095,6 +1163,7 @@ func TestHandler_KickMember(t *testing.T) {
 
 		NewHandler(&Config{
 			R:             router,
+			UserService:   allowSession(new(mocks.UserService)),
 			GuildService:  mockGuildService,
 			SocketService: mockSocketService,
 		})
@@ -1195,6 +1264,7 @@ func TestHandler_KickMember(t *testing.T) {
 
 		NewHandler(&Config{
 			R:             router,
+			UserService:   allowSession(new(mocks.UserService)),
 			GuildService:  mockGuildService,
 			SocketService: mockSocketService,
 		})
@@ -1240,6 +1310,7 @@ func TestHandler_KickMember(t *testing.T) {
 
 		NewHandler(&Config{
 			R:             router,
+			UserService:   allowSession(new(mocks.UserService)),
 			GuildService:  mockGuildService,
 			SocketService: mockSocketService,
 		})
@@ -1288,6 +1359,7 @@ func TestHandler_KickMember(t *testing.T) {
 
 		NewHandler(&Config{
 			R:             router,
+			UserService:   allowSession(new(mocks.UserService)),
 			GuildService:  mockGuildService,
 			SocketService: mockSocketService,
 		})
@@ -1331,6 +1403,7 @@ func TestHandler_KickMember(t *testing.T) {
 
 		NewHandler(&Config{
 			R:             router,
+			UserService:   allowSession(new(mocks.UserService)),
 			GuildService:  mockGuildService,
 			SocketService: mockSocketService,
 		})
@@ -1369,6 +1442,7 @@ func TestHandler_KickMember(t *testing.T) {
 
 		NewHandler(&Config{
 			R:             router,
+			UserService:   allowSession(new(mocks.UserService)),
 			GuildService:  mockGuildService,
 			SocketService: mockSocketService,
 		})
@@ -1426,6 +1500,7 @@ func TestHandler_UnbanMember(t *testing.T) {
 
 		NewHandler(&Config{
 			R:            router,
+			UserService:  allowSession(new(mocks.UserService)),
 			GuildService: mockGuildService,
 		})
 
@@ -1462,6 +1537,7 @@ func TestHandler_UnbanMember(t *testing.T) {
 
 		NewHandler(&Config{
 			R:            router,
+			UserService:  allowSession(new(mocks.UserService)),
 			GuildService: mockGuildService,
 		})
 
@@ -1552,6 +1628,7 @@ func TestHandler_UnbanMember(t *testing.T) {
 
 		NewHandler(&Config{
 			R:             router,
+			UserService:   allowSession(new(mocks.UserService)),
 			GuildService:  mockGuildService,
 			SocketService: mockSocketService,
 		})
@@ -1592,6 +1669,7 @@ func TestHandler_UnbanMember(t *testing.T) {
 
 		NewHandler(&Config{
 			R:            router,
+			UserService:  allowSession(new(mocks.UserService)),
 			GuildService: mockGuildService,
 		})
 
@@ -1630,6 +1708,7 @@ func TestHandler_UnbanMember(t *testing.T) {
 
 		NewHandler(&Config{
 			R:            router,
+			UserService:  allowSession(new(mocks.UserService)),
 			GuildService: mockGuildService,
 		})
 
@@ -1661,6 +1740,7 @@ func TestHandler_UnbanMember(t *testing.T) {
 
 		NewHandler(&Config{
 			R:            router,
+			UserService:  allowSession(new(mocks.UserService)),
 			GuildService: mockGuildService,
 		})
 
@@ -152,6 +152,34 @@ func (h *Handler) CreateMessage(c *gin.Context) {
 		return
 	}
 
+	if channel.Type == model.VoiceChannel {
+		e := apperrors.NewBadRequest(apperrors.VoiceChannelMessage)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	// Users cannot message each other if one blocked the other
+	if channel.IsDM && !channel.IsGroup {
+		blocked, err := h.isDMBlocked(channel.ID, userId)
+
+		if err != nil {
+			c.JSON(apperrors.Status(err), gin.H{
+				"error": err,
+			})
+			return
+		}
+
+		if blocked {
+			e := apperrors.NewBadRequest(apperrors.BlockedUserError)
+			c.JSON(e.Status(), gin.H{
+				"error": e,
+			})
+			return
+		}
+	}
+
 	author, err := h.userService.Get(userId)
 
 	if err != nil {
@@ -162,6 +190,13 @@ func (h *Handler) CreateMessage(c *gin.Context) {
 		return
 	}
 
+	// Run the slash command of a bot instead of posting the text
+	if !channel.IsDM && req.File == nil && strings.HasPrefix(*req.Text, "/") {
+		if handled := h.runCommand(c, channel, author, *req.Text); handled {
+			return
+		}
+	}
+
 	params := model.Message{
 		UserId:    userId,
 		ChannelId: channel.ID,
@@ -217,6 +252,7 @@ func (h *Handler) CreateMessage(c *gin.Context) {
 	response := model.MessageResponse{
 		Id:         message.ID,
 		Text:       message.Text,
+		Type:       message.Type,
 		CreatedAt:  message.CreatedAt,
 		UpdatedAt:  message.UpdatedAt,
 		Attachment: message.Attachment,
@@ -225,6 +261,7 @@ func (h *Handler) CreateMessage(c *gin.Context) {
 			Username:  author.Username,
 			Image:     author.Image,
 			IsOnline:  author.IsOnline,
+			Status:    author.GetPresence().Status,
 			CreatedAt: author.CreatedAt,
 			UpdatedAt: author.UpdatedAt,
 			IsFriend:  false,
@@ -300,6 +337,14 @@ func (h *Handler) EditMessage(c *gin.Context) {
 		return
 	}
 
+	if message.WebhookId != nil {
+		e := apperrors.NewBadRequest(apperrors.EditWebhookMessage)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
 	message.Text = req.Text
 
 	if err = h.messageService.UpdateMessage(message); err != nil {
@@ -313,6 +358,7 @@ func (h *Handler) EditMessage(c *gin.Context) {
 	response := model.MessageResponse{
 		Id:         message.ID,
 		Text:       message.Text,
+		Type:       message.Type,
 		CreatedAt:  message.CreatedAt,
 		UpdatedAt:  message.UpdatedAt,
 		Attachment: message.Attachment,
@@ -407,3 +453,33 @@ func (h *Handler) DeleteMessage(c *gin.Context) {
 
 	c.JSON(http.StatusOK, true)
 }
+
+// isDMBlocked checks if the other member of the DM and the user blocked each other.
+// Returns an error if the block could not be checked, so messages never bypass it.
+func (h *Handler) isDMBlocked(channelId string, userId string) (bool, error) {
+	members, err := h.channelService.GetDMMemberIds(channelId)
+
+	if err != nil {
+		log.Printf("Failed to get the members of DM %s: %v\n", channelId, err.Error())
+		return false, apperrors.NewInternal()
+	}
+
+	for _, id := range *members {
+		if id == userId {
+			continue
+		}
+
+		blocked, err := h.friendService.IsBlocked(userId, id)
+
+		if err != nil {
+			log.Printf("Failed to check if user %s is blocked: %v\n", id, err.Error())
+			return false, apperrors.NewInternal()
+		}
+
+		if blocked {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
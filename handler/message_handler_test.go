@@ -52,6 +52,7 @@ func TestHandler_GetMessages(t *testing.T) {
 
 		NewHandler(&Config{
 			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
 			ChannelService: mockChannelService,
 			MessageService: mockMessageService,
 		})
@@ -85,6 +86,7 @@ func TestHandler_GetMessages(t *testing.T) {
 
 		NewHandler(&Config{
 			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
 			ChannelService: mockChannelService,
 			MessageService: mockMessageService,
 		})
@@ -124,6 +126,7 @@ func TestHandler_GetMessages(t *testing.T) {
 
 		NewHandler(&Config{
 			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
 			ChannelService: mockChannelService,
 			MessageService: mockMessageService,
 		})
@@ -203,6 +206,7 @@ func TestHandler_GetMessages(t *testing.T) {
 
 		NewHandler(&Config{
 			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
 			ChannelService: mockChannelService,
 			MessageService: mockMessageService,
 		})
@@ -257,6 +261,7 @@ func TestHandler_CreateMessage(t *testing.T) {
 		response := model.MessageResponse{
 			Id:         mockMessage.ID,
 			Text:       mockMessage.Text,
+			Type:       mockMessage.Type,
 			CreatedAt:  mockMessage.CreatedAt,
 			UpdatedAt:  mockMessage.UpdatedAt,
 			Attachment: mockMessage.Attachment,
@@ -265,6 +270,7 @@ func TestHandler_CreateMessage(t *testing.T) {
 				Username:  authUser.Username,
 				Image:     authUser.Image,
 				IsOnline:  authUser.IsOnline,
+				Status:    authUser.GetPresence().Status,
 				CreatedAt: authUser.CreatedAt,
 				UpdatedAt: authUser.UpdatedAt,
 				IsFriend:  false,
@@ -285,7 +291,7 @@ func TestHandler_CreateMessage(t *testing.T) {
 			MessageService: mockMessageService,
 			GuildService:   mockGuildService,
 			SocketService:  mockSocketService,
-			UserService:    mockUserService,
+			UserService:    allowSession(mockUserService),
 		})
 
 		form := url.Values{}
@@ -332,7 +338,7 @@ func TestHandler_CreateMessage(t *testing.T) {
 			MessageService: mockMessageService,
 			GuildService:   mockGuildService,
 			SocketService:  mockSocketService,
-			UserService:    mockUserService,
+			UserService:    allowSession(mockUserService),
 		})
 
 		form := url.Values{}
@@ -382,7 +388,7 @@ func TestHandler_CreateMessage(t *testing.T) {
 			MessageService: mockMessageService,
 			GuildService:   mockGuildService,
 			SocketService:  mockSocketService,
-			UserService:    mockUserService,
+			UserService:    allowSession(mockUserService),
 		})
 
 		form := url.Values{}
@@ -409,6 +415,54 @@ func TestHandler_CreateMessage(t *testing.T) {
 		mockSocketService.AssertNotCalled(t, "EmitNewMessage")
 	})
 
+	t.Run("Voice channels do not accept messages", func(t *testing.T) {
+		mockChannel := fixture.GetMockChannel(fixture.RandID())
+		mockChannel.Type = model.VoiceChannel
+		mockError := apperrors.NewBadRequest(apperrors.VoiceChannelMessage)
+
+		mockChannelService := new(mocks.ChannelService)
+		mockChannelService.On("Get", mockChannel.ID).Return(mockChannel, nil)
+		mockChannelService.On("IsChannelMember", mockChannel, authUser.ID).Return(nil)
+
+		mockUserService := new(mocks.UserService)
+		mockMessageService := new(mocks.MessageService)
+		mockSocketService := new(mocks.SocketService)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:              router,
+			ChannelService: mockChannelService,
+			MessageService: mockMessageService,
+			SocketService:  mockSocketService,
+			UserService:    allowSession(mockUserService),
+		})
+
+		form := url.Values{}
+		form.Add("text", fixture.RandStringRunes(8))
+
+		request, err := http.NewRequest(http.MethodPost, "/api/messages/"+mockChannel.ID, strings.NewReader(form.Encode()))
+		assert.NoError(t, err)
+		request.Form = form
+
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal(gin.H{
+			"error": mockError,
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, mockError.Status(), rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+
+		mockChannelService.AssertExpectations(t)
+		mockUserService.AssertNotCalled(t, "Get")
+		mockMessageService.AssertNotCalled(t, "CreateMessage")
+		mockSocketService.AssertNotCalled(t, "EmitNewMessage")
+	})
+
 	t.Run("Unauthorized", func(t *testing.T) {
 		id := fixture.RandID()
 
@@ -490,7 +544,7 @@ func TestHandler_CreateMessage(t *testing.T) {
 			MessageService: mockMessageService,
 			GuildService:   mockGuildService,
 			SocketService:  mockSocketService,
-			UserService:    mockUserService,
+			UserService:    allowSession(mockUserService),
 		})
 
 		form := url.Values{}
@@ -543,7 +597,7 @@ func TestHandler_CreateMessage(t *testing.T) {
 			MessageService: mockMessageService,
 			GuildService:   mockGuildService,
 			SocketService:  mockSocketService,
-			UserService:    mockUserService,
+			UserService:    allowSession(mockUserService),
 		})
 
 		multipartImageFixture := fixture.NewMultipartImage("image.txt", "image/txt")
@@ -612,6 +666,7 @@ func TestHandler_CreateMessage(t *testing.T) {
 		response := model.MessageResponse{
 			Id:         mockMessage.ID,
 			Text:       nil,
+			Type:       mockMessage.Type,
 			CreatedAt:  mockMessage.CreatedAt,
 			UpdatedAt:  mockMessage.UpdatedAt,
 			Attachment: attachment,
@@ -620,6 +675,7 @@ func TestHandler_CreateMessage(t *testing.T) {
 				Username:  authUser.Username,
 				Image:     authUser.Image,
 				IsOnline:  authUser.IsOnline,
+				Status:    authUser.GetPresence().Status,
 				CreatedAt: authUser.CreatedAt,
 				UpdatedAt: authUser.UpdatedAt,
 				IsFriend:  false,
@@ -640,7 +696,7 @@ func TestHandler_CreateMessage(t *testing.T) {
 			MessageService: mockMessageService,
 			GuildService:   mockGuildService,
 			SocketService:  mockSocketService,
-			UserService:    mockUserService,
+			UserService:    allowSession(mockUserService),
 		})
 
 		multipartImageFixture := fixture.NewMultipartImage("image.png", "image/png")
@@ -670,10 +726,15 @@ func TestHandler_CreateMessage(t *testing.T) {
 		mockChannel := fixture.GetMockChannel("")
 		mockChannel.IsDM = true
 		mockMessage := fixture.GetMockMessage(authUser.ID, mockChannel.ID)
+		memberId := fixture.RandID()
 
 		mockChannelService := new(mocks.ChannelService)
 		mockChannelService.On("Get", mockChannel.ID).Return(mockChannel, nil)
 		mockChannelService.On("IsChannelMember", mockChannel, authUser.ID).Return(nil)
+		mockChannelService.On("GetDMMemberIds", mockChannel.ID).Return(&[]string{authUser.ID, memberId}, nil)
+
+		mockFriendService := new(mocks.FriendService)
+		mockFriendService.On("IsBlocked", authUser.ID, memberId).Return(false, nil)
 
 		mockUserService := new(mocks.UserService)
 		mockUserService.On("Get", authUser.ID).Return(authUser, nil)
@@ -690,6 +751,7 @@ func TestHandler_CreateMessage(t *testing.T) {
 		response := model.MessageResponse{
 			Id:         mockMessage.ID,
 			Text:       mockMessage.Text,
+			Type:       mockMessage.Type,
 			CreatedAt:  mockMessage.CreatedAt,
 			UpdatedAt:  mockMessage.UpdatedAt,
 			Attachment: mockMessage.Attachment,
@@ -698,6 +760,7 @@ func TestHandler_CreateMessage(t *testing.T) {
 				Username:  authUser.Username,
 				Image:     authUser.Image,
 				IsOnline:  authUser.IsOnline,
+				Status:    authUser.GetPresence().Status,
 				CreatedAt: authUser.CreatedAt,
 				UpdatedAt: authUser.UpdatedAt,
 				IsFriend:  false,
@@ -717,7 +780,8 @@ func TestHandler_CreateMessage(t *testing.T) {
 			ChannelService: mockChannelService,
 			MessageService: mockMessageService,
 			SocketService:  mockSocketService,
-			UserService:    mockUserService,
+			UserService:    allowSession(mockUserService),
+			FriendService:  mockFriendService,
 		})
 
 		form := url.Values{}
@@ -739,6 +803,111 @@ func TestHandler_CreateMessage(t *testing.T) {
 		mockMessageService.AssertExpectations(t)
 		mockSocketService.AssertExpectations(t)
 		mockUserService.AssertExpectations(t)
+		mockFriendService.AssertExpectations(t)
+	})
+
+	t.Run("DM with a blocked user", func(t *testing.T) {
+		mockChannel := fixture.GetMockDMChannel()
+		memberId := fixture.RandID()
+		mockError := apperrors.NewBadRequest(apperrors.BlockedUserError)
+
+		mockChannelService := new(mocks.ChannelService)
+		mockChannelService.On("Get", mockChannel.ID).Return(mockChannel, nil)
+		mockChannelService.On("IsChannelMember", mockChannel, authUser.ID).Return(nil)
+		mockChannelService.On("GetDMMemberIds", mockChannel.ID).Return(&[]string{authUser.ID, memberId}, nil)
+
+		mockFriendService := new(mocks.FriendService)
+		mockFriendService.On("IsBlocked", authUser.ID, memberId).Return(true, nil)
+
+		mockMessageService := new(mocks.MessageService)
+		mockSocketService := new(mocks.SocketService)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
+			ChannelService: mockChannelService,
+			MessageService: mockMessageService,
+			SocketService:  mockSocketService,
+			FriendService:  mockFriendService,
+		})
+
+		form := url.Values{}
+		form.Add("text", fixture.RandStringRunes(8))
+
+		request, err := http.NewRequest(http.MethodPost, "/api/messages/"+mockChannel.ID, strings.NewReader(form.Encode()))
+		assert.NoError(t, err)
+		request.Form = form
+
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal(gin.H{
+			"error": mockError,
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, mockError.Status(), rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+
+		mockChannelService.AssertExpectations(t)
+		mockFriendService.AssertExpectations(t)
+		mockMessageService.AssertNotCalled(t, "CreateMessage", mock.Anything)
+		mockSocketService.AssertNotCalled(t, "EmitNewMessage", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Block cannot be checked", func(t *testing.T) {
+		mockChannel := fixture.GetMockDMChannel()
+		memberId := fixture.RandID()
+		mockError := apperrors.NewInternal()
+
+		mockChannelService := new(mocks.ChannelService)
+		mockChannelService.On("Get", mockChannel.ID).Return(mockChannel, nil)
+		mockChannelService.On("IsChannelMember", mockChannel, authUser.ID).Return(nil)
+		mockChannelService.On("GetDMMemberIds", mockChannel.ID).Return(&[]string{authUser.ID, memberId}, nil)
+
+		mockFriendService := new(mocks.FriendService)
+		mockFriendService.On("IsBlocked", authUser.ID, memberId).Return(false, mockError)
+
+		mockMessageService := new(mocks.MessageService)
+		mockSocketService := new(mocks.SocketService)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
+			ChannelService: mockChannelService,
+			MessageService: mockMessageService,
+			SocketService:  mockSocketService,
+			FriendService:  mockFriendService,
+		})
+
+		form := url.Values{}
+		form.Add("text", fixture.RandStringRunes(8))
+
+		request, err := http.NewRequest(http.MethodPost, "/api/messages/"+mockChannel.ID, strings.NewReader(form.Encode()))
+		assert.NoError(t, err)
+		request.Form = form
+
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal(gin.H{
+			"error": mockError,
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, mockError.Status(), rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+
+		mockChannelService.AssertExpectations(t)
+		mockFriendService.AssertExpectations(t)
+		mockMessageService.AssertNotCalled(t, "CreateMessage", mock.Anything)
+		mockSocketService.AssertNotCalled(t, "EmitNewMessage", mock.Anything, mock.Anything)
 	})
 }
 
@@ -753,6 +922,7 @@ func TestHandler_CreateMessage_BadRequest(t *testing.T) {
 
 	NewHandler(&Config{
 		R:              router,
+		UserService:    allowSession(new(mocks.UserService)),
 		MessageService: mockMessageService,
 		MaxBodyBytes:   4 * 1024 * 1024,
 	})
@@ -813,6 +983,7 @@ func TestHandler_UpdateMessage(t *testing.T) {
 		response := model.MessageResponse{
 			Id:         mockMessage.ID,
 			Text:       mockMessage.Text,
+			Type:       mockMessage.Type,
 			CreatedAt:  mockMessage.CreatedAt,
 			UpdatedAt:  mockMessage.UpdatedAt,
 			Attachment: mockMessage.Attachment,
@@ -833,6 +1004,7 @@ func TestHandler_UpdateMessage(t *testing.T) {
 
 		NewHandler(&Config{
 			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
 			MessageService: mockMessageService,
 			SocketService:  mockSocketService,
 		})
@@ -874,6 +1046,7 @@ func TestHandler_UpdateMessage(t *testing.T) {
 
 		NewHandler(&Config{
 			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
 			MessageService: mockMessageService,
 			SocketService:  mockSocketService,
 		})
@@ -917,6 +1090,7 @@ func TestHandler_UpdateMessage(t *testing.T) {
 
 		NewHandler(&Config{
 			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
 			MessageService: mockMessageService,
 			SocketService:  mockSocketService,
 		})
@@ -944,6 +1118,53 @@ func TestHandler_UpdateMessage(t *testing.T) {
 		mockSocketService.AssertNotCalled(t, "EmitEditMessage")
 	})
 
+	t.Run("Webhook messages cannot be edited", func(t *testing.T) {
+		mockMessage := fixture.GetMockMessage(authUser.ID, "")
+		webhookId := fixture.RandID()
+		mockMessage.WebhookId = &webhookId
+
+		mockMessageService := new(mocks.MessageService)
+		mockMessageService.On("Get", mockMessage.ID).Return(mockMessage, nil)
+
+		mockSocketService := new(mocks.SocketService)
+
+		reqBody, err := json.Marshal(gin.H{
+			"text": fixture.RandStringRunes(12),
+		})
+		assert.NoError(t, err)
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
+			MessageService: mockMessageService,
+			SocketService:  mockSocketService,
+		})
+
+		// a response recorder for getting written http response
+		rr := httptest.NewRecorder()
+
+		// use bytes.NewBuffer to create a reader
+		request, err := http.NewRequest(http.MethodPut, "/api/messages/"+mockMessage.ID, bytes.NewBuffer(reqBody))
+		assert.NoError(t, err)
+
+		request.Header.Set("Content-Type", "application/json")
+
+		mockError := apperrors.NewBadRequest(apperrors.EditWebhookMessage)
+		respBody, _ := json.Marshal(gin.H{
+			"error": mockError,
+		})
+		router.ServeHTTP(rr, request)
+
+		assert.Equal(t, mockError.Status(), rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+
+		mockMessageService.AssertCalled(t, "Get", mockMessage.ID)
+		mockMessageService.AssertNotCalled(t, "UpdateMessage")
+		mockSocketService.AssertNotCalled(t, "EmitEditMessage")
+	})
+
 	t.Run("Unauthorized", func(t *testing.T) {
 		mockMessage := fixture.GetMockMessage("", "")
 
@@ -1007,6 +1228,7 @@ func TestHandler_UpdateMessage(t *testing.T) {
 
 		NewHandler(&Config{
 			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
 			MessageService: mockMessageService,
 			SocketService:  mockSocketService,
 		})
@@ -1044,6 +1266,7 @@ func TestHandler_UpdateMessage_BadRequest(t *testing.T) {
 
 	NewHandler(&Config{
 		R:              router,
+		UserService:    allowSession(new(mocks.UserService)),
 		MessageService: mockMessageService,
 		MaxBodyBytes:   4 * 1024 * 1024,
 	})
@@ -1116,6 +1339,7 @@ func TestHandler_Delete_Message(t *testing.T) {
 
 		NewHandler(&Config{
 			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
 			MessageService: mockMessageService,
 			GuildService:   mockGuildService,
 			ChannelService: mockChannelService,
@@ -1160,6 +1384,7 @@ func TestHandler_Delete_Message(t *testing.T) {
 
 		NewHandler(&Config{
 			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
 			MessageService: mockMessageService,
 			GuildService:   mockGuildService,
 			ChannelService: mockChannelService,
@@ -1210,6 +1435,7 @@ func TestHandler_Delete_Message(t *testing.T) {
 
 		NewHandler(&Config{
 			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
 			MessageService: mockMessageService,
 			GuildService:   mockGuildService,
 			ChannelService: mockChannelService,
@@ -1264,6 +1490,7 @@ func TestHandler_Delete_Message(t *testing.T) {
 
 		NewHandler(&Config{
 			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
 			MessageService: mockMessageService,
 			GuildService:   mockGuildService,
 			ChannelService: mockChannelService,
@@ -1313,6 +1540,7 @@ func TestHandler_Delete_Message(t *testing.T) {
 
 		NewHandler(&Config{
 			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
 			MessageService: mockMessageService,
 			GuildService:   mockGuildService,
 			ChannelService: mockChannelService,
@@ -1364,6 +1592,7 @@ func TestHandler_Delete_Message(t *testing.T) {
 
 		NewHandler(&Config{
 			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
 			MessageService: mockMessageService,
 			ChannelService: mockChannelService,
 			SocketService:  mockSocketService,
@@ -1408,6 +1637,7 @@ func TestHandler_Delete_Message(t *testing.T) {
 
 		NewHandler(&Config{
 			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
 			MessageService: mockMessageService,
 			ChannelService: mockChannelService,
 			SocketService:  mockSocketService,
@@ -1501,6 +1731,7 @@ func TestHandler_Delete_Message(t *testing.T) {
 
 		NewHandler(&Config{
 			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
 			MessageService: mockMessageService,
 			GuildService:   mockGuildService,
 			ChannelService: mockChannelService,
@@ -3,14 +3,25 @@ package middleware
 import (
 	"github.com/gin-contrib/sessions"
 	"github.com/gin-gonic/gin"
+	"github.com/sentrionic/valkyrie/model"
 	"github.com/sentrionic/valkyrie/model/apperrors"
 	"log"
+	"net/http"
+	"strings"
 )
 
 // AuthUser checks if the request contains a valid session
-// and saves the session's userId in the context
-func AuthUser() gin.HandlerFunc {
+// and saves the session's userId in the context.
+// Rejects sessions the user revoked.
+// Requests with an Authorization header get authenticated with
+// the Bearer access token instead.
+func AuthUser(userService model.UserService, tokenService model.TokenService) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		if header := c.GetHeader("Authorization"); header != "" {
+			authToken(c, tokenService, header)
+			return
+		}
+
 		session := sessions.Default(c)
 		id := session.Get("userId")
 
@@ -25,6 +36,33 @@ func AuthUser() gin.HandlerFunc {
 
 		userId := id.(string)
 
+		// Sessions created before they got tracked cannot be revoked,
+		// so the user has to log in again
+		sessionId, ok := session.Get("sessionId").(string)
+
+		if !ok || sessionId == "" {
+			clearSession(session)
+			e := apperrors.NewAuthorization(apperrors.InvalidSession)
+			c.JSON(e.Status(), gin.H{
+				"error": e,
+			})
+			c.Abort()
+			return
+		}
+
+		if err := userService.ValidateLoginSession(c.Request.Context(), userId, sessionId); err != nil {
+			if apperrors.Status(err) == http.StatusUnauthorized {
+				clearSession(session)
+			}
+
+			c.JSON(apperrors.Status(err), gin.H{
+				"error": err,
+			})
+			c.Abort()
+			return
+		}
+
+		c.Set("sessionId", sessionId)
 		c.Set("userId", userId)
 
 		// Recreate session to extend its lifetime
@@ -36,3 +74,65 @@ func AuthUser() gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// clearSession removes the invalid session from the cookie
+func clearSession(session sessions.Session) {
+	session.Clear()
+	if err := session.Save(); err != nil {
+		log.Printf("Failed to clear the session: %v\n", err.Error())
+	}
+}
+
+// authToken authenticates the request with the given Bearer token and
+// saves the token's userId and the tokenId in the context.
+// Safe methods require the read scope, all others the write scope.
+func authToken(c *gin.Context, tokenService model.TokenService, header string) {
+	secret := strings.TrimPrefix(header, "Bearer ")
+
+	if secret == header || secret == "" {
+		e := apperrors.NewAuthorization(apperrors.InvalidAccessToken)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		c.Abort()
+		return
+	}
+
+	scope := model.WriteScope
+	switch c.Request.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		scope = model.ReadScope
+	}
+
+	token, err := tokenService.AuthenticateToken(secret, scope)
+
+	if err != nil {
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		c.Abort()
+		return
+	}
+
+	c.Set("userId", token.UserId)
+	c.Set("tokenId", token.ID)
+
+	c.Next()
+}
+
+// RequireSession rejects requests that were authenticated with an access token.
+// Tokens and bots cannot manage the credentials of the account.
+func RequireSession() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, ok := c.Get("tokenId"); ok {
+			e := apperrors.NewAuthorization(apperrors.SessionRequired)
+			c.JSON(e.Status(), gin.H{
+				"error": e,
+			})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
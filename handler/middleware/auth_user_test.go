@@ -3,8 +3,11 @@ package middleware
 import (
 	"github.com/gin-contrib/sessions"
 	"github.com/gin-contrib/sessions/cookie"
+	"github.com/sentrionic/valkyrie/mocks"
 	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
 	"github.com/sentrionic/valkyrie/service"
+	"github.com/stretchr/testify/mock"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -28,11 +31,15 @@ func TestAuthUser(t *testing.T) {
 		r.Use(func(c *gin.Context) {
 			session := sessions.Default(c)
 			session.Set("userId", uid)
+			session.Set("sessionId", "session")
 		})
 
+		mockUserService := new(mocks.UserService)
+		mockUserService.On("ValidateLoginSession", mock.Anything, uid, "session").Return(nil)
+
 		var contextUserId string
 
-		r.GET("/api/accounts", AuthUser(), func(c *gin.Context) {
+		r.GET("/api/accounts", AuthUser(mockUserService, new(mocks.TokenService)), func(c *gin.Context) {
 			contextKeyVal, _ := c.Get("userId")
 			contextUserId = contextKeyVal.(string)
 		})
@@ -44,6 +51,33 @@ func TestAuthUser(t *testing.T) {
 		assert.Equal(t, contextUserId, uid)
 	})
 
+	t.Run("Untracked session", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+
+		_, r := gin.CreateTestContext(rr)
+		store := cookie.NewStore([]byte("secret"))
+		r.Use(sessions.Sessions(model.CookieName, store))
+
+		r.Use(func(c *gin.Context) {
+			session := sessions.Default(c)
+			session.Set("userId", uid)
+		})
+
+		mockUserService := new(mocks.UserService)
+		handlerCalled := false
+
+		r.GET("/api/accounts", AuthUser(mockUserService, new(mocks.TokenService)), func(c *gin.Context) {
+			handlerCalled = true
+		})
+
+		request, _ := http.NewRequest(http.MethodGet, "/api/accounts", http.NoBody)
+		r.ServeHTTP(rr, request)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		assert.False(t, handlerCalled)
+		mockUserService.AssertNotCalled(t, "ValidateLoginSession", mock.Anything, mock.Anything, mock.Anything)
+	})
+
 	t.Run("Missing Session", func(t *testing.T) {
 		rr := httptest.NewRecorder()
 
@@ -52,7 +86,7 @@ func TestAuthUser(t *testing.T) {
 		store := cookie.NewStore([]byte("secret"))
 		r.Use(sessions.Sessions(model.CookieName, store))
 
-		r.GET("/api/accounts", AuthUser())
+		r.GET("/api/accounts", AuthUser(new(mocks.UserService), new(mocks.TokenService)))
 
 		request, _ := http.NewRequest(http.MethodGet, "/api/accounts", http.NoBody)
 
@@ -60,4 +94,192 @@ func TestAuthUser(t *testing.T) {
 
 		assert.Equal(t, http.StatusUnauthorized, rr.Code)
 	})
+	t.Run("Tracked session", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+
+		_, r := gin.CreateTestContext(rr)
+		store := cookie.NewStore([]byte("secret"))
+		r.Use(sessions.Sessions(model.CookieName, store))
+
+		r.Use(func(c *gin.Context) {
+			session := sessions.Default(c)
+			session.Set("userId", uid)
+			session.Set("sessionId", "session")
+		})
+
+		mockUserService := new(mocks.UserService)
+		mockUserService.On("ValidateLoginSession", mock.Anything, uid, "session").Return(nil)
+
+		var contextSessionId string
+
+		r.GET("/api/accounts", AuthUser(mockUserService, new(mocks.TokenService)), func(c *gin.Context) {
+			contextSessionId = c.GetString("sessionId")
+		})
+
+		request, _ := http.NewRequest(http.MethodGet, "/api/accounts", http.NoBody)
+		r.ServeHTTP(rr, request)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "session", contextSessionId)
+		mockUserService.AssertExpectations(t)
+	})
+
+	t.Run("Revoked session", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+
+		_, r := gin.CreateTestContext(rr)
+		store := cookie.NewStore([]byte("secret"))
+		r.Use(sessions.Sessions(model.CookieName, store))
+
+		r.Use(func(c *gin.Context) {
+			session := sessions.Default(c)
+			session.Set("userId", uid)
+			session.Set("sessionId", "revoked")
+		})
+
+		mockError := apperrors.NewAuthorization(apperrors.InvalidSession)
+		mockUserService := new(mocks.UserService)
+		mockUserService.On("ValidateLoginSession", mock.Anything, uid, "revoked").Return(mockError)
+
+		handlerCalled := false
+
+		r.GET("/api/accounts", AuthUser(mockUserService, new(mocks.TokenService)), func(c *gin.Context) {
+			handlerCalled = true
+		})
+
+		request, _ := http.NewRequest(http.MethodGet, "/api/accounts", http.NoBody)
+		r.ServeHTTP(rr, request)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		assert.False(t, handlerCalled)
+		mockUserService.AssertExpectations(t)
+	})
+
+	t.Run("Access token", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+
+		_, r := gin.CreateTestContext(rr)
+		store := cookie.NewStore([]byte("secret"))
+		r.Use(sessions.Sessions(model.CookieName, store))
+
+		botId, _ := service.GenerateId()
+		token := &model.AccessToken{UserId: botId}
+		token.ID = "token"
+
+		mockTokenService := new(mocks.TokenService)
+		mockTokenService.On("AuthenticateToken", "vlk_secret", model.WriteScope).Return(token, nil)
+
+		var contextUserId, contextTokenId string
+
+		r.POST("/api/messages", AuthUser(new(mocks.UserService), mockTokenService), func(c *gin.Context) {
+			contextUserId = c.GetString("userId")
+			contextTokenId = c.GetString("tokenId")
+		})
+
+		request, _ := http.NewRequest(http.MethodPost, "/api/messages", http.NoBody)
+		request.Header.Set("Authorization", "Bearer vlk_secret")
+		r.ServeHTTP(rr, request)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, botId, contextUserId)
+		assert.Equal(t, "token", contextTokenId)
+		mockTokenService.AssertExpectations(t)
+	})
+
+	t.Run("Read scope for safe methods", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+
+		_, r := gin.CreateTestContext(rr)
+		store := cookie.NewStore([]byte("secret"))
+		r.Use(sessions.Sessions(model.CookieName, store))
+
+		mockError := apperrors.NewAuthorization(apperrors.MissingTokenScope)
+		mockTokenService := new(mocks.TokenService)
+		mockTokenService.On("AuthenticateToken", "vlk_secret", model.ReadScope).Return(nil, mockError)
+
+		handlerCalled := false
+
+		r.GET("/api/accounts", AuthUser(new(mocks.UserService), mockTokenService), func(c *gin.Context) {
+			handlerCalled = true
+		})
+
+		request, _ := http.NewRequest(http.MethodGet, "/api/accounts", http.NoBody)
+		request.Header.Set("Authorization", "Bearer vlk_secret")
+		r.ServeHTTP(rr, request)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		assert.False(t, handlerCalled)
+		mockTokenService.AssertExpectations(t)
+	})
+
+	t.Run("Malformed authorization header", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+
+		_, r := gin.CreateTestContext(rr)
+		store := cookie.NewStore([]byte("secret"))
+		r.Use(sessions.Sessions(model.CookieName, store))
+
+		// The session must not be used if a header is given
+		r.Use(func(c *gin.Context) {
+			session := sessions.Default(c)
+			session.Set("userId", uid)
+		})
+
+		mockTokenService := new(mocks.TokenService)
+
+		r.GET("/api/accounts", AuthUser(new(mocks.UserService), mockTokenService))
+
+		request, _ := http.NewRequest(http.MethodGet, "/api/accounts", http.NoBody)
+		request.Header.Set("Authorization", "Basic dXNlcjpwYXNz")
+		r.ServeHTTP(rr, request)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		mockTokenService.AssertNotCalled(t, "AuthenticateToken", mock.Anything, mock.Anything)
+	})
+}
+
+func TestRequireSession(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("Allows sessions", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+
+		_, r := gin.CreateTestContext(rr)
+
+		r.Use(func(c *gin.Context) {
+			c.Set("userId", "user")
+		})
+
+		r.GET("/api/account/tokens", RequireSession(), func(c *gin.Context) {
+			c.Status(http.StatusOK)
+		})
+
+		request, _ := http.NewRequest(http.MethodGet, "/api/account/tokens", http.NoBody)
+		r.ServeHTTP(rr, request)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("Rejects access tokens", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+
+		_, r := gin.CreateTestContext(rr)
+
+		r.Use(func(c *gin.Context) {
+			c.Set("userId", "user")
+			c.Set("tokenId", "token")
+		})
+
+		handlerCalled := false
+
+		r.GET("/api/account/tokens", RequireSession(), func(c *gin.Context) {
+			handlerCalled = true
+		})
+
+		request, _ := http.NewRequest(http.MethodGet, "/api/account/tokens", http.NoBody)
+		r.ServeHTTP(rr, request)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		assert.False(t, handlerCalled)
+	})
 }
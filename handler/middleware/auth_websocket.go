@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+)
+
+// AuthWebsocket authenticates websocket connections with the single use ticket
+// in the ticket query parameter, for clients that cannot send the session cookie.
+// Falls back to the session or access token if no ticket is given.
+func AuthWebsocket(userService model.UserService, tokenService model.TokenService) gin.HandlerFunc {
+	sessionAuth := AuthUser(userService, tokenService)
+
+	return func(c *gin.Context) {
+		ticket := c.Query("ticket")
+
+		if ticket == "" {
+			sessionAuth(c)
+			return
+		}
+
+		userId, err := userService.RedeemWSTicket(c.Request.Context(), ticket)
+
+		if err != nil {
+			c.JSON(apperrors.Status(err), gin.H{
+				"error": err,
+			})
+			c.Abort()
+			return
+		}
+
+		c.Set("userId", userId)
+
+		c.Next()
+	}
+}
@@ -0,0 +1,119 @@
+package middleware
+
+import (
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-contrib/sessions/cookie"
+	"github.com/sentrionic/valkyrie/mocks"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"github.com/sentrionic/valkyrie/service"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthWebsocket(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	uid, _ := service.GenerateId()
+
+	t.Run("Adds the userId of the ticket to context", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+
+		_, r := gin.CreateTestContext(rr)
+		store := cookie.NewStore([]byte("secret"))
+		r.Use(sessions.Sessions(model.CookieName, store))
+
+		mockUserService := new(mocks.UserService)
+		mockUserService.On("RedeemWSTicket", mock.Anything, "ticket").Return(uid, nil)
+
+		var contextUserId string
+
+		r.GET("/ws", AuthWebsocket(mockUserService, new(mocks.TokenService)), func(c *gin.Context) {
+			contextKeyVal, _ := c.Get("userId")
+			contextUserId = contextKeyVal.(string)
+		})
+
+		request, _ := http.NewRequest(http.MethodGet, "/ws?ticket=ticket", http.NoBody)
+		r.ServeHTTP(rr, request)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, contextUserId, uid)
+		mockUserService.AssertExpectations(t)
+	})
+
+	t.Run("Invalid ticket", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+
+		_, r := gin.CreateTestContext(rr)
+		store := cookie.NewStore([]byte("secret"))
+		r.Use(sessions.Sessions(model.CookieName, store))
+
+		mockError := apperrors.NewAuthorization(apperrors.InvalidWSTicket)
+		mockUserService := new(mocks.UserService)
+		mockUserService.On("RedeemWSTicket", mock.Anything, "used").Return("", mockError)
+
+		handlerCalled := false
+		r.GET("/ws", AuthWebsocket(mockUserService, new(mocks.TokenService)), func(c *gin.Context) {
+			handlerCalled = true
+		})
+
+		request, _ := http.NewRequest(http.MethodGet, "/ws?ticket=used", http.NoBody)
+		r.ServeHTTP(rr, request)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		assert.False(t, handlerCalled)
+	})
+
+	t.Run("Falls back to the session", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+
+		_, r := gin.CreateTestContext(rr)
+		store := cookie.NewStore([]byte("secret"))
+		r.Use(sessions.Sessions(model.CookieName, store))
+
+		r.Use(func(c *gin.Context) {
+			session := sessions.Default(c)
+			session.Set("userId", uid)
+			session.Set("sessionId", "session")
+		})
+
+		mockUserService := new(mocks.UserService)
+		mockUserService.On("ValidateLoginSession", mock.Anything, uid, "session").Return(nil)
+
+		var contextUserId string
+
+		r.GET("/ws", AuthWebsocket(mockUserService, new(mocks.TokenService)), func(c *gin.Context) {
+			contextKeyVal, _ := c.Get("userId")
+			contextUserId = contextKeyVal.(string)
+		})
+
+		request, _ := http.NewRequest(http.MethodGet, "/ws", http.NoBody)
+		r.ServeHTTP(rr, request)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, contextUserId, uid)
+		mockUserService.AssertNotCalled(t, "RedeemWSTicket", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Missing ticket and session", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+
+		_, r := gin.CreateTestContext(rr)
+		store := cookie.NewStore([]byte("secret"))
+		r.Use(sessions.Sessions(model.CookieName, store))
+
+		mockUserService := new(mocks.UserService)
+
+		r.GET("/ws", AuthWebsocket(mockUserService, new(mocks.TokenService)))
+
+		request, _ := http.NewRequest(http.MethodGet, "/ws", http.NoBody)
+		r.ServeHTTP(rr, request)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+}
@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"net/http"
+)
+
+/*
+ * SessionHandler contains all routes related to the login sessions of the current user
+ */
+
+// GetSessions returns the login sessions of the current user
+// GetSessions godoc
+// @Tags Account
+// @Summary Get Current User's Sessions
+// @Produce  json
+// @Success 200 {array} model.LoginSession
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /account/sessions [get]
+func (h *Handler) GetSessions(c *gin.Context) {
+	userId := c.MustGet("userId").(string)
+
+	sessions, err := h.userService.GetLoginSessions(c.Request.Context(), userId, c.GetString("sessionId"))
+
+	if err != nil {
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, sessions)
+}
+
+// RevokeSession logs the given session of the current user out
+// RevokeSession godoc
+// @Tags Account
+// @Summary Revoke Session
+// @Produce  json
+// @Param id path string true "Session ID"
+// @Success 200 {object} model.Success
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /account/sessions/{id} [delete]
+func (h *Handler) RevokeSession(c *gin.Context) {
+	userId := c.MustGet("userId").(string)
+	sessionId := c.Param("id")
+
+	if err := h.userService.RevokeLoginSession(c.Request.Context(), userId, sessionId); err != nil {
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, true)
+}
@@ -0,0 +1,146 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/sentrionic/valkyrie/mocks"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"github.com/sentrionic/valkyrie/model/fixture"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHandler_GetSessions(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	authUser := fixture.GetMockUser()
+
+	t.Run("Successful Fetch", func(t *testing.T) {
+		now := time.Now()
+		sessions := []model.LoginSession{
+			{
+				ID:         fixture.RandID(),
+				UserAgent:  "Mozilla/5.0",
+				IP:         "127.0.0.1",
+				CreatedAt:  now,
+				LastUsedAt: now,
+			},
+		}
+
+		mockUserService := new(mocks.UserService)
+		mockUserService.On("GetLoginSessions", mock.Anything, authUser.ID, testSessionId).Return(&sessions, nil)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:           router,
+			UserService: allowSession(mockUserService),
+		})
+
+		request, err := http.NewRequest(http.MethodGet, "/api/account/sessions", nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal(sessions)
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockUserService.AssertExpectations(t)
+	})
+
+	t.Run("Unauthorized", func(t *testing.T) {
+		mockUserService := new(mocks.UserService)
+
+		rr := httptest.NewRecorder()
+
+		router := getTestRouter()
+
+		NewHandler(&Config{
+			R:           router,
+			UserService: mockUserService,
+		})
+
+		request, err := http.NewRequest(http.MethodGet, "/api/account/sessions", nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		mockUserService.AssertNotCalled(t, "GetLoginSessions", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestHandler_RevokeSession(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	authUser := fixture.GetMockUser()
+
+	t.Run("Successfully revoked", func(t *testing.T) {
+		sessionId := fixture.RandID()
+
+		mockUserService := new(mocks.UserService)
+		mockUserService.On("RevokeLoginSession", mock.Anything, authUser.ID, sessionId).Return(nil)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:           router,
+			UserService: allowSession(mockUserService),
+		})
+
+		request, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("/api/account/sessions/%s", sessionId), nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal(true)
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockUserService.AssertExpectations(t)
+	})
+
+	t.Run("Session not found", func(t *testing.T) {
+		sessionId := fixture.RandID()
+
+		mockError := apperrors.NewNotFound("session", sessionId)
+		mockUserService := new(mocks.UserService)
+		mockUserService.On("RevokeLoginSession", mock.Anything, authUser.ID, sessionId).Return(mockError)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:           router,
+			UserService: allowSession(mockUserService),
+		})
+
+		request, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("/api/account/sessions/%s", sessionId), nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal(gin.H{
+			"error": mockError,
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, mockError.Status(), rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockUserService.AssertExpectations(t)
+	})
+}
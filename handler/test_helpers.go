@@ -4,9 +4,14 @@ import (
 	"github.com/gin-contrib/sessions"
 	"github.com/gin-contrib/sessions/cookie"
 	"github.com/gin-gonic/gin"
+	"github.com/sentrionic/valkyrie/mocks"
 	"github.com/sentrionic/valkyrie/model"
+	"github.com/stretchr/testify/mock"
 )
 
+// testSessionId is the id of the login session of the authenticated test router
+const testSessionId = "test-session"
+
 func getAuthenticatedTestRouter(uid string) *gin.Engine {
 	router := gin.Default()
 	store := cookie.NewStore([]byte("secret"))
@@ -15,6 +20,7 @@ func getAuthenticatedTestRouter(uid string) *gin.Engine {
 	router.Use(func(c *gin.Context) {
 		session := sessions.Default(c)
 		session.Set("userId", uid)
+		session.Set("sessionId", testSessionId)
 		c.Set("userId", uid)
 	})
 
@@ -38,3 +44,9 @@ func getTestFieldErrorResponse(field, message string) gin.H {
 		},
 	}
 }
+
+// allowSession accepts the login session of the authenticated test router
+func allowSession(userService *mocks.UserService) *mocks.UserService {
+	userService.On("ValidateLoginSession", mock.Anything, mock.Anything, testSessionId).Return(nil).Maybe()
+	return userService
+}
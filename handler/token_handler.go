@@ -0,0 +1,242 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"net/http"
+	"strings"
+	"time"
+)
+
+/*
+ * TokenHandler contains all routes related to access tokens and bot accounts of the current user
+ */
+
+// GetTokens returns the access tokens of the current user and their bots
+// GetTokens godoc
+// @Tags Account
+// @Summary Get Current User's Access Tokens
+// @Produce  json
+// @Success 200 {array} model.AccessTokenResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /account/tokens [get]
+func (h *Handler) GetTokens(c *gin.Context) {
+	userId := c.MustGet("userId").(string)
+
+	tokens, err := h.tokenService.GetTokens(userId)
+
+	if err != nil {
+		e := apperrors.NewInternal()
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	response := make([]model.AccessTokenResponse, 0)
+	for _, token := range *tokens {
+		response = append(response, token.SerializeToken())
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+type createTokenReq struct {
+	// Min 1, max 100 characters.
+	Name string `json:"name"`
+	// The bot the token authenticates as. Defaults to the current user
+	BotId *string `json:"botId"`
+	// read and/or write. Read allows GET requests, write all others
+	Scopes []string `json:"scopes"`
+	// The date the token expires. The token does not expire if omitted
+	ExpiresAt *time.Time `json:"expiresAt"`
+} //@name CreateTokenRequest
+
+func (r createTokenReq) validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Name, validation.Required, validation.Length(1, 100)),
+		validation.Field(&r.BotId, validation.NilOrNotEmpty),
+		validation.Field(&r.Scopes, validation.Required, validation.Each(validation.In(
+			model.ReadScope,
+			model.WriteScope,
+		))),
+		validation.Field(&r.ExpiresAt, validation.Min(time.Now())),
+	)
+}
+
+func (r *createTokenReq) sanitize() {
+	r.Name = strings.TrimSpace(r.Name)
+
+	seen := make(map[string]bool)
+	scopes := make([]string, 0)
+	for _, scope := range r.Scopes {
+		scope = strings.ToLower(strings.TrimSpace(scope))
+		if !seen[scope] {
+			seen[scope] = true
+			scopes = append(scopes, scope)
+		}
+	}
+	r.Scopes = scopes
+}
+
+// CreateToken creates an access token for the current user or one of their bots.
+// The token is only returned once.
+// CreateToken godoc
+// @Tags Account
+// @Summary Create Access Token
+// @Accept  json
+// @Produce  json
+// @Param request body createTokenReq true "Create Access Token"
+// @Success 201 {object} model.AccessTokenResponse
+// @Failure 400 {object} model.ErrorsResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /account/tokens [post]
+func (h *Handler) CreateToken(c *gin.Context) {
+	userId := c.MustGet("userId").(string)
+	var req createTokenReq
+
+	if ok := bindData(c, &req); !ok {
+		return
+	}
+
+	req.sanitize()
+
+	tokenUserId := userId
+	if req.BotId != nil {
+		tokenUserId = *req.BotId
+	}
+
+	token, secret, err := h.tokenService.CreateToken(userId, tokenUserId, req.Name, req.Scopes, req.ExpiresAt)
+
+	if err != nil {
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
+	response := token.SerializeToken()
+	response.Token = secret
+
+	c.JSON(http.StatusCreated, response)
+}
+
+// DeleteToken revokes the given access token of the current user
+// DeleteToken godoc
+// @Tags Account
+// @Summary Delete Access Token
+// @Produce  json
+// @Param id path string true "Token ID"
+// @Success 200 {object} model.Success
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /account/tokens/{id} [delete]
+func (h *Handler) DeleteToken(c *gin.Context) {
+	userId := c.MustGet("userId").(string)
+	tokenId := c.Param("id")
+
+	if err := h.tokenService.DeleteToken(userId, tokenId); err != nil {
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, true)
+}
+
+// GetBots returns the bot accounts of the current user
+// GetBots godoc
+// @Tags Account
+// @Summary Get Current User's Bots
+// @Produce  json
+// @Success 200 {array} model.BotResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /account/bots [get]
+func (h *Handler) GetBots(c *gin.Context) {
+	userId := c.MustGet("userId").(string)
+
+	bots, err := h.tokenService.GetBots(userId)
+
+	if err != nil {
+		e := apperrors.NewInternal()
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	response := make([]model.BotResponse, 0)
+	for _, bot := range *bots {
+		response = append(response, bot.SerializeBot())
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+type createBotReq struct {
+	// Min 3, max 30 characters.
+	Username string `json:"username"`
+} //@name CreateBotRequest
+
+func (r createBotReq) validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Username, validation.Required, validation.Length(3, 30)),
+	)
+}
+
+func (r *createBotReq) sanitize() {
+	r.Username = strings.TrimSpace(r.Username)
+}
+
+// CreateBot creates a bot account owned by the current user.
+// Bots authenticate with access tokens created by their owner.
+// CreateBot godoc
+// @Tags Account
+// @Summary Create Bot
+// @Accept  json
+// @Produce  json
+// @Param request body createBotReq true "Create Bot"
+// @Success 201 {object} model.BotResponse
+// @Failure 400 {object} model.ErrorsResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /account/bots [post]
+func (h *Handler) CreateBot(c *gin.Context) {
+	userId := c.MustGet("userId").(string)
+	var req createBotReq
+
+	if ok := bindData(c, &req); !ok {
+		return
+	}
+
+	req.sanitize()
+
+	authUser, err := h.userService.Get(userId)
+
+	if err != nil {
+		e := apperrors.NewAuthorization(apperrors.InvalidSession)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	bot, err := h.tokenService.CreateBot(authUser, req.Username)
+
+	if err != nil {
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, bot.SerializeBot())
+}
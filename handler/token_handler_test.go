@@ -0,0 +1,496 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/sentrionic/valkyrie/mocks"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"github.com/sentrionic/valkyrie/model/fixture"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func getMockToken(ownerId string, userId string) *model.AccessToken {
+	token := &model.AccessToken{
+		Name:    fixture.RandStr(8),
+		UserId:  userId,
+		OwnerId: ownerId,
+		Scopes:  []string{model.ReadScope, model.WriteScope},
+	}
+	token.ID = fixture.RandID()
+	return token
+}
+
+func TestHandler_GetTokens(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	authUser := fixture.GetMockUser()
+
+	t.Run("Successful Fetch", func(t *testing.T) {
+		tokens := []model.AccessToken{*getMockToken(authUser.ID, authUser.ID)}
+
+		mockTokenService := new(mocks.TokenService)
+		mockTokenService.On("GetTokens", authUser.ID).Return(&tokens, nil)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:            router,
+			UserService:  allowSession(new(mocks.UserService)),
+			TokenService: mockTokenService,
+		})
+
+		request, err := http.NewRequest(http.MethodGet, "/api/account/tokens", nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal([]model.AccessTokenResponse{tokens[0].SerializeToken()})
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		assert.NotContains(t, rr.Body.String(), "token\"")
+		mockTokenService.AssertExpectations(t)
+	})
+
+	t.Run("Unauthorized", func(t *testing.T) {
+		mockTokenService := new(mocks.TokenService)
+
+		rr := httptest.NewRecorder()
+
+		router := getTestRouter()
+
+		NewHandler(&Config{
+			R:            router,
+			TokenService: mockTokenService,
+		})
+
+		request, err := http.NewRequest(http.MethodGet, "/api/account/tokens", nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		mockTokenService.AssertNotCalled(t, "GetTokens", mock.Anything)
+	})
+}
+
+func TestHandler_CreateToken(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	authUser := fixture.GetMockUser()
+
+	t.Run("Token for the current user", func(t *testing.T) {
+		token := getMockToken(authUser.ID, authUser.ID)
+		secret := model.AccessTokenPrefix + fixture.RandStr(32)
+		scopes := []string{model.ReadScope, model.WriteScope}
+
+		mockTokenService := new(mocks.TokenService)
+		mockTokenService.
+			On("CreateToken", authUser.ID, authUser.ID, token.Name, scopes, mock.Anything).
+			Return(token, secret, nil)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:            router,
+			UserService:  allowSession(new(mocks.UserService)),
+			TokenService: mockTokenService,
+		})
+
+		reqBody, err := json.Marshal(gin.H{
+			"name":   token.Name,
+			"scopes": []string{"read", "write", "read"},
+		})
+		assert.NoError(t, err)
+
+		request, err := http.NewRequest(http.MethodPost, "/api/account/tokens", bytes.NewBuffer(reqBody))
+		assert.NoError(t, err)
+
+		request.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(rr, request)
+
+		response := token.SerializeToken()
+		response.Token = secret
+		respBody, err := json.Marshal(response)
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusCreated, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockTokenService.AssertExpectations(t)
+	})
+
+	t.Run("Token for a bot", func(t *testing.T) {
+		botId := fixture.RandID()
+		token := getMockToken(authUser.ID, botId)
+		scopes := []string{model.WriteScope}
+
+		mockTokenService := new(mocks.TokenService)
+		mockTokenService.
+			On("CreateToken", authUser.ID, botId, token.Name, scopes, mock.Anything).
+			Return(token, model.AccessTokenPrefix+"secret", nil)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:            router,
+			UserService:  allowSession(new(mocks.UserService)),
+			TokenService: mockTokenService,
+		})
+
+		reqBody, err := json.Marshal(gin.H{
+			"name":   token.Name,
+			"botId":  botId,
+			"scopes": scopes,
+		})
+		assert.NoError(t, err)
+
+		request, err := http.NewRequest(http.MethodPost, "/api/account/tokens", bytes.NewBuffer(reqBody))
+		assert.NoError(t, err)
+
+		request.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(rr, request)
+
+		assert.Equal(t, http.StatusCreated, rr.Code)
+		mockTokenService.AssertExpectations(t)
+	})
+
+	t.Run("Bot not found", func(t *testing.T) {
+		botId := fixture.RandID()
+		name := fixture.RandStr(8)
+		scopes := []string{model.ReadScope}
+
+		mockError := apperrors.NewNotFound("bot", botId)
+		mockTokenService := new(mocks.TokenService)
+		mockTokenService.
+			On("CreateToken", authUser.ID, botId, name, scopes, mock.Anything).
+			Return(nil, "", mockError)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:            router,
+			UserService:  allowSession(new(mocks.UserService)),
+			TokenService: mockTokenService,
+		})
+
+		reqBody, err := json.Marshal(gin.H{
+			"name":   name,
+			"botId":  botId,
+			"scopes": scopes,
+		})
+		assert.NoError(t, err)
+
+		request, err := http.NewRequest(http.MethodPost, "/api/account/tokens", bytes.NewBuffer(reqBody))
+		assert.NoError(t, err)
+
+		request.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal(gin.H{
+			"error": mockError,
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, mockError.Status(), rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockTokenService.AssertExpectations(t)
+	})
+
+	testCases := []struct {
+		name string
+		body gin.H
+	}{
+		{
+			name: "Name required",
+			body: gin.H{
+				"scopes": []string{model.ReadScope},
+			},
+		},
+		{
+			name: "Scopes required",
+			body: gin.H{
+				"name": "CI",
+			},
+		},
+		{
+			name: "Unknown scope",
+			body: gin.H{
+				"name":   "CI",
+				"scopes": []string{"admin"},
+			},
+		},
+		{
+			name: "Expiry in the past",
+			body: gin.H{
+				"name":      "CI",
+				"scopes":    []string{model.ReadScope},
+				"expiresAt": "2000-01-01T00:00:00Z",
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			mockTokenService := new(mocks.TokenService)
+
+			rr := httptest.NewRecorder()
+
+			router := getAuthenticatedTestRouter(authUser.ID)
+
+			NewHandler(&Config{
+				R:            router,
+				UserService:  allowSession(new(mocks.UserService)),
+				TokenService: mockTokenService,
+			})
+
+			reqBody, err := json.Marshal(tc.body)
+			assert.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/api/account/tokens", bytes.NewBuffer(reqBody))
+			assert.NoError(t, err)
+
+			request.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(rr, request)
+
+			assert.Equal(t, http.StatusBadRequest, rr.Code)
+			mockTokenService.AssertNotCalled(t, "CreateToken", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestHandler_DeleteToken(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	authUser := fixture.GetMockUser()
+
+	t.Run("Successfully deleted", func(t *testing.T) {
+		tokenId := fixture.RandID()
+
+		mockTokenService := new(mocks.TokenService)
+		mockTokenService.On("DeleteToken", authUser.ID, tokenId).Return(nil)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:            router,
+			UserService:  allowSession(new(mocks.UserService)),
+			TokenService: mockTokenService,
+		})
+
+		request, err := http.NewRequest(http.MethodDelete, "/api/account/tokens/"+tokenId, nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal(true)
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockTokenService.AssertExpectations(t)
+	})
+
+	t.Run("Token not found", func(t *testing.T) {
+		tokenId := fixture.RandID()
+
+		mockError := apperrors.NewNotFound("token", tokenId)
+		mockTokenService := new(mocks.TokenService)
+		mockTokenService.On("DeleteToken", authUser.ID, tokenId).Return(mockError)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:            router,
+			UserService:  allowSession(new(mocks.UserService)),
+			TokenService: mockTokenService,
+		})
+
+		request, err := http.NewRequest(http.MethodDelete, "/api/account/tokens/"+tokenId, nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal(gin.H{
+			"error": mockError,
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockTokenService.AssertExpectations(t)
+	})
+}
+
+func TestHandler_GetBots(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	authUser := fixture.GetMockUser()
+
+	t.Run("Successful Fetch", func(t *testing.T) {
+		bot := fixture.GetMockUser()
+		bot.IsBot = true
+		bot.BotOwnerId = &authUser.ID
+		bots := []model.User{*bot}
+
+		mockTokenService := new(mocks.TokenService)
+		mockTokenService.On("GetBots", authUser.ID).Return(&bots, nil)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:            router,
+			UserService:  allowSession(new(mocks.UserService)),
+			TokenService: mockTokenService,
+		})
+
+		request, err := http.NewRequest(http.MethodGet, "/api/account/bots", nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal([]model.BotResponse{bot.SerializeBot()})
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockTokenService.AssertExpectations(t)
+	})
+}
+
+func TestHandler_CreateBot(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	authUser := fixture.GetMockUser()
+
+	t.Run("Successfully created", func(t *testing.T) {
+		bot := fixture.GetMockUser()
+		bot.IsBot = true
+		bot.BotOwnerId = &authUser.ID
+
+		mockUserService := new(mocks.UserService)
+		mockUserService.On("Get", authUser.ID).Return(authUser, nil)
+
+		mockTokenService := new(mocks.TokenService)
+		mockTokenService.On("CreateBot", authUser, bot.Username).Return(bot, nil)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:            router,
+			UserService:  allowSession(mockUserService),
+			TokenService: mockTokenService,
+		})
+
+		reqBody, err := json.Marshal(gin.H{
+			"username": bot.Username,
+		})
+		assert.NoError(t, err)
+
+		request, err := http.NewRequest(http.MethodPost, "/api/account/bots", bytes.NewBuffer(reqBody))
+		assert.NoError(t, err)
+
+		request.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal(bot.SerializeBot())
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusCreated, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockUserService.AssertExpectations(t)
+		mockTokenService.AssertExpectations(t)
+	})
+
+	t.Run("Bot limit reached", func(t *testing.T) {
+		username := fixture.Username()
+
+		mockError := apperrors.NewBadRequest(apperrors.BotLimitReached)
+		mockUserService := new(mocks.UserService)
+		mockUserService.On("Get", authUser.ID).Return(authUser, nil)
+
+		mockTokenService := new(mocks.TokenService)
+		mockTokenService.On("CreateBot", authUser, username).Return(nil, mockError)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:            router,
+			UserService:  allowSession(mockUserService),
+			TokenService: mockTokenService,
+		})
+
+		reqBody, err := json.Marshal(gin.H{
+			"username": username,
+		})
+		assert.NoError(t, err)
+
+		request, err := http.NewRequest(http.MethodPost, "/api/account/bots", bytes.NewBuffer(reqBody))
+		assert.NoError(t, err)
+
+		request.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal(gin.H{
+			"error": mockError,
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, mockError.Status(), rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockTokenService.AssertExpectations(t)
+	})
+
+	t.Run("Username required", func(t *testing.T) {
+		mockUserService := new(mocks.UserService)
+		mockTokenService := new(mocks.TokenService)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:            router,
+			UserService:  allowSession(mockUserService),
+			TokenService: mockTokenService,
+		})
+
+		reqBody, err := json.Marshal(gin.H{})
+		assert.NoError(t, err)
+
+		request, err := http.NewRequest(http.MethodPost, "/api/account/bots", bytes.NewBuffer(reqBody))
+		assert.NoError(t, err)
+
+		request.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(rr, request)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockTokenService.AssertNotCalled(t, "CreateBot", mock.Anything, mock.Anything)
+	})
+}
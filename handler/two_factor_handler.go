@@ -0,0 +1,213 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"net/http"
+	"strings"
+)
+
+/*
+ * TwoFactorHandler contains all routes related to two-factor authentication (/api/account/2fa)
+ */
+
+type twoFactorCodeReq struct {
+	// Authenticator or recovery code.
+	Code string `json:"code"`
+} //@name TwoFactorCodeRequest
+
+func (r twoFactorCodeReq) validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Code, validation.Required),
+	)
+}
+
+func (r *twoFactorCodeReq) sanitize() {
+	r.Code = strings.TrimSpace(r.Code)
+}
+
+// SetupTwoFactor generates a new authenticator secret for the current user
+// SetupTwoFactor godoc
+// @Tags Account
+// @Summary Set up Two-Factor Authentication
+// @Produce  json
+// @Success 200 {object} model.TwoFactorSetup
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /account/2fa/setup [post]
+func (h *Handler) SetupTwoFactor(c *gin.Context) {
+	userId := c.MustGet("userId").(string)
+
+	authUser, err := h.userService.Get(userId)
+
+	if err != nil {
+		e := apperrors.NewAuthorization(apperrors.InvalidSession)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	setup, err := h.userService.SetupTwoFactor(authUser)
+
+	if err != nil {
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, setup)
+}
+
+// EnableTwoFactor enables two-factor authentication once the user
+// confirmed the secret with a valid code
+// EnableTwoFactor godoc
+// @Tags Account
+// @Summary Enable Two-Factor Authentication
+// @Accept  json
+// @Produce  json
+// @Param request body twoFactorCodeReq true "Authenticator Code"
+// @Success 200 {object} model.RecoveryCodes
+// @Failure 400 {object} model.ErrorsResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /account/2fa/enable [post]
+func (h *Handler) EnableTwoFactor(c *gin.Context) {
+	userId := c.MustGet("userId").(string)
+	var req twoFactorCodeReq
+
+	if ok := bindData(c, &req); !ok {
+		return
+	}
+
+	req.sanitize()
+
+	authUser, err := h.userService.Get(userId)
+
+	if err != nil {
+		e := apperrors.NewAuthorization(apperrors.InvalidSession)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	codes, err := h.userService.EnableTwoFactor(c.Request.Context(), authUser, req.Code)
+
+	if err != nil {
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, model.RecoveryCodes{Codes: codes})
+}
+
+type disableTwoFactorReq struct {
+	Password string `json:"password"`
+	// Authenticator or recovery code.
+	Code string `json:"code"`
+} //@name DisableTwoFactorRequest
+
+func (r disableTwoFactorReq) validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Password, validation.Required, validation.Length(6, 150)),
+		validation.Field(&r.Code, validation.Required),
+	)
+}
+
+func (r *disableTwoFactorReq) sanitize() {
+	r.Password = strings.TrimSpace(r.Password)
+	r.Code = strings.TrimSpace(r.Code)
+}
+
+// DisableTwoFactor disables two-factor authentication for the current user
+// DisableTwoFactor godoc
+// @Tags Account
+// @Summary Disable Two-Factor Authentication
+// @Accept  json
+// @Produce  json
+// @Param request body disableTwoFactorReq true "Disable Two-Factor Authentication"
+// @Success 200 {object} model.Success
+// @Failure 400 {object} model.ErrorsResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /account/2fa/disable [post]
+func (h *Handler) DisableTwoFactor(c *gin.Context) {
+	userId := c.MustGet("userId").(string)
+	var req disableTwoFactorReq
+
+	if ok := bindData(c, &req); !ok {
+		return
+	}
+
+	req.sanitize()
+
+	authUser, err := h.userService.Get(userId)
+
+	if err != nil {
+		e := apperrors.NewAuthorization(apperrors.InvalidSession)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	if err = h.userService.DisableTwoFactor(c.Request.Context(), authUser, req.Password, req.Code); err != nil {
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, true)
+}
+
+// RegenerateRecoveryCodes replaces the recovery codes of the current user
+// RegenerateRecoveryCodes godoc
+// @Tags Account
+// @Summary Regenerate Recovery Codes
+// @Accept  json
+// @Produce  json
+// @Param request body twoFactorCodeReq true "Authenticator Code"
+// @Success 200 {object} model.RecoveryCodes
+// @Failure 400 {object} model.ErrorsResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /account/2fa/recovery-codes [post]
+func (h *Handler) RegenerateRecoveryCodes(c *gin.Context) {
+	userId := c.MustGet("userId").(string)
+	var req twoFactorCodeReq
+
+	if ok := bindData(c, &req); !ok {
+		return
+	}
+
+	req.sanitize()
+
+	authUser, err := h.userService.Get(userId)
+
+	if err != nil {
+		e := apperrors.NewAuthorization(apperrors.InvalidSession)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	codes, err := h.userService.RegenerateRecoveryCodes(c.Request.Context(), authUser, req.Code)
+
+	if err != nil {
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, model.RecoveryCodes{Codes: codes})
+}
@@ -0,0 +1,423 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+)
+
+/*
+ * WebhookHandler contains all routes related to incoming channel webhooks
+ */
+
+type webhookReq struct {
+	// Min 1, max 80 characters.
+	Name string `json:"name"`
+	// URL of the avatar. Defaults to a generated one
+	Avatar *string `json:"avatar"`
+} //@name WebhookRequest
+
+func (r webhookReq) validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Name, validation.Required, validation.Length(1, 80)),
+		validation.Field(&r.Avatar, validation.NilOrNotEmpty, is.URL),
+	)
+}
+
+func (r *webhookReq) sanitize() {
+	r.Name = strings.TrimSpace(r.Name)
+
+	if r.Avatar != nil {
+		avatar := strings.TrimSpace(*r.Avatar)
+		r.Avatar = &avatar
+	}
+}
+
+// GetChannelWebhooks returns the webhooks of the given channel
+// GetChannelWebhooks godoc
+// @Tags Webhooks
+// @Summary Get Channel Webhooks
+// @Produce  json
+// @Param channelId path string true "Channel ID"
+// @Success 200 {array} model.WebhookResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /channels/{channelId}/webhooks [get]
+func (h *Handler) GetChannelWebhooks(c *gin.Context) {
+	channelId := c.Param("id")
+	userId := c.MustGet("userId").(string)
+
+	channel, ok := h.getWebhookChannel(c, channelId, userId)
+
+	if !ok {
+		return
+	}
+
+	webhooks, err := h.webhookService.GetChannelWebhooks(channel.ID)
+
+	if err != nil {
+		log.Printf("Unable to find webhooks for channel: %v\n%v", channelId, err)
+		e := apperrors.NewInternal()
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	response := make([]model.WebhookResponse, 0)
+	for _, webhook := range *webhooks {
+		response = append(response, webhook.SerializeWebhook())
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// CreateWebhook creates a webhook for the given channel.
+// The token is only returned once.
+// CreateWebhook godoc
+// @Tags Webhooks
+// @Summary Create Webhook
+// @Accept  json
+// @Produce  json
+// @Param channelId path string true "Channel ID"
+// @Param request body webhookReq true "Create Webhook"
+// @Success 201 {object} model.WebhookResponse
+// @Failure 400 {object} model.ErrorsResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /channels/{channelId}/webhooks [post]
+func (h *Handler) CreateWebhook(c *gin.Context) {
+	channelId := c.Param("id")
+	userId := c.MustGet("userId").(string)
+
+	var req webhookReq
+	if ok := bindData(c, &req); !ok {
+		return
+	}
+
+	req.sanitize()
+
+	channel, ok := h.getWebhookChannel(c, channelId, userId)
+
+	if !ok {
+		return
+	}
+
+	params := model.Webhook{
+		Name:      req.Name,
+		ChannelId: channel.ID,
+		GuildId:   *channel.GuildID,
+		UserId:    userId,
+	}
+
+	if req.Avatar != nil {
+		params.Avatar = *req.Avatar
+	}
+
+	webhook, token, err := h.webhookService.CreateWebhook(&params)
+
+	if err != nil {
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
+	response := webhook.SerializeWebhook()
+	response.Token = token
+
+	c.JSON(http.StatusCreated, response)
+}
+
+// EditWebhook edits the name and avatar of the given webhook
+// EditWebhook godoc
+// @Tags Webhooks
+// @Summary Edit Webhook
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Webhook ID"
+// @Param request body webhookReq true "Edit Webhook"
+// @Success 200 {object} model.WebhookResponse
+// @Failure 400 {object} model.ErrorsResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /webhooks/{id} [put]
+func (h *Handler) EditWebhook(c *gin.Context) {
+	webhookId := c.Param("id")
+	userId := c.MustGet("userId").(string)
+
+	var req webhookReq
+	if ok := bindData(c, &req); !ok {
+		return
+	}
+
+	req.sanitize()
+
+	webhook, ok := h.getOwnedWebhook(c, webhookId, userId)
+
+	if !ok {
+		return
+	}
+
+	webhook.Name = req.Name
+	if req.Avatar != nil {
+		webhook.Avatar = *req.Avatar
+	}
+
+	if err := h.webhookService.UpdateWebhook(webhook); err != nil {
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, webhook.SerializeWebhook())
+}
+
+// DeleteWebhook deletes the given webhook
+// DeleteWebhook godoc
+// @Tags Webhooks
+// @Summary Delete Webhook
+// @Produce  json
+// @Param id path string true "Webhook ID"
+// @Success 200 {object} model.Success
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /webhooks/{id} [delete]
+func (h *Handler) DeleteWebhook(c *gin.Context) {
+	webhookId := c.Param("id")
+	userId := c.MustGet("userId").(string)
+
+	webhook, ok := h.getOwnedWebhook(c, webhookId, userId)
+
+	if !ok {
+		return
+	}
+
+	if err := h.webhookService.DeleteWebhook(webhook); err != nil {
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, true)
+}
+
+type executeWebhookReq struct {
+	// Min 1, max 2000 characters.
+	Text string `json:"text"`
+	// Overrides the name of the webhook for this message. Max 80 characters
+	Username *string `json:"username"`
+	// Overrides the avatar URL of the webhook for this message
+	Avatar *string `json:"avatar"`
+} //@name ExecuteWebhookRequest
+
+func (r executeWebhookReq) validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Text, validation.Required, validation.Length(1, 2000)),
+		validation.Field(&r.Username, validation.NilOrNotEmpty, validation.Length(1, 80)),
+		validation.Field(&r.Avatar, validation.NilOrNotEmpty, is.URL),
+	)
+}
+
+func (r *executeWebhookReq) sanitize() {
+	r.Text = strings.TrimSpace(r.Text)
+
+	if r.Username != nil {
+		username := strings.TrimSpace(*r.Username)
+		r.Username = &username
+	}
+
+	if r.Avatar != nil {
+		avatar := strings.TrimSpace(*r.Avatar)
+		r.Avatar = &avatar
+	}
+}
+
+// ExecuteWebhook posts a message into the channel of the webhook.
+// Authenticated with the webhook token instead of a session.
+// ExecuteWebhook godoc
+// @Tags Webhooks
+// @Summary Execute Webhook
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Webhook ID"
+// @Param token path string true "Webhook Token"
+// @Param request body executeWebhookReq true "Webhook Message"
+// @Success 201 {object} model.Success
+// @Failure 400 {object} model.ErrorsResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /webhooks/{id}/{token} [post]
+func (h *Handler) ExecuteWebhook(c *gin.Context) {
+	webhookId := c.Param("id")
+	token := c.Param("token")
+
+	var req executeWebhookReq
+	if ok := bindData(c, &req); !ok {
+		return
+	}
+
+	req.sanitize()
+
+	webhook, err := h.webhookService.AuthenticateWebhook(webhookId, token)
+
+	if err != nil {
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
+	channel, err := h.channelService.Get(webhook.ChannelId)
+
+	if err != nil {
+		e := apperrors.NewNotFound("channel", webhook.ChannelId)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	name := webhook.Name
+	if req.Username != nil {
+		name = *req.Username
+	}
+
+	avatar := webhook.Avatar
+	if req.Avatar != nil {
+		avatar = *req.Avatar
+	}
+
+	params := model.Message{
+		Text:          &req.Text,
+		UserId:        webhook.UserId,
+		ChannelId:     channel.ID,
+		WebhookId:     &webhook.ID,
+		WebhookName:   &name,
+		WebhookAvatar: &avatar,
+	}
+
+	message, err := h.messageService.CreateMessage(&params)
+
+	if err != nil {
+		log.Printf("Failed to create webhook message: %v\n", err.Error())
+		c.JSON(apperrors.Status(err), gin.H{
+			"error": err,
+		})
+		return
+	}
+
+	response := model.MessageResponse{
+		Id:         message.ID,
+		Text:       message.Text,
+		Type:       message.Type,
+		CreatedAt:  message.CreatedAt,
+		UpdatedAt:  message.UpdatedAt,
+		Attachment: message.Attachment,
+		User: model.MemberResponse{
+			Id:        webhook.ID,
+			Username:  name,
+			Image:     avatar,
+			Status:    model.OfflineStatus,
+			CreatedAt: webhook.CreatedAt,
+			UpdatedAt: webhook.UpdatedAt,
+		},
+		WebhookId: &webhook.ID,
+	}
+
+	// Emit new message to the channel
+	h.socketService.EmitNewMessage(channel.ID, &response)
+
+	// Update last activity in channel
+	channel.LastActivity = time.Now()
+	_ = h.channelService.UpdateChannel(channel)
+	// Post a notification
+	h.socketService.EmitNewNotification(webhook.GuildId, channel.ID)
+
+	c.JSON(http.StatusCreated, true)
+}
+
+// getWebhookChannel returns the given guild text channel if the user owns its guild.
+// Writes the error response otherwise.
+func (h *Handler) getWebhookChannel(c *gin.Context, channelId string, userId string) (*model.Channel, bool) {
+	channel, err := h.channelService.Get(channelId)
+
+	if err != nil {
+		e := apperrors.NewNotFound("channel", channelId)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return nil, false
+	}
+
+	if channel.GuildID == nil || channel.Type == model.VoiceChannel {
+		e := apperrors.NewBadRequest(apperrors.WebhookChannelError)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return nil, false
+	}
+
+	if ok := h.isWebhookGuildOwner(c, *channel.GuildID, userId); !ok {
+		return nil, false
+	}
+
+	return channel, true
+}
+
+// getOwnedWebhook returns the given webhook if the user owns its guild.
+// Writes the error response otherwise.
+func (h *Handler) getOwnedWebhook(c *gin.Context, webhookId string, userId string) (*model.Webhook, bool) {
+	webhook, err := h.webhookService.Get(webhookId)
+
+	if err != nil {
+		e := apperrors.NewNotFound("webhook", webhookId)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return nil, false
+	}
+
+	if ok := h.isWebhookGuildOwner(c, webhook.GuildId, userId); !ok {
+		return nil, false
+	}
+
+	return webhook, true
+}
+
+// isWebhookGuildOwner checks if the user owns the given guild.
+// Writes the error response otherwise.
+func (h *Handler) isWebhookGuildOwner(c *gin.Context, guildId string, userId string) bool {
+	guild, err := h.guildService.GetGuild(guildId)
+
+	if err != nil {
+		e := apperrors.NewNotFound("guild", guildId)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return false
+	}
+
+	if guild.OwnerId != userId {
+		e := apperrors.NewAuthorization(apperrors.MustBeOwner)
+		c.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,521 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/sentrionic/valkyrie/mocks"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"github.com/sentrionic/valkyrie/model/fixture"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func getMockWebhook(guildId string, channelId string, userId string) *model.Webhook {
+	webhook := &model.Webhook{
+		Name:      fixture.RandStr(8),
+		Avatar:    "https://example.com/avatar.png",
+		ChannelId: channelId,
+		GuildId:   guildId,
+		UserId:    userId,
+	}
+	webhook.ID = fixture.RandID()
+	webhook.CreatedAt = time.Now()
+	webhook.UpdatedAt = time.Now()
+	return webhook
+}
+
+func TestHandler_GetChannelWebhooks(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	authUser := fixture.GetMockUser()
+
+	t.Run("Successful Fetch", func(t *testing.T) {
+		mockGuild := fixture.GetMockGuild(authUser.ID)
+		mockChannel := fixture.GetMockChannel(mockGuild.ID)
+		webhooks := []model.Webhook{*getMockWebhook(mockGuild.ID, mockChannel.ID, authUser.ID)}
+
+		mockChannelService := new(mocks.ChannelService)
+		mockChannelService.On("Get", mockChannel.ID).Return(mockChannel, nil)
+
+		mockGuildService := new(mocks.GuildService)
+		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
+
+		mockWebhookService := new(mocks.WebhookService)
+		mockWebhookService.On("GetChannelWebhooks", mockChannel.ID).Return(&webhooks, nil)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
+			ChannelService: mockChannelService,
+			GuildService:   mockGuildService,
+			WebhookService: mockWebhookService,
+		})
+
+		request, err := http.NewRequest(http.MethodGet, "/api/channels/"+mockChannel.ID+"/webhooks", nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal([]model.WebhookResponse{webhooks[0].SerializeWebhook()})
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockWebhookService.AssertExpectations(t)
+	})
+
+	t.Run("Not the owner", func(t *testing.T) {
+		mockGuild := fixture.GetMockGuild(fixture.RandID())
+		mockChannel := fixture.GetMockChannel(mockGuild.ID)
+
+		mockChannelService := new(mocks.ChannelService)
+		mockChannelService.On("Get", mockChannel.ID).Return(mockChannel, nil)
+
+		mockGuildService := new(mocks.GuildService)
+		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
+
+		mockWebhookService := new(mocks.WebhookService)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
+			ChannelService: mockChannelService,
+			GuildService:   mockGuildService,
+			WebhookService: mockWebhookService,
+		})
+
+		request, err := http.NewRequest(http.MethodGet, "/api/channels/"+mockChannel.ID+"/webhooks", nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		mockError := apperrors.NewAuthorization(apperrors.MustBeOwner)
+		respBody, err := json.Marshal(gin.H{
+			"error": mockError,
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, mockError.Status(), rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockWebhookService.AssertNotCalled(t, "GetChannelWebhooks", mock.Anything)
+	})
+}
+
+func TestHandler_CreateWebhook(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	authUser := fixture.GetMockUser()
+
+	t.Run("Successfully created", func(t *testing.T) {
+		mockGuild := fixture.GetMockGuild(authUser.ID)
+		mockChannel := fixture.GetMockChannel(mockGuild.ID)
+		name := "CI"
+		token := fixture.RandStr(43)
+
+		params := &model.Webhook{
+			Name:      name,
+			ChannelId: mockChannel.ID,
+			GuildId:   mockGuild.ID,
+			UserId:    authUser.ID,
+		}
+
+		webhook := getMockWebhook(mockGuild.ID, mockChannel.ID, authUser.ID)
+		webhook.Name = name
+
+		mockChannelService := new(mocks.ChannelService)
+		mockChannelService.On("Get", mockChannel.ID).Return(mockChannel, nil)
+
+		mockGuildService := new(mocks.GuildService)
+		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
+
+		mockWebhookService := new(mocks.WebhookService)
+		mockWebhookService.On("CreateWebhook", params).Return(webhook, token, nil)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
+			ChannelService: mockChannelService,
+			GuildService:   mockGuildService,
+			WebhookService: mockWebhookService,
+		})
+
+		reqBody, err := json.Marshal(gin.H{
+			"name": name,
+		})
+		assert.NoError(t, err)
+
+		request, err := http.NewRequest(http.MethodPost, "/api/channels/"+mockChannel.ID+"/webhooks", bytes.NewBuffer(reqBody))
+		assert.NoError(t, err)
+
+		request.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(rr, request)
+
+		response := webhook.SerializeWebhook()
+		response.Token = token
+		respBody, err := json.Marshal(response)
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusCreated, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockWebhookService.AssertExpectations(t)
+	})
+
+	t.Run("Voice channel", func(t *testing.T) {
+		mockGuild := fixture.GetMockGuild(authUser.ID)
+		mockChannel := fixture.GetMockChannel(mockGuild.ID)
+		mockChannel.Type = model.VoiceChannel
+
+		mockChannelService := new(mocks.ChannelService)
+		mockChannelService.On("Get", mockChannel.ID).Return(mockChannel, nil)
+
+		mockWebhookService := new(mocks.WebhookService)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
+			ChannelService: mockChannelService,
+			WebhookService: mockWebhookService,
+		})
+
+		reqBody, err := json.Marshal(gin.H{
+			"name": "CI",
+		})
+		assert.NoError(t, err)
+
+		request, err := http.NewRequest(http.MethodPost, "/api/channels/"+mockChannel.ID+"/webhooks", bytes.NewBuffer(reqBody))
+		assert.NoError(t, err)
+
+		request.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(rr, request)
+
+		mockError := apperrors.NewBadRequest(apperrors.WebhookChannelError)
+		respBody, err := json.Marshal(gin.H{
+			"error": mockError,
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, mockError.Status(), rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockWebhookService.AssertNotCalled(t, "CreateWebhook", mock.Anything)
+	})
+
+	t.Run("Invalid avatar", func(t *testing.T) {
+		mockWebhookService := new(mocks.WebhookService)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
+			WebhookService: mockWebhookService,
+		})
+
+		reqBody, err := json.Marshal(gin.H{
+			"name":   "CI",
+			"avatar": "not a url",
+		})
+		assert.NoError(t, err)
+
+		request, err := http.NewRequest(http.MethodPost, "/api/channels/"+fixture.RandID()+"/webhooks", bytes.NewBuffer(reqBody))
+		assert.NoError(t, err)
+
+		request.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(rr, request)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockWebhookService.AssertNotCalled(t, "CreateWebhook", mock.Anything)
+	})
+}
+
+func TestHandler_EditWebhook(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	authUser := fixture.GetMockUser()
+
+	t.Run("Successfully edited", func(t *testing.T) {
+		mockGuild := fixture.GetMockGuild(authUser.ID)
+		webhook := getMockWebhook(mockGuild.ID, fixture.RandID(), authUser.ID)
+		name := "Deployments"
+		avatar := "https://example.com/deploy.png"
+
+		mockGuildService := new(mocks.GuildService)
+		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
+
+		mockWebhookService := new(mocks.WebhookService)
+		mockWebhookService.On("Get", webhook.ID).Return(webhook, nil)
+		mockWebhookService.On("UpdateWebhook", webhook).Return(nil)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
+			GuildService:   mockGuildService,
+			WebhookService: mockWebhookService,
+		})
+
+		reqBody, err := json.Marshal(gin.H{
+			"name":   name,
+			"avatar": avatar,
+		})
+		assert.NoError(t, err)
+
+		request, err := http.NewRequest(http.MethodPut, "/api/webhooks/"+webhook.ID, bytes.NewBuffer(reqBody))
+		assert.NoError(t, err)
+
+		request.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(rr, request)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, name, webhook.Name)
+		assert.Equal(t, avatar, webhook.Avatar)
+		mockWebhookService.AssertExpectations(t)
+	})
+}
+
+func TestHandler_DeleteWebhook(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	authUser := fixture.GetMockUser()
+
+	t.Run("Successfully deleted", func(t *testing.T) {
+		mockGuild := fixture.GetMockGuild(authUser.ID)
+		webhook := getMockWebhook(mockGuild.ID, fixture.RandID(), authUser.ID)
+
+		mockGuildService := new(mocks.GuildService)
+		mockGuildService.On("GetGuild", mockGuild.ID).Return(mockGuild, nil)
+
+		mockWebhookService := new(mocks.WebhookService)
+		mockWebhookService.On("Get", webhook.ID).Return(webhook, nil)
+		mockWebhookService.On("DeleteWebhook", webhook).Return(nil)
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
+			GuildService:   mockGuildService,
+			WebhookService: mockWebhookService,
+		})
+
+		request, err := http.NewRequest(http.MethodDelete, "/api/webhooks/"+webhook.ID, nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal(true)
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockWebhookService.AssertExpectations(t)
+	})
+
+	t.Run("Webhook not found", func(t *testing.T) {
+		webhookId := fixture.RandID()
+
+		mockWebhookService := new(mocks.WebhookService)
+		mockWebhookService.On("Get", webhookId).Return(nil, apperrors.NewNotFound("webhook", webhookId))
+
+		rr := httptest.NewRecorder()
+
+		router := getAuthenticatedTestRouter(authUser.ID)
+
+		NewHandler(&Config{
+			R:              router,
+			UserService:    allowSession(new(mocks.UserService)),
+			WebhookService: mockWebhookService,
+		})
+
+		request, err := http.NewRequest(http.MethodDelete, "/api/webhooks/"+webhookId, nil)
+		assert.NoError(t, err)
+
+		router.ServeHTTP(rr, request)
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		mockWebhookService.AssertNotCalled(t, "DeleteWebhook", mock.Anything)
+	})
+}
+
+func TestHandler_ExecuteWebhook(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+
+	t.Run("Posts a message with overrides", func(t *testing.T) {
+		mockGuild := fixture.GetMockGuild(fixture.RandID())
+		mockChannel := fixture.GetMockChannel(mockGuild.ID)
+		webhook := getMockWebhook(mockGuild.ID, mockChannel.ID, mockGuild.OwnerId)
+		token := fixture.RandStr(43)
+		text := "Build passed"
+		username := "GitHub"
+		avatar := "https://example.com/github.png"
+
+		params := &model.Message{
+			Text:          &text,
+			UserId:        webhook.UserId,
+			ChannelId:     mockChannel.ID,
+			WebhookId:     &webhook.ID,
+			WebhookName:   &username,
+			WebhookAvatar: &avatar,
+		}
+
+		message := *params
+		message.ID = fixture.RandID()
+		message.Type = model.DefaultMessage
+
+		response := model.MessageResponse{
+			Id:   message.ID,
+			Text: &text,
+			Type: model.DefaultMessage,
+			User: model.MemberResponse{
+				Id:        webhook.ID,
+				Username:  username,
+				Image:     avatar,
+				Status:    model.OfflineStatus,
+				CreatedAt: webhook.CreatedAt,
+				UpdatedAt: webhook.UpdatedAt,
+			},
+			WebhookId: &webhook.ID,
+		}
+
+		mockWebhookService := new(mocks.WebhookService)
+		mockWebhookService.On("AuthenticateWebhook", webhook.ID, token).Return(webhook, nil)
+
+		mockChannelService := new(mocks.ChannelService)
+		mockChannelService.On("Get", mockChannel.ID).Return(mockChannel, nil)
+		mockChannelService.On("UpdateChannel", mockChannel).Return(nil)
+
+		mockMessageService := new(mocks.MessageService)
+		mockMessageService.On("CreateMessage", params).Return(&message, nil)
+
+		mockSocketService := new(mocks.SocketService)
+		mockSocketService.On("EmitNewMessage", mockChannel.ID, &response).Return()
+		mockSocketService.On("EmitNewNotification", mockGuild.ID, mockChannel.ID).Return()
+
+		rr := httptest.NewRecorder()
+
+		router := getTestRouter()
+
+		NewHandler(&Config{
+			R:              router,
+			ChannelService: mockChannelService,
+			MessageService: mockMessageService,
+			SocketService:  mockSocketService,
+			WebhookService: mockWebhookService,
+		})
+
+		reqBody, err := json.Marshal(gin.H{
+			"text":     text,
+			"username": username,
+			"avatar":   avatar,
+		})
+		assert.NoError(t, err)
+
+		request, err := http.NewRequest(http.MethodPost, "/api/webhooks/"+webhook.ID+"/"+token, bytes.NewBuffer(reqBody))
+		assert.NoError(t, err)
+
+		request.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(rr, request)
+
+		assert.Equal(t, http.StatusCreated, rr.Code)
+		mockMessageService.AssertExpectations(t)
+		mockSocketService.AssertExpectations(t)
+		mockChannelService.AssertExpectations(t)
+	})
+
+	t.Run("Invalid token", func(t *testing.T) {
+		webhookId := fixture.RandID()
+		token := fixture.RandStr(43)
+
+		mockError := apperrors.NewAuthorization(apperrors.InvalidWebhookToken)
+		mockWebhookService := new(mocks.WebhookService)
+		mockWebhookService.On("AuthenticateWebhook", webhookId, token).Return(nil, mockError)
+
+		mockMessageService := new(mocks.MessageService)
+
+		rr := httptest.NewRecorder()
+
+		router := getTestRouter()
+
+		NewHandler(&Config{
+			R:              router,
+			MessageService: mockMessageService,
+			WebhookService: mockWebhookService,
+		})
+
+		reqBody, err := json.Marshal(gin.H{
+			"text": "Build passed",
+		})
+		assert.NoError(t, err)
+
+		request, err := http.NewRequest(http.MethodPost, "/api/webhooks/"+webhookId+"/"+token, bytes.NewBuffer(reqBody))
+		assert.NoError(t, err)
+
+		request.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(rr, request)
+
+		respBody, err := json.Marshal(gin.H{
+			"error": mockError,
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, mockError.Status(), rr.Code)
+		assert.Equal(t, respBody, rr.Body.Bytes())
+		mockMessageService.AssertNotCalled(t, "CreateMessage", mock.Anything)
+	})
+
+	t.Run("Text required", func(t *testing.T) {
+		mockWebhookService := new(mocks.WebhookService)
+
+		rr := httptest.NewRecorder()
+
+		router := getTestRouter()
+
+		NewHandler(&Config{
+			R:              router,
+			WebhookService: mockWebhookService,
+		})
+
+		reqBody, err := json.Marshal(gin.H{
+			"username": "GitHub",
+		})
+		assert.NoError(t, err)
+
+		request, err := http.NewRequest(http.MethodPost, "/api/webhooks/"+fixture.RandID()+"/token", bytes.NewBuffer(reqBody))
+		assert.NoError(t, err)
+
+		request.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(rr, request)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockWebhookService.AssertNotCalled(t, "AuthenticateWebhook", mock.Anything, mock.Anything)
+	})
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"github.com/gin-contrib/sessions"
 	"github.com/gin-contrib/sessions/redis"
@@ -19,6 +20,7 @@ import (
 	"net/http"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -33,6 +35,12 @@ func inject(d *dataSources) (*gin.Engine, error) {
 	guildRepository := repository.NewGuildRepository(d.DB)
 	channelRepository := repository.NewChannelRepository(d.DB)
 	messageRepository := repository.NewMessageRepository(d.DB)
+	tokenRepository := repository.NewTokenRepository(d.DB)
+	webhookRepository := repository.NewWebhookRepository(d.DB)
+	eventRepository := repository.NewEventRepository(d.DB)
+	commandRepository := repository.NewCommandRepository(d.DB)
+	identityRepository := repository.NewIdentityRepository(d.DB)
+	exportRepository := repository.NewExportRepository(d.DB)
 
 	bucketName := os.Getenv("AWS_STORAGE_BUCKET_NAME")
 	fileRepository := repository.NewFileRepository(d.S3Session, bucketName)
@@ -48,6 +56,7 @@ func inject(d *dataSources) (*gin.Engine, error) {
 	 */
 	userService := service.NewUserService(&service.USConfig{
 		UserRepository:  userRepository,
+		GuildRepository: guildRepository,
 		FileRepository:  fileRepository,
 		RedisRepository: redisRepository,
 		MailRepository:  mailRepository,
@@ -69,6 +78,7 @@ func inject(d *dataSources) (*gin.Engine, error) {
 	channelService := service.NewChannelService(&service.CSConfig{
 		ChannelRepository: channelRepository,
 		GuildRepository:   guildRepository,
+		RedisRepository:   redisRepository,
 	})
 
 	messageService := service.NewMessageService(&service.MSConfig{
@@ -76,12 +86,67 @@ func inject(d *dataSources) (*gin.Engine, error) {
 		FileRepository:    fileRepository,
 	})
 
+	tokenService := service.NewTokenService(&service.TSConfig{
+		UserRepository:  userRepository,
+		TokenRepository: tokenRepository,
+	})
+
+	webhookService := service.NewWebhookService(&service.WHSConfig{
+		WebhookRepository: webhookRepository,
+	})
+
+	// Self-hosters can allow event webhooks and command callbacks to reach services on their network
+	allowPrivateNetworks := os.Getenv("ALLOW_PRIVATE_NETWORKS") == "true"
+
+	eventService := service.NewEventService(&service.ESConfig{
+		EventRepository:      eventRepository,
+		ChannelRepository:    channelRepository,
+		AllowPrivateNetworks: allowPrivateNetworks,
+	})
+
+	commandService := service.NewCommandService(&service.CMSConfig{
+		CommandRepository:    commandRepository,
+		RedisRepository:      redisRepository,
+		AllowPrivateNetworks: allowPrivateNetworks,
+	})
+
+	// Login with an OpenID Connect provider is disabled if no issuer is set
+	identityService := service.NewIdentityService(&service.IDSConfig{
+		UserRepository:     userRepository,
+		IdentityRepository: identityRepository,
+		Issuer:             os.Getenv("OIDC_ISSUER"),
+		ClientID:           os.Getenv("OIDC_CLIENT_ID"),
+		ClientSecret:       os.Getenv("OIDC_CLIENT_SECRET"),
+		RedirectURL:        os.Getenv("OIDC_REDIRECT_URL"),
+	})
+
+	exportService := service.NewExportService(&service.EXSConfig{
+		ExportRepository:  exportRepository,
+		UserRepository:    userRepository,
+		FriendRepository:  friendRepository,
+		GuildRepository:   guildRepository,
+		MessageRepository: messageRepository,
+		FileRepository:    fileRepository,
+		MailRepository:    mailRepository,
+		RedisRepository:   redisRepository,
+	})
+
 	// initialize gin.Engine
 	router := gin.Default()
 
+	// The origins allowed to call the API and connect to the websocket.
+	// Defaults to the CORS_ORIGIN
+	allowedOrigins := []string{origin}
+	if origins := os.Getenv("ALLOWED_ORIGINS"); origins != "" {
+		allowedOrigins = strings.Split(origins, ",")
+		for i := range allowedOrigins {
+			allowedOrigins[i] = strings.TrimSpace(allowedOrigins[i])
+		}
+	}
+
 	// set cors settings
 	c := cors.New(cors.Options{
-		AllowedOrigins:   []string{origin},
+		AllowedOrigins:   allowedOrigins,
 		AllowCredentials: true,
 		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE"},
 	})
@@ -98,7 +163,7 @@ func inject(d *dataSources) (*gin.Engine, error) {
 
 	store.Options(sessions.Options{
 		Domain:   domain,
-		MaxAge:   60 * 60 * 24 * 7, // 7 days
+		MaxAge:   model.SessionMaxAge,
 		Secure:   gin.Mode() == gin.ReleaseMode,
 		HttpOnly: true,
 		Path:     "/",
@@ -138,17 +203,50 @@ func inject(d *dataSources) (*gin.Engine, error) {
 		GuildService:   guildService,
 		ChannelService: channelService,
 		Redis:          d.RedisClient,
+		AllowedOrigins: allowedOrigins,
 	})
 	go hub.Run()
 
-	router.GET("/ws", middleware.AuthUser(), func(c *gin.Context) {
+	// Delete the accounts whose grace period is over
+	go func() {
+		ticker := time.NewTicker(service.AccountDeletionInterval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			if err := userService.DeleteScheduledAccounts(context.Background()); err != nil {
+				log.Printf("could not delete scheduled accounts: %v", err)
+			}
+		}
+	}()
+
+	// Build the requested data exports and remove the expired ones
+	go func() {
+		ticker := time.NewTicker(service.ExportInterval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			if err := exportService.ProcessExports(); err != nil {
+				log.Printf("could not process data exports: %v", err)
+			}
+		}
+	}()
+
+	router.GET("/ws", middleware.AuthWebsocket(userService, tokenService), func(c *gin.Context) {
 		ws.ServeWs(hub, c)
 	})
 
+	// Fallback for clients that cannot use websockets
+	router.GET("/events", middleware.AuthUser(userService, tokenService), func(c *gin.Context) {
+		ws.ServeSSE(hub, c)
+	})
+
 	socketService := service.NewSocketService(&service.SSConfig{
 		Hub:               *hub,
+		UserRepository:    userRepository,
 		GuildRepository:   guildRepository,
 		ChannelRepository: channelRepository,
+		FriendRepository:  friendRepository,
+		EventService:      eventService,
 	})
 
 	handler.NewHandler(&handler.Config{
@@ -159,6 +257,12 @@ func inject(d *dataSources) (*gin.Engine, error) {
 		ChannelService:  channelService,
 		MessageService:  messageService,
 		SocketService:   socketService,
+		TokenService:    tokenService,
+		WebhookService:  webhookService,
+		EventService:    eventService,
+		CommandService:  commandService,
+		IdentityService: identityService,
+		ExportService:   exportService,
 		TimeoutDuration: time.Duration(ht) * time.Second,
 		MaxBodyBytes:    mbb,
 	})
@@ -181,6 +181,29 @@ func (_m *ChannelRepository) GetDMMemberIds(channelId string) (*[]string, error)
 	return r0, r1
 }
 
+// GetDMRequests provides a mock function with given fields: userId
+func (_m *ChannelRepository) GetDMRequests(userId string) (*[]model.DirectMessage, error) {
+	ret := _m.Called(userId)
+
+	var r0 *[]model.DirectMessage
+	if rf, ok := ret.Get(0).(func(string) *[]model.DirectMessage); ok {
+		r0 = rf(userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]model.DirectMessage)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetDirectMessageChannel provides a mock function with given fields: userId, memberId
 func (_m *ChannelRepository) GetDirectMessageChannel(userId string, memberId string) (*string, error) {
 	ret := _m.Called(userId, memberId)
@@ -301,6 +324,20 @@ func (_m *ChannelRepository) RemovePrivateChannelMembers(memberIds []string, cha
 	return r0
 }
 
+// SetDirectMessageRequest provides a mock function with given fields: dmId, userId, isRequest
+func (_m *ChannelRepository) SetDirectMessageRequest(dmId string, userId string, isRequest bool) error {
+	ret := _m.Called(dmId, userId, isRequest)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, bool) error); ok {
+		r0 = rf(dmId, userId, isRequest)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SetDirectMessageStatus provides a mock function with given fields: dmId, userId, isOpen
 func (_m *ChannelRepository) SetDirectMessageStatus(dmId string, userId string, isOpen bool) error {
 	ret := _m.Called(dmId, userId, isOpen)
@@ -328,3 +365,40 @@ func (_m *ChannelRepository) UpdateChannel(channel *model.Channel) error {
 
 	return r0
 }
+
+// GetDirectMessage provides a mock function with given fields: channelId, userId
+func (_m *ChannelRepository) GetDirectMessage(channelId string, userId string) (*model.DirectMessage, error) {
+	ret := _m.Called(channelId, userId)
+
+	var r0 *model.DirectMessage
+	if rf, ok := ret.Get(0).(func(string, string) *model.DirectMessage); ok {
+		r0 = rf(channelId, userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.DirectMessage)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(channelId, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveDMChannelMember provides a mock function with given fields: channelId, userId
+func (_m *ChannelRepository) RemoveDMChannelMember(channelId string, userId string) error {
+	ret := _m.Called(channelId, userId)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(channelId, userId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
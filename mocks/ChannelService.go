@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	context "context"
+
 	model "github.com/sentrionic/valkyrie/model"
 	mock "github.com/stretchr/testify/mock"
 )
@@ -40,6 +42,27 @@ func (_m *ChannelService) AddPrivateChannelMembers(memberIds []string, channelId
 	return r0
 }
 
+// AllowsDirectMessage provides a mock function with given fields: user, senderId
+func (_m *ChannelService) AllowsDirectMessage(user *model.User, senderId string) (bool, error) {
+	ret := _m.Called(user, senderId)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(*model.User, string) bool); ok {
+		r0 = rf(user, senderId)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*model.User, string) error); ok {
+		r1 = rf(user, senderId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CleanPCMembers provides a mock function with given fields: channelId
 func (_m *ChannelService) CleanPCMembers(channelId string) error {
 	ret := _m.Called(channelId)
@@ -91,6 +114,29 @@ func (_m *ChannelService) DeleteChannel(channel *model.Channel) error {
 	return r0
 }
 
+// ExpireVoiceStates provides a mock function with given fields: ctx
+func (_m *ChannelService) ExpireVoiceStates(ctx context.Context) (*[]model.VoiceState, error) {
+	ret := _m.Called(ctx)
+
+	var r0 *[]model.VoiceState
+	if rf, ok := ret.Get(0).(func(context.Context) *[]model.VoiceState); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]model.VoiceState)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Get provides a mock function with given fields: channelId
 func (_m *ChannelService) Get(channelId string) (*model.Channel, error) {
 	ret := _m.Called(channelId)
@@ -158,6 +204,29 @@ func (_m *ChannelService) GetDMByUserAndChannel(userId string, channelId string)
 	return r0, r1
 }
 
+// GetDMRequests provides a mock function with given fields: userId
+func (_m *ChannelService) GetDMRequests(userId string) (*[]model.DirectMessage, error) {
+	ret := _m.Called(userId)
+
+	var r0 *[]model.DirectMessage
+	if rf, ok := ret.Get(0).(func(string) *[]model.DirectMessage); ok {
+		r0 = rf(userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]model.DirectMessage)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetDirectMessageChannel provides a mock function with given fields: userId, memberId
 func (_m *ChannelService) GetDirectMessageChannel(userId string, memberId string) (*string, error) {
 	ret := _m.Called(userId, memberId)
@@ -255,6 +324,20 @@ func (_m *ChannelService) OpenDMForAll(dmId string) error {
 	return r0
 }
 
+// RefreshVoiceState provides a mock function with given fields: ctx, userId
+func (_m *ChannelService) RefreshVoiceState(ctx context.Context, userId string) error {
+	ret := _m.Called(ctx, userId)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, userId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // RemovePrivateChannelMembers provides a mock function with given fields: memberIds, channelId
 func (_m *ChannelService) RemovePrivateChannelMembers(memberIds []string, channelId string) error {
 	ret := _m.Called(memberIds, channelId)
@@ -269,6 +352,20 @@ func (_m *ChannelService) RemovePrivateChannelMembers(memberIds []string, channe
 	return r0
 }
 
+// SetDirectMessageRequest provides a mock function with given fields: dmId, userId, isRequest
+func (_m *ChannelService) SetDirectMessageRequest(dmId string, userId string, isRequest bool) error {
+	ret := _m.Called(dmId, userId, isRequest)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, bool) error); ok {
+		r0 = rf(dmId, userId, isRequest)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SetDirectMessageStatus provides a mock function with given fields: dmId, userId, isOpen
 func (_m *ChannelService) SetDirectMessageStatus(dmId string, userId string, isOpen bool) error {
 	ret := _m.Called(dmId, userId, isOpen)
@@ -296,3 +393,178 @@ func (_m *ChannelService) UpdateChannel(channel *model.Channel) error {
 
 	return r0
 }
+
+// JoinVoiceChannel provides a mock function with given fields: ctx, state
+func (_m *ChannelService) JoinVoiceChannel(ctx context.Context, state *model.VoiceState) (*model.VoiceState, error) {
+	ret := _m.Called(ctx, state)
+
+	var r0 *model.VoiceState
+	if rf, ok := ret.Get(0).(func(context.Context, *model.VoiceState) *model.VoiceState); ok {
+		r0 = rf(ctx, state)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.VoiceState)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *model.VoiceState) error); ok {
+		r1 = rf(ctx, state)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LeaveVoiceChannel provides a mock function with given fields: ctx, userId
+func (_m *ChannelService) LeaveVoiceChannel(ctx context.Context, userId string) (*model.VoiceState, error) {
+	ret := _m.Called(ctx, userId)
+
+	var r0 *model.VoiceState
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.VoiceState); ok {
+		r0 = rf(ctx, userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.VoiceState)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateVoiceState provides a mock function with given fields: ctx, userId, muted, deafened
+func (_m *ChannelService) UpdateVoiceState(ctx context.Context, userId string, muted *bool, deafened *bool) (*model.VoiceState, error) {
+	ret := _m.Called(ctx, userId, muted, deafened)
+
+	var r0 *model.VoiceState
+	if rf, ok := ret.Get(0).(func(context.Context, string, *bool, *bool) *model.VoiceState); ok {
+		r0 = rf(ctx, userId, muted, deafened)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.VoiceState)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, *bool, *bool) error); ok {
+		r1 = rf(ctx, userId, muted, deafened)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetVoiceState provides a mock function with given fields: ctx, userId
+func (_m *ChannelService) GetVoiceState(ctx context.Context, userId string) (*model.VoiceState, error) {
+	ret := _m.Called(ctx, userId)
+
+	var r0 *model.VoiceState
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.VoiceState); ok {
+		r0 = rf(ctx, userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.VoiceState)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetVoiceParticipants provides a mock function with given fields: ctx, channelId
+func (_m *ChannelService) GetVoiceParticipants(ctx context.Context, channelId string) (*[]model.VoiceState, error) {
+	ret := _m.Called(ctx, channelId)
+
+	var r0 *[]model.VoiceState
+	if rf, ok := ret.Get(0).(func(context.Context, string) *[]model.VoiceState); ok {
+		r0 = rf(ctx, channelId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]model.VoiceState)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, channelId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDirectMessage provides a mock function with given fields: channelId, userId
+func (_m *ChannelService) GetDirectMessage(channelId string, userId string) (*model.DirectMessage, error) {
+	ret := _m.Called(channelId, userId)
+
+	var r0 *model.DirectMessage
+	if rf, ok := ret.Get(0).(func(string, string) *model.DirectMessage); ok {
+		r0 = rf(channelId, userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.DirectMessage)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(channelId, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDMMemberIds provides a mock function with given fields: channelId
+func (_m *ChannelService) GetDMMemberIds(channelId string) (*[]string, error) {
+	ret := _m.Called(channelId)
+
+	var r0 *[]string
+	if rf, ok := ret.Get(0).(func(string) *[]string); ok {
+		r0 = rf(channelId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(channelId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveDMChannelMember provides a mock function with given fields: channelId, userId
+func (_m *ChannelService) RemoveDMChannelMember(channelId string, userId string) error {
+	ret := _m.Called(channelId, userId)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(channelId, userId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -5,7 +5,11 @@ package mocks
 import (
 	mock "github.com/stretchr/testify/mock"
 
+	io "io"
+
 	multipart "mime/multipart"
+
+	time "time"
 )
 
 // FileRepository is an autogenerated mock type for the FileRepository type
@@ -27,6 +31,27 @@ func (_m *FileRepository) DeleteImage(key string) error {
 	return r0
 }
 
+// GetSignedURL provides a mock function with given fields: key, expires
+func (_m *FileRepository) GetSignedURL(key string, expires time.Duration) (string, error) {
+	ret := _m.Called(key, expires)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string, time.Duration) string); ok {
+		r0 = rf(key, expires)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, time.Duration) error); ok {
+		r1 = rf(key, expires)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UploadAvatar provides a mock function with given fields: header, directory
 func (_m *FileRepository) UploadAvatar(header *multipart.FileHeader, directory string) (string, error) {
 	ret := _m.Called(header, directory)
@@ -48,6 +73,27 @@ func (_m *FileRepository) UploadAvatar(header *multipart.FileHeader, directory s
 	return r0, r1
 }
 
+// UploadExport provides a mock function with given fields: body, directory, filename
+func (_m *FileRepository) UploadExport(body io.Reader, directory string, filename string) (string, error) {
+	ret := _m.Called(body, directory, filename)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(io.Reader, string, string) string); ok {
+		r0 = rf(body, directory, filename)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(io.Reader, string, string) error); ok {
+		r1 = rf(body, directory, filename)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UploadFile provides a mock function with given fields: header, directory, filename, mimetype
 func (_m *FileRepository) UploadFile(header *multipart.FileHeader, directory string, filename string, mimetype string) (string, error) {
 	ret := _m.Called(header, directory, filename, mimetype)
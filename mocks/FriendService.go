@@ -122,3 +122,75 @@ func (_m *FriendService) SaveRequests(user *model.User) error {
 
 	return r0
 }
+
+// GetBlockedUsers provides a mock function with given fields: id
+func (_m *FriendService) GetBlockedUsers(id string) (*[]model.BlockedUser, error) {
+	ret := _m.Called(id)
+
+	var r0 *[]model.BlockedUser
+	if rf, ok := ret.Get(0).(func(string) *[]model.BlockedUser); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]model.BlockedUser)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BlockUser provides a mock function with given fields: userId, memberId
+func (_m *FriendService) BlockUser(userId string, memberId string) error {
+	ret := _m.Called(userId, memberId)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(userId, memberId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UnblockUser provides a mock function with given fields: userId, memberId
+func (_m *FriendService) UnblockUser(userId string, memberId string) error {
+	ret := _m.Called(userId, memberId)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(userId, memberId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IsBlocked provides a mock function with given fields: userId, memberId
+func (_m *FriendService) IsBlocked(userId string, memberId string) (bool, error) {
+	ret := _m.Called(userId, memberId)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string, string) bool); ok {
+		r0 = rf(userId, memberId)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(userId, memberId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
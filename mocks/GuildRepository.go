@@ -72,6 +72,29 @@ func (_m *GuildRepository) FindByID(id string) (*model.Guild, error) {
 	return r0, r1
 }
 
+// FindNextOwner provides a mock function with given fields: guildId, ownerId
+func (_m *GuildRepository) FindNextOwner(guildId string, ownerId string) (*model.User, error) {
+	ret := _m.Called(guildId, ownerId)
+
+	var r0 *model.User
+	if rf, ok := ret.Get(0).(func(string, string) *model.User); ok {
+		r0 = rf(guildId, ownerId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.User)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(guildId, ownerId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindUserByID provides a mock function with given fields: uid
 func (_m *GuildRepository) FindUserByID(uid string) (*model.User, error) {
 	ret := _m.Called(uid)
@@ -210,6 +233,29 @@ func (_m *GuildRepository) GetMemberSettings(userId string, guildId string) (*mo
 	return r0, r1
 }
 
+// GetSharedMemberSettings provides a mock function with given fields: userId, memberId
+func (_m *GuildRepository) GetSharedMemberSettings(userId string, memberId string) (*[]model.MemberSettings, error) {
+	ret := _m.Called(userId, memberId)
+
+	var r0 *[]model.MemberSettings
+	if rf, ok := ret.Get(0).(func(string, string) *[]model.MemberSettings); ok {
+		r0 = rf(userId, memberId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]model.MemberSettings)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(userId, memberId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GuildMembers provides a mock function with given fields: userId, guildId
 func (_m *GuildRepository) GuildMembers(userId string, guildId string) (*[]model.MemberResponse, error) {
 	ret := _m.Called(userId, guildId)
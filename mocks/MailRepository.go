@@ -9,6 +9,62 @@ type MailRepository struct {
 	mock.Mock
 }
 
+// SendEmailChangeMail provides a mock function with given fields: email, token
+func (_m *MailRepository) SendEmailChangeMail(email string, token string) error {
+	ret := _m.Called(email, token)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(email, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SendEmailChangedMail provides a mock function with given fields: email, newEmail, token
+func (_m *MailRepository) SendEmailChangedMail(email string, newEmail string, token string) error {
+	ret := _m.Called(email, newEmail, token)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, string) error); ok {
+		r0 = rf(email, newEmail, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SendExportReadyMail provides a mock function with given fields: email, url
+func (_m *MailRepository) SendExportReadyMail(email string, url string) error {
+	ret := _m.Called(email, url)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(email, url)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SendLockoutMail provides a mock function with given fields: email
+func (_m *MailRepository) SendLockoutMail(email string) error {
+	ret := _m.Called(email)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(email)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SendResetMail provides a mock function with given fields: email, html
 func (_m *MailRepository) SendResetMail(email string, html string) error {
 	ret := _m.Called(email, html)
@@ -22,3 +78,17 @@ func (_m *MailRepository) SendResetMail(email string, html string) error {
 
 	return r0
 }
+
+// SendVerificationMail provides a mock function with given fields: email, token
+func (_m *MailRepository) SendVerificationMail(email string, token string) error {
+	ret := _m.Called(email, token)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(email, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
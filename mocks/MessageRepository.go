@@ -49,6 +49,43 @@ func (_m *MessageRepository) DeleteMessage(message *model.Message) error {
 	return r0
 }
 
+// FindAuthorChannels provides a mock function with given fields: userId
+func (_m *MessageRepository) FindAuthorChannels(userId string) ([]string, error) {
+	ret := _m.Called(userId)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(string) []string); ok {
+		r0 = rf(userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindByAuthorInBatches provides a mock function with given fields: userId, channelId, batchSize, fn
+func (_m *MessageRepository) FindByAuthorInBatches(userId string, channelId string, batchSize int, fn func(*[]model.Message) error) error {
+	ret := _m.Called(userId, channelId, batchSize, fn)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, int, func(*[]model.Message) error) error); ok {
+		r0 = rf(userId, channelId, batchSize, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // GetById provides a mock function with given fields: messageId
 func (_m *MessageRepository) GetById(messageId string) (*model.Message, error) {
 	ret := _m.Called(messageId)
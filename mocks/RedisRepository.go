@@ -7,6 +7,8 @@ import (
 
 	model "github.com/sentrionic/valkyrie/model"
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // RedisRepository is an autogenerated mock type for the RedisRepository type
@@ -14,6 +16,208 @@ type RedisRepository struct {
 	mock.Mock
 }
 
+// AcquireLock provides a mock function with given fields: ctx, name, ttl
+func (_m *RedisRepository) AcquireLock(ctx context.Context, name string, ttl time.Duration) (string, error) {
+	ret := _m.Called(ctx, name, ttl)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) string); ok {
+		r0 = rf(ctx, name, ttl)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Duration) error); ok {
+		r1 = rf(ctx, name, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AddFailedLogin provides a mock function with given fields: ctx, key, window
+func (_m *RedisRepository) AddFailedLogin(ctx context.Context, key string, window time.Duration) (int64, error) {
+	ret := _m.Called(ctx, key, window)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) int64); ok {
+		r0 = rf(ctx, key, window)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Duration) error); ok {
+		r1 = rf(ctx, key, window)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AddFailedTwoFactorAttempt provides a mock function with given fields: ctx, ticket
+func (_m *RedisRepository) AddFailedTwoFactorAttempt(ctx context.Context, ticket string) (int64, error) {
+	ret := _m.Called(ctx, ticket)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = rf(ctx, ticket)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, ticket)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteEmailChange provides a mock function with given fields: ctx, userId
+func (_m *RedisRepository) DeleteEmailChange(ctx context.Context, userId string) error {
+	ret := _m.Called(ctx, userId)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, userId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteInteraction provides a mock function with given fields: ctx, id
+func (_m *RedisRepository) DeleteInteraction(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteLoginSession provides a mock function with given fields: ctx, userId, sessionId
+func (_m *RedisRepository) DeleteLoginSession(ctx context.Context, userId string, sessionId string) error {
+	ret := _m.Called(ctx, userId, sessionId)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userId, sessionId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteOtherLoginSessions provides a mock function with given fields: ctx, userId, keepId
+func (_m *RedisRepository) DeleteOtherLoginSessions(ctx context.Context, userId string, keepId string) error {
+	ret := _m.Called(ctx, userId, keepId)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userId, keepId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteTwoFactorTicket provides a mock function with given fields: ctx, ticket
+func (_m *RedisRepository) DeleteTwoFactorTicket(ctx context.Context, ticket string) error {
+	ret := _m.Called(ctx, ticket)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, ticket)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ExpireVoiceStates provides a mock function with given fields: ctx
+func (_m *RedisRepository) ExpireVoiceStates(ctx context.Context) (*[]model.VoiceState, error) {
+	ret := _m.Called(ctx)
+
+	var r0 *[]model.VoiceState
+	if rf, ok := ret.Get(0).(func(context.Context) *[]model.VoiceState); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]model.VoiceState)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetEmailChange provides a mock function with given fields: ctx, token
+func (_m *RedisRepository) GetEmailChange(ctx context.Context, token string) (*model.EmailChange, error) {
+	ret := _m.Called(ctx, token)
+
+	var r0 *model.EmailChange
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.EmailChange); ok {
+		r0 = rf(ctx, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.EmailChange)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetEmailRevert provides a mock function with given fields: ctx, token
+func (_m *RedisRepository) GetEmailRevert(ctx context.Context, token string) (*model.EmailChange, error) {
+	ret := _m.Called(ctx, token)
+
+	var r0 *model.EmailChange
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.EmailChange); ok {
+		r0 = rf(ctx, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.EmailChange)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetIdFromToken provides a mock function with given fields: ctx, token
 func (_m *RedisRepository) GetIdFromToken(ctx context.Context, token string) (string, error) {
 	ret := _m.Called(ctx, token)
@@ -35,6 +239,71 @@ func (_m *RedisRepository) GetIdFromToken(ctx context.Context, token string) (st
 	return r0, r1
 }
 
+// GetIdFromTwoFactorTicket provides a mock function with given fields: ctx, ticket
+func (_m *RedisRepository) GetIdFromTwoFactorTicket(ctx context.Context, ticket string) (string, error) {
+	ret := _m.Called(ctx, ticket)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, ticket)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, ticket)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetIdFromVerificationToken provides a mock function with given fields: ctx, token
+func (_m *RedisRepository) GetIdFromVerificationToken(ctx context.Context, token string) (string, error) {
+	ret := _m.Called(ctx, token)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, token)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetInteraction provides a mock function with given fields: ctx, id
+func (_m *RedisRepository) GetInteraction(ctx context.Context, id string) (*model.Interaction, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *model.Interaction
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.Interaction); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Interaction)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetInvite provides a mock function with given fields: ctx, token
 func (_m *RedisRepository) GetInvite(ctx context.Context, token string) (string, error) {
 	ret := _m.Called(ctx, token)
@@ -56,11 +325,134 @@ func (_m *RedisRepository) GetInvite(ctx context.Context, token string) (string,
 	return r0, r1
 }
 
+// GetLoginLockout provides a mock function with given fields: ctx, key
+func (_m *RedisRepository) GetLoginLockout(ctx context.Context, key string) (time.Duration, error) {
+	ret := _m.Called(ctx, key)
+
+	var r0 time.Duration
+	if rf, ok := ret.Get(0).(func(context.Context, string) time.Duration); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Get(0).(time.Duration)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLoginSession provides a mock function with given fields: ctx, userId, sessionId
+func (_m *RedisRepository) GetLoginSession(ctx context.Context, userId string, sessionId string) (*model.LoginSession, error) {
+	ret := _m.Called(ctx, userId, sessionId)
+
+	var r0 *model.LoginSession
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *model.LoginSession); ok {
+		r0 = rf(ctx, userId, sessionId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.LoginSession)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, userId, sessionId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLoginSessions provides a mock function with given fields: ctx, userId
+func (_m *RedisRepository) GetLoginSessions(ctx context.Context, userId string) (*[]model.LoginSession, error) {
+	ret := _m.Called(ctx, userId)
+
+	var r0 *[]model.LoginSession
+	if rf, ok := ret.Get(0).(func(context.Context, string) *[]model.LoginSession); ok {
+		r0 = rf(ctx, userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]model.LoginSession)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // InvalidateInvites provides a mock function with given fields: ctx, guild
 func (_m *RedisRepository) InvalidateInvites(ctx context.Context, guild *model.Guild) {
 	_m.Called(ctx, guild)
 }
 
+// RefreshVoiceState provides a mock function with given fields: ctx, userId, ttl
+func (_m *RedisRepository) RefreshVoiceState(ctx context.Context, userId string, ttl time.Duration) error {
+	ret := _m.Called(ctx, userId, ttl)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) error); ok {
+		r0 = rf(ctx, userId, ttl)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReleaseLock provides a mock function with given fields: ctx, name, lockId
+func (_m *RedisRepository) ReleaseLock(ctx context.Context, name string, lockId string) error {
+	ret := _m.Called(ctx, name, lockId)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, name, lockId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ResetFailedLogins provides a mock function with given fields: ctx, key
+func (_m *RedisRepository) ResetFailedLogins(ctx context.Context, key string) error {
+	ret := _m.Called(ctx, key)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveInteraction provides a mock function with given fields: ctx, interaction
+func (_m *RedisRepository) SaveInteraction(ctx context.Context, interaction *model.Interaction) error {
+	ret := _m.Called(ctx, interaction)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Interaction) error); ok {
+		r0 = rf(ctx, interaction)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SaveInvite provides a mock function with given fields: ctx, guildId, id, isPermanent
 func (_m *RedisRepository) SaveInvite(ctx context.Context, guildId string, id string, isPermanent bool) error {
 	ret := _m.Called(ctx, guildId, id, isPermanent)
@@ -75,6 +467,76 @@ func (_m *RedisRepository) SaveInvite(ctx context.Context, guildId string, id st
 	return r0
 }
 
+// SaveLoginSession provides a mock function with given fields: ctx, userId, session
+func (_m *RedisRepository) SaveLoginSession(ctx context.Context, userId string, session *model.LoginSession) error {
+	ret := _m.Called(ctx, userId, session)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *model.LoginSession) error); ok {
+		r0 = rf(ctx, userId, session)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetEmailChangeToken provides a mock function with given fields: ctx, change
+func (_m *RedisRepository) SetEmailChangeToken(ctx context.Context, change *model.EmailChange) (string, error) {
+	ret := _m.Called(ctx, change)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, *model.EmailChange) string); ok {
+		r0 = rf(ctx, change)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *model.EmailChange) error); ok {
+		r1 = rf(ctx, change)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetEmailRevertToken provides a mock function with given fields: ctx, change
+func (_m *RedisRepository) SetEmailRevertToken(ctx context.Context, change *model.EmailChange) (string, error) {
+	ret := _m.Called(ctx, change)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, *model.EmailChange) string); ok {
+		r0 = rf(ctx, change)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *model.EmailChange) error); ok {
+		r1 = rf(ctx, change)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetLoginLockout provides a mock function with given fields: ctx, key, duration
+func (_m *RedisRepository) SetLoginLockout(ctx context.Context, key string, duration time.Duration) error {
+	ret := _m.Called(ctx, key, duration)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) error); ok {
+		r0 = rf(ctx, key, duration)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SetResetToken provides a mock function with given fields: ctx, id
 func (_m *RedisRepository) SetResetToken(ctx context.Context, id string) (string, error) {
 	ret := _m.Called(ctx, id)
@@ -95,3 +557,268 @@ func (_m *RedisRepository) SetResetToken(ctx context.Context, id string) (string
 
 	return r0, r1
 }
+
+// AddSession provides a mock function with given fields: ctx, userId, sessionId, ttl
+func (_m *RedisRepository) AddSession(ctx context.Context, userId string, sessionId string, ttl time.Duration) (bool, error) {
+	ret := _m.Called(ctx, userId, sessionId, ttl)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Duration) bool); ok {
+		r0 = rf(ctx, userId, sessionId, ttl)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, time.Duration) error); ok {
+		r1 = rf(ctx, userId, sessionId, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveSession provides a mock function with given fields: ctx, userId, sessionId
+func (_m *RedisRepository) RemoveSession(ctx context.Context, userId string, sessionId string) (bool, error) {
+	ret := _m.Called(ctx, userId, sessionId)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) bool); ok {
+		r0 = rf(ctx, userId, sessionId)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, userId, sessionId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HasLiveSession provides a mock function with given fields: ctx, userId
+func (_m *RedisRepository) HasLiveSession(ctx context.Context, userId string) (bool, error) {
+	ret := _m.Called(ctx, userId)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, userId)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExpireSessions provides a mock function with given fields: ctx
+func (_m *RedisRepository) ExpireSessions(ctx context.Context) ([]string, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(context.Context) []string); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetTwoFactorTicket provides a mock function with given fields: ctx, id
+func (_m *RedisRepository) SetTwoFactorTicket(ctx context.Context, id string) (string, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetVerificationToken provides a mock function with given fields: ctx, id
+func (_m *RedisRepository) SetVerificationToken(ctx context.Context, id string) (string, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetWSTicket provides a mock function with given fields: ctx, id
+func (_m *RedisRepository) SetWSTicket(ctx context.Context, id string) (string, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetIdFromWSTicket provides a mock function with given fields: ctx, ticket
+func (_m *RedisRepository) GetIdFromWSTicket(ctx context.Context, ticket string) (string, error) {
+	ret := _m.Called(ctx, ticket)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, ticket)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, ticket)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveVoiceState provides a mock function with given fields: ctx, state, ttl
+func (_m *RedisRepository) SaveVoiceState(ctx context.Context, state *model.VoiceState, ttl time.Duration) error {
+	ret := _m.Called(ctx, state, ttl)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.VoiceState, time.Duration) error); ok {
+		r0 = rf(ctx, state, ttl)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetVoiceState provides a mock function with given fields: ctx, userId
+func (_m *RedisRepository) GetVoiceState(ctx context.Context, userId string) (*model.VoiceState, error) {
+	ret := _m.Called(ctx, userId)
+
+	var r0 *model.VoiceState
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.VoiceState); ok {
+		r0 = rf(ctx, userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.VoiceState)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveVoiceState provides a mock function with given fields: ctx, state
+func (_m *RedisRepository) RemoveVoiceState(ctx context.Context, state *model.VoiceState) error {
+	ret := _m.Called(ctx, state)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.VoiceState) error); ok {
+		r0 = rf(ctx, state)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetVoiceStates provides a mock function with given fields: ctx, channelId
+func (_m *RedisRepository) GetVoiceStates(ctx context.Context, channelId string) (*[]model.VoiceState, error) {
+	ret := _m.Called(ctx, channelId)
+
+	var r0 *[]model.VoiceState
+	if rf, ok := ret.Get(0).(func(context.Context, string) *[]model.VoiceState); ok {
+		r0 = rf(ctx, channelId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]model.VoiceState)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, channelId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UseTwoFactorStep provides a mock function with given fields: ctx, userId, step, window
+func (_m *RedisRepository) UseTwoFactorStep(ctx context.Context, userId string, step int64, window time.Duration) (bool, error) {
+	ret := _m.Called(ctx, userId, step, window)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, time.Duration) bool); ok {
+		r0 = rf(ctx, userId, step, window)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64, time.Duration) error); ok {
+		r1 = rf(ctx, userId, step, window)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -82,6 +82,11 @@ func (_m *SocketService) EmitNewPrivateChannel(members []string, channel *model.
 	_m.Called(members, channel)
 }
 
+// EmitPresenceUpdate provides a mock function with given fields: user
+func (_m *SocketService) EmitPresenceUpdate(user *model.User) {
+	_m.Called(user)
+}
+
 // EmitRemoveFriend provides a mock function with given fields: userId, memberId
 func (_m *SocketService) EmitRemoveFriend(userId string, memberId string) {
 	_m.Called(userId, memberId)
@@ -101,3 +106,33 @@ func (_m *SocketService) EmitRemoveMember(room string, memberId string) {
 func (_m *SocketService) EmitSendRequest(room string) {
 	_m.Called(room)
 }
+
+// EmitStartTyping provides a mock function with given fields: user
+func (_m *SocketService) EmitStartTyping(user *model.TypingUser) {
+	_m.Called(user)
+}
+
+// EmitStopTyping provides a mock function with given fields: channelId, userId
+func (_m *SocketService) EmitStopTyping(channelId string, userId string) {
+	_m.Called(channelId, userId)
+}
+
+// EmitAddDM provides a mock function with given fields: members, channelId
+func (_m *SocketService) EmitAddDM(members []string, channelId string) {
+	_m.Called(members, channelId)
+}
+
+// EmitEditDM provides a mock function with given fields: channelId
+func (_m *SocketService) EmitEditDM(channelId string) {
+	_m.Called(channelId)
+}
+
+// EmitRemoveDM provides a mock function with given fields: userId, channelId
+func (_m *SocketService) EmitRemoveDM(userId string, channelId string) {
+	_m.Called(userId, channelId)
+}
+
+// EmitTransferGuild provides a mock function with given fields: transfer
+func (_m *SocketService) EmitTransferGuild(transfer *model.GuildTransfer) {
+	_m.Called(transfer)
+}
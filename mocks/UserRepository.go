@@ -5,6 +5,8 @@ package mocks
 import (
 	model "github.com/sentrionic/valkyrie/model"
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // UserRepository is an autogenerated mock type for the UserRepository type
@@ -12,6 +14,20 @@ type UserRepository struct {
 	mock.Mock
 }
 
+// Anonymize provides a mock function with given fields: user
+func (_m *UserRepository) Anonymize(user *model.User) error {
+	ret := _m.Called(user)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.User) error); ok {
+		r0 = rf(user)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Create provides a mock function with given fields: user
 func (_m *UserRepository) Create(user *model.User) (*model.User, error) {
 	ret := _m.Called(user)
@@ -35,6 +51,29 @@ func (_m *UserRepository) Create(user *model.User) (*model.User, error) {
 	return r0, r1
 }
 
+// FindBots provides a mock function with given fields: ownerId
+func (_m *UserRepository) FindBots(ownerId string) (*[]model.User, error) {
+	ret := _m.Called(ownerId)
+
+	var r0 *[]model.User
+	if rf, ok := ret.Get(0).(func(string) *[]model.User); ok {
+		r0 = rf(ownerId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]model.User)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(ownerId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindByEmail provides a mock function with given fields: email
 func (_m *UserRepository) FindByEmail(email string) (*model.User, error) {
 	ret := _m.Called(email)
@@ -81,6 +120,29 @@ func (_m *UserRepository) FindByID(id string) (*model.User, error) {
 	return r0, r1
 }
 
+// FindScheduledForDeletion provides a mock function with given fields: before
+func (_m *UserRepository) FindScheduledForDeletion(before time.Time) (*[]model.User, error) {
+	ret := _m.Called(before)
+
+	var r0 *[]model.User
+	if rf, ok := ret.Get(0).(func(time.Time) *[]model.User); ok {
+		r0 = rf(before)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]model.User)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(time.Time) error); ok {
+		r1 = rf(before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetFriendAndGuildIds provides a mock function with given fields: userId
 func (_m *UserRepository) GetFriendAndGuildIds(userId string) (*[]string, error) {
 	ret := _m.Called(userId)
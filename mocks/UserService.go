@@ -16,6 +16,20 @@ type UserService struct {
 	mock.Mock
 }
 
+// CancelDeletion provides a mock function with given fields: user
+func (_m *UserService) CancelDeletion(user *model.User) error {
+	ret := _m.Called(user)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.User) error); ok {
+		r0 = rf(user)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // ChangeAvatar provides a mock function with given fields: header, directory
 func (_m *UserService) ChangeAvatar(header *multipart.FileHeader, directory string) (string, error) {
 	ret := _m.Called(header, directory)
@@ -37,13 +51,13 @@ func (_m *UserService) ChangeAvatar(header *multipart.FileHeader, directory stri
 	return r0, r1
 }
 
-// ChangePassword provides a mock function with given fields: currentPassword, newPassword, user
-func (_m *UserService) ChangePassword(currentPassword string, newPassword string, user *model.User) error {
-	ret := _m.Called(currentPassword, newPassword, user)
+// ChangePassword provides a mock function with given fields: ctx, currentPassword, newPassword, code, user
+func (_m *UserService) ChangePassword(ctx context.Context, currentPassword string, newPassword string, code string, user *model.User) error {
+	ret := _m.Called(ctx, currentPassword, newPassword, code, user)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string, *model.User) error); ok {
-		r0 = rf(currentPassword, newPassword, user)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, *model.User) error); ok {
+		r0 = rf(ctx, currentPassword, newPassword, code, user)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -51,6 +65,71 @@ func (_m *UserService) ChangePassword(currentPassword string, newPassword string
 	return r0
 }
 
+// ConfirmEmailChange provides a mock function with given fields: ctx, token
+func (_m *UserService) ConfirmEmailChange(ctx context.Context, token string) (*model.User, error) {
+	ret := _m.Called(ctx, token)
+
+	var r0 *model.User
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.User); ok {
+		r0 = rf(ctx, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.User)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateLoginSession provides a mock function with given fields: ctx, userId, userAgent, ip
+func (_m *UserService) CreateLoginSession(ctx context.Context, userId string, userAgent string, ip string) (string, error) {
+	ret := _m.Called(ctx, userId, userAgent, ip)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) string); ok {
+		r0 = rf(ctx, userId, userAgent, ip)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, userId, userAgent, ip)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateTwoFactorTicket provides a mock function with given fields: ctx, userId
+func (_m *UserService) CreateTwoFactorTicket(ctx context.Context, userId string) (string, error) {
+	ret := _m.Called(ctx, userId)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, userId)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DeleteImage provides a mock function with given fields: key
 func (_m *UserService) DeleteImage(key string) error {
 	ret := _m.Called(key)
@@ -65,6 +144,57 @@ func (_m *UserService) DeleteImage(key string) error {
 	return r0
 }
 
+// DeleteScheduledAccounts provides a mock function with given fields: ctx
+func (_m *UserService) DeleteScheduledAccounts(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DisableTwoFactor provides a mock function with given fields: ctx, user, password, code
+func (_m *UserService) DisableTwoFactor(ctx context.Context, user *model.User, password string, code string) error {
+	ret := _m.Called(ctx, user, password, code)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.User, string, string) error); ok {
+		r0 = rf(ctx, user, password, code)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EnableTwoFactor provides a mock function with given fields: ctx, user, code
+func (_m *UserService) EnableTwoFactor(ctx context.Context, user *model.User, code string) ([]string, error) {
+	ret := _m.Called(ctx, user, code)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(context.Context, *model.User, string) []string); ok {
+		r0 = rf(ctx, user, code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *model.User, string) error); ok {
+		r1 = rf(ctx, user, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ForgotPassword provides a mock function with given fields: ctx, user
 func (_m *UserService) ForgotPassword(ctx context.Context, user *model.User) error {
 	ret := _m.Called(ctx, user)
@@ -148,6 +278,29 @@ func (_m *UserService) GetFriendAndGuildIds(userId string) (*[]string, error) {
 	return r0, r1
 }
 
+// GetLoginSessions provides a mock function with given fields: ctx, userId, currentId
+func (_m *UserService) GetLoginSessions(ctx context.Context, userId string, currentId string) (*[]model.LoginSession, error) {
+	ret := _m.Called(ctx, userId, currentId)
+
+	var r0 *[]model.LoginSession
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *[]model.LoginSession); ok {
+		r0 = rf(ctx, userId, currentId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]model.LoginSession)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, userId, currentId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetRequestCount provides a mock function with given fields: userId
 func (_m *UserService) GetRequestCount(userId string) (*int64, error) {
 	ret := _m.Called(userId)
@@ -185,13 +338,13 @@ func (_m *UserService) IsEmailAlreadyInUse(email string) bool {
 	return r0
 }
 
-// Login provides a mock function with given fields: email, password
-func (_m *UserService) Login(email string, password string) (*model.User, error) {
-	ret := _m.Called(email, password)
+// Login provides a mock function with given fields: ctx, email, password, ip
+func (_m *UserService) Login(ctx context.Context, email string, password string, ip string) (*model.User, error) {
+	ret := _m.Called(ctx, email, password, ip)
 
 	var r0 *model.User
-	if rf, ok := ret.Get(0).(func(string, string) *model.User); ok {
-		r0 = rf(email, password)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) *model.User); ok {
+		r0 = rf(ctx, email, password, ip)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*model.User)
@@ -199,8 +352,31 @@ func (_m *UserService) Login(email string, password string) (*model.User, error)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(email, password)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, email, password, ip)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RegenerateRecoveryCodes provides a mock function with given fields: ctx, user, code
+func (_m *UserService) RegenerateRecoveryCodes(ctx context.Context, user *model.User, code string) ([]string, error) {
+	ret := _m.Called(ctx, user, code)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(context.Context, *model.User, string) []string); ok {
+		r0 = rf(ctx, user, code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *model.User, string) error); ok {
+		r1 = rf(ctx, user, code)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -231,6 +407,20 @@ func (_m *UserService) Register(user *model.User) (*model.User, error) {
 	return r0, r1
 }
 
+// RequestEmailChange provides a mock function with given fields: ctx, user, email
+func (_m *UserService) RequestEmailChange(ctx context.Context, user *model.User, email string) error {
+	ret := _m.Called(ctx, user, email)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.User, string) error); ok {
+		r0 = rf(ctx, user, email)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // ResetPassword provides a mock function with given fields: ctx, password, token
 func (_m *UserService) ResetPassword(ctx context.Context, password string, token string) (*model.User, error) {
 	ret := _m.Called(ctx, password, token)
@@ -254,6 +444,108 @@ func (_m *UserService) ResetPassword(ctx context.Context, password string, token
 	return r0, r1
 }
 
+// RevertEmailChange provides a mock function with given fields: ctx, token
+func (_m *UserService) RevertEmailChange(ctx context.Context, token string) (*model.User, error) {
+	ret := _m.Called(ctx, token)
+
+	var r0 *model.User
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.User); ok {
+		r0 = rf(ctx, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.User)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevokeLoginSession provides a mock function with given fields: ctx, userId, sessionId
+func (_m *UserService) RevokeLoginSession(ctx context.Context, userId string, sessionId string) error {
+	ret := _m.Called(ctx, userId, sessionId)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userId, sessionId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokeOtherLoginSessions provides a mock function with given fields: ctx, userId, currentId
+func (_m *UserService) RevokeOtherLoginSessions(ctx context.Context, userId string, currentId string) error {
+	ret := _m.Called(ctx, userId, currentId)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userId, currentId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ScheduleDeletion provides a mock function with given fields: ctx, user, password, code
+func (_m *UserService) ScheduleDeletion(ctx context.Context, user *model.User, password string, code string) error {
+	ret := _m.Called(ctx, user, password, code)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.User, string, string) error); ok {
+		r0 = rf(ctx, user, password, code)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SendVerificationMail provides a mock function with given fields: ctx, user
+func (_m *UserService) SendVerificationMail(ctx context.Context, user *model.User) error {
+	ret := _m.Called(ctx, user)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.User) error); ok {
+		r0 = rf(ctx, user)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetupTwoFactor provides a mock function with given fields: user
+func (_m *UserService) SetupTwoFactor(user *model.User) (*model.TwoFactorSetup, error) {
+	ret := _m.Called(user)
+
+	var r0 *model.TwoFactorSetup
+	if rf, ok := ret.Get(0).(func(*model.User) *model.TwoFactorSetup); ok {
+		r0 = rf(user)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.TwoFactorSetup)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*model.User) error); ok {
+		r1 = rf(user)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateAccount provides a mock function with given fields: user
 func (_m *UserService) UpdateAccount(user *model.User) error {
 	ret := _m.Called(user)
@@ -267,3 +559,216 @@ func (_m *UserService) UpdateAccount(user *model.User) error {
 
 	return r0
 }
+
+// UpdatePresence provides a mock function with given fields: ctx, user
+func (_m *UserService) UpdatePresence(ctx context.Context, user *model.User) error {
+	ret := _m.Called(ctx, user)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.User) error); ok {
+		r0 = rf(ctx, user)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ConnectSession provides a mock function with given fields: ctx, userId, sessionId
+func (_m *UserService) ConnectSession(ctx context.Context, userId string, sessionId string) (*model.User, error) {
+	ret := _m.Called(ctx, userId, sessionId)
+
+	var r0 *model.User
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *model.User); ok {
+		r0 = rf(ctx, userId, sessionId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.User)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, userId, sessionId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DisconnectSession provides a mock function with given fields: ctx, userId, sessionId
+func (_m *UserService) DisconnectSession(ctx context.Context, userId string, sessionId string) (*model.User, error) {
+	ret := _m.Called(ctx, userId, sessionId)
+
+	var r0 *model.User
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *model.User); ok {
+		r0 = rf(ctx, userId, sessionId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.User)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, userId, sessionId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExpireSessions provides a mock function with given fields: ctx
+func (_m *UserService) ExpireSessions(ctx context.Context) ([]*model.User, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*model.User
+	if rf, ok := ret.Get(0).(func(context.Context) []*model.User); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.User)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateWSTicket provides a mock function with given fields: ctx, userId
+func (_m *UserService) CreateWSTicket(ctx context.Context, userId string) (string, error) {
+	ret := _m.Called(ctx, userId)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, userId)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RedeemWSTicket provides a mock function with given fields: ctx, ticket
+func (_m *UserService) RedeemWSTicket(ctx context.Context, ticket string) (string, error) {
+	ret := _m.Called(ctx, ticket)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, ticket)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, ticket)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ValidateLoginSession provides a mock function with given fields: ctx, userId, sessionId
+func (_m *UserService) ValidateLoginSession(ctx context.Context, userId string, sessionId string) error {
+	ret := _m.Called(ctx, userId, sessionId)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userId, sessionId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// VerifyEmail provides a mock function with given fields: ctx, token
+func (_m *UserService) VerifyEmail(ctx context.Context, token string) (*model.User, error) {
+	ret := _m.Called(ctx, token)
+
+	var r0 *model.User
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.User); ok {
+		r0 = rf(ctx, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.User)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VerifyPassword provides a mock function with given fields: user, password
+func (_m *UserService) VerifyPassword(user *model.User, password string) error {
+	ret := _m.Called(user, password)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.User, string) error); ok {
+		r0 = rf(user, password)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// VerifyTwoFactor provides a mock function with given fields: ctx, user, code
+func (_m *UserService) VerifyTwoFactor(ctx context.Context, user *model.User, code string) error {
+	ret := _m.Called(ctx, user, code)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.User, string) error); ok {
+		r0 = rf(ctx, user, code)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// VerifyTwoFactorLogin provides a mock function with given fields: ctx, ticket, code, ip
+func (_m *UserService) VerifyTwoFactorLogin(ctx context.Context, ticket string, code string, ip string) (*model.User, error) {
+	ret := _m.Called(ctx, ticket, code, ip)
+
+	var r0 *model.User
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) *model.User); ok {
+		r0 = rf(ctx, ticket, code, ip)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.User)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, ticket, code, ip)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
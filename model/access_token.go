@@ -0,0 +1,96 @@
+package model
+
+import (
+	"github.com/lib/pq"
+	"time"
+)
+
+// Access token scopes. Read allows safe requests, write all others.
+const (
+	ReadScope  = "read"
+	WriteScope = "write"
+)
+
+// AccessTokenPrefix is prepended to every generated access token
+// so leaked tokens are easy to recognize
+const AccessTokenPrefix = "vlk_"
+
+// AccessToken is an API token that authenticates requests as its user.
+// The user is either the owner or one of their bots.
+// Only the sha256 hash of the token gets stored.
+type AccessToken struct {
+	BaseModel
+	Name       string         `gorm:"not null"`
+	TokenHash  string         `gorm:"not null;uniqueIndex"`
+	UserId     string         `gorm:"index;constraint:OnDelete:CASCADE;"`
+	OwnerId    string         `gorm:"index;constraint:OnDelete:CASCADE;"`
+	Scopes     pq.StringArray `gorm:"type:text[]"`
+	ExpiresAt  *time.Time
+	LastUsedAt *time.Time
+}
+
+// AccessTokenResponse is the API response of an AccessToken.
+// Token is only set right after creating it.
+type AccessTokenResponse struct {
+	Id         string     `json:"id"`
+	Name       string     `json:"name"`
+	UserId     string     `json:"userId"`
+	Scopes     []string   `json:"scopes"`
+	ExpiresAt  *time.Time `json:"expiresAt"`
+	LastUsedAt *time.Time `json:"lastUsedAt"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	Token      string     `json:"token,omitempty"`
+} //@name AccessToken
+
+// BotResponse is the API response of a bot account
+type BotResponse struct {
+	Id        string    `json:"id"`
+	Username  string    `json:"username"`
+	Image     string    `json:"image"`
+	CreatedAt time.Time `json:"createdAt"`
+} //@name Bot
+
+// SerializeToken returns the API response of the token.
+func (t AccessToken) SerializeToken() AccessTokenResponse {
+	return AccessTokenResponse{
+		Id:         t.ID,
+		Name:       t.Name,
+		UserId:     t.UserId,
+		Scopes:     t.Scopes,
+		ExpiresAt:  t.ExpiresAt,
+		LastUsedAt: t.LastUsedAt,
+		CreatedAt:  t.CreatedAt,
+	}
+}
+
+// SerializeBot returns the API response of the bot.
+func (u User) SerializeBot() BotResponse {
+	return BotResponse{
+		Id:        u.ID,
+		Username:  u.Username,
+		Image:     u.Image,
+		CreatedAt: u.CreatedAt,
+	}
+}
+
+// TokenService defines methods related to access token and bot operations the handler layer expects
+// any service it interacts with to implement
+type TokenService interface {
+	CreateBot(owner *User, username string) (*User, error)
+	GetBots(ownerId string) (*[]User, error)
+	CreateToken(ownerId string, userId string, name string, scopes []string, expiresAt *time.Time) (*AccessToken, string, error)
+	GetTokens(ownerId string) (*[]AccessToken, error)
+	DeleteToken(ownerId string, tokenId string) error
+	AuthenticateToken(token string, scope string) (*AccessToken, error)
+}
+
+// TokenRepository defines methods related to access token db operations the service layer expects
+// any repository it interacts with to implement
+type TokenRepository interface {
+	FindByID(id string) (*AccessToken, error)
+	FindByHash(hash string) (*AccessToken, error)
+	FindByOwner(ownerId string) (*[]AccessToken, error)
+	Create(token *AccessToken) error
+	Delete(token *AccessToken) error
+	UpdateLastUsed(id string, lastUsedAt time.Time) error
+}
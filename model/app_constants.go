@@ -1,9 +1,30 @@
 package model
 
+import "time"
+
 // Application Constants
 const (
-	MinimumChannels = 1
-	MaximumChannels = 50
-	MaximumGuilds   = 100
-	CookieName      = "vlk"
+	MinimumChannels          = 1
+	MaximumChannels          = 50
+	MaximumGuilds            = 100
+	CookieName               = "vlk"
+	SessionMaxAge            = 60 * 60 * 24 * 7 // 7 days
+	MaximumBots              = 10
+	MaximumTokens            = 25
+	MaximumWebhooks          = 10
+	MaximumEventWebhooks     = 5
+	EventWebhookFailureLimit = 10 // failed deliveries in a row
+	MaximumCommands          = 50
+	DeletedUsername          = "Deleted User"
+)
+
+// AccountDeletionGracePeriod is the time after which a scheduled account deletion
+// gets carried out. Logging in during this time cancels the deletion.
+const AccountDeletionGracePeriod = 14 * 24 * time.Hour
+
+// Data exports can be downloaded for ExportLifetime after they are ready.
+// A user can request a new export once per ExportCooldown.
+const (
+	ExportLifetime = 7 * 24 * time.Hour
+	ExportCooldown = 24 * time.Hour
 )
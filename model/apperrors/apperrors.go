@@ -13,22 +13,91 @@ const (
 	InvalidInviteError     = "Invalid Link or the server got deleted"
 	BannedFromServer       = "You are banned from this server"
 	DeleteGuildError       = "Only the owner can delete their server"
-	OwnerCantLeave         = "The owner cannot leave their server"
+	OwnerCantLeave         = "The owner cannot leave their server. Transfer it to another member first"
 	BanYourselfError       = "You cannot ban yourself"
 	KickYourselfError      = "You cannot kick yourself"
 	UnbanYourselfError     = "You cannot unban yourself"
 	OneChannelRequired     = "A server needs at least one channel"
 	ChannelLimitError      = "The channel limit is 50"
 	DMYourselfError        = "You cannot dm yourself"
+	NotAVoiceChannel       = "The channel is not a voice channel"
+	NotInVoiceChannel      = "You are not connected to a voice channel"
+	NotInSameVoiceChannel  = "The user is not in your voice channel"
+	VoiceChannelMessage    = "You cannot send messages in a voice channel"
+	WebhookLimitReached    = "A channel can have at most 10 webhooks"
+	InvalidWebhookToken    = "Invalid webhook token"
+	WebhookChannelError    = "Webhooks can only be added to text channels of a server"
+	EditWebhookMessage     = "Messages of webhooks cannot be edited"
+	EventWebhookLimit      = "A server can have at most 5 event webhooks"
+	TransferYourselfError  = "You already own this server"
+	TransferToBotError     = "Bots cannot own a server"
+)
+
+// Direct Message Errors
+const (
+	NotAGroupDM        = "The channel is not a group DM"
+	GroupDMLimitError  = "A group DM can have at most 10 members"
+	GroupDMFriendsOnly = "You can only add friends to a group DM"
+	AlreadyGroupMember = "The user is already a member of the group DM"
+	NotAGroupMember    = "The user is not a member of the group DM"
+	DMPrivacyError     = "This user does not accept direct messages from you"
 )
 
 // Account Errors
 const (
-	InvalidOldPassword  = "Invalid old password"
-	InvalidCredentials  = "Invalid email and password combination"
-	DuplicateEmail      = "An account with that email already exists"
-	PasswordsDoNotMatch = "Passwords do not match"
-	InvalidResetToken   = "Invalid reset token"
+	InvalidOldPassword       = "Invalid old password"
+	InvalidCredentials       = "Invalid email and password combination"
+	DuplicateEmail           = "An account with that email already exists"
+	PasswordsDoNotMatch      = "Passwords do not match"
+	InvalidResetToken        = "Invalid reset token"
+	InvalidWSTicket          = "Invalid or expired websocket ticket"
+	InvalidVerificationToken = "Invalid or expired verification token"
+	EmailAlreadyVerified     = "Your email is already verified"
+	EmailNotVerified         = "You need to verify your email first"
+	InvalidEmailChangeToken  = "Invalid or expired email change token"
+	TwoFactorRequired        = "A two-factor authentication code is required"
+	InvalidTwoFactorCode     = "Invalid two-factor authentication code"
+	InvalidTwoFactorTicket   = "Invalid or expired login, please log in again"
+	TwoFactorAlreadyEnabled  = "Two-factor authentication is already enabled"
+	TwoFactorNotEnabled      = "Two-factor authentication is not enabled"
+	TwoFactorNotSetup        = "Set up two-factor authentication first"
+	LoginLocked              = "Too many failed login attempts. Try again later"
+	InvalidPassword          = "Invalid password"
+	PasswordNotSet           = "Set a password for your account first"
+	ExportInProgress         = "Your data export is still being prepared"
+	ExportCooldown           = "You can request one data export per day"
+)
+
+// Identity Errors
+const (
+	OIDCNotConfigured     = "Login with an identity provider is not enabled"
+	InvalidOIDCState      = "Invalid or expired login, please try again"
+	InvalidOIDCLogin      = "The identity provider could not verify your login"
+	OIDCEmailRequired     = "The identity provider did not share your email"
+	OIDCEmailNotVerified  = "Verify your email here and at the identity provider to link your account"
+	IdentityAlreadyLinked = "This identity is already linked to another account"
+	LastLoginMethod       = "Set a password before removing your last identity"
+)
+
+// Access Token Errors
+const (
+	InvalidAccessToken = "Invalid or expired access token"
+	MissingTokenScope  = "The access token is missing the required scope"
+	SessionRequired    = "This action requires you to be logged in"
+	BotLimitReached    = "You can own at most 10 bots"
+	TokenLimitReached  = "You can have at most 25 access tokens"
+)
+
+// Command Errors
+const (
+	CommandLimitReached    = "A server can have at most 50 commands"
+	CommandBotsOnly        = "Only bots can register commands"
+	MissingCommandOption   = "Missing required option: %s"
+	UnknownCommandOption   = "Unknown option: %s"
+	InvalidCommandOption   = "Option %s must be of type %s"
+	DuplicateCommandOption = "Option names must be unique"
+	CommandOptionOrder     = "Required options must come before optional ones"
+	CommandFailed          = "The command did not respond"
 )
 
 // Friend Errors
@@ -40,6 +109,9 @@ const (
 	UnableAddError      = "Unable to add user as friend. Try again later"
 	UnableRemoveError   = "Unable to remove the user. Try again later"
 	UnableAcceptError   = "Unable to accept the request. Try again later"
+	BlockYourselfError  = "You cannot block yourself"
+	UnableBlockError    = "Unable to block the user. Try again later"
+	BlockedUserError    = "You cannot interact with this user"
 )
 
 // Generic Errors
@@ -56,3 +128,14 @@ const (
 	DeleteMessageError    = "Only the author or owner can delete the message"
 	DeleteDMMessageError  = "Only the author can delete the message"
 )
+
+// Websocket Errors
+const (
+	InvalidEncoding  = "encoding must be 'json' or 'msgpack'"
+	InvalidWSMessage = "could not decode the message"
+	UnknownAction    = "unknown action"
+	MissingRoom      = "room is required for this action"
+	MissingSignal    = "target and signal are required for this action"
+	ForeignUserRoom  = "you can only join your own user room"
+	RateLimited      = "too many actions, try again later"
+)
@@ -3,7 +3,9 @@ package apperrors
 import (
 	"errors"
 	"fmt"
+	"math"
 	"net/http"
+	"time"
 )
 
 // Type holds a type string and integer code for the error
@@ -18,6 +20,7 @@ const (
 	NotFound             Type = "NOTFOUND"             // For not finding resource
 	PayloadTooLarge      Type = "PAYLOADTOOLARGE"      // for uploading tons of JSON, or an image over the limit - 413
 	ServiceUnavailable   Type = "SERVICE_UNAVAILABLE"  // For long running handlers
+	TooManyRequests      Type = "TOO_MANY_REQUESTS"    // For rate limited or locked actions - 429
 	UnsupportedMediaType Type = "UNSUPPORTEDMEDIATYPE" // for http 415
 )
 
@@ -27,6 +30,8 @@ const (
 type Error struct {
 	Type    Type   `json:"type"`
 	Message string `json:"message"`
+	// Seconds until the action can be retried. Only set for 429 errors
+	RetryAfter int `json:"retryAfter,omitempty"`
 }
 
 // Error satisfies standard error interface
@@ -55,6 +60,8 @@ func (e *Error) Status() int {
 		return http.StatusRequestEntityTooLarge
 	case ServiceUnavailable:
 		return http.StatusServiceUnavailable
+	case TooManyRequests:
+		return http.StatusTooManyRequests
 	case UnsupportedMediaType:
 		return http.StatusUnsupportedMediaType
 	default:
@@ -133,6 +140,16 @@ func NewServiceUnavailable() *Error {
 	}
 }
 
+// NewTooManyRequests to create an error for 429 that tells
+// the client when to retry
+func NewTooManyRequests(reason string, retryAfter time.Duration) *Error {
+	return &Error{
+		Type:       TooManyRequests,
+		Message:    reason,
+		RetryAfter: int(math.Ceil(retryAfter.Seconds())),
+	}
+}
+
 // NewUnsupportedMediaType to create an error for 415
 func NewUnsupportedMediaType(reason string) *Error {
 	return &Error{
@@ -1,17 +1,31 @@
 package model
 
-import "time"
+import (
+	"context"
+	"time"
+)
 
-// Channel represents a text channel in a guild
+// Channel Types
+const (
+	TextChannel  = "text"
+	VoiceChannel = "voice"
+)
+
+// Channel represents a text or voice channel in a guild
 // or a text channel for DMs between users.
 // GuildID should only be nil if it is a DM channel
 // PCMembers should only be used if the channel is private.
+// OwnerId and Icon are only used by group DMs.
 type Channel struct {
 	BaseModel
-	GuildID      *string   `gorm:"index"`
-	Name         string    `gorm:"name"`
-	IsPublic     bool      `gorm:"index"`
-	IsDM         bool      `gorm:"is_dm"`
+	GuildID      *string `gorm:"index"`
+	Name         string  `gorm:"name"`
+	Type         string  `gorm:"not null;default:text"`
+	IsPublic     bool    `gorm:"index"`
+	IsDM         bool    `gorm:"is_dm"`
+	IsGroup      bool    `gorm:"default:false"`
+	OwnerId      *string
+	Icon         *string
 	LastActivity time.Time `gorm:"autoCreateTime"`
 	PCMembers    []User    `gorm:"many2many:pcmembers;constraint:OnDelete:CASCADE;"`
 	Messages     []Message `gorm:"constraint:OnDelete:CASCADE;"`
@@ -21,6 +35,7 @@ type Channel struct {
 type ChannelResponse struct {
 	Id              string    `json:"id"`
 	Name            string    `json:"name"`
+	Type            string    `json:"type"`
 	IsPublic        bool      `json:"isPublic"`
 	CreatedAt       time.Time `json:"createdAt"`
 	UpdatedAt       time.Time `json:"updatedAt"`
@@ -32,6 +47,7 @@ func (c Channel) SerializeChannel() ChannelResponse {
 	return ChannelResponse{
 		Id:              c.ID,
 		Name:            c.Name,
+		Type:            c.Type,
 		IsPublic:        c.IsPublic,
 		CreatedAt:       c.CreatedAt,
 		UpdatedAt:       c.UpdatedAt,
@@ -47,10 +63,16 @@ type ChannelService interface {
 	Get(channelId string) (*Channel, error)
 	GetPrivateChannelMembers(channelId string) (*[]string, error)
 	GetDirectMessages(userId string) (*[]DirectMessage, error)
+	GetDirectMessage(channelId string, userId string) (*DirectMessage, error)
 	GetDirectMessageChannel(userId string, memberId string) (*string, error)
 	GetDMByUserAndChannel(userId string, channelId string) (string, error)
+	GetDMMemberIds(channelId string) (*[]string, error)
 	AddDMChannelMembers(memberIds []string, channelId string, userId string) error
+	RemoveDMChannelMember(channelId string, userId string) error
 	SetDirectMessageStatus(dmId string, userId string, isOpen bool) error
+	GetDMRequests(userId string) (*[]DirectMessage, error)
+	SetDirectMessageRequest(dmId string, userId string, isRequest bool) error
+	AllowsDirectMessage(user *User, senderId string) (bool, error)
 	DeleteChannel(channel *Channel) error
 	UpdateChannel(channel *Channel) error
 	CleanPCMembers(channelId string) error
@@ -58,6 +80,13 @@ type ChannelService interface {
 	RemovePrivateChannelMembers(memberIds []string, channelId string) error
 	IsChannelMember(channel *Channel, userId string) error
 	OpenDMForAll(dmId string) error
+	JoinVoiceChannel(ctx context.Context, state *VoiceState) (*VoiceState, error)
+	LeaveVoiceChannel(ctx context.Context, userId string) (*VoiceState, error)
+	UpdateVoiceState(ctx context.Context, userId string, muted, deafened *bool) (*VoiceState, error)
+	GetVoiceState(ctx context.Context, userId string) (*VoiceState, error)
+	GetVoiceParticipants(ctx context.Context, channelId string) (*[]VoiceState, error)
+	RefreshVoiceState(ctx context.Context, userId string) error
+	ExpireVoiceStates(ctx context.Context) (*[]VoiceState, error)
 }
 
 // ChannelRepository defines methods related to channel db operations the service layer expects
@@ -67,11 +96,15 @@ type ChannelRepository interface {
 	GetGuildDefault(guildId string) (*Channel, error)
 	Get(userId string, guildId string) (*[]ChannelResponse, error)
 	GetDirectMessages(userId string) (*[]DirectMessage, error)
+	GetDirectMessage(channelId string, userId string) (*DirectMessage, error)
 	GetDirectMessageChannel(userId string, memberId string) (*string, error)
 	GetById(channelId string) (*Channel, error)
 	GetPrivateChannelMembers(channelId string) (*[]string, error)
 	AddDMChannelMembers(members []DMMember) error
+	RemoveDMChannelMember(channelId string, userId string) error
 	SetDirectMessageStatus(dmId string, userId string, isOpen bool) error
+	GetDMRequests(userId string) (*[]DirectMessage, error)
+	SetDirectMessageRequest(dmId string, userId string, isRequest bool) error
 	DeleteChannel(channel *Channel) error
 	UpdateChannel(channel *Channel) error
 	CleanPCMembers(channelId string) error
@@ -0,0 +1,162 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// Types a command option can have
+const (
+	StringOption  = "string"
+	IntegerOption = "integer"
+	NumberOption  = "number"
+	BooleanOption = "boolean"
+)
+
+// Types of responses a command callback can return
+const (
+	// MessageInteractionResponse posts the text right away
+	MessageInteractionResponse = "message"
+	// DeferredInteractionResponse acknowledges the command.
+	// The bot posts the text later using the interaction id.
+	DeferredInteractionResponse = "deferred"
+)
+
+// Command is a slash command a bot registered in a guild.
+// Members invoke it by sending "/name options..." in a channel of the guild,
+// which posts an interaction to the callback URL of the command.
+type Command struct {
+	BaseModel
+	GuildId     string         `gorm:"uniqueIndex:idx_guild_command;constraint:OnDelete:CASCADE;"`
+	BotId       string         `gorm:"index;not null"`
+	Name        string         `gorm:"uniqueIndex:idx_guild_command;not null"`
+	Description string         `gorm:"not null"`
+	Options     CommandOptions `gorm:"type:jsonb"`
+	CallbackUrl string         `gorm:"not null"`
+	Secret      string         `gorm:"not null"`
+}
+
+// CommandOption is a typed argument of a command
+type CommandOption struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// string, integer, number or boolean
+	Type     string `json:"type"`
+	Required bool   `json:"required"`
+} //@name CommandOption
+
+// CommandOptions stores the options of a command as JSON
+type CommandOptions []CommandOption
+
+// Value implements the driver.Valuer interface
+func (o CommandOptions) Value() (driver.Value, error) {
+	if o == nil {
+		return "[]", nil
+	}
+	value, err := json.Marshal(o)
+	return string(value), err
+}
+
+// Scan implements the sql.Scanner interface
+func (o *CommandOptions) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, o)
+	case string:
+		return json.Unmarshal([]byte(v), o)
+	case nil:
+		*o = nil
+		return nil
+	}
+	return errors.New("unsupported type for command options")
+}
+
+// CommandResponse is the API response of a Command.
+// Secret is only set right after creating the command.
+type CommandResponse struct {
+	Id          string          `json:"id"`
+	GuildId     string          `json:"guildId"`
+	BotId       string          `json:"botId"`
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	Options     []CommandOption `json:"options"`
+	CreatedAt   time.Time       `json:"createdAt"`
+	UpdatedAt   time.Time       `json:"updatedAt"`
+	Secret      string          `json:"secret,omitempty"`
+} //@name Command
+
+// SerializeCommand returns the API response of the command.
+func (c Command) SerializeCommand() CommandResponse {
+	options := make([]CommandOption, 0)
+	options = append(options, c.Options...)
+
+	return CommandResponse{
+		Id:          c.ID,
+		GuildId:     c.GuildId,
+		BotId:       c.BotId,
+		Name:        c.Name,
+		Description: c.Description,
+		Options:     options,
+		CreatedAt:   c.CreatedAt,
+		UpdatedAt:   c.UpdatedAt,
+	}
+}
+
+// Interaction is a single invocation of a command.
+// It is kept for a while so the bot can post a deferred response.
+type Interaction struct {
+	Id        string `json:"id"`
+	CommandId string `json:"commandId"`
+	BotId     string `json:"botId"`
+	GuildId   string `json:"guildId"`
+	ChannelId string `json:"channelId"`
+	UserId    string `json:"userId"`
+}
+
+// InteractionPayload is the body posted to the callback URL of a command
+type InteractionPayload struct {
+	Id          string                 `json:"id"`
+	CommandId   string                 `json:"commandId"`
+	CommandName string                 `json:"commandName"`
+	GuildId     string                 `json:"guildId"`
+	ChannelId   string                 `json:"channelId"`
+	User        MemberResponse         `json:"user"`
+	Options     map[string]interface{} `json:"options"`
+	CreatedAt   time.Time              `json:"createdAt"`
+} //@name InteractionPayload
+
+// InteractionResponse is the body the callback URL responds with
+type InteractionResponse struct {
+	// message or deferred
+	Type string `json:"type"`
+	// The text of the message. Only used by message responses
+	Text string `json:"text"`
+} //@name InteractionResponse
+
+// CommandService defines methods related to command operations the handler layer expects
+// any service it interacts with to implement
+type CommandService interface {
+	Get(id string) (*Command, error)
+	GetGuildCommands(guildId string, query string) (*[]Command, error)
+	FindCommand(guildId string, name string) (*Command, error)
+	CreateCommand(command *Command) (*Command, error)
+	UpdateCommand(command *Command) error
+	DeleteCommand(command *Command) error
+	ParseOptions(command *Command, input string) (map[string]interface{}, error)
+	Invoke(command *Command, channelId string, user *User, options map[string]interface{}) (*InteractionResponse, error)
+	GetInteraction(id string) (*Interaction, error)
+	CompleteInteraction(id string) error
+}
+
+// CommandRepository defines methods related to command db operations the service layer expects
+// any repository it interacts with to implement
+type CommandRepository interface {
+	FindByID(id string) (*Command, error)
+	FindByGuild(guildId string, prefix string) (*[]Command, error)
+	FindByName(guildId string, name string) (*Command, error)
+	Create(command *Command) (*Command, error)
+	Update(command *Command) error
+	Delete(command *Command) error
+}
@@ -1,13 +1,33 @@
 package model
 
-// DirectMessage is the json response of the channel ID
-// and the other user of the DM.
+// MaximumGroupDMMembers is the max amount of users in a group DM
+const MaximumGroupDMMembers = 10
+
+// DM Privacy Settings decide who besides friends can start a DM with the user.
+// Members can override the setting for each guild.
+const (
+	DMPrivacyEveryone     = "everyone"
+	DMPrivacyGuildMembers = "guild_members"
+	DMPrivacyFriends      = "friends"
+)
+
+// DirectMessage is the json response of a DM or group DM channel.
+// User is the other member of a two person DM and nil for group DMs.
+// Members contains all members except the current user.
+// IsRequest is true if a non friend started the DM and the current
+// user has not accepted it yet.
 type DirectMessage struct {
-	Id   string `json:"id"`
-	User DMUser `json:"user"`
+	Id        string   `json:"id"`
+	User      *DMUser  `json:"user,omitempty"`
+	Name      *string  `json:"name"`
+	Icon      *string  `json:"icon"`
+	IsGroup   bool     `json:"isGroup"`
+	OwnerId   *string  `json:"ownerId"`
+	Members   []DMUser `json:"members"`
+	IsRequest bool     `json:"isRequest"`
 } //@name DirectMessage
 
-// DMUser is the other member of the DM.
+// DMUser is another member of the DM.
 type DMUser struct {
 	Id       string `json:"id"`
 	Username string `json:"username"`
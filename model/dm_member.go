@@ -4,11 +4,14 @@ import "time"
 
 // DMMember represents a member of a DM channel.
 // IsOpen indicates if the DM is open on the client.
+// IsRequest indicates that a non friend started the DM and
+// the member has not accepted it yet.
 type DMMember struct {
 	ID        string `gorm:"primaryKey"`
 	UserID    string `gorm:"primaryKey"`
 	ChannelId string `gorm:"primaryKey;"`
 	IsOpen    bool
+	IsRequest bool      `gorm:"default:false"`
 	CreatedAt time.Time `gorm:"index"`
 	UpdatedAt time.Time
 }
@@ -0,0 +1,124 @@
+package model
+
+import (
+	"github.com/lib/pq"
+	"time"
+)
+
+// Guild event types event webhooks can subscribe to
+const (
+	MessageCreatedEvent = "message.created"
+	MessageUpdatedEvent = "message.updated"
+	MessageDeletedEvent = "message.deleted"
+	MemberJoinedEvent   = "member.joined"
+	MemberLeftEvent     = "member.left"
+	ChannelCreatedEvent = "channel.created"
+	ChannelUpdatedEvent = "channel.updated"
+	ChannelDeletedEvent = "channel.deleted"
+)
+
+// EventWebhook is an HTTP callback that receives the subscribed events of a guild.
+// Deliveries are signed with the secret. The webhook gets disabled
+// after too many deliveries failed in a row.
+type EventWebhook struct {
+	BaseModel
+	GuildId      string         `gorm:"index;constraint:OnDelete:CASCADE;"`
+	UserId       string         `gorm:"not null"`
+	Url          string         `gorm:"not null"`
+	Secret       string         `gorm:"not null"`
+	Events       pq.StringArray `gorm:"type:text[]"`
+	Enabled      bool           `gorm:"not null;default:true"`
+	FailureCount int            `gorm:"not null;default:0"`
+}
+
+// EventWebhookResponse is the API response of an EventWebhook.
+// Secret is only set right after creating the webhook.
+type EventWebhookResponse struct {
+	Id           string    `json:"id"`
+	GuildId      string    `json:"guildId"`
+	Url          string    `json:"url"`
+	Events       []string  `json:"events"`
+	Enabled      bool      `json:"enabled"`
+	FailureCount int       `json:"failureCount"`
+	CreatedAt    time.Time `json:"createdAt"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+	Secret       string    `json:"secret,omitempty"`
+} //@name EventWebhook
+
+// SerializeEventWebhook returns the API response of the event webhook.
+func (w EventWebhook) SerializeEventWebhook() EventWebhookResponse {
+	return EventWebhookResponse{
+		Id:           w.ID,
+		GuildId:      w.GuildId,
+		Url:          w.Url,
+		Events:       w.Events,
+		Enabled:      w.Enabled,
+		FailureCount: w.FailureCount,
+		CreatedAt:    w.CreatedAt,
+		UpdatedAt:    w.UpdatedAt,
+	}
+}
+
+// Subscribes checks if the webhook wants to receive the given event
+func (w EventWebhook) Subscribes(event string) bool {
+	for _, e := range w.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// EventPayload is the body of an event delivery.
+// The Id stays the same for all attempts of a delivery.
+type EventPayload struct {
+	Id        string      `json:"id"`
+	Event     string      `json:"event"`
+	GuildId   string      `json:"guildId"`
+	ChannelId string      `json:"channelId,omitempty"`
+	CreatedAt time.Time   `json:"createdAt"`
+	Data      interface{} `json:"data"`
+} //@name EventPayload
+
+// EventDelivery logs a single delivery attempt of an event webhook
+type EventDelivery struct {
+	ID             uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	CreatedAt      time.Time `json:"createdAt"`
+	EventWebhookId string    `gorm:"index;constraint:OnDelete:CASCADE;" json:"-"`
+	// The id of the payload. Retries share it
+	DeliveryId string `gorm:"index" json:"deliveryId"`
+	Event      string `json:"event"`
+	Attempt    int    `json:"attempt"`
+	StatusCode int    `json:"statusCode"`
+	Error      string `json:"error"`
+	Success    bool   `json:"success"`
+	// Milliseconds the receiver took to respond
+	Duration int64 `json:"duration"`
+} //@name EventDelivery
+
+// EventService defines methods related to event webhook operations the handler layer expects
+// any service it interacts with to implement
+type EventService interface {
+	Get(id string) (*EventWebhook, error)
+	GetGuildEventWebhooks(guildId string) (*[]EventWebhook, error)
+	CreateEventWebhook(webhook *EventWebhook) (*EventWebhook, error)
+	UpdateEventWebhook(webhook *EventWebhook) error
+	DeleteEventWebhook(webhook *EventWebhook) error
+	GetDeliveries(webhookId string) (*[]EventDelivery, error)
+	Dispatch(guildId string, event string, data interface{})
+	DispatchChannelEvent(channelId string, event string, data interface{})
+}
+
+// EventRepository defines methods related to event webhook db operations the service layer expects
+// any repository it interacts with to implement
+type EventRepository interface {
+	FindByID(id string) (*EventWebhook, error)
+	FindByGuild(guildId string) (*[]EventWebhook, error)
+	Create(webhook *EventWebhook) (*EventWebhook, error)
+	Update(webhook *EventWebhook) error
+	Delete(webhook *EventWebhook) error
+	ResetFailures(id string) error
+	AddFailure(id string, limit int) error
+	CreateDelivery(delivery *EventDelivery) error
+	FindDeliveries(webhookId string) (*[]EventDelivery, error)
+}
@@ -0,0 +1,87 @@
+package model
+
+import (
+	"time"
+)
+
+// Data Export Statuses
+const (
+	ExportPending = "pending"
+	ExportReady   = "ready"
+	ExportFailed  = "failed"
+	// The archive got removed and has to be requested again
+	ExportExpired = "expired"
+)
+
+// DataExport is an archive of all data stored about a user.
+// The archive gets built in the background and can be
+// downloaded until it expires.
+type DataExport struct {
+	BaseModel
+	UserId string `gorm:"not null;index;constraint:OnDelete:CASCADE;"`
+	Status string `gorm:"not null;default:pending"`
+	// Key of the archive in the bucket. Empty until the archive is ready
+	Key       string
+	ExpiresAt *time.Time
+}
+
+// DataExportResponse is the API response of a DataExport
+type DataExportResponse struct {
+	Id        string    `json:"id"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"createdAt"`
+	// Set once the archive is ready
+	ExpiresAt *time.Time `json:"expiresAt"`
+	// Download link of the archive. Only set while the archive is ready
+	Url *string `json:"url"`
+} //@name DataExport
+
+// ExportedMessage is a message of the user as it appears in the archive
+type ExportedMessage struct {
+	Id         string      `json:"id"`
+	Text       *string     `json:"text"`
+	Type       string      `json:"type"`
+	CreatedAt  time.Time   `json:"createdAt"`
+	UpdatedAt  time.Time   `json:"updatedAt"`
+	Attachment *Attachment `json:"attachment"`
+}
+
+// ExportedAttachment is the link to a file the user uploaded
+type ExportedAttachment struct {
+	MessageId string `json:"messageId"`
+	ChannelId string `json:"channelId"`
+	Url       string `json:"url"`
+	FileType  string `json:"filetype"`
+	Filename  string `json:"filename"`
+}
+
+// SerializeExport returns the API response of the export.
+// The url is only set if the archive can be downloaded.
+func (e DataExport) SerializeExport(url *string) DataExportResponse {
+	return DataExportResponse{
+		Id:        e.ID,
+		Status:    e.Status,
+		CreatedAt: e.CreatedAt,
+		ExpiresAt: e.ExpiresAt,
+		Url:       url,
+	}
+}
+
+// ExportService defines methods related to data exports the handler layer expects
+// any service it interacts with to implement
+type ExportService interface {
+	RequestExport(user *User) (*DataExport, error)
+	GetLatestExport(userId string) (*DataExport, error)
+	GetDownloadURL(export *DataExport) (*string, error)
+	ProcessExports() error
+}
+
+// ExportRepository defines methods related to data export db operations the service layer expects
+// any repository it interacts with to implement
+type ExportRepository interface {
+	FindLatest(userId string) (*DataExport, error)
+	FindPending() (*[]DataExport, error)
+	FindExpired(before time.Time) (*[]DataExport, error)
+	Create(export *DataExport) error
+	Save(export *DataExport) error
+}
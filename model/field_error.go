@@ -24,4 +24,6 @@ type HttpError struct {
 	Type string `json:"type"`
 	// The specific error message
 	Message string `json:"message"`
+	// Seconds until the request can be retried. Only set for 429 responses
+	RetryAfter int `json:"retryAfter,omitempty"`
 } //@name HttpError
@@ -22,6 +22,7 @@ func GetMockChannel(guildId string) *model.Channel {
 		GuildID:      guild,
 		Name:         RandStr(8),
 		IsPublic:     true,
+		Type:         model.TextChannel,
 		LastActivity: time.Now(),
 	}
 }
@@ -36,6 +37,7 @@ func GetMockDMChannel() *model.Channel {
 		},
 		Name:         RandID(),
 		IsDM:         true,
+		Type:         model.TextChannel,
 		LastActivity: time.Now(),
 	}
 }
@@ -21,6 +21,7 @@ func GetMockMessage(uid, cid string) *model.Message {
 			UpdatedAt: time.Now(),
 		},
 		Text:       &text,
+		Type:       model.DefaultMessage,
 		UserId:     ownerId,
 		ChannelId:  cid,
 		Attachment: nil,
@@ -35,6 +36,7 @@ func GetMockMessageResponse(uid, cid string) *model.MessageResponse {
 	return &model.MessageResponse{
 		Id:         message.ID,
 		Text:       message.Text,
+		Type:       message.Type,
 		CreatedAt:  message.CreatedAt,
 		UpdatedAt:  message.UpdatedAt,
 		Attachment: message.Attachment,
@@ -14,9 +14,10 @@ func GetMockUser() *model.User {
 			CreatedAt: time.Now(),
 			UpdatedAt: time.Now(),
 		},
-		Username: Username(),
-		Email:    email,
-		Password: RandStr(8),
-		Image:    generateAvatar(email),
+		Username:      Username(),
+		Email:         email,
+		EmailVerified: true,
+		Password:      RandStr(8),
+		Image:         generateAvatar(email),
 	}
 }
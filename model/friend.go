@@ -6,8 +6,16 @@ type Friend struct {
 	Username string `json:"username"`
 	Image    string `json:"image"`
 	IsOnline bool   `json:"isOnline"`
+	Status   string `json:"status"`
 } //@name Friend
 
+// BlockedUser represents the api response of a user the current user blocked.
+type BlockedUser struct {
+	Id       string `json:"id"`
+	Username string `json:"username"`
+	Image    string `json:"image"`
+} //@name BlockedUser
+
 // FriendService defines methods related to friend operations the handler layer expects
 // any service it interacts with to implement
 type FriendService interface {
@@ -17,6 +25,10 @@ type FriendService interface {
 	DeleteRequest(memberId string, userId string) error
 	RemoveFriend(memberId string, userId string) error
 	SaveRequests(user *User) error
+	GetBlockedUsers(id string) (*[]BlockedUser, error)
+	BlockUser(userId string, memberId string) error
+	UnblockUser(userId string, memberId string) error
+	IsBlocked(userId string, memberId string) (bool, error)
 }
 
 // FriendRepository defines methods related to friend db operations the service layer expects
@@ -28,4 +40,9 @@ type FriendRepository interface {
 	DeleteRequest(memberId string, userId string) error
 	RemoveFriend(memberId string, userId string) error
 	Save(user *User) error
+	BlockList(id string) (*[]BlockedUser, error)
+	Block(userId string, memberId string) error
+	Unblock(userId string, memberId string) error
+	IsBlocked(userId string, memberId string) (bool, error)
+	HasBlocked(userId string, memberId string) (bool, error)
 }
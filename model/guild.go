@@ -47,6 +47,14 @@ func (g Guild) SerializeGuild(channelId string) GuildResponse {
 	}
 }
 
+// GuildTransfer is emitted to the previous and the new owner
+// after the ownership of a guild got transferred
+type GuildTransfer struct {
+	GuildId    string `json:"guildId"`
+	OldOwnerId string `json:"oldOwnerId"`
+	NewOwnerId string `json:"newOwnerId"`
+} //@name GuildTransfer
+
 // GuildService defines methods related to guild operations the handler layer expects
 // any service it interacts with to implement
 type GuildService interface {
@@ -85,8 +93,10 @@ type GuildRepository interface {
 	GetBanList(guildId string) (*[]BanResponse, error)
 	GetMemberSettings(userId string, guildId string) (*MemberSettings, error)
 	UpdateMemberSettings(settings *MemberSettings, userId string, guildId string) error
+	GetSharedMemberSettings(userId string, memberId string) (*[]MemberSettings, error)
 	FindUsersByIds(ids []string, guildId string) (*[]User, error)
 	GetMember(userId, guildId string) (*User, error)
 	UpdateMemberLastSeen(userId, guildId string) error
 	GetMemberIds(guildId string) (*[]string, error)
+	FindNextOwner(guildId string, ownerId string) (*User, error)
 }
@@ -0,0 +1,69 @@
+package model
+
+import (
+	"context"
+	"time"
+)
+
+// UserIdentity links an account of an OpenID Connect provider to a user.
+// The subject is the stable ID of the account at the issuer.
+type UserIdentity struct {
+	BaseModel
+	UserId  string `gorm:"not null;index;constraint:OnDelete:CASCADE;"`
+	Issuer  string `gorm:"not null;uniqueIndex:idx_issuer_subject"`
+	Subject string `gorm:"not null;uniqueIndex:idx_issuer_subject"`
+	Email   string
+}
+
+// IdentityResponse is the API response of a UserIdentity
+type IdentityResponse struct {
+	Id        string    `json:"id"`
+	Issuer    string    `json:"issuer"`
+	Email     string    `json:"email"`
+	CreatedAt time.Time `json:"createdAt"`
+} //@name Identity
+
+// OIDCAuthRequest contains the values of a started login at the identity provider.
+// State, Nonce and Verifier must be kept until the provider redirects back.
+type OIDCAuthRequest struct {
+	URL      string
+	State    string
+	Nonce    string
+	Verifier string
+}
+
+// OIDCAuthorization contains the URL of the provider the user has to be sent to
+type OIDCAuthorization struct {
+	URL string `json:"url"`
+} //@name OIDCAuthorization
+
+// SerializeIdentity returns the API response of the identity.
+func (i UserIdentity) SerializeIdentity() IdentityResponse {
+	return IdentityResponse{
+		Id:        i.ID,
+		Issuer:    i.Issuer,
+		Email:     i.Email,
+		CreatedAt: i.CreatedAt,
+	}
+}
+
+// IdentityService defines methods related to OpenID Connect login the handler layer expects
+// any service it interacts with to implement
+type IdentityService interface {
+	Enabled() bool
+	CreateAuthRequest(ctx context.Context) (*OIDCAuthRequest, error)
+	Login(ctx context.Context, code, verifier, nonce string) (*User, error)
+	Link(ctx context.Context, user *User, code, verifier, nonce string) (*UserIdentity, error)
+	GetIdentities(userId string) (*[]UserIdentity, error)
+	Unlink(user *User, identityId string) error
+}
+
+// IdentityRepository defines methods related to identity db operations the service layer expects
+// any repository it interacts with to implement
+type IdentityRepository interface {
+	FindByID(id string) (*UserIdentity, error)
+	FindBySubject(issuer string, subject string) (*UserIdentity, error)
+	FindByUser(userId string) (*[]UserIdentity, error)
+	Create(identity *UserIdentity) error
+	Delete(identity *UserIdentity) error
+}
@@ -2,7 +2,9 @@ package model
 
 import (
 	"context"
+	"io"
 	"mime/multipart"
+	"time"
 )
 
 // FileRepository defines methods related to file upload the service layer expects
@@ -11,12 +13,19 @@ type FileRepository interface {
 	UploadAvatar(header *multipart.FileHeader, directory string) (string, error)
 	UploadFile(header *multipart.FileHeader, directory, filename, mimetype string) (string, error)
 	DeleteImage(key string) error
+	UploadExport(body io.Reader, directory, filename string) (string, error)
+	GetSignedURL(key string, expires time.Duration) (string, error)
 }
 
 // MailRepository defines methods related to mail operations the service layer expects
 // any repository it interacts with to implement
 type MailRepository interface {
 	SendResetMail(email string, html string) error
+	SendVerificationMail(email string, token string) error
+	SendEmailChangeMail(email string, token string) error
+	SendEmailChangedMail(email string, newEmail string, token string) error
+	SendLockoutMail(email string) error
+	SendExportReadyMail(email string, url string) error
 }
 
 // RedisRepository defines methods related to the redis db the service layer expects
@@ -24,7 +33,45 @@ type MailRepository interface {
 type RedisRepository interface {
 	SetResetToken(ctx context.Context, id string) (string, error)
 	GetIdFromToken(ctx context.Context, token string) (string, error)
+	SetVerificationToken(ctx context.Context, id string) (string, error)
+	GetIdFromVerificationToken(ctx context.Context, token string) (string, error)
+	SetEmailChangeToken(ctx context.Context, change *EmailChange) (string, error)
+	GetEmailChange(ctx context.Context, token string) (*EmailChange, error)
+	DeleteEmailChange(ctx context.Context, userId string) error
+	SetEmailRevertToken(ctx context.Context, change *EmailChange) (string, error)
+	GetEmailRevert(ctx context.Context, token string) (*EmailChange, error)
+	AddFailedLogin(ctx context.Context, key string, window time.Duration) (int64, error)
+	ResetFailedLogins(ctx context.Context, key string) error
+	SetLoginLockout(ctx context.Context, key string, duration time.Duration) error
+	GetLoginLockout(ctx context.Context, key string) (time.Duration, error)
+	SetTwoFactorTicket(ctx context.Context, id string) (string, error)
+	GetIdFromTwoFactorTicket(ctx context.Context, ticket string) (string, error)
+	AddFailedTwoFactorAttempt(ctx context.Context, ticket string) (int64, error)
+	DeleteTwoFactorTicket(ctx context.Context, ticket string) error
+	UseTwoFactorStep(ctx context.Context, userId string, step int64, window time.Duration) (bool, error)
+	SaveLoginSession(ctx context.Context, userId string, session *LoginSession) error
+	GetLoginSession(ctx context.Context, userId string, sessionId string) (*LoginSession, error)
+	GetLoginSessions(ctx context.Context, userId string) (*[]LoginSession, error)
+	DeleteLoginSession(ctx context.Context, userId string, sessionId string) error
+	DeleteOtherLoginSessions(ctx context.Context, userId string, keepId string) error
+	SetWSTicket(ctx context.Context, id string) (string, error)
+	GetIdFromWSTicket(ctx context.Context, ticket string) (string, error)
 	SaveInvite(ctx context.Context, guildId string, id string, isPermanent bool) error
 	GetInvite(ctx context.Context, token string) (string, error)
 	InvalidateInvites(ctx context.Context, guild *Guild)
+	AddSession(ctx context.Context, userId string, sessionId string, ttl time.Duration) (bool, error)
+	RemoveSession(ctx context.Context, userId string, sessionId string) (bool, error)
+	HasLiveSession(ctx context.Context, userId string) (bool, error)
+	ExpireSessions(ctx context.Context) ([]string, error)
+	SaveVoiceState(ctx context.Context, state *VoiceState, ttl time.Duration) error
+	GetVoiceState(ctx context.Context, userId string) (*VoiceState, error)
+	RemoveVoiceState(ctx context.Context, state *VoiceState) error
+	GetVoiceStates(ctx context.Context, channelId string) (*[]VoiceState, error)
+	RefreshVoiceState(ctx context.Context, userId string, ttl time.Duration) error
+	ExpireVoiceStates(ctx context.Context) (*[]VoiceState, error)
+	AcquireLock(ctx context.Context, name string, ttl time.Duration) (string, error)
+	ReleaseLock(ctx context.Context, name string, lockId string) error
+	SaveInteraction(ctx context.Context, interaction *Interaction) error
+	GetInteraction(ctx context.Context, id string) (*Interaction, error)
+	DeleteInteraction(ctx context.Context, id string) error
 }
@@ -0,0 +1,14 @@
+package model
+
+import "time"
+
+// LoginSession contains the metadata of a session the user logged in with.
+// Current marks the session of the request.
+type LoginSession struct {
+	ID         string    `json:"id"`
+	UserAgent  string    `json:"userAgent"`
+	IP         string    `json:"ip"`
+	CreatedAt  time.Time `json:"createdAt"`
+	LastUsedAt time.Time `json:"lastUsedAt"`
+	Current    bool      `json:"current"`
+} //@name LoginSession
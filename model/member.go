@@ -4,11 +4,13 @@ import "time"
 
 // Member represents a user in a guild and is the join table between
 // User and Guild.
+// AllowDMs overrides the user's DM privacy setting for the guild.
 type Member struct {
 	UserID    string    `gorm:"primaryKey;constraint:OnDelete:CASCADE;"`
 	GuildID   string    `gorm:"primaryKey;constraint:OnDelete:CASCADE;"`
 	Nickname  *string   `gorm:"nickname"`
 	Color     *string   `gorm:"color"`
+	AllowDMs  *bool     `gorm:"allow_dms"`
 	LastSeen  time.Time `gorm:"autoCreateTime"`
 	CreatedAt time.Time `gorm:"index"`
 	UpdatedAt time.Time
@@ -20,6 +22,7 @@ type MemberResponse struct {
 	Username  string    `json:"username"`
 	Image     string    `json:"image"`
 	IsOnline  bool      `json:"isOnline"`
+	Status    string    `json:"status"`
 	CreatedAt time.Time `json:"createdAt"`
 	UpdatedAt time.Time `json:"updatedAt"`
 	Nickname  *string   `json:"nickname"`
@@ -35,7 +38,9 @@ type BanResponse struct {
 } //@name BanResponse
 
 // MemberSettings is the API response of a member's guild settings.
+// AllowDMs is nil if the user's DM privacy setting applies.
 type MemberSettings struct {
 	Nickname *string `json:"nickname"`
 	Color    *string `json:"color"`
+	AllowDMs *bool   `json:"allowDMs"`
 } //@name MemberSettings
@@ -5,24 +5,52 @@ import (
 	"time"
 )
 
+// Message Types
+const (
+	DefaultMessage = "default"
+	// Text holds the id of the added user
+	RecipientAddMessage = "recipient_add"
+	// Text holds the id of the removed user. The author is the removed
+	// user if they left the group
+	RecipientRemoveMessage = "recipient_remove"
+	// Text holds the new name of the group
+	ChannelNameChangeMessage = "channel_name_change"
+	// Text holds the new icon url of the group or nil if it got reset
+	ChannelIconChangeMessage = "channel_icon_change"
+)
+
 // Message represents a text message in a channel.
 // It may contain an Attachment that is displayed instead of text.
+// Messages that are not of DefaultMessage type are system messages
+// about membership or group changes.
 type Message struct {
 	BaseModel
 	Text       *string
+	Type       string      `gorm:"not null;default:default"`
 	UserId     string      `gorm:"index;constraint:OnDelete:CASCADE;"`
 	ChannelId  string      `gorm:"index;constraint:OnDelete:CASCADE;"`
 	Attachment *Attachment `gorm:"constraint:OnDelete:CASCADE;"`
+	// Set if a webhook posted the message. The name and avatar
+	// are the ones the webhook used for this message.
+	WebhookId     *string `gorm:"index"`
+	WebhookName   *string
+	WebhookAvatar *string
 }
 
 // MessageResponse is the API response of a Message
 type MessageResponse struct {
 	Id         string         `json:"id"`
 	Text       *string        `json:"text"`
+	Type       string         `json:"type"`
 	CreatedAt  time.Time      `json:"createdAt"`
 	UpdatedAt  time.Time      `json:"updatedAt"`
 	Attachment *Attachment    `json:"attachment"`
 	User       MemberResponse `json:"user"`
+	// Set if a webhook posted the message. User then contains
+	// the name and avatar of the webhook.
+	WebhookId *string `json:"webhookId"`
+	// The author is blocked by the current user and the message should be collapsed
+	Collapsed bool `json:"collapsed"`
 } //@name Message
 
 // Attachment represents a message attachment that displays
@@ -56,4 +84,6 @@ type MessageRepository interface {
 	UpdateMessage(message *Message) error
 	DeleteMessage(message *Message) error
 	GetById(messageId string) (*Message, error)
+	FindAuthorChannels(userId string) ([]string, error)
+	FindByAuthorInBatches(userId string, channelId string, batchSize int, fn func(messages *[]Message) error) error
 }
@@ -0,0 +1,65 @@
+package model
+
+import "time"
+
+// Presence Statuses
+const (
+	OnlineStatus       = "online"
+	IdleStatus         = "idle"
+	DoNotDisturbStatus = "dnd"
+	InvisibleStatus    = "invisible"
+	OfflineStatus      = "offline"
+)
+
+// CustomStatus is the optional status text a user can set.
+// If ExpiresAt is set the status gets removed after that date.
+type CustomStatus struct {
+	Text      *string    `json:"text"`
+	Emoji     *string    `json:"emoji"`
+	ExpiresAt *time.Time `json:"expiresAt"`
+} //@name CustomStatus
+
+// Presence is the presence of a user as seen by other users.
+// Status is either online, idle, dnd or offline.
+type Presence struct {
+	UserId       string        `json:"userId"`
+	Status       string        `json:"status"`
+	CustomStatus *CustomStatus `json:"customStatus"`
+} //@name Presence
+
+// GetCustomStatus returns the user's custom status or nil
+// if they do not have one or it already expired.
+func (u *User) GetCustomStatus() *CustomStatus {
+	if u.CustomStatus == nil && u.CustomStatusEmoji == nil {
+		return nil
+	}
+
+	if u.CustomStatusExpiresAt != nil && u.CustomStatusExpiresAt.Before(time.Now()) {
+		return nil
+	}
+
+	return &CustomStatus{
+		Text:      u.CustomStatus,
+		Emoji:     u.CustomStatusEmoji,
+		ExpiresAt: u.CustomStatusExpiresAt,
+	}
+}
+
+// GetPresence returns the user's presence as seen by other users.
+// Invisible or disconnected users appear offline.
+func (u *User) GetPresence() Presence {
+	status := u.Status
+	if status == "" {
+		status = OnlineStatus
+	}
+
+	if !u.IsOnline || status == InvisibleStatus {
+		status = OfflineStatus
+	}
+
+	return Presence{
+		UserId:       u.ID,
+		Status:       status,
+		CustomStatus: u.GetCustomStatus(),
+	}
+}
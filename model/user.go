@@ -3,38 +3,106 @@ package model
 import (
 	"context"
 	"mime/multipart"
+	"time"
 )
 
 // User represents the user of the website.
 type User struct {
 	BaseModel
-	Username string    `gorm:"not null" json:"username"`
-	Email    string    `gorm:"not null;uniqueIndex" json:"email"`
-	Password string    `gorm:"not null" json:"-"`
-	Image    string    `json:"image"`
-	IsOnline bool      `gorm:"index;default:true" json:"isOnline"`
-	Friends  []User    `gorm:"many2many:friends;" json:"-"`
-	Requests []User    `gorm:"many2many:friend_requests;joinForeignKey:sender_id;joinReferences:receiver_id" json:"-"`
-	Guilds   []Guild   `gorm:"many2many:members;" json:"-"`
-	Message  []Message `json:"-"`
+	Username              string     `gorm:"not null" json:"username"`
+	Email                 string     `gorm:"not null;uniqueIndex" json:"email"`
+	EmailVerified         bool       `gorm:"not null;default:false" json:"emailVerified"`
+	Password              string     `gorm:"not null" json:"-"`
+	TwoFactorEnabled      bool       `gorm:"not null;default:false" json:"twoFactorEnabled"`
+	TwoFactorSecret       string     `json:"-"`
+	RecoveryCodes         string     `json:"-"`
+	IsBot                 bool       `gorm:"not null;default:false" json:"isBot"`
+	BotOwnerId            *string    `gorm:"index" json:"-"`
+	Image                 string     `json:"image"`
+	IsOnline              bool       `gorm:"index;default:false" json:"isOnline"`
+	Status                string     `gorm:"not null;default:online" json:"status"`
+	CustomStatus          *string    `json:"customStatus"`
+	CustomStatusEmoji     *string    `json:"customStatusEmoji"`
+	CustomStatusExpiresAt *time.Time `json:"customStatusExpiresAt"`
+	DMPrivacy             string     `gorm:"not null;default:everyone" json:"dmPrivacy"`
+	DeletionScheduledAt   *time.Time `gorm:"index" json:"deletionScheduledAt"`
+	Friends               []User     `gorm:"many2many:friends;" json:"-"`
+	Requests              []User     `gorm:"many2many:friend_requests;joinForeignKey:sender_id;joinReferences:receiver_id" json:"-"`
+	Blocks                []User     `gorm:"many2many:blocks;joinForeignKey:user_id;joinReferences:blocked_id" json:"-"`
+	Guilds                []Guild    `gorm:"many2many:members;" json:"-"`
+	Message               []Message  `json:"-"`
 } //@name User
 
+// EmailChange is a pending or reversible email change of the user.
+// Email is the new address while the change waits for confirmation
+// and the previous address once it can be reverted.
+type EmailChange struct {
+	UserId string `json:"userId"`
+	Email  string `json:"email"`
+}
+
+// TwoFactorSetup contains the secret the user adds to their authenticator app
+type TwoFactorSetup struct {
+	Secret string `json:"secret"`
+	URI    string `json:"uri"`
+} //@name TwoFactorSetup
+
+// TwoFactorChallenge gets returned by the login if the user still
+// needs to provide their second factor
+type TwoFactorChallenge struct {
+	TwoFactorRequired bool   `json:"twoFactorRequired"`
+	Ticket            string `json:"ticket"`
+} //@name TwoFactorChallenge
+
+// RecoveryCodes are the one-time codes the user can log in with
+// if they lose access to their authenticator app
+type RecoveryCodes struct {
+	Codes []string `json:"codes"`
+} //@name RecoveryCodes
+
 // UserService defines methods related to account operations the handler layer expects
 // any service it interacts with to implement
 type UserService interface {
 	Get(id string) (*User, error)
 	GetByEmail(email string) (*User, error)
 	Register(user *User) (*User, error)
-	Login(email, password string) (*User, error)
+	Login(ctx context.Context, email, password, ip string) (*User, error)
 	UpdateAccount(user *User) error
 	IsEmailAlreadyInUse(email string) bool
 	ChangeAvatar(header *multipart.FileHeader, directory string) (string, error)
 	DeleteImage(key string) error
-	ChangePassword(currentPassword, newPassword string, user *User) error
+	ChangePassword(ctx context.Context, currentPassword, newPassword, code string, user *User) error
 	ForgotPassword(ctx context.Context, user *User) error
 	ResetPassword(ctx context.Context, password string, token string) (*User, error)
+	SendVerificationMail(ctx context.Context, user *User) error
+	VerifyEmail(ctx context.Context, token string) (*User, error)
+	RequestEmailChange(ctx context.Context, user *User, email string) error
+	ConfirmEmailChange(ctx context.Context, token string) (*User, error)
+	RevertEmailChange(ctx context.Context, token string) (*User, error)
+	SetupTwoFactor(user *User) (*TwoFactorSetup, error)
+	EnableTwoFactor(ctx context.Context, user *User, code string) ([]string, error)
+	DisableTwoFactor(ctx context.Context, user *User, password, code string) error
+	RegenerateRecoveryCodes(ctx context.Context, user *User, code string) ([]string, error)
+	ScheduleDeletion(ctx context.Context, user *User, password, code string) error
+	CancelDeletion(user *User) error
+	DeleteScheduledAccounts(ctx context.Context) error
+	VerifyPassword(user *User, password string) error
+	VerifyTwoFactor(ctx context.Context, user *User, code string) error
+	CreateTwoFactorTicket(ctx context.Context, userId string) (string, error)
+	VerifyTwoFactorLogin(ctx context.Context, ticket string, code string, ip string) (*User, error)
+	CreateLoginSession(ctx context.Context, userId string, userAgent string, ip string) (string, error)
+	ValidateLoginSession(ctx context.Context, userId string, sessionId string) error
+	GetLoginSessions(ctx context.Context, userId string, currentId string) (*[]LoginSession, error)
+	RevokeLoginSession(ctx context.Context, userId string, sessionId string) error
+	RevokeOtherLoginSessions(ctx context.Context, userId string, currentId string) error
 	GetFriendAndGuildIds(userId string) (*[]string, error)
 	GetRequestCount(userId string) (*int64, error)
+	UpdatePresence(ctx context.Context, user *User) error
+	ConnectSession(ctx context.Context, userId string, sessionId string) (*User, error)
+	DisconnectSession(ctx context.Context, userId string, sessionId string) (*User, error)
+	ExpireSessions(ctx context.Context) ([]*User, error)
+	CreateWSTicket(ctx context.Context, userId string) (string, error)
+	RedeemWSTicket(ctx context.Context, ticket string) (string, error)
 }
 
 // UserRepository defines methods related to account db operations the service layer expects
@@ -46,4 +114,7 @@ type UserRepository interface {
 	Update(user *User) error
 	GetFriendAndGuildIds(userId string) (*[]string, error)
 	GetRequestCount(userId string) (*int64, error)
+	FindBots(ownerId string) (*[]User, error)
+	FindScheduledForDeletion(before time.Time) (*[]User, error)
+	Anonymize(user *User) error
 }
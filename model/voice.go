@@ -0,0 +1,26 @@
+package model
+
+// VoiceState is the state of a user connected to a voice channel.
+// It is only kept in Redis while the user is connected.
+type VoiceState struct {
+	UserId    string `json:"userId"`
+	ChannelId string `json:"channelId"`
+	GuildId   string `json:"guildId"`
+	Muted     bool   `json:"muted"`
+	Deafened  bool   `json:"deafened"`
+} //@name VoiceState
+
+// VoiceChannelState contains all participants of a voice channel
+type VoiceChannelState struct {
+	ChannelId    string       `json:"channelId"`
+	Participants []VoiceState `json:"participants"`
+} //@name VoiceChannelState
+
+// VoiceSignal is a WebRTC offer, answer or ICE candidate that gets
+// relayed from UserId to another participant of the voice channel.
+// Signal is forwarded as is.
+type VoiceSignal struct {
+	ChannelId string      `json:"channelId"`
+	UserId    string      `json:"userId"`
+	Signal    interface{} `json:"signal"`
+} //@name VoiceSignal
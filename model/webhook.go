@@ -0,0 +1,65 @@
+package model
+
+import "time"
+
+// Webhook lets external services post messages into a guild channel
+// without an account. Only the sha256 hash of its token gets stored.
+type Webhook struct {
+	BaseModel
+	Name      string `gorm:"not null"`
+	Avatar    string
+	TokenHash string `gorm:"not null"`
+	ChannelId string `gorm:"index;constraint:OnDelete:CASCADE;"`
+	GuildId   string `gorm:"index;constraint:OnDelete:CASCADE;"`
+	// The user that created the webhook. Used as the author of its messages
+	UserId string `gorm:"index;constraint:OnDelete:CASCADE;"`
+}
+
+// WebhookResponse is the API response of a Webhook.
+// Token is only set right after creating the webhook.
+type WebhookResponse struct {
+	Id        string    `json:"id"`
+	Name      string    `json:"name"`
+	Avatar    string    `json:"avatar"`
+	ChannelId string    `json:"channelId"`
+	GuildId   string    `json:"guildId"`
+	UserId    string    `json:"userId"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+	Token     string    `json:"token,omitempty"`
+} //@name Webhook
+
+// SerializeWebhook returns the API response of the webhook.
+func (w Webhook) SerializeWebhook() WebhookResponse {
+	return WebhookResponse{
+		Id:        w.ID,
+		Name:      w.Name,
+		Avatar:    w.Avatar,
+		ChannelId: w.ChannelId,
+		GuildId:   w.GuildId,
+		UserId:    w.UserId,
+		CreatedAt: w.CreatedAt,
+		UpdatedAt: w.UpdatedAt,
+	}
+}
+
+// WebhookService defines methods related to webhook operations the handler layer expects
+// any service it interacts with to implement
+type WebhookService interface {
+	Get(id string) (*Webhook, error)
+	GetChannelWebhooks(channelId string) (*[]Webhook, error)
+	CreateWebhook(webhook *Webhook) (*Webhook, string, error)
+	UpdateWebhook(webhook *Webhook) error
+	DeleteWebhook(webhook *Webhook) error
+	AuthenticateWebhook(id string, token string) (*Webhook, error)
+}
+
+// WebhookRepository defines methods related to webhook db operations the service layer expects
+// any repository it interacts with to implement
+type WebhookRepository interface {
+	FindByID(id string) (*Webhook, error)
+	FindByChannel(channelId string) (*[]Webhook, error)
+	Create(webhook *Webhook) (*Webhook, error)
+	Update(webhook *Webhook) error
+	Delete(webhook *Webhook) error
+}
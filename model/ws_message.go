@@ -10,6 +10,15 @@ type ReceivedMessage struct {
 	Action  string  `json:"action"`
 	Room    string  `json:"room"`
 	Message *string `json:"message"`
+	// The event categories the client wants to receive. Only used by identify
+	Intents []string `json:"intents"`
+	// The user a WebRTC signal is sent to and the signal itself.
+	// Only used by the voice signaling actions
+	Target string      `json:"target"`
+	Signal interface{} `json:"signal"`
+	// The new mute and deafen state. Only used by updateVoiceState
+	Mute *bool `json:"mute"`
+	Deaf *bool `json:"deaf"`
 }
 
 // WebsocketMessage represents an emitted message
@@ -18,6 +27,31 @@ type WebsocketMessage struct {
 	Data   interface{} `json:"data"`
 }
 
+// WSTicket is a single use ticket to authenticate the websocket
+// connection with instead of the session cookie.
+// It has to be used within 30 seconds.
+type WSTicket struct {
+	Ticket string `json:"ticket"`
+} //@name WSTicket
+
+// WebsocketError is emitted to the client if it sent an invalid action.
+// RetryAfter is the time in milliseconds until the action is accepted again
+// and only set if the client got rate limited.
+type WebsocketError struct {
+	Code       string `json:"code"`
+	Message    string `json:"message"`
+	Action     string `json:"action"`
+	RetryAfter int64  `json:"retryAfter,omitempty"`
+} //@name WebsocketError
+
+// TypingUser is the user that started or stopped typing in a channel.
+// Username is their nickname in guild channels if they set one.
+type TypingUser struct {
+	ChannelId string `json:"channelId"`
+	UserId    string `json:"userId"`
+	Username  string `json:"username"`
+} //@name TypingUser
+
 // Encode turns the message into a byte array
 func (message *WebsocketMessage) Encode() []byte {
 	encoding, err := json.Marshal(message)
@@ -43,15 +77,24 @@ type SocketService interface {
 	EmitEditGuild(guild *Guild)
 	EmitDeleteGuild(guildId string, members []string)
 	EmitRemoveFromGuild(memberId, guildId string)
+	EmitTransferGuild(transfer *GuildTransfer)
 
 	EmitAddMember(room string, member *User)
 	EmitRemoveMember(room, memberId string)
 
 	EmitNewDMNotification(channelId string, user *User)
+	EmitAddDM(members []string, channelId string)
+	EmitEditDM(channelId string)
+	EmitRemoveDM(userId, channelId string)
 	EmitNewNotification(guildId, channelId string)
 
 	EmitSendRequest(room string)
 	EmitAddFriendRequest(room string, request *FriendRequest)
 	EmitAddFriend(user, member *User)
 	EmitRemoveFriend(userId, memberId string)
+
+	EmitPresenceUpdate(user *User)
+
+	EmitStartTyping(user *TypingUser)
+	EmitStopTyping(channelId, userId string)
 }
@@ -50,7 +50,7 @@ func (r *channelRepository) Get(userId string, guildId string) (*[]model.Channel
 
 	result := r.DB.
 		Raw(`
-			SELECT DISTINCT ON (c.id, c."created_at") c.id, c.name, 
+			SELECT DISTINCT ON (c.id, c."created_at") c.id, c.name, c.type,
 			c."is_public", c."created_at", c."updated_at",
 			(c."last_activity" > m."last_seen") AS "hasNotification"
 			FROM channels AS c
@@ -66,8 +66,18 @@ func (r *channelRepository) Get(userId string, guildId string) (*[]model.Channel
 	return &channels, result.Error
 }
 
-// dmQuery represents the fetched fields for GetDirectMessages
+// dmQuery represents the fetched fields of a DM channel
 type dmQuery struct {
+	Id        string
+	Name      string
+	Icon      *string
+	IsGroup   bool
+	OwnerId   *string
+	IsRequest bool
+}
+
+// dmMemberQuery represents the fetched fields of a DM member
+type dmMemberQuery struct {
 	ChannelId string
 	Id        string
 	Username  string
@@ -76,49 +86,159 @@ type dmQuery struct {
 	IsFriend  bool
 }
 
-// GetDirectMessages returns all DMs for the given user
+// GetDirectMessages returns all open DMs for the given user
 func (r *channelRepository) GetDirectMessages(userId string) (*[]model.DirectMessage, error) {
 	var results []dmQuery
 
 	err := r.DB.
 		Raw(`
-			SELECT dm."channel_id", u.username, u.image, u.id, u."is_online", u."created_at", u."updated_at"
+			SELECT c.id, c.name, c.icon, c."is_group", c."owner_id"
+			FROM channels c
+			JOIN dm_members dm ON dm."channel_id" = c.id
+			WHERE c."is_public" = false
+			AND c.is_dm = true
+			AND dm."is_open" = true
+			AND dm."is_request" = false
+			AND dm."user_id" = ?
+			ORDER BY dm."updated_at" DESC
+		`, userId).
+		Scan(&results).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return r.toDirectMessages(results, userId)
+}
+
+// GetDMRequests returns all DMs started by non friends that the given user did not accept yet
+func (r *channelRepository) GetDMRequests(userId string) (*[]model.DirectMessage, error) {
+	var results []dmQuery
+
+	err := r.DB.
+		Raw(`
+			SELECT c.id, c.name, c.icon, c."is_group", c."owner_id", dm."is_request"
+			FROM channels c
+			JOIN dm_members dm ON dm."channel_id" = c.id
+			WHERE c.is_dm = true
+			AND dm."is_request" = true
+			AND dm."user_id" = ?
+			ORDER BY c."last_activity" DESC
+		`, userId).
+		Scan(&results).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return r.toDirectMessages(results, userId)
+}
+
+// GetDirectMessage returns the DM with the given id from the perspective of the given user
+func (r *channelRepository) GetDirectMessage(channelId string, userId string) (*model.DirectMessage, error) {
+	var results []dmQuery
+
+	err := r.DB.
+		Raw(`
+			SELECT c.id, c.name, c.icon, c."is_group", c."owner_id",
+			COALESCE(dm."is_request", false) AS "is_request"
+			FROM channels c
+			LEFT JOIN dm_members dm ON dm."channel_id" = c.id AND dm."user_id" = ?
+			WHERE c.id = ? AND c.is_dm = true
+		`, userId, channelId).
+		Scan(&results).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(results) == 0 {
+		return nil, apperrors.NewNotFound("dms", channelId)
+	}
+
+	channels, err := r.toDirectMessages(results, userId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &(*channels)[0], nil
+}
+
+// toDirectMessages fetches the other members of the given channels
+// and turns them into DirectMessage responses
+func (r *channelRepository) toDirectMessages(results []dmQuery, userId string) (*[]model.DirectMessage, error) {
+	channels := make([]model.DirectMessage, 0, len(results))
+
+	if len(results) == 0 {
+		return &channels, nil
+	}
+
+	ids := make([]string, 0, len(results))
+	for _, dm := range results {
+		ids = append(ids, dm.Id)
+	}
+
+	var members []dmMemberQuery
+
+	err := r.DB.
+		Raw(`
+			SELECT dm."channel_id", u.id, u.username, u.image, u."is_online",
+			EXISTS(
+				SELECT 1 FROM friends f
+				WHERE f."user_id" = @id AND f."friend_id" = u.id
+			) AS "is_friend"
 			FROM users u
 			JOIN dm_members dm ON dm."user_id" = u.id
 			WHERE u.id != @id
-			AND dm."channel_id" IN (
-				SELECT DISTINCT c.id
-				FROM channels as c
-				LEFT OUTER JOIN dm_members as dm
-				ON c."id" = dm."channel_id"
-				JOIN users u on dm."user_id" = u.id
-				WHERE c."is_public" = false
-				AND c.is_dm = true
-				AND dm."is_open" = true
-				AND dm."user_id" = @id
-			)
-			order by dm."updated_at" DESC 
-		`, sql.Named("id", userId)).
-		Scan(&results)
-
-	var channels []model.DirectMessage
+			AND dm."channel_id" IN @channels
+			ORDER BY dm."created_at"
+		`, sql.Named("id", userId), sql.Named("channels", ids)).
+		Scan(&members).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	membersByChannel := make(map[string][]model.DMUser)
+	for _, m := range members {
+		membersByChannel[m.ChannelId] = append(membersByChannel[m.ChannelId], model.DMUser{
+			Id:       m.Id,
+			Username: m.Username,
+			Image:    m.Image,
+			IsOnline: m.IsOnline,
+			IsFriend: m.IsFriend,
+		})
+	}
 
 	// Turn into DirectMessage response
 	for _, dm := range results {
 		channel := model.DirectMessage{
-			Id: dm.ChannelId,
-			User: model.DMUser{
-				Id:       dm.Id,
-				Username: dm.Username,
-				Image:    dm.Image,
-				IsOnline: dm.IsOnline,
-				IsFriend: dm.IsFriend,
-			},
+			Id:        dm.Id,
+			Icon:      dm.Icon,
+			IsGroup:   dm.IsGroup,
+			OwnerId:   dm.OwnerId,
+			Members:   membersByChannel[dm.Id],
+			IsRequest: dm.IsRequest,
+		}
+
+		if channel.Members == nil {
+			channel.Members = make([]model.DMUser, 0)
 		}
+
+		if dm.IsGroup {
+			if dm.Name != "" {
+				name := dm.Name
+				channel.Name = &name
+			}
+		} else if len(channel.Members) > 0 {
+			channel.User = &channel.Members[0]
+		}
+
 		channels = append(channels, channel)
 	}
 
-	return &channels, err.Error
+	return &channels, nil
 }
 
 // GetDirectMessageChannel returns the dm channel ID of the given members
@@ -131,6 +251,7 @@ func (r *channelRepository) GetDirectMessageChannel(userId string, memberId stri
 			SELECT c.id
 			FROM channels as c, dm_members dm 
 			WHERE dm."channel_id" = c."id" AND c.is_dm = true AND c."is_public" = false
+			AND c."is_group" = false
 			GROUP BY c."id"
 			HAVING array_agg(dm."user_id"::text) @> Array[?,?]
 			AND count(dm."user_id") = 2;
@@ -166,6 +287,20 @@ func (r *channelRepository) AddDMChannelMembers(members []model.DMMember) error
 	return nil
 }
 
+// RemoveDMChannelMember removes the given user from the DM
+func (r *channelRepository) RemoveDMChannelMember(channelId string, userId string) error {
+	err := r.DB.
+		Where("channel_id = ? AND user_id = ?", channelId, userId).
+		Delete(&model.DMMember{}).
+		Error
+
+	if err != nil {
+		log.Printf("Could not remove member from DM. Reason: %v\n", err)
+		return apperrors.NewInternal()
+	}
+	return nil
+}
+
 // SetDirectMessageStatus opens or closes the dm channel for the given
 // userId
 func (r *channelRepository) SetDirectMessageStatus(dmId string, userId string, isOpen bool) error {
@@ -180,11 +315,26 @@ func (r *channelRepository) SetDirectMessageStatus(dmId string, userId string, i
 	return err
 }
 
+// SetDirectMessageRequest marks the dm channel as a pending message request
+// for the given userId or accepts it
+func (r *channelRepository) SetDirectMessageRequest(dmId string, userId string, isRequest bool) error {
+	err := r.DB.
+		Table("dm_members").
+		Where("channel_id = ? AND user_id = ?", dmId, userId).
+		Updates(map[string]interface{}{
+			"is_request": isRequest,
+			"updated_at": time.Now(),
+		}).
+		Error
+	return err
+}
+
 // OpenDMForAll opens the given dm channel for all users in the channel
+// that do not have a pending message request
 func (r *channelRepository) OpenDMForAll(dmId string) error {
 	err := r.DB.
 		Table("dm_members").
-		Where("channel_id = ? ", dmId).
+		Where("channel_id = ? AND is_request = false", dmId).
 		Updates(map[string]interface{}{
 			"is_open":    true,
 			"updated_at": time.Now(),
@@ -255,10 +405,11 @@ func (r *channelRepository) FindDMByUserAndChannelId(channelId, userId string) (
 }
 
 // GetDMMemberIds returns the ids of all dm members for the given channel
+// ordered by the time they joined
 func (r *channelRepository) GetDMMemberIds(channelId string) (*[]string, error) {
 	var members []string
 	err := r.DB.
-		Raw("SELECT u.id FROM users u JOIN dm_members dm on u.id = dm.user_id WHERE dm.channel_id = ?", channelId).
+		Raw("SELECT u.id FROM users u JOIN dm_members dm on u.id = dm.user_id WHERE dm.channel_id = ? ORDER BY dm.created_at", channelId).
 		Scan(&members).Error
 	return &members, err
 }
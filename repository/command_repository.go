@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"errors"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"gorm.io/gorm"
+	"log"
+	"strings"
+)
+
+// commandRepository is data/repository implementation
+// of service layer CommandRepository
+type commandRepository struct {
+	DB *gorm.DB
+}
+
+// NewCommandRepository is a factory for initializing Command Repositories
+func NewCommandRepository(db *gorm.DB) model.CommandRepository {
+	return &commandRepository{
+		DB: db,
+	}
+}
+
+// FindByID returns the command for the given ID
+func (r *commandRepository) FindByID(id string) (*model.Command, error) {
+	command := &model.Command{}
+
+	if err := r.DB.Where("id = ?", id).First(&command).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return command, apperrors.NewNotFound("command", id)
+		}
+		return command, apperrors.NewInternal()
+	}
+
+	return command, nil
+}
+
+// FindByGuild returns the commands of the guild whose name starts with the given prefix
+func (r *commandRepository) FindByGuild(guildId string, prefix string) (*[]model.Command, error) {
+	var commands []model.Command
+
+	query := r.DB.Where("guild_id = ?", guildId)
+
+	if prefix != "" {
+		escaped := strings.NewReplacer("\\", "\\\\", "%", "\\%", "_", "\\_").Replace(prefix)
+		query = query.Where("name LIKE ?", escaped+"%")
+	}
+
+	result := query.
+		Order("name ASC").
+		Find(&commands)
+
+	return &commands, result.Error
+}
+
+// FindByName returns the command of the guild with the given name
+func (r *commandRepository) FindByName(guildId string, name string) (*model.Command, error) {
+	command := &model.Command{}
+
+	if err := r.DB.Where("guild_id = ? AND name = ?", guildId, name).First(&command).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return command, apperrors.NewNotFound("command", name)
+		}
+		return command, apperrors.NewInternal()
+	}
+
+	return command, nil
+}
+
+// Create inserts the command in the DB
+func (r *commandRepository) Create(command *model.Command) (*model.Command, error) {
+	if result := r.DB.Create(&command); result.Error != nil {
+		// check unique constraint
+		if isDuplicateKeyError(result.Error) {
+			return nil, apperrors.NewConflict("command", command.Name)
+		}
+
+		log.Printf("Could not create a command for guild: %v. Reason: %v\n", command.GuildId, result.Error)
+		return nil, apperrors.NewInternal()
+	}
+
+	return command, nil
+}
+
+// Update updates the command in the DB
+func (r *commandRepository) Update(command *model.Command) error {
+	if result := r.DB.Save(&command); result.Error != nil {
+		if isDuplicateKeyError(result.Error) {
+			return apperrors.NewConflict("command", command.Name)
+		}
+
+		log.Printf("Could not update the command with id: %v. Reason: %v\n", command.ID, result.Error)
+		return apperrors.NewInternal()
+	}
+
+	return nil
+}
+
+// Delete removes the command from the DB
+func (r *commandRepository) Delete(command *model.Command) error {
+	if result := r.DB.Delete(&command); result.Error != nil {
+		log.Printf("Could not delete the command with id: %v. Reason: %v\n", command.ID, result.Error)
+		return apperrors.NewInternal()
+	}
+
+	return nil
+}
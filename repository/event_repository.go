@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"errors"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"gorm.io/gorm"
+	"log"
+)
+
+// eventRepository is data/repository implementation
+// of service layer EventRepository
+type eventRepository struct {
+	DB *gorm.DB
+}
+
+// NewEventRepository is a factory for initializing Event Repositories
+func NewEventRepository(db *gorm.DB) model.EventRepository {
+	return &eventRepository{
+		DB: db,
+	}
+}
+
+// FindByID returns the event webhook for the given ID
+func (r *eventRepository) FindByID(id string) (*model.EventWebhook, error) {
+	webhook := &model.EventWebhook{}
+
+	if err := r.DB.Where("id = ?", id).First(&webhook).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return webhook, apperrors.NewNotFound("webhook", id)
+		}
+		return webhook, apperrors.NewInternal()
+	}
+
+	return webhook, nil
+}
+
+// FindByGuild returns the event webhooks of the given guild
+func (r *eventRepository) FindByGuild(guildId string) (*[]model.EventWebhook, error) {
+	var webhooks []model.EventWebhook
+
+	result := r.DB.
+		Where("guild_id = ?", guildId).
+		Order("created_at ASC").
+		Find(&webhooks)
+
+	return &webhooks, result.Error
+}
+
+// Create inserts the event webhook in the DB
+func (r *eventRepository) Create(webhook *model.EventWebhook) (*model.EventWebhook, error) {
+	if result := r.DB.Create(&webhook); result.Error != nil {
+		log.Printf("Could not create an event webhook for guild: %v. Reason: %v\n", webhook.GuildId, result.Error)
+		return nil, apperrors.NewInternal()
+	}
+
+	return webhook, nil
+}
+
+// Update updates the event webhook in the DB
+func (r *eventRepository) Update(webhook *model.EventWebhook) error {
+	if result := r.DB.Save(&webhook); result.Error != nil {
+		log.Printf("Could not update the event webhook with id: %v. Reason: %v\n", webhook.ID, result.Error)
+		return apperrors.NewInternal()
+	}
+
+	return nil
+}
+
+// Delete removes the event webhook and its delivery log from the DB
+func (r *eventRepository) Delete(webhook *model.EventWebhook) error {
+	if result := r.DB.
+		Exec("DELETE FROM event_deliveries WHERE event_webhook_id = ?", webhook.ID).
+		Exec("DELETE FROM event_webhooks WHERE id = ?", webhook.ID); result.Error != nil {
+		log.Printf("Could not delete the event webhook with id: %v. Reason: %v\n", webhook.ID, result.Error)
+		return apperrors.NewInternal()
+	}
+
+	return nil
+}
+
+// ResetFailures resets the failed deliveries in a row of the event webhook
+func (r *eventRepository) ResetFailures(id string) error {
+	return r.DB.
+		Exec("UPDATE event_webhooks SET failure_count = 0 WHERE id = ?", id).
+		Error
+}
+
+// AddFailure increments the failed deliveries in a row of the event webhook
+// and disables it once they reach the limit
+func (r *eventRepository) AddFailure(id string, limit int) error {
+	return r.DB.
+		Exec(`
+			UPDATE event_webhooks
+			SET failure_count = failure_count + 1,
+				enabled = enabled AND failure_count + 1 < ?
+			WHERE id = ?
+		`, limit, id).
+		Error
+}
+
+// CreateDelivery inserts the delivery attempt in the DB
+func (r *eventRepository) CreateDelivery(delivery *model.EventDelivery) error {
+	return r.DB.Create(&delivery).Error
+}
+
+// FindDeliveries returns the 50 most recent delivery attempts of the event webhook
+func (r *eventRepository) FindDeliveries(webhookId string) (*[]model.EventDelivery, error) {
+	var deliveries []model.EventDelivery
+
+	result := r.DB.
+		Where("event_webhook_id = ?", webhookId).
+		Order("created_at DESC").
+		Limit(50).
+		Find(&deliveries)
+
+	return &deliveries, result.Error
+}
@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"errors"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"gorm.io/gorm"
+	"log"
+	"time"
+)
+
+// exportRepository is data/repository implementation
+// of service layer ExportRepository
+type exportRepository struct {
+	DB *gorm.DB
+}
+
+// NewExportRepository is a factory for initializing Export Repositories
+func NewExportRepository(db *gorm.DB) model.ExportRepository {
+	return &exportRepository{
+		DB: db,
+	}
+}
+
+// FindLatest returns the most recently requested export of the given user
+func (r *exportRepository) FindLatest(userId string) (*model.DataExport, error) {
+	export := &model.DataExport{}
+
+	if err := r.DB.
+		Where("user_id = ?", userId).
+		Order("created_at DESC").
+		First(&export).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return export, apperrors.NewNotFound("export", userId)
+		}
+		return export, apperrors.NewInternal()
+	}
+
+	return export, nil
+}
+
+// FindPending returns all exports that still need to be built
+func (r *exportRepository) FindPending() (*[]model.DataExport, error) {
+	var exports []model.DataExport
+
+	result := r.DB.
+		Where("status = ?", model.ExportPending).
+		Order("created_at ASC").
+		Find(&exports)
+
+	return &exports, result.Error
+}
+
+// FindExpired returns all ready exports that expired before the given time
+func (r *exportRepository) FindExpired(before time.Time) (*[]model.DataExport, error) {
+	var exports []model.DataExport
+
+	result := r.DB.
+		Where("status = ? AND expires_at <= ?", model.ExportReady, before).
+		Find(&exports)
+
+	return &exports, result.Error
+}
+
+// Create inserts the export in the DB
+func (r *exportRepository) Create(export *model.DataExport) error {
+	if result := r.DB.Create(&export); result.Error != nil {
+		log.Printf("Could not create an export for user: %v. Reason: %v\n", export.UserId, result.Error)
+		return apperrors.NewInternal()
+	}
+
+	return nil
+}
+
+// Save updates the export in the DB
+func (r *exportRepository) Save(export *model.DataExport) error {
+	if result := r.DB.Save(&export); result.Error != nil {
+		log.Printf("Could not update the export with id: %v. Reason: %v\n", export.ID, result.Error)
+		return apperrors.NewInternal()
+	}
+
+	return nil
+}
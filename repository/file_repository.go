@@ -13,6 +13,7 @@ import (
 	"github.com/sentrionic/valkyrie/service"
 	"image"
 	"image/jpeg"
+	"io"
 	"log"
 
 	// Register accepted file type jpeg
@@ -20,6 +21,7 @@ import (
 	// Register accepted file type png
 	_ "image/png"
 	"mime/multipart"
+	"time"
 )
 
 // s3FileRepository includes the S3 session and the BucketName
@@ -139,3 +141,47 @@ func (s *s3FileRepository) DeleteImage(key string) error {
 
 	return nil
 }
+
+// UploadExport uploads the given archive to the initialized Bucket.
+// The archive is not publicly readable and can only be downloaded
+// with a signed URL.
+// It returns the key of the uploaded archive.
+func (s *s3FileRepository) UploadExport(body io.Reader, directory, filename string) (string, error) {
+	uploader := s3manager.NewUploader(s.S3Session)
+
+	key := fmt.Sprintf("exports/%s/%s", directory, filename)
+
+	_, err := uploader.Upload(&s3manager.UploadInput{
+		Body:        body,
+		Bucket:      aws.String(s.BucketName),
+		ContentType: aws.String("application/zip"),
+		Key:         aws.String(key),
+		ACL:         aws.String(s3.ObjectCannedACLPrivate),
+	})
+
+	if err != nil {
+		log.Printf("Failed to upload archive: %v\n", err.Error())
+		return "", apperrors.NewInternal()
+	}
+
+	return key, nil
+}
+
+// GetSignedURL returns a URL that allows downloading the file
+// with the given key until it expires.
+func (s *s3FileRepository) GetSignedURL(key string, expires time.Duration) (string, error) {
+	srv := s3.New(s.S3Session)
+	req, _ := srv.GetObjectRequest(&s3.GetObjectInput{
+		Bucket: aws.String(s.BucketName),
+		Key:    aws.String(key),
+	})
+
+	url, err := req.Presign(expires)
+
+	if err != nil {
+		log.Printf("Failed to sign url: %v\n", err.Error())
+		return "", apperrors.NewInternal()
+	}
+
+	return url, nil
+}
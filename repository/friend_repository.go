@@ -27,6 +27,8 @@ func (r *friendRepository) FriendsList(id string) (*[]model.Friend, error) {
 
 	result := r.DB.
 		Table("users").
+		Select(`users.id, users.username, users.image, users.is_online,
+			CASE WHEN users.is_online THEN users.status ELSE 'offline' END AS status`).
 		Joins(`JOIN friends ON friends.user_id = "users".id`).
 		Where("friends.friend_id = ?", id).
 		Find(&friends)
@@ -61,6 +63,7 @@ func (r *friendRepository) FindByID(id string) (*model.User, error) {
 	if err := r.DB.
 		Preload("Friends").
 		Preload("Requests").
+		Preload("Blocks").
 		Where("id = ?", id).
 		First(&user).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -94,3 +97,83 @@ func (r *friendRepository) RemoveFriend(memberId string, userId string) error {
 func (r *friendRepository) Save(user *model.User) error {
 	return r.DB.Save(&user).Error
 }
+
+// BlockList returns the users the given user blocked
+func (r *friendRepository) BlockList(id string) (*[]model.BlockedUser, error) {
+	var users []model.BlockedUser
+
+	result := r.DB.
+		Table("users").
+		Select("users.id, users.username, users.image").
+		Joins(`JOIN blocks ON blocks.blocked_id = "users".id`).
+		Where("blocks.user_id = ?", id).
+		Order("users.username").
+		Find(&users)
+
+	return &users, result.Error
+}
+
+// Block adds the member to the blocked users of the user and removes
+// their friendship and pending requests
+func (r *friendRepository) Block(userId string, memberId string) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(
+			"INSERT INTO blocks (user_id, blocked_id) VALUES (?, ?) ON CONFLICT DO NOTHING",
+			userId, memberId,
+		).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Exec(`
+			DELETE
+			FROM friends
+			WHERE user_id = @memberId AND friend_id = @userId
+			   OR user_id = @userId AND friend_id = @memberId
+		`, sql.Named("memberId", memberId), sql.Named("userId", userId)).Error; err != nil {
+			return err
+		}
+
+		return tx.Exec(`
+			DELETE
+			FROM friend_requests
+			WHERE receiver_id = @memberId AND sender_id = @userId
+			   OR receiver_id = @userId AND sender_id = @memberId
+		`, sql.Named("memberId", memberId), sql.Named("userId", userId)).Error
+	})
+}
+
+// Unblock removes the member from the blocked users of the user
+func (r *friendRepository) Unblock(userId string, memberId string) error {
+	return r.DB.
+		Exec("DELETE FROM blocks WHERE user_id = ? AND blocked_id = ?", userId, memberId).
+		Error
+}
+
+// IsBlocked checks if one of the users blocked the other one
+func (r *friendRepository) IsBlocked(userId string, memberId string) (bool, error) {
+	var exists bool
+
+	err := r.DB.
+		Raw(`
+			SELECT EXISTS(
+				SELECT 1
+				FROM blocks
+				WHERE user_id = @memberId AND blocked_id = @userId
+				   OR user_id = @userId AND blocked_id = @memberId
+			)
+		`, sql.Named("memberId", memberId), sql.Named("userId", userId)).
+		Scan(&exists).Error
+
+	return exists, err
+}
+
+// HasBlocked checks if the user blocked the member
+func (r *friendRepository) HasBlocked(userId string, memberId string) (bool, error) {
+	var exists bool
+
+	err := r.DB.
+		Raw("SELECT EXISTS(SELECT 1 FROM blocks WHERE user_id = ? AND blocked_id = ?)", userId, memberId).
+		Scan(&exists).Error
+
+	return exists, err
+}
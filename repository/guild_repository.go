@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"database/sql"
 	"errors"
 	"github.com/sentrionic/valkyrie/model"
 	"github.com/sentrionic/valkyrie/model/apperrors"
@@ -64,6 +65,7 @@ func (r *guildRepository) GuildMembers(userId string, guildId string) (*[]model.
 		u.username,
 		u.image,
 		u."is_online",
+		CASE WHEN u."is_online" THEN u.status ELSE 'offline' END AS status,
 		u."created_at",
 		u."updated_at",
 		m.nickname,
@@ -206,12 +208,31 @@ func (r *guildRepository) UpdateMemberSettings(settings *model.MemberSettings, u
 		Updates(map[string]interface{}{
 			"color":      settings.Color,
 			"nickname":   settings.Nickname,
+			"allow_dms":  settings.AllowDMs,
 			"updated_at": time.Now(),
 		}).
 		Error
 	return err
 }
 
+// GetSharedMemberSettings returns the settings of the given user in all guilds
+// they share with the given member
+func (r *guildRepository) GetSharedMemberSettings(userId string, memberId string) (*[]model.MemberSettings, error) {
+	var settings []model.MemberSettings
+	err := r.DB.
+		Raw(`
+			SELECT m.nickname, m.color, m."allow_dms"
+			FROM members m
+			WHERE m."user_id" = @userId
+			AND m."guild_id" IN (
+				SELECT guild_id FROM members WHERE "user_id" = @memberId
+			)
+		`, sql.Named("userId", userId), sql.Named("memberId", memberId)).
+		Scan(&settings).
+		Error
+	return &settings, err
+}
+
 // FindUsersByIds returns the found users for the given user IDs and guild ID
 func (r *guildRepository) FindUsersByIds(ids []string, guildId string) (*[]model.User, error) {
 	var users []model.User
@@ -264,3 +285,29 @@ func (r *guildRepository) GetMemberIds(guildId string) (*[]string, error) {
 
 	return &users, result.Error
 }
+
+// FindNextOwner returns the longest standing member of the guild that is neither
+// the given owner nor a bot
+func (r *guildRepository) FindNextOwner(guildId string, ownerId string) (*model.User, error) {
+	user := &model.User{}
+
+	if err := r.DB.Raw(`
+		SELECT u.*
+		FROM users AS u
+		JOIN members m ON u."id"::text = m."user_id"
+		WHERE m."guild_id" = ?
+		AND m."user_id" <> ?
+		AND u."is_bot" = false
+		ORDER BY m."created_at"
+		LIMIT 1
+	`, guildId, ownerId).Scan(&user).Error; err != nil {
+		log.Printf("Could not find the next owner of the guild with id: %v. Reason: %v\n", guildId, err)
+		return user, apperrors.NewInternal()
+	}
+
+	if user.ID == "" {
+		return user, apperrors.NewNotFound("member", guildId)
+	}
+
+	return user, nil
+}
@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"errors"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"gorm.io/gorm"
+	"log"
+)
+
+// identityRepository is data/repository implementation
+// of service layer IdentityRepository
+type identityRepository struct {
+	DB *gorm.DB
+}
+
+// NewIdentityRepository is a factory for initializing Identity Repositories
+func NewIdentityRepository(db *gorm.DB) model.IdentityRepository {
+	return &identityRepository{
+		DB: db,
+	}
+}
+
+// FindByID returns the identity for the given ID
+func (r *identityRepository) FindByID(id string) (*model.UserIdentity, error) {
+	identity := &model.UserIdentity{}
+
+	if err := r.DB.Where("id = ?", id).First(&identity).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return identity, apperrors.NewNotFound("identity", id)
+		}
+		return identity, apperrors.NewInternal()
+	}
+
+	return identity, nil
+}
+
+// FindBySubject returns the identity of the given account at the issuer
+func (r *identityRepository) FindBySubject(issuer string, subject string) (*model.UserIdentity, error) {
+	identity := &model.UserIdentity{}
+
+	if err := r.DB.Where("issuer = ? AND subject = ?", issuer, subject).First(&identity).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return identity, apperrors.NewNotFound("identity", subject)
+		}
+		return identity, apperrors.NewInternal()
+	}
+
+	return identity, nil
+}
+
+// FindByUser returns all identities linked to the given user
+func (r *identityRepository) FindByUser(userId string) (*[]model.UserIdentity, error) {
+	var identities []model.UserIdentity
+
+	result := r.DB.
+		Where("user_id = ?", userId).
+		Order("created_at ASC").
+		Find(&identities)
+
+	return &identities, result.Error
+}
+
+// Create inserts the identity in the DB
+func (r *identityRepository) Create(identity *model.UserIdentity) error {
+	if result := r.DB.Create(&identity); result.Error != nil {
+		// check unique constraint
+		if isDuplicateKeyError(result.Error) {
+			return apperrors.NewConflict("identity", identity.Subject)
+		}
+
+		log.Printf("Could not create an identity for user: %v. Reason: %v\n", identity.UserId, result.Error)
+		return apperrors.NewInternal()
+	}
+
+	return nil
+}
+
+// Delete removes the identity from the DB
+func (r *identityRepository) Delete(identity *model.UserIdentity) error {
+	if result := r.DB.Delete(&identity); result.Error != nil {
+		log.Printf("Could not delete the identity with id: %v. Reason: %v\n", identity.ID, result.Error)
+		return apperrors.NewInternal()
+	}
+
+	return nil
+}
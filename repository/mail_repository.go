@@ -37,3 +37,83 @@ func (m *mailRepository) SendResetMail(email string, token string) error {
 
 	return err
 }
+
+// SendVerificationMail sends an email verification link with the given token
+func (m *mailRepository) SendVerificationMail(email string, token string) error {
+
+	msg := "From: " + m.username + "\n" +
+		"To: " + email + "\n" +
+		"Subject: Verify Email\n\n" +
+		fmt.Sprintf("<a href=\"%s/verify-email/%s\">Verify Email</a>", m.origin, token)
+
+	err := smtp.SendMail("smtp.gmail.com:587",
+		smtp.PlainAuth("", m.username, m.password, "smtp.gmail.com"),
+		m.username, []string{email}, []byte(msg))
+
+	return err
+}
+
+// SendEmailChangeMail sends a link to the new email that confirms the email change
+func (m *mailRepository) SendEmailChangeMail(email string, token string) error {
+
+	msg := "From: " + m.username + "\n" +
+		"To: " + email + "\n" +
+		"Subject: Confirm Email Change\n\n" +
+		fmt.Sprintf("<a href=\"%s/confirm-email/%s\">Confirm Email</a>", m.origin, token)
+
+	err := smtp.SendMail("smtp.gmail.com:587",
+		smtp.PlainAuth("", m.username, m.password, "smtp.gmail.com"),
+		m.username, []string{email}, []byte(msg))
+
+	return err
+}
+
+// SendEmailChangedMail notifies the previous email about the change
+// and sends a link that reverts it
+func (m *mailRepository) SendEmailChangedMail(email string, newEmail string, token string) error {
+
+	msg := "From: " + m.username + "\n" +
+		"To: " + email + "\n" +
+		"Subject: Email Changed\n\n" +
+		fmt.Sprintf("Your email got changed to %s. ", newEmail) +
+		fmt.Sprintf("<a href=\"%s/revert-email/%s\">This wasn't me</a>", m.origin, token)
+
+	err := smtp.SendMail("smtp.gmail.com:587",
+		smtp.PlainAuth("", m.username, m.password, "smtp.gmail.com"),
+		m.username, []string{email}, []byte(msg))
+
+	return err
+}
+
+// SendLockoutMail notifies the user that their account got locked
+// because of too many failed login attempts
+func (m *mailRepository) SendLockoutMail(email string) error {
+
+	msg := "From: " + m.username + "\n" +
+		"To: " + email + "\n" +
+		"Subject: Account Locked\n\n" +
+		"Your account got temporarily locked after too many failed login attempts. " +
+		fmt.Sprintf("If this wasn't you, <a href=\"%s/forgot-password\">reset your password</a>.", m.origin)
+
+	err := smtp.SendMail("smtp.gmail.com:587",
+		smtp.PlainAuth("", m.username, m.password, "smtp.gmail.com"),
+		m.username, []string{email}, []byte(msg))
+
+	return err
+}
+
+// SendExportReadyMail sends the download link of the user's data export
+func (m *mailRepository) SendExportReadyMail(email string, url string) error {
+
+	msg := "From: " + m.username + "\n" +
+		"To: " + email + "\n" +
+		"Subject: Your Data Export\n\n" +
+		"The export of your data is ready. The link expires in 7 days. " +
+		fmt.Sprintf("<a href=\"%s\">Download Export</a>", url)
+
+	err := smtp.SendMail("smtp.gmail.com:587",
+		smtp.PlainAuth("", m.username, m.password, "smtp.gmail.com"),
+		m.username, []string{email}, []byte(msg))
+
+	return err
+}
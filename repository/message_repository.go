@@ -28,21 +28,27 @@ func NewMessageRepository(db *gorm.DB) model.MessageRepository {
 type messageQuery struct {
 	Id            string
 	Text          *string
+	Type          string
 	CreatedAt     time.Time
 	UpdatedAt     time.Time
 	FileType      *string
 	Url           *string
 	Filename      *string
 	AttachmentId  *string
+	WebhookId     *string
+	WebhookName   *string
+	WebhookAvatar *string
 	UserId        string
 	UserCreatedAt time.Time
 	UserUpdatedAt time.Time
 	Username      string
 	Image         string
 	IsOnline      bool
+	Status        string
 	Nickname      *string
 	Color         *string
 	IsFriend      bool
+	Collapsed     bool
 }
 
 // GetMessages returns the 35 most recent messages for the given channel.
@@ -72,25 +78,35 @@ func (r *messageRepository) GetMessages(userId string, channel *model.Channel, c
 		Raw(fmt.Sprintf(`
 		SELECT messages.id,
 			messages.text,
+			messages.type,
 			messages.created_at,
 			messages.updated_at,
 			a.file_type,
 			a.url,
 			a.filename,
 			a.id                as "attachment_id",
+			messages.webhook_id,
+			messages.webhook_name,
+			messages.webhook_avatar,
 			users.id         as "user_id",
 			users.created_at as "user_created_at",
 			users.updated_at as "user_updated_at",
 			users.username,
 			users.image,
 			users.is_online,
+			CASE WHEN users.is_online THEN users.status ELSE 'offline' END AS status,
 			%s 
 			EXISTS(
 			  SELECT 1
 			  FROM users
 			   LEFT JOIN friends f ON users.id = f.user_id
 			  WHERE f.friend_id = messages.user_id
-				AND f.user_id = @userId) as is_friend
+				AND f.user_id = @userId) as is_friend,
+			EXISTS(
+			  SELECT 1
+			  FROM blocks b
+			  WHERE b.user_id = @userId
+				AND b.blocked_id = messages.user_id) as collapsed
 		FROM messages
 		LEFT JOIN "users"
 		ON users.id = messages.user_id
@@ -124,6 +140,7 @@ func (r *messageRepository) GetMessages(userId string, channel *model.Channel, c
 		message := model.MessageResponse{
 			Id:         m.Id,
 			Text:       m.Text,
+			Type:       m.Type,
 			CreatedAt:  m.CreatedAt,
 			UpdatedAt:  m.UpdatedAt,
 			Attachment: attachment,
@@ -132,13 +149,30 @@ func (r *messageRepository) GetMessages(userId string, channel *model.Channel, c
 				Username:  m.Username,
 				Image:     m.Image,
 				IsOnline:  m.IsOnline,
+				Status:    m.Status,
 				CreatedAt: m.UserCreatedAt,
 				UpdatedAt: m.UserUpdatedAt,
 				Nickname:  m.Nickname,
 				Color:     m.Color,
 				IsFriend:  m.IsFriend,
 			},
+			Collapsed: m.Collapsed,
 		}
+
+		// Webhook messages display the webhook instead of its creator
+		if m.WebhookId != nil {
+			message.WebhookId = m.WebhookId
+			message.User = model.MemberResponse{
+				Id:        *m.WebhookId,
+				Username:  *m.WebhookName,
+				Image:     *m.WebhookAvatar,
+				Status:    model.OfflineStatus,
+				CreatedAt: m.CreatedAt,
+				UpdatedAt: m.UpdatedAt,
+			}
+			message.Collapsed = false
+		}
+
 		messages = append(messages, message)
 	}
 
@@ -186,3 +220,31 @@ func (r *messageRepository) GetById(messageId string) (*model.Message, error) {
 
 	return message, nil
 }
+
+// FindAuthorChannels returns the ids of all channels the user sent messages in
+func (r *messageRepository) FindAuthorChannels(userId string) ([]string, error) {
+	var ids []string
+
+	result := r.DB.
+		Model(&model.Message{}).
+		Distinct("channel_id").
+		Where("user_id = ?", userId).
+		Pluck("channel_id", &ids)
+
+	return ids, result.Error
+}
+
+// FindByAuthorInBatches passes the messages of the user in the given channel including
+// their attachments to fn in batches, so they do not have to be held in memory at once
+func (r *messageRepository) FindByAuthorInBatches(userId string, channelId string, batchSize int, fn func(messages *[]model.Message) error) error {
+	var messages []model.Message
+
+	result := r.DB.
+		Preload("Attachment").
+		Where("user_id = ? AND channel_id = ?", userId, channelId).
+		FindInBatches(&messages, batchSize, func(tx *gorm.DB, batch int) error {
+			return fn(&messages)
+		})
+
+	return result.Error
+}
@@ -25,10 +25,113 @@ func NewRedisRepository(rds *redis.Client) model.RedisRepository {
 
 // Redis Prefixes
 const (
-	InviteLinkPrefix     = "inviteLink"
-	ForgotPasswordPrefix = "forgot-password"
+	InviteLinkPrefix        = "inviteLink"
+	ForgotPasswordPrefix    = "forgot-password"
+	VerifyEmailPrefix       = "verify-email"
+	ChangeEmailPrefix       = "change-email"
+	ChangeEmailUserPrefix   = "change-email-user"
+	RevertEmailPrefix       = "revert-email"
+	TwoFactorPrefix         = "two-factor"
+	TwoFactorAttemptsPrefix = "two-factor-attempts"
+	TwoFactorStepPrefix     = "two-factor-step"
+	LoginAttemptsPrefix     = "login-attempts"
+	LoginLockoutPrefix      = "login-lockout"
+	LoginSessionPrefix      = "login-sessions"
+	WSTicketPrefix          = "ws-ticket"
+	PresencePrefix          = "presence"
+	VoiceChannelPrefix      = "voice"
+	VoiceUserPrefix         = "voice-user"
+	PresenceUsersKey        = "presence-users"
+	VoiceStatesKey          = "voice-states"
+	InteractionPrefix       = "interaction"
+	LockPrefix              = "lock"
 )
 
+// TwoFactorTicketTTL is the time the user has to enter their second factor
+const TwoFactorTicketTTL = 5 * time.Minute
+
+// addSessionScript drops the expired sessions of the user, stores the given session
+// and returns 1 if it is the only live session of the user.
+// The user's entry in the presence set holds the expiry of their latest session.
+var addSessionScript = redis.NewScript(`
+	redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', ARGV[1])
+	local live = redis.call('ZCARD', KEYS[1])
+	redis.call('ZADD', KEYS[1], ARGV[2], ARGV[3])
+	local latest = redis.call('ZSCORE', KEYS[2], ARGV[4])
+	if not latest or tonumber(latest) < tonumber(ARGV[2]) then
+		redis.call('ZADD', KEYS[2], ARGV[2], ARGV[4])
+	end
+	if live == 0 then
+		return 1
+	end
+	return 0
+`)
+
+// removeSessionScript removes the given session and returns 1
+// if the user does not have any live sessions left
+var removeSessionScript = redis.NewScript(`
+	redis.call('ZREM', KEYS[1], ARGV[2])
+	redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', ARGV[1])
+	if redis.call('ZCARD', KEYS[1]) == 0 then
+		redis.call('DEL', KEYS[1])
+		redis.call('ZREM', KEYS[2], ARGV[3])
+		return 1
+	end
+	return 0
+`)
+
+// expireSessionsScript checks all users whose latest session expired and returns
+// the ids of those without any live session. The script runs atomically, so each
+// user only gets returned to one server instance.
+var expireSessionsScript = redis.NewScript(`
+	local expired = {}
+	local candidates = redis.call('ZRANGEBYSCORE', KEYS[1], '-inf', ARGV[1], 'LIMIT', 0, 100)
+	for _, id in ipairs(candidates) do
+		local key = ARGV[2] .. ':' .. id
+		redis.call('ZREMRANGEBYSCORE', key, '-inf', ARGV[1])
+		local latest = redis.call('ZRANGE', key, -1, -1, 'WITHSCORES')
+		if #latest == 0 then
+			redis.call('DEL', key)
+			redis.call('ZREM', KEYS[1], id)
+			table.insert(expired, id)
+		else
+			redis.call('ZADD', KEYS[1], latest[2], id)
+		end
+	end
+	return expired
+`)
+
+// expireVoiceStatesScript removes the voice states whose client stopped refreshing them
+// and returns the removed states. The set of voice states holds the expiry of each user's state.
+var expireVoiceStatesScript = redis.NewScript(`
+	local expired = {}
+	local candidates = redis.call('ZRANGEBYSCORE', KEYS[1], '-inf', ARGV[1], 'LIMIT', 0, 100)
+	for _, id in ipairs(candidates) do
+		local userKey = ARGV[3] .. ':' .. id
+		local channelId = redis.call('GET', userKey)
+		if channelId then
+			local channelKey = ARGV[2] .. ':' .. channelId
+			local state = redis.call('HGET', channelKey, id)
+			redis.call('HDEL', channelKey, id)
+			if state then
+				table.insert(expired, state)
+			end
+		end
+		redis.call('DEL', userKey)
+		redis.call('ZREM', KEYS[1], id)
+	end
+	return expired
+`)
+
+// releaseLockScript deletes the lock only if it is still held by the given owner,
+// so a run that took longer than the lock's ttl cannot release the lock of another instance
+var releaseLockScript = redis.NewScript(`
+	if redis.call('GET', KEYS[1]) == ARGV[1] then
+		return redis.call('DEL', KEYS[1])
+	end
+	return 0
+`)
+
 // SetResetToken inserts a password reset token in the DB and returns the generated token
 func (r *redisRepository) SetResetToken(ctx context.Context, id string) (string, error) {
 	uid, err := gonanoid.New()
@@ -64,6 +167,438 @@ func (r *redisRepository) GetIdFromToken(ctx context.Context, token string) (str
 	return val, nil
 }
 
+// SetVerificationToken inserts an email verification token in the DB and returns the generated token
+func (r *redisRepository) SetVerificationToken(ctx context.Context, id string) (string, error) {
+	uid, err := gonanoid.New()
+
+	if err != nil {
+		log.Printf("Failed to generate id: %v\n", err.Error())
+		return "", apperrors.NewInternal()
+	}
+
+	if err = r.rds.Set(ctx, fmt.Sprintf("%s:%s", VerifyEmailPrefix, uid), id, 24*time.Hour).Err(); err != nil {
+		log.Printf("Failed to set link in redis: %v\n", err.Error())
+		return "", apperrors.NewInternal()
+	}
+
+	return uid, nil
+}
+
+// GetIdFromVerificationToken returns the user ID from the DB for the given verification token
+func (r *redisRepository) GetIdFromVerificationToken(ctx context.Context, token string) (string, error) {
+	key := fmt.Sprintf("%s:%s", VerifyEmailPrefix, token)
+	val, err := r.rds.Get(ctx, key).Result()
+
+	if err == redis.Nil {
+		return "", apperrors.NewBadRequest(apperrors.InvalidVerificationToken)
+	}
+	if err != nil {
+		log.Printf("Failed to get value from redis: %v\n", err)
+		return "", apperrors.NewInternal()
+	}
+
+	r.rds.Del(ctx, key)
+
+	return val, nil
+}
+
+// SetEmailChangeToken inserts the pending email change in the DB and returns the generated token.
+// The user can only have one pending email change, so the previous token stops working.
+func (r *redisRepository) SetEmailChangeToken(ctx context.Context, change *model.EmailChange) (string, error) {
+	if err := r.DeleteEmailChange(ctx, change.UserId); err != nil {
+		return "", err
+	}
+
+	token, err := r.setEmailChange(ctx, ChangeEmailPrefix, change, 24*time.Hour)
+
+	if err != nil {
+		return "", err
+	}
+
+	userKey := fmt.Sprintf("%s:%s", ChangeEmailUserPrefix, change.UserId)
+	if err = r.rds.Set(ctx, userKey, token, 24*time.Hour).Err(); err != nil {
+		log.Printf("Failed to set link in redis: %v\n", err.Error())
+		return "", apperrors.NewInternal()
+	}
+
+	return token, nil
+}
+
+// DeleteEmailChange removes the pending email change of the given user
+func (r *redisRepository) DeleteEmailChange(ctx context.Context, userId string) error {
+	userKey := fmt.Sprintf("%s:%s", ChangeEmailUserPrefix, userId)
+	token, err := r.rds.Get(ctx, userKey).Result()
+
+	if err == redis.Nil {
+		return nil
+	}
+	if err != nil {
+		log.Printf("Failed to get value from redis: %v\n", err)
+		return apperrors.NewInternal()
+	}
+
+	if err = r.rds.Del(ctx, userKey, fmt.Sprintf("%s:%s", ChangeEmailPrefix, token)).Err(); err != nil {
+		log.Printf("Failed to delete email change in redis: %v\n", err.Error())
+		return apperrors.NewInternal()
+	}
+
+	return nil
+}
+
+// GetEmailChange returns the pending email change from the DB for the given token
+func (r *redisRepository) GetEmailChange(ctx context.Context, token string) (*model.EmailChange, error) {
+	return r.getEmailChange(ctx, ChangeEmailPrefix, token)
+}
+
+// SetEmailRevertToken inserts the previous email of the user in the DB and returns the generated token
+func (r *redisRepository) SetEmailRevertToken(ctx context.Context, change *model.EmailChange) (string, error) {
+	return r.setEmailChange(ctx, RevertEmailPrefix, change, 7*24*time.Hour)
+}
+
+// GetEmailRevert returns the previous email of the user from the DB for the given token
+func (r *redisRepository) GetEmailRevert(ctx context.Context, token string) (*model.EmailChange, error) {
+	return r.getEmailChange(ctx, RevertEmailPrefix, token)
+}
+
+// setEmailChange stores the email change under a generated token with the given prefix
+func (r *redisRepository) setEmailChange(ctx context.Context, prefix string, change *model.EmailChange, ttl time.Duration) (string, error) {
+	uid, err := gonanoid.New()
+
+	if err != nil {
+		log.Printf("Failed to generate id: %v\n", err.Error())
+		return "", apperrors.NewInternal()
+	}
+
+	value, err := json.Marshal(change)
+
+	if err != nil {
+		log.Printf("Error marshalling: %v\n", err.Error())
+		return "", apperrors.NewInternal()
+	}
+
+	if err = r.rds.Set(ctx, fmt.Sprintf("%s:%s", prefix, uid), value, ttl).Err(); err != nil {
+		log.Printf("Failed to set link in redis: %v\n", err.Error())
+		return "", apperrors.NewInternal()
+	}
+
+	return uid, nil
+}
+
+// getEmailChange returns and removes the email change stored under the given token
+func (r *redisRepository) getEmailChange(ctx context.Context, prefix string, token string) (*model.EmailChange, error) {
+	key := fmt.Sprintf("%s:%s", prefix, token)
+	val, err := r.rds.Get(ctx, key).Result()
+
+	if err == redis.Nil {
+		return nil, apperrors.NewBadRequest(apperrors.InvalidEmailChangeToken)
+	}
+	if err != nil {
+		log.Printf("Failed to get value from redis: %v\n", err)
+		return nil, apperrors.NewInternal()
+	}
+
+	r.rds.Del(ctx, key)
+
+	var change model.EmailChange
+	if err = json.Unmarshal([]byte(val), &change); err != nil {
+		log.Printf("Error unmarshalling: %v\n", err.Error())
+		return nil, apperrors.NewInternal()
+	}
+
+	return &change, nil
+}
+
+// AddFailedLogin counts a failed login for the given key and returns the number
+// of failed logins since the first one in the window
+func (r *redisRepository) AddFailedLogin(ctx context.Context, key string, window time.Duration) (int64, error) {
+	attemptsKey := fmt.Sprintf("%s:%s", LoginAttemptsPrefix, key)
+	count, err := r.rds.Incr(ctx, attemptsKey).Result()
+
+	if err != nil {
+		log.Printf("Failed to count failed login in redis: %v\n", err.Error())
+		return 0, apperrors.NewInternal()
+	}
+
+	if count == 1 {
+		if err = r.rds.Expire(ctx, attemptsKey, window).Err(); err != nil {
+			log.Printf("Failed to set expiry in redis: %v\n", err.Error())
+			return 0, apperrors.NewInternal()
+		}
+	}
+
+	return count, nil
+}
+
+// ResetFailedLogins removes the failed logins and the lockout of the given key
+func (r *redisRepository) ResetFailedLogins(ctx context.Context, key string) error {
+	err := r.rds.Del(ctx,
+		fmt.Sprintf("%s:%s", LoginAttemptsPrefix, key),
+		fmt.Sprintf("%s:%s", LoginLockoutPrefix, key),
+	).Err()
+
+	if err != nil {
+		log.Printf("Failed to reset failed logins in redis: %v\n", err.Error())
+		return apperrors.NewInternal()
+	}
+
+	return nil
+}
+
+// SetLoginLockout blocks logins for the given key for the given duration
+func (r *redisRepository) SetLoginLockout(ctx context.Context, key string, duration time.Duration) error {
+	if err := r.rds.Set(ctx, fmt.Sprintf("%s:%s", LoginLockoutPrefix, key), 1, duration).Err(); err != nil {
+		log.Printf("Failed to set login lockout in redis: %v\n", err.Error())
+		return apperrors.NewInternal()
+	}
+
+	return nil
+}
+
+// GetLoginLockout returns the remaining time logins for the given key are blocked.
+// Returns zero if there is no lockout.
+func (r *redisRepository) GetLoginLockout(ctx context.Context, key string) (time.Duration, error) {
+	ttl, err := r.rds.PTTL(ctx, fmt.Sprintf("%s:%s", LoginLockoutPrefix, key)).Result()
+
+	if err != nil {
+		log.Printf("Failed to get login lockout from redis: %v\n", err.Error())
+		return 0, apperrors.NewInternal()
+	}
+
+	// Negative values mean that the key does not exist or does not expire
+	if ttl < 0 {
+		return 0, nil
+	}
+
+	return ttl, nil
+}
+
+// SetTwoFactorTicket inserts a ticket for a login that still awaits
+// the second factor in the DB and returns the generated ticket
+func (r *redisRepository) SetTwoFactorTicket(ctx context.Context, id string) (string, error) {
+	ticket, err := gonanoid.New(32)
+
+	if err != nil {
+		log.Printf("Failed to generate id: %v\n", err.Error())
+		return "", apperrors.NewInternal()
+	}
+
+	if err = r.rds.Set(ctx, fmt.Sprintf("%s:%s", TwoFactorPrefix, ticket), id, TwoFactorTicketTTL).Err(); err != nil {
+		log.Printf("Failed to set ticket in redis: %v\n", err.Error())
+		return "", apperrors.NewInternal()
+	}
+
+	return ticket, nil
+}
+
+// GetIdFromTwoFactorTicket returns the user ID for the given ticket.
+// The ticket stays valid, so the user can retry a mistyped code,
+// until it gets deleted after too many failed attempts.
+func (r *redisRepository) GetIdFromTwoFactorTicket(ctx context.Context, ticket string) (string, error) {
+	key := fmt.Sprintf("%s:%s", TwoFactorPrefix, ticket)
+	val, err := r.rds.Get(ctx, key).Result()
+
+	if err == redis.Nil {
+		return "", apperrors.NewAuthorization(apperrors.InvalidTwoFactorTicket)
+	}
+	if err != nil {
+		log.Printf("Failed to get value from redis: %v\n", err)
+		return "", apperrors.NewInternal()
+	}
+
+	return val, nil
+}
+
+// AddFailedTwoFactorAttempt counts a wrong code entered for the ticket
+// and returns the number of failed attempts
+func (r *redisRepository) AddFailedTwoFactorAttempt(ctx context.Context, ticket string) (int64, error) {
+	attemptsKey := fmt.Sprintf("%s:%s", TwoFactorAttemptsPrefix, ticket)
+	count, err := r.rds.Incr(ctx, attemptsKey).Result()
+
+	if err != nil {
+		log.Printf("Failed to count failed attempt in redis: %v\n", err.Error())
+		return 0, apperrors.NewInternal()
+	}
+
+	if count == 1 {
+		if err = r.rds.Expire(ctx, attemptsKey, TwoFactorTicketTTL).Err(); err != nil {
+			log.Printf("Failed to set expiry in redis: %v\n", err.Error())
+			return 0, apperrors.NewInternal()
+		}
+	}
+
+	return count, nil
+}
+
+// DeleteTwoFactorTicket removes the ticket and its failed attempts
+// once the login succeeded or too many wrong codes got entered
+func (r *redisRepository) DeleteTwoFactorTicket(ctx context.Context, ticket string) error {
+	if err := r.rds.Del(ctx,
+		fmt.Sprintf("%s:%s", TwoFactorPrefix, ticket),
+		fmt.Sprintf("%s:%s", TwoFactorAttemptsPrefix, ticket),
+	).Err(); err != nil {
+		log.Printf("Failed to delete ticket in redis: %v\n", err)
+		return apperrors.NewInternal()
+	}
+
+	return nil
+}
+
+// UseTwoFactorStep marks the time step of the user's authenticator code as used
+// for the given window. Returns false if the code of that step was used before.
+func (r *redisRepository) UseTwoFactorStep(ctx context.Context, userId string, step int64, window time.Duration) (bool, error) {
+	key := fmt.Sprintf("%s:%s:%d", TwoFactorStepPrefix, userId, step)
+	ok, err := r.rds.SetNX(ctx, key, 1, window).Result()
+
+	if err != nil {
+		log.Printf("Failed to set used step in redis: %v\n", err.Error())
+		return false, apperrors.NewInternal()
+	}
+
+	return ok, nil
+}
+
+// SaveLoginSession stores or updates the given login session of the user.
+// The sessions of a user expire together once none got used for the lifetime of a session.
+func (r *redisRepository) SaveLoginSession(ctx context.Context, userId string, session *model.LoginSession) error {
+	value, err := json.Marshal(session)
+
+	if err != nil {
+		log.Printf("Error marshalling: %v\n", err.Error())
+		return apperrors.NewInternal()
+	}
+
+	key := fmt.Sprintf("%s:%s", LoginSessionPrefix, userId)
+
+	pipe := r.rds.TxPipeline()
+	pipe.HSet(ctx, key, session.ID, value)
+	pipe.Expire(ctx, key, model.SessionMaxAge*time.Second)
+
+	if _, err = pipe.Exec(ctx); err != nil {
+		log.Printf("Failed to save login session in redis: %v\n", err.Error())
+		return apperrors.NewInternal()
+	}
+
+	return nil
+}
+
+// GetLoginSession returns the given login session of the user
+func (r *redisRepository) GetLoginSession(ctx context.Context, userId string, sessionId string) (*model.LoginSession, error) {
+	value, err := r.rds.HGet(ctx, fmt.Sprintf("%s:%s", LoginSessionPrefix, userId), sessionId).Result()
+
+	if err == redis.Nil {
+		return nil, apperrors.NewNotFound("session", sessionId)
+	}
+	if err != nil {
+		log.Printf("Failed to get login session from redis: %v\n", err.Error())
+		return nil, apperrors.NewInternal()
+	}
+
+	var session model.LoginSession
+	if err = json.Unmarshal([]byte(value), &session); err != nil {
+		log.Printf("Error unmarshalling: %v\n", err.Error())
+		return nil, apperrors.NewInternal()
+	}
+
+	return &session, nil
+}
+
+// GetLoginSessions returns all login sessions of the user
+func (r *redisRepository) GetLoginSessions(ctx context.Context, userId string) (*[]model.LoginSession, error) {
+	values, err := r.rds.HVals(ctx, fmt.Sprintf("%s:%s", LoginSessionPrefix, userId)).Result()
+
+	if err != nil {
+		log.Printf("Failed to get login sessions from redis: %v\n", err.Error())
+		return nil, apperrors.NewInternal()
+	}
+
+	sessions := make([]model.LoginSession, 0, len(values))
+	for _, value := range values {
+		var session model.LoginSession
+		if err = json.Unmarshal([]byte(value), &session); err != nil {
+			log.Printf("Error unmarshalling: %v\n", err.Error())
+			continue
+		}
+		sessions = append(sessions, session)
+	}
+
+	return &sessions, nil
+}
+
+// DeleteLoginSession removes the given login session of the user
+func (r *redisRepository) DeleteLoginSession(ctx context.Context, userId string, sessionId string) error {
+	if err := r.rds.HDel(ctx, fmt.Sprintf("%s:%s", LoginSessionPrefix, userId), sessionId).Err(); err != nil {
+		log.Printf("Failed to delete login session in redis: %v\n", err.Error())
+		return apperrors.NewInternal()
+	}
+
+	return nil
+}
+
+// DeleteOtherLoginSessions removes all login sessions of the user except the given one.
+// Removes all sessions if keepId is empty.
+func (r *redisRepository) DeleteOtherLoginSessions(ctx context.Context, userId string, keepId string) error {
+	key := fmt.Sprintf("%s:%s", LoginSessionPrefix, userId)
+	ids, err := r.rds.HKeys(ctx, key).Result()
+
+	if err != nil {
+		log.Printf("Failed to get login sessions from redis: %v\n", err.Error())
+		return apperrors.NewInternal()
+	}
+
+	others := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if id != keepId {
+			others = append(others, id)
+		}
+	}
+
+	if len(others) == 0 {
+		return nil
+	}
+
+	if err = r.rds.HDel(ctx, key, others...).Err(); err != nil {
+		log.Printf("Failed to delete login sessions in redis: %v\n", err.Error())
+		return apperrors.NewInternal()
+	}
+
+	return nil
+}
+
+// SetWSTicket inserts a single use websocket ticket for the given user
+// in the DB and returns the generated ticket
+func (r *redisRepository) SetWSTicket(ctx context.Context, id string) (string, error) {
+	ticket, err := gonanoid.New(32)
+
+	if err != nil {
+		log.Printf("Failed to generate id: %v\n", err.Error())
+		return "", apperrors.NewInternal()
+	}
+
+	if err = r.rds.Set(ctx, fmt.Sprintf("%s:%s", WSTicketPrefix, ticket), id, 30*time.Second).Err(); err != nil {
+		log.Printf("Failed to set ticket in redis: %v\n", err.Error())
+		return "", apperrors.NewInternal()
+	}
+
+	return ticket, nil
+}
+
+// GetIdFromWSTicket returns the user ID for the given ticket and deletes
+// the ticket in the same step, so it can only be used once
+func (r *redisRepository) GetIdFromWSTicket(ctx context.Context, ticket string) (string, error) {
+	key := fmt.Sprintf("%s:%s", WSTicketPrefix, ticket)
+	val, err := r.rds.GetDel(ctx, key).Result()
+
+	if err == redis.Nil {
+		return "", apperrors.NewAuthorization(apperrors.InvalidWSTicket)
+	}
+	if err != nil {
+		log.Printf("Failed to get value from redis: %v\n", err)
+		return "", apperrors.NewInternal()
+	}
+
+	return val, nil
+}
+
 // SaveInvite inserts an invite for the given guild in the DB.
 // If isPermanent is true, the invite won't expire
 func (r *redisRepository) SaveInvite(ctx context.Context, guildId string, id string, isPermanent bool) error {
@@ -122,3 +657,298 @@ func (r *redisRepository) InvalidateInvites(ctx context.Context, guild *model.Gu
 		r.rds.Del(ctx, key)
 	}
 }
+
+// AddSession stores or refreshes the given websocket session of the user.
+// The session expires after the given ttl if it does not get refreshed.
+// Returns true if it is the only live session of the user.
+func (r *redisRepository) AddSession(ctx context.Context, userId string, sessionId string, ttl time.Duration) (bool, error) {
+	now := time.Now()
+	keys := []string{fmt.Sprintf("%s:%s", PresencePrefix, userId), PresenceUsersKey}
+
+	isFirst, err := addSessionScript.Run(ctx, r.rds, keys,
+		now.UnixMilli(), now.Add(ttl).UnixMilli(), sessionId, userId).Int()
+
+	if err != nil {
+		log.Printf("Failed to add session in redis: %v\n", err.Error())
+		return false, apperrors.NewInternal()
+	}
+
+	return isFirst == 1, nil
+}
+
+// RemoveSession removes the given websocket session of the user.
+// Returns true if the user does not have any live sessions left.
+func (r *redisRepository) RemoveSession(ctx context.Context, userId string, sessionId string) (bool, error) {
+	keys := []string{fmt.Sprintf("%s:%s", PresencePrefix, userId), PresenceUsersKey}
+
+	isLast, err := removeSessionScript.Run(ctx, r.rds, keys,
+		time.Now().UnixMilli(), sessionId, userId).Int()
+
+	if err != nil {
+		log.Printf("Failed to remove session from redis: %v\n", err.Error())
+		return false, apperrors.NewInternal()
+	}
+
+	return isLast == 1, nil
+}
+
+// HasLiveSession checks if the user has any session that did not expire yet
+func (r *redisRepository) HasLiveSession(ctx context.Context, userId string) (bool, error) {
+	key := fmt.Sprintf("%s:%s", PresencePrefix, userId)
+	count, err := r.rds.ZCount(ctx, key, fmt.Sprintf("(%d", time.Now().UnixMilli()), "+inf").Result()
+
+	if err != nil {
+		log.Printf("Failed to get sessions from redis: %v\n", err.Error())
+		return false, apperrors.NewInternal()
+	}
+
+	return count > 0, nil
+}
+
+// ExpireSessions removes all expired sessions and returns
+// the ids of the users whose last session expired
+func (r *redisRepository) ExpireSessions(ctx context.Context) ([]string, error) {
+	result, err := expireSessionsScript.Run(ctx, r.rds, []string{PresenceUsersKey},
+		time.Now().UnixMilli(), PresencePrefix).Result()
+
+	if err != nil {
+		log.Printf("Failed to expire sessions in redis: %v\n", err.Error())
+		return nil, apperrors.NewInternal()
+	}
+
+	values, _ := result.([]interface{})
+	ids := make([]string, 0, len(values))
+	for _, id := range values {
+		ids = append(ids, id.(string))
+	}
+
+	return ids, nil
+}
+
+// SaveVoiceState stores the voice state of the user in the participants of
+// their voice channel and remembers the channel the user is connected to.
+// The state expires after the given ttl unless it gets refreshed.
+func (r *redisRepository) SaveVoiceState(ctx context.Context, state *model.VoiceState, ttl time.Duration) error {
+	value, err := json.Marshal(state)
+
+	if err != nil {
+		log.Printf("Error marshalling: %v\n", err.Error())
+		return apperrors.NewInternal()
+	}
+
+	_, err = r.rds.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, fmt.Sprintf("%s:%s", VoiceChannelPrefix, state.ChannelId), state.UserId, value)
+		pipe.Set(ctx, fmt.Sprintf("%s:%s", VoiceUserPrefix, state.UserId), state.ChannelId, 0)
+		pipe.ZAdd(ctx, VoiceStatesKey, &redis.Z{
+			Score:  float64(time.Now().Add(ttl).UnixMilli()),
+			Member: state.UserId,
+		})
+		return nil
+	})
+
+	if err != nil {
+		log.Printf("Failed to set voice state in redis: %v\n", err.Error())
+		return apperrors.NewInternal()
+	}
+
+	return nil
+}
+
+// GetVoiceState returns the voice state of the user or nil
+// if they are not connected to a voice channel
+func (r *redisRepository) GetVoiceState(ctx context.Context, userId string) (*model.VoiceState, error) {
+	channelId, err := r.rds.Get(ctx, fmt.Sprintf("%s:%s", VoiceUserPrefix, userId)).Result()
+
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		log.Printf("Failed to get voice channel from redis: %v\n", err.Error())
+		return nil, apperrors.NewInternal()
+	}
+
+	value, err := r.rds.HGet(ctx, fmt.Sprintf("%s:%s", VoiceChannelPrefix, channelId), userId).Result()
+
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		log.Printf("Failed to get voice state from redis: %v\n", err.Error())
+		return nil, apperrors.NewInternal()
+	}
+
+	var state model.VoiceState
+	if err = json.Unmarshal([]byte(value), &state); err != nil {
+		log.Printf("Error unmarshalling: %v\n", err.Error())
+		return nil, apperrors.NewInternal()
+	}
+
+	return &state, nil
+}
+
+// RemoveVoiceState removes the user from the participants of the voice channel
+func (r *redisRepository) RemoveVoiceState(ctx context.Context, state *model.VoiceState) error {
+	_, err := r.rds.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HDel(ctx, fmt.Sprintf("%s:%s", VoiceChannelPrefix, state.ChannelId), state.UserId)
+		pipe.Del(ctx, fmt.Sprintf("%s:%s", VoiceUserPrefix, state.UserId))
+		pipe.ZRem(ctx, VoiceStatesKey, state.UserId)
+		return nil
+	})
+
+	if err != nil {
+		log.Printf("Failed to remove voice state from redis: %v\n", err.Error())
+		return apperrors.NewInternal()
+	}
+
+	return nil
+}
+
+// RefreshVoiceState extends the expiry of the user's voice state by the given ttl.
+// Does nothing if the user is not connected to a voice channel.
+func (r *redisRepository) RefreshVoiceState(ctx context.Context, userId string, ttl time.Duration) error {
+	err := r.rds.ZAddXX(ctx, VoiceStatesKey, &redis.Z{
+		Score:  float64(time.Now().Add(ttl).UnixMilli()),
+		Member: userId,
+	}).Err()
+
+	if err != nil {
+		log.Printf("Failed to refresh voice state in redis: %v\n", err.Error())
+		return apperrors.NewInternal()
+	}
+
+	return nil
+}
+
+// ExpireVoiceStates removes all voice states that did not get
+// refreshed in time and returns them
+func (r *redisRepository) ExpireVoiceStates(ctx context.Context) (*[]model.VoiceState, error) {
+	result, err := expireVoiceStatesScript.Run(ctx, r.rds, []string{VoiceStatesKey},
+		time.Now().UnixMilli(), VoiceChannelPrefix, VoiceUserPrefix).Result()
+
+	if err != nil {
+		log.Printf("Failed to expire voice states in redis: %v\n", err.Error())
+		return nil, apperrors.NewInternal()
+	}
+
+	values, _ := result.([]interface{})
+	states := make([]model.VoiceState, 0, len(values))
+	for _, value := range values {
+		var state model.VoiceState
+		if err = json.Unmarshal([]byte(value.(string)), &state); err != nil {
+			log.Printf("Error unmarshalling: %v\n", err.Error())
+			continue
+		}
+		states = append(states, state)
+	}
+
+	return &states, nil
+}
+
+// GetVoiceStates returns the voice states of all participants of the given channel
+func (r *redisRepository) GetVoiceStates(ctx context.Context, channelId string) (*[]model.VoiceState, error) {
+	values, err := r.rds.HVals(ctx, fmt.Sprintf("%s:%s", VoiceChannelPrefix, channelId)).Result()
+
+	if err != nil {
+		log.Printf("Failed to get voice states from redis: %v\n", err.Error())
+		return nil, apperrors.NewInternal()
+	}
+
+	states := make([]model.VoiceState, 0, len(values))
+	for _, value := range values {
+		var state model.VoiceState
+		if err = json.Unmarshal([]byte(value), &state); err != nil {
+			log.Printf("Error unmarshalling: %v\n", err.Error())
+			continue
+		}
+		states = append(states, state)
+	}
+
+	return &states, nil
+}
+
+// SaveInteraction stores the interaction so the bot can respond to it later.
+// Interactions expire after 15 minutes.
+func (r *redisRepository) SaveInteraction(ctx context.Context, interaction *model.Interaction) error {
+	value, err := json.Marshal(interaction)
+
+	if err != nil {
+		log.Printf("Error marshalling: %v\n", err.Error())
+		return apperrors.NewInternal()
+	}
+
+	key := fmt.Sprintf("%s:%s", InteractionPrefix, interaction.Id)
+	if err = r.rds.Set(ctx, key, value, 15*time.Minute).Err(); err != nil {
+		log.Printf("Failed to set interaction in redis: %v\n", err.Error())
+		return apperrors.NewInternal()
+	}
+
+	return nil
+}
+
+// GetInteraction returns the pending interaction for the given id
+func (r *redisRepository) GetInteraction(ctx context.Context, id string) (*model.Interaction, error) {
+	val, err := r.rds.Get(ctx, fmt.Sprintf("%s:%s", InteractionPrefix, id)).Result()
+
+	if err == redis.Nil {
+		return nil, apperrors.NewNotFound("interaction", id)
+	}
+	if err != nil {
+		log.Printf("Failed to get value from redis: %v\n", err)
+		return nil, apperrors.NewInternal()
+	}
+
+	var interaction model.Interaction
+	if err = json.Unmarshal([]byte(val), &interaction); err != nil {
+		log.Printf("Error unmarshalling: %v\n", err.Error())
+		return nil, apperrors.NewInternal()
+	}
+
+	return &interaction, nil
+}
+
+// DeleteInteraction removes the interaction once the bot responded
+func (r *redisRepository) DeleteInteraction(ctx context.Context, id string) error {
+	if err := r.rds.Del(ctx, fmt.Sprintf("%s:%s", InteractionPrefix, id)).Err(); err != nil {
+		log.Printf("Failed to delete interaction in redis: %v\n", err)
+		return apperrors.NewInternal()
+	}
+
+	return nil
+}
+
+// AcquireLock claims the lock with the given name for the ttl.
+// Returns the id needed to release the lock or an empty string
+// if another instance holds the lock.
+func (r *redisRepository) AcquireLock(ctx context.Context, name string, ttl time.Duration) (string, error) {
+	id, err := gonanoid.New()
+
+	if err != nil {
+		log.Printf("Failed to generate id: %v\n", err.Error())
+		return "", apperrors.NewInternal()
+	}
+
+	acquired, err := r.rds.SetNX(ctx, fmt.Sprintf("%s:%s", LockPrefix, name), id, ttl).Result()
+
+	if err != nil {
+		log.Printf("Failed to acquire lock in redis: %v\n", err.Error())
+		return "", apperrors.NewInternal()
+	}
+
+	if !acquired {
+		return "", nil
+	}
+
+	return id, nil
+}
+
+// ReleaseLock releases the given lock if it is still held with the given id
+func (r *redisRepository) ReleaseLock(ctx context.Context, name string, lockId string) error {
+	key := fmt.Sprintf("%s:%s", LockPrefix, name)
+
+	if err := releaseLockScript.Run(ctx, r.rds, []string{key}, lockId).Err(); err != nil {
+		log.Printf("Failed to release lock in redis: %v\n", err.Error())
+		return apperrors.NewInternal()
+	}
+
+	return nil
+}
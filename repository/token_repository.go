@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"errors"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"gorm.io/gorm"
+	"log"
+	"time"
+)
+
+// tokenRepository is data/repository implementation
+// of service layer TokenRepository
+type tokenRepository struct {
+	DB *gorm.DB
+}
+
+// NewTokenRepository is a factory for initializing Token Repositories
+func NewTokenRepository(db *gorm.DB) model.TokenRepository {
+	return &tokenRepository{
+		DB: db,
+	}
+}
+
+// FindByID returns the access token for the given ID
+func (r *tokenRepository) FindByID(id string) (*model.AccessToken, error) {
+	token := &model.AccessToken{}
+
+	if err := r.DB.Where("id = ?", id).First(&token).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return token, apperrors.NewNotFound("token", id)
+		}
+		return token, apperrors.NewInternal()
+	}
+
+	return token, nil
+}
+
+// FindByHash returns the access token with the given hash
+func (r *tokenRepository) FindByHash(hash string) (*model.AccessToken, error) {
+	token := &model.AccessToken{}
+
+	if err := r.DB.Where("token_hash = ?", hash).First(&token).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return token, apperrors.NewAuthorization(apperrors.InvalidAccessToken)
+		}
+		return token, apperrors.NewInternal()
+	}
+
+	return token, nil
+}
+
+// FindByOwner returns all access tokens the given user created
+func (r *tokenRepository) FindByOwner(ownerId string) (*[]model.AccessToken, error) {
+	var tokens []model.AccessToken
+
+	result := r.DB.
+		Where("owner_id = ?", ownerId).
+		Order("created_at DESC").
+		Find(&tokens)
+
+	return &tokens, result.Error
+}
+
+// Create inserts the access token in the DB
+func (r *tokenRepository) Create(token *model.AccessToken) error {
+	if result := r.DB.Create(&token); result.Error != nil {
+		log.Printf("Could not create an access token for user: %v. Reason: %v\n", token.UserId, result.Error)
+		return apperrors.NewInternal()
+	}
+
+	return nil
+}
+
+// Delete removes the access token from the DB
+func (r *tokenRepository) Delete(token *model.AccessToken) error {
+	if result := r.DB.Delete(&token); result.Error != nil {
+		log.Printf("Could not delete the access token with id: %v. Reason: %v\n", token.ID, result.Error)
+		return apperrors.NewInternal()
+	}
+
+	return nil
+}
+
+// UpdateLastUsed sets the time the access token was last used
+func (r *tokenRepository) UpdateLastUsed(id string, lastUsedAt time.Time) error {
+	return r.DB.
+		Table("access_tokens").
+		Where("id = ?", id).
+		Update("last_used_at", lastUsedAt).
+		Error
+}
@@ -8,6 +8,7 @@ import (
 	"gorm.io/gorm"
 	"log"
 	"regexp"
+	"time"
 )
 
 // userRepository is data/repository implementation
@@ -104,6 +105,103 @@ func (r *userRepository) GetRequestCount(userId string) (*int64, error) {
 	return &count, err
 }
 
+// FindBots returns the bot accounts the given user owns
+func (r *userRepository) FindBots(ownerId string) (*[]model.User, error) {
+	var bots []model.User
+
+	result := r.DB.
+		Where("is_bot = true AND bot_owner_id = ?", ownerId).
+		Order("created_at ASC").
+		Find(&bots)
+
+	return &bots, result.Error
+}
+
+// FindScheduledForDeletion returns the users whose deletion was scheduled before the given time
+func (r *userRepository) FindScheduledForDeletion(before time.Time) (*[]model.User, error) {
+	var users []model.User
+
+	result := r.DB.
+		Where("deletion_scheduled_at <= ?", before).
+		Order("deletion_scheduled_at ASC").
+		Find(&users)
+
+	return &users, result.Error
+}
+
+// Anonymize removes the friends, requests, blocks, guild and DM memberships,
+// credentials and commands of the user and saves the anonymized user.
+// The row is kept so the messages of the user remain in their channels.
+// Owned group DMs get passed on to the next member or deleted if nobody is left.
+func (r *userRepository) Anonymize(user *model.User) error {
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		id := sql.Named("id", user.ID)
+
+		var emptyGroups []string
+		if err := tx.Raw(`
+			SELECT c.id
+			FROM channels c
+			JOIN dm_members dm ON dm."channel_id" = c.id
+			WHERE c."is_group" = true
+			AND dm."user_id" = @id
+			AND NOT EXISTS(
+				SELECT 1
+				FROM dm_members o
+				WHERE o."channel_id" = c.id AND o."user_id" <> @id
+			)
+		`, id).Scan(&emptyGroups).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Exec(`
+			UPDATE channels c
+			SET owner_id = (
+				SELECT dm."user_id"
+				FROM dm_members dm
+				WHERE dm."channel_id" = c.id AND dm."user_id" <> @id
+				ORDER BY dm."created_at"
+				LIMIT 1
+			)
+			WHERE c."is_group" = true AND c."owner_id" = @id
+		`, id).Error; err != nil {
+			return err
+		}
+
+		statements := []string{
+			"DELETE FROM friends WHERE user_id = @id OR friend_id = @id",
+			"DELETE FROM friend_requests WHERE sender_id = @id OR receiver_id = @id",
+			"DELETE FROM blocks WHERE user_id = @id OR blocked_id = @id",
+			"DELETE FROM members WHERE user_id = @id",
+			"DELETE FROM dm_members WHERE user_id = @id",
+			"DELETE FROM pcmembers WHERE user_id = @id",
+			"DELETE FROM access_tokens WHERE user_id = @id OR owner_id = @id",
+			"DELETE FROM user_identities WHERE user_id = @id",
+			"DELETE FROM commands WHERE bot_id = @id",
+		}
+
+		for _, statement := range statements {
+			if err := tx.Exec(statement, id).Error; err != nil {
+				return err
+			}
+		}
+
+		if len(emptyGroups) > 0 {
+			if err := tx.Exec("DELETE FROM channels WHERE id IN ?", emptyGroups).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Save(&user).Error
+	})
+
+	if err != nil {
+		log.Printf("Could not anonymize the user with id: %v. Reason: %v\n", user.ID, err)
+		return apperrors.NewInternal()
+	}
+
+	return nil
+}
+
 // isDuplicateKeyError checks if the provided error is a PostgreSQL duplicate key error
 func isDuplicateKeyError(err error) bool {
 	duplicate := regexp.MustCompile(`\(SQLSTATE 23505\)$`)
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"errors"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"gorm.io/gorm"
+	"log"
+)
+
+// webhookRepository is data/repository implementation
+// of service layer WebhookRepository
+type webhookRepository struct {
+	DB *gorm.DB
+}
+
+// NewWebhookRepository is a factory for initializing Webhook Repositories
+func NewWebhookRepository(db *gorm.DB) model.WebhookRepository {
+	return &webhookRepository{
+		DB: db,
+	}
+}
+
+// FindByID returns the webhook for the given ID
+func (r *webhookRepository) FindByID(id string) (*model.Webhook, error) {
+	webhook := &model.Webhook{}
+
+	if err := r.DB.Where("id = ?", id).First(&webhook).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return webhook, apperrors.NewNotFound("webhook", id)
+		}
+		return webhook, apperrors.NewInternal()
+	}
+
+	return webhook, nil
+}
+
+// FindByChannel returns the webhooks of the given channel
+func (r *webhookRepository) FindByChannel(channelId string) (*[]model.Webhook, error) {
+	var webhooks []model.Webhook
+
+	result := r.DB.
+		Where("channel_id = ?", channelId).
+		Order("created_at ASC").
+		Find(&webhooks)
+
+	return &webhooks, result.Error
+}
+
+// Create inserts the webhook in the DB
+func (r *webhookRepository) Create(webhook *model.Webhook) (*model.Webhook, error) {
+	if result := r.DB.Create(&webhook); result.Error != nil {
+		log.Printf("Could not create a webhook for channel: %v. Reason: %v\n", webhook.ChannelId, result.Error)
+		return nil, apperrors.NewInternal()
+	}
+
+	return webhook, nil
+}
+
+// Update updates the webhook in the DB
+func (r *webhookRepository) Update(webhook *model.Webhook) error {
+	if result := r.DB.Save(&webhook); result.Error != nil {
+		log.Printf("Could not update the webhook with id: %v. Reason: %v\n", webhook.ID, result.Error)
+		return apperrors.NewInternal()
+	}
+
+	return nil
+}
+
+// Delete removes the webhook from the DB
+func (r *webhookRepository) Delete(webhook *model.Webhook) error {
+	if result := r.DB.Delete(&webhook); result.Error != nil {
+		log.Printf("Could not delete the webhook with id: %v. Reason: %v\n", webhook.ID, result.Error)
+		return apperrors.NewInternal()
+	}
+
+	return nil
+}
@@ -1,15 +1,21 @@
 package service
 
 import (
+	"context"
 	"github.com/sentrionic/valkyrie/model"
 	"github.com/sentrionic/valkyrie/model/apperrors"
 )
 
+// VoiceStateTTL is the time after which a user gets disconnected from
+// their voice channel if their client did not send a heartbeat
+const VoiceStateTTL = SessionTTL
+
 // channelService acts as a struct for injecting an implementation of ChannelRepository
 // for use in service methods
 type channelService struct {
 	ChannelRepository model.ChannelRepository
 	GuildRepository   model.GuildRepository
+	RedisRepository   model.RedisRepository
 }
 
 // CSConfig will hold repositories that will eventually be injected into
@@ -17,6 +23,7 @@ type channelService struct {
 type CSConfig struct {
 	ChannelRepository model.ChannelRepository
 	GuildRepository   model.GuildRepository
+	RedisRepository   model.RedisRepository
 }
 
 // NewChannelService is a factory function for
@@ -25,6 +32,7 @@ func NewChannelService(c *CSConfig) model.ChannelService {
 	return &channelService{
 		ChannelRepository: c.ChannelRepository,
 		GuildRepository:   c.GuildRepository,
+		RedisRepository:   c.RedisRepository,
 	}
 }
 
@@ -37,6 +45,10 @@ func (c *channelService) CreateChannel(channel *model.Channel) (*model.Channel,
 
 	channel.ID = id
 
+	if channel.Type == "" {
+		channel.Type = model.TextChannel
+	}
+
 	return c.ChannelRepository.Create(channel)
 }
 
@@ -56,6 +68,10 @@ func (c *channelService) GetDirectMessages(userId string) (*[]model.DirectMessag
 	return c.ChannelRepository.GetDirectMessages(userId)
 }
 
+func (c *channelService) GetDirectMessage(channelId string, userId string) (*model.DirectMessage, error) {
+	return c.ChannelRepository.GetDirectMessage(channelId, userId)
+}
+
 func (c *channelService) GetDirectMessageChannel(userId string, memberId string) (*string, error) {
 	return c.ChannelRepository.GetDirectMessageChannel(userId, memberId)
 }
@@ -85,6 +101,57 @@ func (c *channelService) SetDirectMessageStatus(dmId string, userId string, isOp
 	return c.ChannelRepository.SetDirectMessageStatus(dmId, userId, isOpen)
 }
 
+func (c *channelService) GetDMRequests(userId string) (*[]model.DirectMessage, error) {
+	return c.ChannelRepository.GetDMRequests(userId)
+}
+
+func (c *channelService) SetDirectMessageRequest(dmId string, userId string, isRequest bool) error {
+	return c.ChannelRepository.SetDirectMessageRequest(dmId, userId, isRequest)
+}
+
+// AllowsDirectMessage checks if the sender can start a DM with the given user.
+// Friends can always start a DM. Otherwise, the user's DM privacy setting applies,
+// unless they overrode it in a guild they share with the sender. Allowing DMs in
+// one shared guild wins over disabling them in another one.
+func (c *channelService) AllowsDirectMessage(user *model.User, senderId string) (bool, error) {
+	for _, friend := range user.Friends {
+		if friend.ID == senderId {
+			return true, nil
+		}
+	}
+
+	settings, err := c.GuildRepository.GetSharedMemberSettings(user.ID, senderId)
+
+	if err != nil {
+		return false, err
+	}
+
+	sharesGuild := false
+	denied := false
+	for _, s := range *settings {
+		if s.AllowDMs == nil {
+			sharesGuild = true
+		} else if *s.AllowDMs {
+			return true, nil
+		} else {
+			denied = true
+		}
+	}
+
+	if denied {
+		return false, nil
+	}
+
+	switch user.DMPrivacy {
+	case model.DMPrivacyFriends:
+		return false, nil
+	case model.DMPrivacyGuildMembers:
+		return sharesGuild, nil
+	default:
+		return true, nil
+	}
+}
+
 func (c *channelService) DeleteChannel(channel *model.Channel) error {
 	return c.ChannelRepository.DeleteChannel(channel)
 }
@@ -113,6 +180,14 @@ func (c *channelService) GetDMByUserAndChannel(userId string, channelId string)
 	return c.ChannelRepository.FindDMByUserAndChannelId(channelId, userId)
 }
 
+func (c *channelService) GetDMMemberIds(channelId string) (*[]string, error) {
+	return c.ChannelRepository.GetDMMemberIds(channelId)
+}
+
+func (c *channelService) RemoveDMChannelMember(channelId string, userId string) error {
+	return c.ChannelRepository.RemoveDMChannelMember(channelId, userId)
+}
+
 // IsChannelMember checks if the user has access to the given channel.
 // Returns an error if they do not, otherwise nil
 func (c *channelService) IsChannelMember(channel *model.Channel, userId string) error {
@@ -143,3 +218,99 @@ func (c *channelService) IsChannelMember(channel *model.Channel, userId string)
 	}
 	return nil
 }
+
+// JoinVoiceChannel connects the user to the voice channel of the given state.
+// A user can only be in one voice channel, so they get disconnected from
+// their previous one, which gets returned if it was a different channel.
+func (c *channelService) JoinVoiceChannel(ctx context.Context, state *model.VoiceState) (*model.VoiceState, error) {
+	previous, err := c.RedisRepository.GetVoiceState(ctx, state.UserId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if previous != nil {
+		if err = c.RedisRepository.RemoveVoiceState(ctx, previous); err != nil {
+			return nil, err
+		}
+
+		if previous.ChannelId == state.ChannelId {
+			previous = nil
+		}
+	}
+
+	if err = c.RedisRepository.SaveVoiceState(ctx, state, VoiceStateTTL); err != nil {
+		return nil, err
+	}
+
+	return previous, nil
+}
+
+// LeaveVoiceChannel disconnects the user from their voice channel
+// and returns their last state or nil if they were not connected
+func (c *channelService) LeaveVoiceChannel(ctx context.Context, userId string) (*model.VoiceState, error) {
+	state, err := c.RedisRepository.GetVoiceState(ctx, userId)
+
+	if err != nil || state == nil {
+		return nil, err
+	}
+
+	if err = c.RedisRepository.RemoveVoiceState(ctx, state); err != nil {
+		return nil, err
+	}
+
+	return state, nil
+}
+
+// UpdateVoiceState sets the mute and deafen state of the user.
+// Deafened users are always muted.
+func (c *channelService) UpdateVoiceState(ctx context.Context, userId string, muted, deafened *bool) (*model.VoiceState, error) {
+	state, err := c.RedisRepository.GetVoiceState(ctx, userId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if state == nil {
+		return nil, apperrors.NewBadRequest(apperrors.NotInVoiceChannel)
+	}
+
+	if muted != nil {
+		state.Muted = *muted
+	}
+
+	if deafened != nil {
+		state.Deafened = *deafened
+	}
+
+	if state.Deafened {
+		state.Muted = true
+	}
+
+	if err = c.RedisRepository.SaveVoiceState(ctx, state, VoiceStateTTL); err != nil {
+		return nil, err
+	}
+
+	return state, nil
+}
+
+// GetVoiceState returns the voice state of the user or nil if they are not connected
+func (c *channelService) GetVoiceState(ctx context.Context, userId string) (*model.VoiceState, error) {
+	return c.RedisRepository.GetVoiceState(ctx, userId)
+}
+
+// GetVoiceParticipants returns the voice states of all users connected to the channel
+func (c *channelService) GetVoiceParticipants(ctx context.Context, channelId string) (*[]model.VoiceState, error) {
+	return c.RedisRepository.GetVoiceStates(ctx, channelId)
+}
+
+// RefreshVoiceState keeps the voice state of the user from expiring
+func (c *channelService) RefreshVoiceState(ctx context.Context, userId string) error {
+	return c.RedisRepository.RefreshVoiceState(ctx, userId, VoiceStateTTL)
+}
+
+// ExpireVoiceStates disconnects all users whose client stopped refreshing
+// their voice state and returns their last states
+func (c *channelService) ExpireVoiceStates(ctx context.Context) (*[]model.VoiceState, error) {
+	return c.RedisRepository.ExpireVoiceStates(ctx)
+}
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"github.com/sentrionic/valkyrie/mocks"
 	"github.com/sentrionic/valkyrie/model"
 	"github.com/sentrionic/valkyrie/model/apperrors"
@@ -237,3 +238,344 @@ func TestChannelService_IsChannelMember(t *testing.T) {
 		assert.Equal(t, err, mockError)
 	})
 }
+
+func TestChannelService_JoinVoiceChannel(t *testing.T) {
+	userId := fixture.RandID()
+	guildId := fixture.RandID()
+
+	t.Run("Joins the channel", func(t *testing.T) {
+		state := &model.VoiceState{UserId: userId, ChannelId: fixture.RandID(), GuildId: guildId}
+
+		mockRedisRepository := new(mocks.RedisRepository)
+		cs := NewChannelService(&CSConfig{
+			RedisRepository: mockRedisRepository,
+		})
+
+		mockRedisRepository.On("GetVoiceState", mock.Anything, userId).Return(nil, nil)
+		mockRedisRepository.On("SaveVoiceState", mock.Anything, state, VoiceStateTTL).Return(nil)
+
+		previous, err := cs.JoinVoiceChannel(context.Background(), state)
+
+		assert.NoError(t, err)
+		assert.Nil(t, previous)
+
+		mockRedisRepository.AssertExpectations(t)
+	})
+
+	t.Run("Leaves the previous channel", func(t *testing.T) {
+		state := &model.VoiceState{UserId: userId, ChannelId: fixture.RandID(), GuildId: guildId}
+		old := &model.VoiceState{UserId: userId, ChannelId: fixture.RandID(), GuildId: guildId}
+
+		mockRedisRepository := new(mocks.RedisRepository)
+		cs := NewChannelService(&CSConfig{
+			RedisRepository: mockRedisRepository,
+		})
+
+		mockRedisRepository.On("GetVoiceState", mock.Anything, userId).Return(old, nil)
+		mockRedisRepository.On("RemoveVoiceState", mock.Anything, old).Return(nil)
+		mockRedisRepository.On("SaveVoiceState", mock.Anything, state, VoiceStateTTL).Return(nil)
+
+		previous, err := cs.JoinVoiceChannel(context.Background(), state)
+
+		assert.NoError(t, err)
+		assert.Equal(t, old, previous)
+
+		mockRedisRepository.AssertExpectations(t)
+	})
+
+	t.Run("Rejoining the same channel resets the state", func(t *testing.T) {
+		channelId := fixture.RandID()
+		state := &model.VoiceState{UserId: userId, ChannelId: channelId, GuildId: guildId}
+		old := &model.VoiceState{UserId: userId, ChannelId: channelId, GuildId: guildId, Muted: true}
+
+		mockRedisRepository := new(mocks.RedisRepository)
+		cs := NewChannelService(&CSConfig{
+			RedisRepository: mockRedisRepository,
+		})
+
+		mockRedisRepository.On("GetVoiceState", mock.Anything, userId).Return(old, nil)
+		mockRedisRepository.On("RemoveVoiceState", mock.Anything, old).Return(nil)
+		mockRedisRepository.On("SaveVoiceState", mock.Anything, state, VoiceStateTTL).Return(nil)
+
+		previous, err := cs.JoinVoiceChannel(context.Background(), state)
+
+		assert.NoError(t, err)
+		assert.Nil(t, previous)
+
+		mockRedisRepository.AssertExpectations(t)
+	})
+}
+
+func TestChannelService_LeaveVoiceChannel(t *testing.T) {
+	userId := fixture.RandID()
+
+	t.Run("Leaves the channel", func(t *testing.T) {
+		state := &model.VoiceState{UserId: userId, ChannelId: fixture.RandID(), GuildId: fixture.RandID()}
+
+		mockRedisRepository := new(mocks.RedisRepository)
+		cs := NewChannelService(&CSConfig{
+			RedisRepository: mockRedisRepository,
+		})
+
+		mockRedisRepository.On("GetVoiceState", mock.Anything, userId).Return(state, nil)
+		mockRedisRepository.On("RemoveVoiceState", mock.Anything, state).Return(nil)
+
+		result, err := cs.LeaveVoiceChannel(context.Background(), userId)
+
+		assert.NoError(t, err)
+		assert.Equal(t, state, result)
+
+		mockRedisRepository.AssertExpectations(t)
+	})
+
+	t.Run("Not connected", func(t *testing.T) {
+		mockRedisRepository := new(mocks.RedisRepository)
+		cs := NewChannelService(&CSConfig{
+			RedisRepository: mockRedisRepository,
+		})
+
+		mockRedisRepository.On("GetVoiceState", mock.Anything, userId).Return(nil, nil)
+
+		result, err := cs.LeaveVoiceChannel(context.Background(), userId)
+
+		assert.NoError(t, err)
+		assert.Nil(t, result)
+
+		mockRedisRepository.AssertNotCalled(t, "RemoveVoiceState", mock.Anything, mock.Anything)
+	})
+}
+
+func TestChannelService_UpdateVoiceState(t *testing.T) {
+	userId := fixture.RandID()
+
+	t.Run("Deafened users are muted", func(t *testing.T) {
+		state := &model.VoiceState{UserId: userId, ChannelId: fixture.RandID(), GuildId: fixture.RandID()}
+		deafened := true
+
+		mockRedisRepository := new(mocks.RedisRepository)
+		cs := NewChannelService(&CSConfig{
+			RedisRepository: mockRedisRepository,
+		})
+
+		mockRedisRepository.On("GetVoiceState", mock.Anything, userId).Return(state, nil)
+		mockRedisRepository.On("SaveVoiceState", mock.Anything, state, VoiceStateTTL).Return(nil)
+
+		result, err := cs.UpdateVoiceState(context.Background(), userId, nil, &deafened)
+
+		assert.NoError(t, err)
+		assert.True(t, result.Deafened)
+		assert.True(t, result.Muted)
+
+		mockRedisRepository.AssertExpectations(t)
+	})
+
+	t.Run("Not connected", func(t *testing.T) {
+		muted := true
+
+		mockRedisRepository := new(mocks.RedisRepository)
+		cs := NewChannelService(&CSConfig{
+			RedisRepository: mockRedisRepository,
+		})
+
+		mockRedisRepository.On("GetVoiceState", mock.Anything, userId).Return(nil, nil)
+
+		result, err := cs.UpdateVoiceState(context.Background(), userId, &muted, nil)
+
+		assert.EqualError(t, err, apperrors.NewBadRequest(apperrors.NotInVoiceChannel).Error())
+		assert.Nil(t, result)
+
+		mockRedisRepository.AssertNotCalled(t, "SaveVoiceState", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestChannelService_AllowsDirectMessage(t *testing.T) {
+	allow := true
+	deny := false
+
+	t.Run("Friends can always start a DM", func(t *testing.T) {
+		mockUser := fixture.GetMockUser()
+		mockUser.DMPrivacy = model.DMPrivacyFriends
+		sender := fixture.GetMockUser()
+		mockUser.Friends = append(mockUser.Friends, *sender)
+
+		mockGuildRepository := new(mocks.GuildRepository)
+		cs := NewChannelService(&CSConfig{
+			GuildRepository: mockGuildRepository,
+		})
+
+		allowed, err := cs.AllowsDirectMessage(mockUser, sender.ID)
+
+		assert.NoError(t, err)
+		assert.True(t, allowed)
+		mockGuildRepository.AssertNotCalled(t, "GetSharedMemberSettings", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Everyone can start a DM", func(t *testing.T) {
+		mockUser := fixture.GetMockUser()
+		mockUser.DMPrivacy = model.DMPrivacyEveryone
+		senderId := fixture.RandID()
+
+		mockGuildRepository := new(mocks.GuildRepository)
+		mockGuildRepository.On("GetSharedMemberSettings", mockUser.ID, senderId).Return(&[]model.MemberSettings{}, nil)
+		cs := NewChannelService(&CSConfig{
+			GuildRepository: mockGuildRepository,
+		})
+
+		allowed, err := cs.AllowsDirectMessage(mockUser, senderId)
+
+		assert.NoError(t, err)
+		assert.True(t, allowed)
+		mockGuildRepository.AssertExpectations(t)
+	})
+
+	t.Run("Guild members can start a DM", func(t *testing.T) {
+		mockUser := fixture.GetMockUser()
+		mockUser.DMPrivacy = model.DMPrivacyGuildMembers
+		senderId := fixture.RandID()
+
+		settings := []model.MemberSettings{{AllowDMs: nil}}
+		mockGuildRepository := new(mocks.GuildRepository)
+		mockGuildRepository.On("GetSharedMemberSettings", mockUser.ID, senderId).Return(&settings, nil)
+		cs := NewChannelService(&CSConfig{
+			GuildRepository: mockGuildRepository,
+		})
+
+		allowed, err := cs.AllowsDirectMessage(mockUser, senderId)
+
+		assert.NoError(t, err)
+		assert.True(t, allowed)
+		mockGuildRepository.AssertExpectations(t)
+	})
+
+	t.Run("Guild override disables DMs from everyone", func(t *testing.T) {
+		mockUser := fixture.GetMockUser()
+		mockUser.DMPrivacy = model.DMPrivacyEveryone
+		senderId := fixture.RandID()
+
+		settings := []model.MemberSettings{{AllowDMs: &deny}}
+		mockGuildRepository := new(mocks.GuildRepository)
+		mockGuildRepository.On("GetSharedMemberSettings", mockUser.ID, senderId).Return(&settings, nil)
+		cs := NewChannelService(&CSConfig{
+			GuildRepository: mockGuildRepository,
+		})
+
+		allowed, err := cs.AllowsDirectMessage(mockUser, senderId)
+
+		assert.NoError(t, err)
+		assert.False(t, allowed)
+		mockGuildRepository.AssertExpectations(t)
+	})
+
+	t.Run("Guild override disables DMs in other shared guilds", func(t *testing.T) {
+		mockUser := fixture.GetMockUser()
+		mockUser.DMPrivacy = model.DMPrivacyGuildMembers
+		senderId := fixture.RandID()
+
+		settings := []model.MemberSettings{{AllowDMs: &deny}, {AllowDMs: nil}}
+		mockGuildRepository := new(mocks.GuildRepository)
+		mockGuildRepository.On("GetSharedMemberSettings", mockUser.ID, senderId).Return(&settings, nil)
+		cs := NewChannelService(&CSConfig{
+			GuildRepository: mockGuildRepository,
+		})
+
+		allowed, err := cs.AllowsDirectMessage(mockUser, senderId)
+
+		assert.NoError(t, err)
+		assert.False(t, allowed)
+		mockGuildRepository.AssertExpectations(t)
+	})
+
+	t.Run("Guild override enabling DMs wins", func(t *testing.T) {
+		mockUser := fixture.GetMockUser()
+		mockUser.DMPrivacy = model.DMPrivacyGuildMembers
+		senderId := fixture.RandID()
+
+		settings := []model.MemberSettings{{AllowDMs: &deny}, {AllowDMs: &allow}}
+		mockGuildRepository := new(mocks.GuildRepository)
+		mockGuildRepository.On("GetSharedMemberSettings", mockUser.ID, senderId).Return(&settings, nil)
+		cs := NewChannelService(&CSConfig{
+			GuildRepository: mockGuildRepository,
+		})
+
+		allowed, err := cs.AllowsDirectMessage(mockUser, senderId)
+
+		assert.NoError(t, err)
+		assert.True(t, allowed)
+		mockGuildRepository.AssertExpectations(t)
+	})
+
+	t.Run("Guild override disables DMs", func(t *testing.T) {
+		mockUser := fixture.GetMockUser()
+		mockUser.DMPrivacy = model.DMPrivacyGuildMembers
+		senderId := fixture.RandID()
+
+		settings := []model.MemberSettings{{AllowDMs: &deny}}
+		mockGuildRepository := new(mocks.GuildRepository)
+		mockGuildRepository.On("GetSharedMemberSettings", mockUser.ID, senderId).Return(&settings, nil)
+		cs := NewChannelService(&CSConfig{
+			GuildRepository: mockGuildRepository,
+		})
+
+		allowed, err := cs.AllowsDirectMessage(mockUser, senderId)
+
+		assert.NoError(t, err)
+		assert.False(t, allowed)
+		mockGuildRepository.AssertExpectations(t)
+	})
+
+	t.Run("Guild override enables DMs for friends only", func(t *testing.T) {
+		mockUser := fixture.GetMockUser()
+		mockUser.DMPrivacy = model.DMPrivacyFriends
+		senderId := fixture.RandID()
+
+		settings := []model.MemberSettings{{AllowDMs: nil}, {AllowDMs: &allow}}
+		mockGuildRepository := new(mocks.GuildRepository)
+		mockGuildRepository.On("GetSharedMemberSettings", mockUser.ID, senderId).Return(&settings, nil)
+		cs := NewChannelService(&CSConfig{
+			GuildRepository: mockGuildRepository,
+		})
+
+		allowed, err := cs.AllowsDirectMessage(mockUser, senderId)
+
+		assert.NoError(t, err)
+		assert.True(t, allowed)
+		mockGuildRepository.AssertExpectations(t)
+	})
+
+	t.Run("Friends only", func(t *testing.T) {
+		mockUser := fixture.GetMockUser()
+		mockUser.DMPrivacy = model.DMPrivacyFriends
+		senderId := fixture.RandID()
+
+		settings := []model.MemberSettings{{AllowDMs: nil}}
+		mockGuildRepository := new(mocks.GuildRepository)
+		mockGuildRepository.On("GetSharedMemberSettings", mockUser.ID, senderId).Return(&settings, nil)
+		cs := NewChannelService(&CSConfig{
+			GuildRepository: mockGuildRepository,
+		})
+
+		allowed, err := cs.AllowsDirectMessage(mockUser, senderId)
+
+		assert.NoError(t, err)
+		assert.False(t, allowed)
+		mockGuildRepository.AssertExpectations(t)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		mockUser := fixture.GetMockUser()
+		senderId := fixture.RandID()
+
+		mockErr := apperrors.NewInternal()
+		mockGuildRepository := new(mocks.GuildRepository)
+		mockGuildRepository.On("GetSharedMemberSettings", mockUser.ID, senderId).Return(nil, mockErr)
+		cs := NewChannelService(&CSConfig{
+			GuildRepository: mockGuildRepository,
+		})
+
+		allowed, err := cs.AllowsDirectMessage(mockUser, senderId)
+
+		assert.EqualError(t, err, mockErr.Error())
+		assert.False(t, allowed)
+		mockGuildRepository.AssertExpectations(t)
+	})
+}
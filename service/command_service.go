@@ -0,0 +1,337 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"io"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// commandService acts as a struct for injecting an implementation of CommandRepository
+// and RedisRepository for use in service methods
+type commandService struct {
+	CommandRepository model.CommandRepository
+	RedisRepository   model.RedisRepository
+	Client            *http.Client
+}
+
+// CMSConfig will hold repositories that will eventually be injected into
+// this service layer
+type CMSConfig struct {
+	CommandRepository model.CommandRepository
+	RedisRepository   model.RedisRepository
+	// Client used for the callbacks. Defaults to a client with a 3 second timeout
+	// that cannot reach the internal network
+	Client *http.Client
+	// Allows the default client to send callbacks to private addresses
+	AllowPrivateNetworks bool
+}
+
+// NewCommandService is a factory function for
+// initializing a CommandService with its repository layer dependencies
+func NewCommandService(c *CMSConfig) model.CommandService {
+	client := c.Client
+	if client == nil {
+		client = NewOutgoingClient(3*time.Second, c.AllowPrivateNetworks)
+	}
+
+	return &commandService{
+		CommandRepository: c.CommandRepository,
+		RedisRepository:   c.RedisRepository,
+		Client:            client,
+	}
+}
+
+func (s *commandService) Get(id string) (*model.Command, error) {
+	return s.CommandRepository.FindByID(id)
+}
+
+// GetGuildCommands returns the commands of the guild starting with the given query.
+// Returns all commands if the query is empty.
+func (s *commandService) GetGuildCommands(guildId string, query string) (*[]model.Command, error) {
+	return s.CommandRepository.FindByGuild(guildId, strings.ToLower(query))
+}
+
+func (s *commandService) FindCommand(guildId string, name string) (*model.Command, error) {
+	return s.CommandRepository.FindByName(guildId, strings.ToLower(name))
+}
+
+// CreateCommand creates the command with a new signing secret
+func (s *commandService) CreateCommand(command *model.Command) (*model.Command, error) {
+	commands, err := s.CommandRepository.FindByGuild(command.GuildId, "")
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(*commands) >= model.MaximumCommands {
+		return nil, apperrors.NewBadRequest(apperrors.CommandLimitReached)
+	}
+
+	id, err := GenerateId()
+
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := generateEventSecret()
+
+	if err != nil {
+		log.Printf("Failed to generate a command secret: %v\n", err.Error())
+		return nil, apperrors.NewInternal()
+	}
+
+	command.ID = id
+	command.Secret = secret
+
+	return s.CommandRepository.Create(command)
+}
+
+func (s *commandService) UpdateCommand(command *model.Command) error {
+	return s.CommandRepository.Update(command)
+}
+
+func (s *commandService) DeleteCommand(command *model.Command) error {
+	return s.CommandRepository.Delete(command)
+}
+
+// ParseOptions validates the input following the command name against the options of the command.
+// Arguments are either given as "name:value" or by position in the order of the options.
+// Values containing spaces can be quoted.
+func (s *commandService) ParseOptions(command *model.Command, input string) (map[string]interface{}, error) {
+	values := make(map[string]interface{})
+	position := 0
+
+	for _, arg := range splitCommandArgs(input) {
+		var option *model.CommandOption
+		value := arg
+
+		if i := strings.Index(arg, ":"); i > 0 {
+			option = findCommandOption(command.Options, arg[:i])
+			if option != nil {
+				value = arg[i+1:]
+			}
+		}
+
+		// Fill the next option that has not been given by name
+		if option == nil {
+			for position < len(command.Options) && values[command.Options[position].Name] != nil {
+				position++
+			}
+
+			if position >= len(command.Options) {
+				return nil, apperrors.NewBadRequest(fmt.Sprintf(apperrors.UnknownCommandOption, arg))
+			}
+
+			option = &command.Options[position]
+		}
+
+		parsed, err := parseCommandOption(option, value)
+
+		if err != nil {
+			return nil, err
+		}
+
+		values[option.Name] = parsed
+	}
+
+	for _, option := range command.Options {
+		if option.Required && values[option.Name] == nil {
+			return nil, apperrors.NewBadRequest(fmt.Sprintf(apperrors.MissingCommandOption, option.Name))
+		}
+	}
+
+	return values, nil
+}
+
+// Invoke posts a signed interaction to the callback URL of the command and returns
+// the response of the bot. Deferred interactions are stored so the bot can respond later.
+func (s *commandService) Invoke(command *model.Command, channelId string, user *model.User, options map[string]interface{}) (*model.InteractionResponse, error) {
+	id, err := GenerateId()
+
+	if err != nil {
+		return nil, err
+	}
+
+	payload := model.InteractionPayload{
+		Id:          id,
+		CommandId:   command.ID,
+		CommandName: command.Name,
+		GuildId:     command.GuildId,
+		ChannelId:   channelId,
+		User: model.MemberResponse{
+			Id:        user.ID,
+			Username:  user.Username,
+			Image:     user.Image,
+			IsOnline:  user.IsOnline,
+			Status:    user.GetPresence().Status,
+			CreatedAt: user.CreatedAt,
+			UpdatedAt: user.UpdatedAt,
+		},
+		Options:   options,
+		CreatedAt: time.Now(),
+	}
+
+	body, err := json.Marshal(payload)
+
+	if err != nil {
+		log.Printf("Failed to marshal the interaction: %v\n", err)
+		return nil, apperrors.NewInternal()
+	}
+
+	response, err := s.callback(command, body)
+
+	if err != nil {
+		log.Printf("Command %v of guild %v failed: %v\n", command.Name, command.GuildId, err)
+		return nil, apperrors.NewBadRequest(apperrors.CommandFailed)
+	}
+
+	if response.Type == model.DeferredInteractionResponse {
+		interaction := model.Interaction{
+			Id:        id,
+			CommandId: command.ID,
+			BotId:     command.BotId,
+			GuildId:   command.GuildId,
+			ChannelId: channelId,
+			UserId:    user.ID,
+		}
+
+		if err := s.RedisRepository.SaveInteraction(context.Background(), &interaction); err != nil {
+			return nil, err
+		}
+	}
+
+	return response, nil
+}
+
+func (s *commandService) GetInteraction(id string) (*model.Interaction, error) {
+	return s.RedisRepository.GetInteraction(context.Background(), id)
+}
+
+func (s *commandService) CompleteInteraction(id string) error {
+	return s.RedisRepository.DeleteInteraction(context.Background(), id)
+}
+
+// callback posts the interaction and decodes the response of the bot
+func (s *commandService) callback(command *model.Command, body []byte) (*model.InteractionResponse, error) {
+	req, err := http.NewRequest(http.MethodPost, command.CallbackUrl, bytes.NewReader(body))
+
+	if err != nil {
+		return nil, err
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Valkyrie-Webhooks")
+	req.Header.Set(EventTimestampHeader, timestamp)
+	req.Header.Set(EventSignatureHeader, signEventPayload(command.Secret, timestamp, body))
+
+	resp, err := s.Client.Do(req)
+
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	var response model.InteractionResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 64*1024)).Decode(&response); err != nil {
+		return nil, err
+	}
+
+	switch response.Type {
+	case model.DeferredInteractionResponse:
+		return &response, nil
+	case model.MessageInteractionResponse:
+		response.Text = strings.TrimSpace(response.Text)
+		if response.Text == "" || len([]rune(response.Text)) > 2000 {
+			return nil, fmt.Errorf("invalid message length")
+		}
+		return &response, nil
+	}
+
+	return nil, fmt.Errorf("unknown response type %q", response.Type)
+}
+
+// splitCommandArgs splits the input at whitespace that are not inside double quotes
+// and removes the quotes
+func splitCommandArgs(input string) []string {
+	var args []string
+	var current strings.Builder
+	quoted, started := false, false
+
+	for _, r := range input {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			started = true
+		case unicode.IsSpace(r) && !quoted:
+			if started {
+				args = append(args, current.String())
+				current.Reset()
+				started = false
+			}
+		default:
+			current.WriteRune(r)
+			started = true
+		}
+	}
+
+	if started {
+		args = append(args, current.String())
+	}
+
+	return args
+}
+
+// findCommandOption returns the option with the given name or nil
+func findCommandOption(options model.CommandOptions, name string) *model.CommandOption {
+	for i := range options {
+		if options[i].Name == strings.ToLower(name) {
+			return &options[i]
+		}
+	}
+	return nil
+}
+
+// parseCommandOption converts the value to the type of the option
+func parseCommandOption(option *model.CommandOption, value string) (interface{}, error) {
+	invalid := apperrors.NewBadRequest(fmt.Sprintf(apperrors.InvalidCommandOption, option.Name, option.Type))
+
+	switch option.Type {
+	case model.IntegerOption:
+		parsed, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, invalid
+		}
+		return parsed, nil
+	case model.NumberOption:
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil || math.IsNaN(parsed) || math.IsInf(parsed, 0) {
+			return nil, invalid
+		}
+		return parsed, nil
+	case model.BooleanOption:
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, invalid
+		}
+		return parsed, nil
+	}
+
+	return value, nil
+}
@@ -0,0 +1,233 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/sentrionic/valkyrie/mocks"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"github.com/sentrionic/valkyrie/model/fixture"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func getMockCommand(url string) *model.Command {
+	command := &model.Command{
+		GuildId:     fixture.RandID(),
+		BotId:       fixture.RandID(),
+		Name:        "remind",
+		Description: "Sets a reminder",
+		Options: model.CommandOptions{
+			{Name: "text", Type: model.StringOption, Required: true},
+			{Name: "minutes", Type: model.IntegerOption, Required: true},
+			{Name: "loud", Type: model.BooleanOption},
+		},
+		CallbackUrl: url,
+		Secret:      fixture.RandStr(43),
+	}
+	command.ID = fixture.RandID()
+	return command
+}
+
+func TestCommandService_CreateCommand(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		params := getMockCommand("https://example.com")
+		params.ID = ""
+		params.Secret = ""
+
+		mockCommandRepository := new(mocks.CommandRepository)
+		cs := NewCommandService(&CMSConfig{
+			CommandRepository: mockCommandRepository,
+		})
+
+		mockCommandRepository.On("FindByGuild", params.GuildId, "").Return(&[]model.Command{}, nil)
+		mockCommandRepository.On("Create", params).Return(params, nil)
+
+		command, err := cs.CreateCommand(params)
+
+		assert.NoError(t, err)
+		assert.NotEmpty(t, command.ID)
+		assert.NotEmpty(t, command.Secret)
+		mockCommandRepository.AssertExpectations(t)
+	})
+
+	t.Run("Command limit reached", func(t *testing.T) {
+		params := getMockCommand("https://example.com")
+		commands := make([]model.Command, model.MaximumCommands)
+
+		mockCommandRepository := new(mocks.CommandRepository)
+		cs := NewCommandService(&CMSConfig{
+			CommandRepository: mockCommandRepository,
+		})
+
+		mockCommandRepository.On("FindByGuild", params.GuildId, "").Return(&commands, nil)
+
+		command, err := cs.CreateCommand(params)
+
+		assert.Nil(t, command)
+		assert.Equal(t, apperrors.NewBadRequest(apperrors.CommandLimitReached), err)
+		mockCommandRepository.AssertNotCalled(t, "Create", mock.Anything)
+	})
+}
+
+func TestCommandService_ParseOptions(t *testing.T) {
+	command := getMockCommand("https://example.com")
+	cs := NewCommandService(&CMSConfig{})
+
+	testCases := []struct {
+		name     string
+		input    string
+		expected map[string]interface{}
+		err      error
+	}{
+		{
+			name:     "Positional options",
+			input:    `"Buy milk" 10`,
+			expected: map[string]interface{}{"text": "Buy milk", "minutes": int64(10)},
+		},
+		{
+			name:     "Named options",
+			input:    `loud:true minutes:5 text:"Feed the cat"`,
+			expected: map[string]interface{}{"text": "Feed the cat", "minutes": int64(5), "loud": true},
+		},
+		{
+			name:     "Mixed options",
+			input:    `minutes:5 Stretch`,
+			expected: map[string]interface{}{"text": "Stretch", "minutes": int64(5)},
+		},
+		{
+			name:  "Missing required option",
+			input: `Stretch`,
+			err:   apperrors.NewBadRequest(fmt.Sprintf(apperrors.MissingCommandOption, "minutes")),
+		},
+		{
+			name:  "Invalid type",
+			input: `Stretch soon`,
+			err:   apperrors.NewBadRequest(fmt.Sprintf(apperrors.InvalidCommandOption, "minutes", model.IntegerOption)),
+		},
+		{
+			name:  "Too many options",
+			input: `Stretch 5 true extra`,
+			err:   apperrors.NewBadRequest(fmt.Sprintf(apperrors.UnknownCommandOption, "extra")),
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			options, err := cs.ParseOptions(command, tc.input)
+
+			assert.Equal(t, tc.err, err)
+			if tc.err == nil {
+				assert.Equal(t, tc.expected, options)
+			}
+		})
+	}
+}
+
+func TestCommandService_Invoke(t *testing.T) {
+	user := fixture.GetMockUser()
+	channelId := fixture.RandID()
+	options := map[string]interface{}{"text": "Stretch", "minutes": int64(5)}
+
+	t.Run("Message response", func(t *testing.T) {
+		var command *model.Command
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := ioutil.ReadAll(r.Body)
+
+			if signEventPayload(command.Secret, r.Header.Get(EventTimestampHeader), body) != r.Header.Get(EventSignatureHeader) {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+
+			var payload model.InteractionPayload
+			_ = json.Unmarshal(body, &payload)
+
+			_ = json.NewEncoder(w).Encode(model.InteractionResponse{
+				Type: model.MessageInteractionResponse,
+				Text: fmt.Sprintf("%s in %v minutes", payload.Options["text"], payload.Options["minutes"]),
+			})
+		}))
+		defer server.Close()
+
+		command = getMockCommand(server.URL)
+
+		mockRedisRepository := new(mocks.RedisRepository)
+		cs := NewCommandService(&CMSConfig{
+			RedisRepository:      mockRedisRepository,
+			AllowPrivateNetworks: true,
+		})
+
+		response, err := cs.Invoke(command, channelId, user, options)
+
+		assert.NoError(t, err)
+		assert.Equal(t, model.MessageInteractionResponse, response.Type)
+		assert.Equal(t, "Stretch in 5 minutes", response.Text)
+		mockRedisRepository.AssertNotCalled(t, "SaveInteraction", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Deferred response", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(model.InteractionResponse{
+				Type: model.DeferredInteractionResponse,
+			})
+		}))
+		defer server.Close()
+
+		command := getMockCommand(server.URL)
+
+		mockRedisRepository := new(mocks.RedisRepository)
+		cs := NewCommandService(&CMSConfig{
+			RedisRepository:      mockRedisRepository,
+			AllowPrivateNetworks: true,
+		})
+
+		mockRedisRepository.On("SaveInteraction", mock.Anything, mock.MatchedBy(func(interaction *model.Interaction) bool {
+			return interaction.BotId == command.BotId && interaction.ChannelId == channelId && interaction.UserId == user.ID
+		})).Return(nil)
+
+		response, err := cs.Invoke(command, channelId, user, options)
+
+		assert.NoError(t, err)
+		assert.Equal(t, model.DeferredInteractionResponse, response.Type)
+		mockRedisRepository.AssertExpectations(t)
+	})
+
+	t.Run("Callback failed", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		command := getMockCommand(server.URL)
+		cs := NewCommandService(&CMSConfig{})
+
+		response, err := cs.Invoke(command, channelId, user, options)
+
+		assert.Nil(t, response)
+		assert.Equal(t, apperrors.NewBadRequest(apperrors.CommandFailed), err)
+	})
+
+	t.Run("Invalid response", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(model.InteractionResponse{
+				Type: model.MessageInteractionResponse,
+			})
+		}))
+		defer server.Close()
+
+		command := getMockCommand(server.URL)
+		cs := NewCommandService(&CMSConfig{})
+
+		response, err := cs.Invoke(command, channelId, user, options)
+
+		assert.Nil(t, response)
+		assert.Equal(t, apperrors.NewBadRequest(apperrors.CommandFailed), err)
+	})
+}
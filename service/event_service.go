@@ -0,0 +1,356 @@
+package service
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"log"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+// Headers sent with every event delivery
+const (
+	EventHeader          = "X-Valkyrie-Event"
+	EventDeliveryHeader  = "X-Valkyrie-Delivery"
+	EventTimestampHeader = "X-Valkyrie-Timestamp"
+	EventSignatureHeader = "X-Valkyrie-Signature"
+)
+
+// MaxEventDeliveryAttempts is the amount of times a delivery gets
+// attempted before it counts as failed
+const MaxEventDeliveryAttempts = 5
+
+// MaxPendingEventRetries is the amount of retries that can wait at the same time.
+// Further retries get dropped so unreachable receivers cannot exhaust the server.
+const MaxPendingEventRetries = 1000
+
+// eventJob is a dispatched event waiting for the workers
+type eventJob struct {
+	guildId   string
+	channelId string
+	event     string
+	data      interface{}
+	createdAt time.Time
+}
+
+// eventService acts as a struct for injecting an implementation of EventRepository
+// for use in service methods. Deliveries are sent by background workers.
+type eventService struct {
+	EventRepository   model.EventRepository
+	ChannelRepository model.ChannelRepository
+	Client            *http.Client
+	RetryDelay        time.Duration
+	queue             chan eventJob
+	pendingRetries    int64
+}
+
+// ESConfig will hold repositories that will eventually be injected into
+// this service layer
+type ESConfig struct {
+	EventRepository   model.EventRepository
+	ChannelRepository model.ChannelRepository
+	// Client used for the deliveries. Defaults to a client with a 10 second timeout
+	// that cannot reach the internal network
+	Client *http.Client
+	// Allows the default client to deliver events to private addresses
+	AllowPrivateNetworks bool
+	// Delay before the first retry. It doubles with every attempt. Defaults to 10 seconds
+	RetryDelay time.Duration
+	// Amount of background workers. Defaults to 4
+	Workers int
+}
+
+// NewEventService is a factory function for
+// initializing an EventService with its repository layer dependencies.
+// It starts the workers that deliver the events.
+func NewEventService(c *ESConfig) model.EventService {
+	s := &eventService{
+		EventRepository:   c.EventRepository,
+		ChannelRepository: c.ChannelRepository,
+		Client:            c.Client,
+		RetryDelay:        c.RetryDelay,
+		queue:             make(chan eventJob, 1024),
+	}
+
+	if s.Client == nil {
+		s.Client = NewOutgoingClient(10*time.Second, c.AllowPrivateNetworks)
+	}
+
+	if s.RetryDelay == 0 {
+		s.RetryDelay = 10 * time.Second
+	}
+
+	workers := c.Workers
+	if workers == 0 {
+		workers = 4
+	}
+
+	for i := 0; i < workers; i++ {
+		go s.work()
+	}
+
+	return s
+}
+
+func (s *eventService) Get(id string) (*model.EventWebhook, error) {
+	return s.EventRepository.FindByID(id)
+}
+
+func (s *eventService) GetGuildEventWebhooks(guildId string) (*[]model.EventWebhook, error) {
+	return s.EventRepository.FindByGuild(guildId)
+}
+
+// CreateEventWebhook creates the event webhook with a new signing secret
+func (s *eventService) CreateEventWebhook(webhook *model.EventWebhook) (*model.EventWebhook, error) {
+	webhooks, err := s.EventRepository.FindByGuild(webhook.GuildId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(*webhooks) >= model.MaximumEventWebhooks {
+		return nil, apperrors.NewBadRequest(apperrors.EventWebhookLimit)
+	}
+
+	id, err := GenerateId()
+
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := generateEventSecret()
+
+	if err != nil {
+		log.Printf("Failed to generate an event webhook secret: %v\n", err.Error())
+		return nil, apperrors.NewInternal()
+	}
+
+	webhook.ID = id
+	webhook.Secret = secret
+	webhook.Enabled = true
+
+	return s.EventRepository.Create(webhook)
+}
+
+func (s *eventService) UpdateEventWebhook(webhook *model.EventWebhook) error {
+	return s.EventRepository.Update(webhook)
+}
+
+func (s *eventService) DeleteEventWebhook(webhook *model.EventWebhook) error {
+	return s.EventRepository.Delete(webhook)
+}
+
+func (s *eventService) GetDeliveries(webhookId string) (*[]model.EventDelivery, error) {
+	return s.EventRepository.FindDeliveries(webhookId)
+}
+
+// Dispatch queues the event for the event webhooks of the given guild
+func (s *eventService) Dispatch(guildId string, event string, data interface{}) {
+	s.enqueue(eventJob{guildId: guildId, event: event, data: data, createdAt: time.Now()})
+}
+
+// DispatchChannelEvent queues the event for the event webhooks of the guild
+// the channel belongs to. Events of DM channels get ignored.
+func (s *eventService) DispatchChannelEvent(channelId string, event string, data interface{}) {
+	s.enqueue(eventJob{channelId: channelId, event: event, data: data, createdAt: time.Now()})
+}
+
+// enqueue hands the job to the workers without blocking the caller.
+// The event gets dropped if the queue is full.
+func (s *eventService) enqueue(job eventJob) {
+	select {
+	case s.queue <- job:
+	default:
+		log.Printf("Event queue is full, dropping %v event\n", job.event)
+	}
+}
+
+func (s *eventService) work() {
+	for job := range s.queue {
+		s.process(job)
+	}
+}
+
+// process sends the event to every enabled webhook of the guild that subscribes to it
+func (s *eventService) process(job eventJob) {
+	if job.guildId == "" {
+		channel, err := s.ChannelRepository.GetById(job.channelId)
+
+		if err != nil || channel.GuildID == nil {
+			return
+		}
+
+		job.guildId = *channel.GuildID
+	}
+
+	webhooks, err := s.EventRepository.FindByGuild(job.guildId)
+
+	if err != nil {
+		log.Printf("Failed to get the event webhooks of guild: %v\n%v", job.guildId, err)
+		return
+	}
+
+	var payload *model.EventPayload
+	var body []byte
+
+	for _, webhook := range *webhooks {
+		if !webhook.Enabled || !webhook.Subscribes(job.event) {
+			continue
+		}
+
+		// Only build the payload if someone is listening
+		if payload == nil {
+			id, err := GenerateId()
+
+			if err != nil {
+				return
+			}
+
+			payload = &model.EventPayload{
+				Id:        id,
+				Event:     job.event,
+				GuildId:   job.guildId,
+				ChannelId: job.channelId,
+				CreatedAt: job.createdAt,
+				Data:      job.data,
+			}
+
+			body, err = json.Marshal(payload)
+
+			if err != nil {
+				log.Printf("Failed to marshal the %v event: %v\n", job.event, err)
+				return
+			}
+		}
+
+		s.deliver(webhook, payload, body, 1)
+	}
+}
+
+// deliver posts the payload to the webhook and logs the attempt.
+// Failed attempts get retried with an exponential backoff. Once all
+// attempts failed the failure gets counted towards disabling the webhook.
+func (s *eventService) deliver(webhook model.EventWebhook, payload *model.EventPayload, body []byte, attempt int) {
+	delivery := model.EventDelivery{
+		EventWebhookId: webhook.ID,
+		DeliveryId:     payload.Id,
+		Event:          payload.Event,
+		Attempt:        attempt,
+		CreatedAt:      time.Now(),
+	}
+
+	statusCode, err := s.post(webhook, payload, body)
+
+	delivery.Duration = time.Since(delivery.CreatedAt).Milliseconds()
+	delivery.StatusCode = statusCode
+	delivery.Success = err == nil
+
+	if err != nil {
+		delivery.Error = err.Error()
+	}
+
+	if err := s.EventRepository.CreateDelivery(&delivery); err != nil {
+		log.Printf("Failed to log the delivery for event webhook: %v\n%v", webhook.ID, err)
+	}
+
+	if delivery.Success {
+		if webhook.FailureCount > 0 {
+			_ = s.EventRepository.ResetFailures(webhook.ID)
+		}
+		return
+	}
+
+	if attempt < MaxEventDeliveryAttempts {
+		s.retry(webhook.ID, payload, body, attempt+1)
+		return
+	}
+
+	if err := s.EventRepository.AddFailure(webhook.ID, model.EventWebhookFailureLimit); err != nil {
+		log.Printf("Failed to count the failure for event webhook: %v\n%v", webhook.ID, err)
+	}
+}
+
+// retry schedules the given attempt of the delivery with an exponential backoff.
+// The webhook gets loaded again before the attempt, so retries of deleted, disabled
+// or edited webhooks are not sent to the old receiver.
+func (s *eventService) retry(webhookId string, payload *model.EventPayload, body []byte, attempt int) {
+	if atomic.AddInt64(&s.pendingRetries, 1) > MaxPendingEventRetries {
+		atomic.AddInt64(&s.pendingRetries, -1)
+		log.Printf("Too many pending retries, dropping delivery %v for event webhook: %v\n", payload.Id, webhookId)
+		return
+	}
+
+	delay := s.RetryDelay * time.Duration(1<<(attempt-2))
+	time.AfterFunc(delay, func() {
+		defer atomic.AddInt64(&s.pendingRetries, -1)
+
+		webhook, err := s.EventRepository.FindByID(webhookId)
+
+		if err != nil || !webhook.Enabled || !webhook.Subscribes(payload.Event) {
+			return
+		}
+
+		s.deliver(*webhook, payload, body, attempt)
+	})
+}
+
+// post sends the signed payload and returns the status code of the receiver.
+// Any status other than 2xx counts as an error.
+func (s *eventService) post(webhook model.EventWebhook, payload *model.EventPayload, body []byte) (int, error) {
+	req, err := http.NewRequest(http.MethodPost, webhook.Url, bytes.NewReader(body))
+
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Valkyrie-Webhooks")
+	req.Header.Set(EventHeader, payload.Event)
+	req.Header.Set(EventDeliveryHeader, payload.Id)
+	req.Header.Set(EventTimestampHeader, timestamp)
+	req.Header.Set(EventSignatureHeader, signEventPayload(webhook.Secret, timestamp, body))
+
+	resp, err := s.Client.Do(req)
+
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
+
+// signEventPayload returns the signature receivers use to verify a delivery.
+// It is the hex encoded HMAC-SHA256 of "<timestamp>.<body>" keyed with the secret.
+func signEventPayload(secret string, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// generateEventSecret returns a new random signing secret
+func generateEventSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
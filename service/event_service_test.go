@@ -0,0 +1,299 @@
+package service
+
+import (
+	"encoding/json"
+	"github.com/sentrionic/valkyrie/mocks"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"github.com/sentrionic/valkyrie/model/fixture"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestEventService_CreateEventWebhook(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		params := &model.EventWebhook{
+			GuildId: fixture.RandID(),
+			UserId:  fixture.RandID(),
+			Url:     "https://example.com/events",
+			Events:  []string{model.MessageCreatedEvent},
+		}
+
+		mockEventRepository := new(mocks.EventRepository)
+		es := NewEventService(&ESConfig{
+			EventRepository: mockEventRepository,
+		})
+
+		mockEventRepository.On("FindByGuild", params.GuildId).Return(&[]model.EventWebhook{}, nil)
+		mockEventRepository.On("Create", params).Return(params, nil)
+
+		webhook, err := es.CreateEventWebhook(params)
+
+		assert.NoError(t, err)
+		assert.NotEmpty(t, webhook.ID)
+		assert.NotEmpty(t, webhook.Secret)
+		assert.True(t, webhook.Enabled)
+		mockEventRepository.AssertExpectations(t)
+	})
+
+	t.Run("Event webhook limit reached", func(t *testing.T) {
+		params := &model.EventWebhook{
+			GuildId: fixture.RandID(),
+		}
+		webhooks := make([]model.EventWebhook, model.MaximumEventWebhooks)
+
+		mockEventRepository := new(mocks.EventRepository)
+		es := NewEventService(&ESConfig{
+			EventRepository: mockEventRepository,
+		})
+
+		mockEventRepository.On("FindByGuild", params.GuildId).Return(&webhooks, nil)
+
+		webhook, err := es.CreateEventWebhook(params)
+
+		assert.Nil(t, webhook)
+		assert.Equal(t, apperrors.NewBadRequest(apperrors.EventWebhookLimit), err)
+		mockEventRepository.AssertNotCalled(t, "Create", mock.Anything)
+	})
+}
+
+func TestEventService_DispatchChannelEvent(t *testing.T) {
+	guildId := fixture.RandID()
+	channel := fixture.GetMockChannel(guildId)
+
+	getWebhook := func(url string) model.EventWebhook {
+		webhook := model.EventWebhook{
+			GuildId: guildId,
+			Url:     url,
+			Secret:  fixture.RandStr(43),
+			Events:  []string{model.MessageCreatedEvent},
+			Enabled: true,
+		}
+		webhook.ID = fixture.RandID()
+		return webhook
+	}
+
+	t.Run("Delivers a signed payload", func(t *testing.T) {
+		received := make(chan *http.Request, 1)
+		var body []byte
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ = ioutil.ReadAll(r.Body)
+			received <- r
+		}))
+		defer server.Close()
+
+		webhook := getWebhook(server.URL)
+
+		mockEventRepository := new(mocks.EventRepository)
+		mockChannelRepository := new(mocks.ChannelRepository)
+		es := NewEventService(&ESConfig{
+			EventRepository:      mockEventRepository,
+			ChannelRepository:    mockChannelRepository,
+			AllowPrivateNetworks: true,
+		})
+
+		deliveries := make(chan model.EventDelivery, 1)
+		mockChannelRepository.On("GetById", channel.ID).Return(channel, nil)
+		mockEventRepository.On("FindByGuild", guildId).Return(&[]model.EventWebhook{webhook}, nil)
+		mockEventRepository.On("CreateDelivery", mock.AnythingOfType("*model.EventDelivery")).
+			Return(nil).
+			Run(func(args mock.Arguments) {
+				deliveries <- *args.Get(0).(*model.EventDelivery)
+			})
+
+		es.DispatchChannelEvent(channel.ID, model.MessageCreatedEvent, map[string]string{"text": "Hello"})
+
+		r := <-received
+		delivery := <-deliveries
+
+		assert.Equal(t, model.MessageCreatedEvent, r.Header.Get(EventHeader))
+		assert.Equal(t, delivery.DeliveryId, r.Header.Get(EventDeliveryHeader))
+		assert.Equal(t, signEventPayload(webhook.Secret, r.Header.Get(EventTimestampHeader), body), r.Header.Get(EventSignatureHeader))
+
+		var payload model.EventPayload
+		err := json.Unmarshal(body, &payload)
+		assert.NoError(t, err)
+		assert.Equal(t, guildId, payload.GuildId)
+		assert.Equal(t, channel.ID, payload.ChannelId)
+		assert.Equal(t, map[string]interface{}{"text": "Hello"}, payload.Data)
+
+		assert.True(t, delivery.Success)
+		assert.Equal(t, 1, delivery.Attempt)
+		assert.Equal(t, http.StatusOK, delivery.StatusCode)
+	})
+
+	t.Run("Retries failed deliveries", func(t *testing.T) {
+		var calls int32
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&calls, 1) == 1 {
+				w.WriteHeader(http.StatusInternalServerError)
+			}
+		}))
+		defer server.Close()
+
+		webhook := getWebhook(server.URL)
+
+		mockEventRepository := new(mocks.EventRepository)
+		mockChannelRepository := new(mocks.ChannelRepository)
+		es := NewEventService(&ESConfig{
+			EventRepository:      mockEventRepository,
+			ChannelRepository:    mockChannelRepository,
+			AllowPrivateNetworks: true,
+			RetryDelay:           time.Millisecond,
+		})
+
+		deliveries := make(chan model.EventDelivery, 2)
+		mockChannelRepository.On("GetById", channel.ID).Return(channel, nil)
+		mockEventRepository.On("FindByGuild", guildId).Return(&[]model.EventWebhook{webhook}, nil)
+		mockEventRepository.On("FindByID", webhook.ID).Return(&webhook, nil)
+		mockEventRepository.On("CreateDelivery", mock.AnythingOfType("*model.EventDelivery")).
+			Return(nil).
+			Run(func(args mock.Arguments) {
+				deliveries <- *args.Get(0).(*model.EventDelivery)
+			})
+
+		es.DispatchChannelEvent(channel.ID, model.MessageCreatedEvent, "data")
+
+		first := <-deliveries
+		second := <-deliveries
+
+		assert.False(t, first.Success)
+		assert.Equal(t, http.StatusInternalServerError, first.StatusCode)
+		assert.NotEmpty(t, first.Error)
+		assert.True(t, second.Success)
+		assert.Equal(t, 2, second.Attempt)
+		assert.Equal(t, first.DeliveryId, second.DeliveryId)
+		mockEventRepository.AssertNotCalled(t, "AddFailure", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Counts the failure after all attempts failed", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+		defer server.Close()
+
+		webhook := getWebhook(server.URL)
+
+		mockEventRepository := new(mocks.EventRepository)
+		mockChannelRepository := new(mocks.ChannelRepository)
+		es := NewEventService(&ESConfig{
+			EventRepository:      mockEventRepository,
+			ChannelRepository:    mockChannelRepository,
+			AllowPrivateNetworks: true,
+			RetryDelay:           time.Millisecond,
+		})
+
+		failed := make(chan bool, 1)
+		mockChannelRepository.On("GetById", channel.ID).Return(channel, nil)
+		mockEventRepository.On("FindByGuild", guildId).Return(&[]model.EventWebhook{webhook}, nil)
+		mockEventRepository.On("FindByID", webhook.ID).Return(&webhook, nil)
+		mockEventRepository.On("CreateDelivery", mock.AnythingOfType("*model.EventDelivery")).Return(nil)
+		mockEventRepository.On("AddFailure", webhook.ID, model.EventWebhookFailureLimit).
+			Return(nil).
+			Run(func(args mock.Arguments) {
+				failed <- true
+			})
+
+		es.DispatchChannelEvent(channel.ID, model.MessageCreatedEvent, "data")
+
+		select {
+		case <-failed:
+		case <-time.After(5 * time.Second):
+			t.Fatal("failure was not counted")
+		}
+
+		mockEventRepository.AssertNumberOfCalls(t, "CreateDelivery", MaxEventDeliveryAttempts)
+	})
+
+	t.Run("Drops retries of disabled webhooks", func(t *testing.T) {
+		var calls int32
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		webhook := getWebhook(server.URL)
+		disabled := webhook
+		disabled.Enabled = false
+
+		mockEventRepository := new(mocks.EventRepository)
+		mockChannelRepository := new(mocks.ChannelRepository)
+		es := NewEventService(&ESConfig{
+			EventRepository:      mockEventRepository,
+			ChannelRepository:    mockChannelRepository,
+			AllowPrivateNetworks: true,
+			RetryDelay:           time.Millisecond,
+		})
+
+		reloaded := make(chan bool, 1)
+		mockChannelRepository.On("GetById", channel.ID).Return(channel, nil)
+		mockEventRepository.On("FindByGuild", guildId).Return(&[]model.EventWebhook{webhook}, nil)
+		mockEventRepository.On("CreateDelivery", mock.AnythingOfType("*model.EventDelivery")).Return(nil)
+		mockEventRepository.On("FindByID", webhook.ID).
+			Return(&disabled, nil).
+			Run(func(args mock.Arguments) {
+				reloaded <- true
+			})
+
+		es.DispatchChannelEvent(channel.ID, model.MessageCreatedEvent, "data")
+
+		select {
+		case <-reloaded:
+		case <-time.After(5 * time.Second):
+			t.Fatal("webhook was not reloaded")
+		}
+		time.Sleep(50 * time.Millisecond)
+
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+		mockEventRepository.AssertNumberOfCalls(t, "CreateDelivery", 1)
+		mockEventRepository.AssertNotCalled(t, "AddFailure", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Skips unsubscribed and disabled webhooks", func(t *testing.T) {
+		var calls int32
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+		}))
+		defer server.Close()
+
+		unsubscribed := getWebhook(server.URL)
+		unsubscribed.Events = []string{model.MemberJoinedEvent}
+		disabled := getWebhook(server.URL)
+		disabled.Enabled = false
+
+		mockEventRepository := new(mocks.EventRepository)
+		mockChannelRepository := new(mocks.ChannelRepository)
+		es := NewEventService(&ESConfig{
+			EventRepository:      mockEventRepository,
+			ChannelRepository:    mockChannelRepository,
+			AllowPrivateNetworks: true,
+		})
+
+		searched := make(chan bool, 1)
+		mockChannelRepository.On("GetById", channel.ID).Return(channel, nil)
+		mockEventRepository.On("FindByGuild", guildId).
+			Return(&[]model.EventWebhook{unsubscribed, disabled}, nil).
+			Run(func(args mock.Arguments) {
+				searched <- true
+			})
+
+		es.DispatchChannelEvent(channel.ID, model.MessageCreatedEvent, "data")
+
+		<-searched
+		time.Sleep(50 * time.Millisecond)
+
+		assert.Equal(t, int32(0), atomic.LoadInt32(&calls))
+		mockEventRepository.AssertNotCalled(t, "CreateDelivery", mock.Anything)
+	})
+}
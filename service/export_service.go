@@ -0,0 +1,370 @@
+package service
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"io"
+	"log"
+	"net/http"
+	"time"
+)
+
+// ExportInterval is the time between the runs building
+// the requested exports and removing the expired ones
+const ExportInterval = time.Minute
+
+// ExportLockTTL is the longest time an instance can hold the lock for building the exports
+const ExportLockTTL = 30 * time.Minute
+
+// exportLock is the name of the lock held while processing the exports
+const exportLock = "data-exports"
+
+// exportBatchSize is the amount of messages loaded at once while building an archive
+const exportBatchSize = 500
+
+// exportService acts as a struct for injecting an implementation of ExportRepository
+// and the repositories holding the user's data for use in service methods
+type exportService struct {
+	ExportRepository  model.ExportRepository
+	UserRepository    model.UserRepository
+	FriendRepository  model.FriendRepository
+	GuildRepository   model.GuildRepository
+	MessageRepository model.MessageRepository
+	FileRepository    model.FileRepository
+	MailRepository    model.MailRepository
+	RedisRepository   model.RedisRepository
+}
+
+// EXSConfig will hold repositories that will eventually be injected into
+// this service layer
+type EXSConfig struct {
+	ExportRepository  model.ExportRepository
+	UserRepository    model.UserRepository
+	FriendRepository  model.FriendRepository
+	GuildRepository   model.GuildRepository
+	MessageRepository model.MessageRepository
+	FileRepository    model.FileRepository
+	MailRepository    model.MailRepository
+	RedisRepository   model.RedisRepository
+}
+
+// NewExportService is a factory function for
+// initializing an ExportService with its repository layer dependencies
+func NewExportService(c *EXSConfig) model.ExportService {
+	return &exportService{
+		ExportRepository:  c.ExportRepository,
+		UserRepository:    c.UserRepository,
+		FriendRepository:  c.FriendRepository,
+		GuildRepository:   c.GuildRepository,
+		MessageRepository: c.MessageRepository,
+		FileRepository:    c.FileRepository,
+		MailRepository:    c.MailRepository,
+		RedisRepository:   c.RedisRepository,
+	}
+}
+
+// RequestExport queues a new export of the user's data.
+// Only one export can be requested per day.
+func (s *exportService) RequestExport(user *model.User) (*model.DataExport, error) {
+	latest, err := s.ExportRepository.FindLatest(user.ID)
+
+	if err != nil && apperrors.Status(err) != http.StatusNotFound {
+		return nil, err
+	}
+
+	if err == nil {
+		if latest.Status == model.ExportPending {
+			return nil, apperrors.NewBadRequest(apperrors.ExportInProgress)
+		}
+
+		if latest.Status != model.ExportFailed && time.Since(latest.CreatedAt) < model.ExportCooldown {
+			return nil, apperrors.NewBadRequest(apperrors.ExportCooldown)
+		}
+	}
+
+	id, err := GenerateId()
+
+	if err != nil {
+		return nil, err
+	}
+
+	export := &model.DataExport{
+		UserId: user.ID,
+		Status: model.ExportPending,
+	}
+	export.ID = id
+
+	if err = s.ExportRepository.Create(export); err != nil {
+		return nil, err
+	}
+
+	return export, nil
+}
+
+// GetLatestExport returns the most recently requested export of the user
+func (s *exportService) GetLatestExport(userId string) (*model.DataExport, error) {
+	return s.ExportRepository.FindLatest(userId)
+}
+
+// GetDownloadURL returns a signed link to the archive that is valid until the export expires.
+// Returns nil if the archive cannot be downloaded.
+func (s *exportService) GetDownloadURL(export *model.DataExport) (*string, error) {
+	if export.Status != model.ExportReady || export.ExpiresAt == nil {
+		return nil, nil
+	}
+
+	expires := time.Until(*export.ExpiresAt)
+
+	if expires <= 0 {
+		return nil, nil
+	}
+
+	url, err := s.FileRepository.GetSignedURL(export.Key, expires)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &url, nil
+}
+
+// ProcessExports builds the pending exports, mails their download links
+// and removes the archives of expired exports
+func (s *exportService) ProcessExports() error {
+	ctx := context.Background()
+
+	// Only one instance builds the exports at a time
+	lockId, err := s.RedisRepository.AcquireLock(ctx, exportLock, ExportLockTTL)
+
+	if err != nil || lockId == "" {
+		return err
+	}
+	defer s.RedisRepository.ReleaseLock(ctx, exportLock, lockId)
+
+	pending, err := s.ExportRepository.FindPending()
+
+	if err != nil {
+		return err
+	}
+
+	for i := range *pending {
+		export := &(*pending)[i]
+
+		if err = s.buildExport(export); err != nil {
+			log.Printf("Failed to build the export %v: %v\n", export.ID, err)
+
+			export.Status = model.ExportFailed
+			if err = s.ExportRepository.Save(export); err != nil {
+				log.Printf("Failed to update the export %v: %v\n", export.ID, err)
+			}
+		}
+	}
+
+	expired, err := s.ExportRepository.FindExpired(time.Now())
+
+	if err != nil {
+		return err
+	}
+
+	for i := range *expired {
+		export := &(*expired)[i]
+
+		if err = s.FileRepository.DeleteImage(export.Key); err != nil {
+			log.Printf("Failed to delete the archive of export %v: %v\n", export.ID, err)
+			continue
+		}
+
+		export.Status = model.ExportExpired
+		export.Key = ""
+		if err = s.ExportRepository.Save(export); err != nil {
+			log.Printf("Failed to update the export %v: %v\n", export.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// buildExport uploads the archive of the export and sends its link to the user
+func (s *exportService) buildExport(export *model.DataExport) error {
+	user, err := s.UserRepository.FindByID(export.UserId)
+
+	if err != nil {
+		return err
+	}
+
+	// The archive gets uploaded while it is being written,
+	// so it never has to be held in memory
+	reader, writer := io.Pipe()
+	go func() {
+		_ = writer.CloseWithError(s.writeArchive(writer, user))
+	}()
+
+	key, err := s.FileRepository.UploadExport(reader, user.ID, fmt.Sprintf("%s.zip", export.ID))
+
+	// Stops the writer if the upload failed before reading the whole archive
+	_ = reader.Close()
+
+	if err != nil {
+		return err
+	}
+
+	expiresAt := time.Now().Add(model.ExportLifetime)
+	export.Status = model.ExportReady
+	export.Key = key
+	export.ExpiresAt = &expiresAt
+
+	if err = s.ExportRepository.Save(export); err != nil {
+		return err
+	}
+
+	url, err := s.FileRepository.GetSignedURL(key, model.ExportLifetime)
+
+	if err != nil {
+		log.Printf("Failed to sign the link of export %v: %v\n", export.ID, err)
+		return nil
+	}
+
+	if err = s.MailRepository.SendExportReadyMail(user.Email, url); err != nil {
+		log.Printf("Failed to send the export mail to user %v: %v\n", user.ID, err)
+	}
+
+	return nil
+}
+
+// writeArchive writes a zip containing the profile, friends, guild memberships,
+// messages grouped by channel and the links to the attachments of the user
+func (s *exportService) writeArchive(out io.Writer, user *model.User) error {
+	friends, err := s.FriendRepository.FriendsList(user.ID)
+
+	if err != nil {
+		return err
+	}
+
+	guilds, err := s.GuildRepository.List(user.ID)
+
+	if err != nil {
+		return err
+	}
+
+	channels, err := s.MessageRepository.FindAuthorChannels(user.ID)
+
+	if err != nil {
+		return err
+	}
+
+	w := zip.NewWriter(out)
+
+	files := map[string]interface{}{
+		"profile.json": user,
+		"friends.json": friends,
+		"guilds.json":  guilds,
+	}
+	for name, data := range files {
+		if err = writeArchiveFile(w, name, data); err != nil {
+			return err
+		}
+	}
+
+	attachments := make([]model.ExportedAttachment, 0)
+	for _, channelId := range channels {
+		f, err := w.Create(fmt.Sprintf("messages/%s.json", channelId))
+
+		if err != nil {
+			log.Printf("Failed to add the messages of channel %v to the archive: %v\n", channelId, err.Error())
+			return apperrors.NewInternal()
+		}
+
+		if err = s.writeMessages(f, user.ID, channelId, &attachments); err != nil {
+			log.Printf("Failed to write the messages of channel %v: %v\n", channelId, err.Error())
+			return apperrors.NewInternal()
+		}
+	}
+
+	if err = writeArchiveFile(w, "attachments.json", attachments); err != nil {
+		return err
+	}
+
+	if err = w.Close(); err != nil {
+		log.Printf("Failed to close the archive: %v\n", err.Error())
+		return apperrors.NewInternal()
+	}
+
+	return nil
+}
+
+// writeMessages writes the messages of the user in the given channel as a JSON array.
+// The messages get loaded in batches and the links to their attachments get collected.
+func (s *exportService) writeMessages(w io.Writer, userId, channelId string, attachments *[]model.ExportedAttachment) error {
+	if _, err := io.WriteString(w, "["); err != nil {
+		return err
+	}
+
+	separator := "\n  "
+	err := s.MessageRepository.FindByAuthorInBatches(userId, channelId, exportBatchSize, func(messages *[]model.Message) error {
+		for _, m := range *messages {
+			data, err := json.MarshalIndent(model.ExportedMessage{
+				Id:         m.ID,
+				Text:       m.Text,
+				Type:       m.Type,
+				CreatedAt:  m.CreatedAt,
+				UpdatedAt:  m.UpdatedAt,
+				Attachment: m.Attachment,
+			}, "  ", "  ")
+
+			if err != nil {
+				return err
+			}
+
+			if _, err = io.WriteString(w, separator); err != nil {
+				return err
+			}
+
+			if _, err = w.Write(data); err != nil {
+				return err
+			}
+			separator = ",\n  "
+
+			if m.Attachment != nil {
+				*attachments = append(*attachments, model.ExportedAttachment{
+					MessageId: m.ID,
+					ChannelId: m.ChannelId,
+					Url:       m.Attachment.Url,
+					FileType:  m.Attachment.FileType,
+					Filename:  m.Attachment.Filename,
+				})
+			}
+		}
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(w, "\n]\n")
+	return err
+}
+
+// writeArchiveFile adds the data as an indented JSON file to the archive
+func writeArchiveFile(w *zip.Writer, name string, data interface{}) error {
+	f, err := w.Create(name)
+
+	if err != nil {
+		log.Printf("Failed to add %v to the archive: %v\n", name, err.Error())
+		return apperrors.NewInternal()
+	}
+
+	encoder := json.NewEncoder(f)
+	encoder.SetIndent("", "  ")
+
+	if err = encoder.Encode(data); err != nil {
+		log.Printf("Failed to encode %v: %v\n", name, err.Error())
+		return apperrors.NewInternal()
+	}
+
+	return nil
+}
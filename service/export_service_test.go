@@ -0,0 +1,372 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/sentrionic/valkyrie/mocks"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"github.com/sentrionic/valkyrie/model/fixture"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"io"
+	"testing"
+	"time"
+)
+
+func getMockExport(userId string, status string) *model.DataExport {
+	return &model.DataExport{
+		BaseModel: model.BaseModel{
+			ID:        fixture.RandID(),
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		},
+		UserId: userId,
+		Status: status,
+	}
+}
+
+func TestExportService_RequestExport(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockUser := fixture.GetMockUser()
+
+		mockExportRepository := new(mocks.ExportRepository)
+		es := NewExportService(&EXSConfig{
+			ExportRepository: mockExportRepository,
+		})
+
+		mockExportRepository.On("FindLatest", mockUser.ID).Return(nil, apperrors.NewNotFound("export", mockUser.ID))
+		mockExportRepository.On("Create", mock.AnythingOfType("*model.DataExport")).Return(nil)
+
+		export, err := es.RequestExport(mockUser)
+
+		assert.NoError(t, err)
+		assert.NotEmpty(t, export.ID)
+		assert.Equal(t, mockUser.ID, export.UserId)
+		assert.Equal(t, model.ExportPending, export.Status)
+		mockExportRepository.AssertExpectations(t)
+	})
+
+	t.Run("Export still in progress", func(t *testing.T) {
+		mockUser := fixture.GetMockUser()
+		latest := getMockExport(mockUser.ID, model.ExportPending)
+
+		mockExportRepository := new(mocks.ExportRepository)
+		es := NewExportService(&EXSConfig{
+			ExportRepository: mockExportRepository,
+		})
+
+		mockExportRepository.On("FindLatest", mockUser.ID).Return(latest, nil)
+
+		export, err := es.RequestExport(mockUser)
+
+		assert.Nil(t, export)
+		assert.Equal(t, apperrors.NewBadRequest(apperrors.ExportInProgress), err)
+		mockExportRepository.AssertNotCalled(t, "Create", mock.Anything)
+	})
+
+	t.Run("One export per day", func(t *testing.T) {
+		mockUser := fixture.GetMockUser()
+		latest := getMockExport(mockUser.ID, model.ExportReady)
+		latest.CreatedAt = time.Now().Add(-time.Hour)
+
+		mockExportRepository := new(mocks.ExportRepository)
+		es := NewExportService(&EXSConfig{
+			ExportRepository: mockExportRepository,
+		})
+
+		mockExportRepository.On("FindLatest", mockUser.ID).Return(latest, nil)
+
+		export, err := es.RequestExport(mockUser)
+
+		assert.Nil(t, export)
+		assert.Equal(t, apperrors.NewBadRequest(apperrors.ExportCooldown), err)
+		mockExportRepository.AssertNotCalled(t, "Create", mock.Anything)
+	})
+
+	t.Run("Failed exports can be requested again", func(t *testing.T) {
+		mockUser := fixture.GetMockUser()
+		latest := getMockExport(mockUser.ID, model.ExportFailed)
+
+		mockExportRepository := new(mocks.ExportRepository)
+		es := NewExportService(&EXSConfig{
+			ExportRepository: mockExportRepository,
+		})
+
+		mockExportRepository.On("FindLatest", mockUser.ID).Return(latest, nil)
+		mockExportRepository.On("Create", mock.AnythingOfType("*model.DataExport")).Return(nil)
+
+		export, err := es.RequestExport(mockUser)
+
+		assert.NoError(t, err)
+		assert.Equal(t, model.ExportPending, export.Status)
+		mockExportRepository.AssertExpectations(t)
+	})
+}
+
+func TestExportService_GetDownloadURL(t *testing.T) {
+	t.Run("Ready export", func(t *testing.T) {
+		export := getMockExport(fixture.RandID(), model.ExportReady)
+		export.Key = fmt.Sprintf("exports/%s/%s.zip", export.UserId, export.ID)
+		expiresAt := time.Now().Add(time.Hour)
+		export.ExpiresAt = &expiresAt
+
+		mockFileRepository := new(mocks.FileRepository)
+		es := NewExportService(&EXSConfig{
+			FileRepository: mockFileRepository,
+		})
+
+		signed := "https://bucket.s3.amazonaws.com/" + export.Key + "?X-Amz-Signature=signature"
+		mockFileRepository.On("GetSignedURL", export.Key, mock.AnythingOfType("time.Duration")).Return(signed, nil)
+
+		url, err := es.GetDownloadURL(export)
+
+		assert.NoError(t, err)
+		assert.Equal(t, signed, *url)
+		mockFileRepository.AssertExpectations(t)
+	})
+
+	t.Run("Pending export", func(t *testing.T) {
+		export := getMockExport(fixture.RandID(), model.ExportPending)
+
+		mockFileRepository := new(mocks.FileRepository)
+		es := NewExportService(&EXSConfig{
+			FileRepository: mockFileRepository,
+		})
+
+		url, err := es.GetDownloadURL(export)
+
+		assert.NoError(t, err)
+		assert.Nil(t, url)
+		mockFileRepository.AssertNotCalled(t, "GetSignedURL", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Expired export", func(t *testing.T) {
+		export := getMockExport(fixture.RandID(), model.ExportReady)
+		expiresAt := time.Now().Add(-time.Minute)
+		export.ExpiresAt = &expiresAt
+
+		mockFileRepository := new(mocks.FileRepository)
+		es := NewExportService(&EXSConfig{
+			FileRepository: mockFileRepository,
+		})
+
+		url, err := es.GetDownloadURL(export)
+
+		assert.NoError(t, err)
+		assert.Nil(t, url)
+		mockFileRepository.AssertNotCalled(t, "GetSignedURL", mock.Anything, mock.Anything)
+	})
+}
+
+func TestExportService_ProcessExports(t *testing.T) {
+	t.Run("Builds the archive and mails the link", func(t *testing.T) {
+		mockUser := fixture.GetMockUser()
+		export := getMockExport(mockUser.ID, model.ExportPending)
+
+		channelId := fixture.RandID()
+		message := fixture.GetMockMessage(mockUser.ID, channelId)
+		upload := fixture.GetMockMessage(mockUser.ID, channelId)
+		upload.Text = nil
+		upload.Attachment = &model.Attachment{
+			Url:      "https://bucket.s3.amazonaws.com/files/channels/" + channelId + "/image.png",
+			FileType: "image/png",
+			Filename: "image.png",
+		}
+		other := fixture.GetMockMessage(mockUser.ID, fixture.RandID())
+
+		friends := []model.Friend{{Id: fixture.RandID(), Username: fixture.Username()}}
+		guild := fixture.GetMockGuild("")
+
+		mockExportRepository := new(mocks.ExportRepository)
+		mockUserRepository := new(mocks.UserRepository)
+		mockFriendRepository := new(mocks.FriendRepository)
+		mockGuildRepository := new(mocks.GuildRepository)
+		mockMessageRepository := new(mocks.MessageRepository)
+		mockFileRepository := new(mocks.FileRepository)
+		mockMailRepository := new(mocks.MailRepository)
+		mockRedisRepository := new(mocks.RedisRepository)
+		es := NewExportService(&EXSConfig{
+			ExportRepository:  mockExportRepository,
+			UserRepository:    mockUserRepository,
+			FriendRepository:  mockFriendRepository,
+			GuildRepository:   mockGuildRepository,
+			MessageRepository: mockMessageRepository,
+			FileRepository:    mockFileRepository,
+			MailRepository:    mockMailRepository,
+			RedisRepository:   mockRedisRepository,
+		})
+
+		key := fmt.Sprintf("exports/%s/%s.zip", mockUser.ID, export.ID)
+		signed := "https://bucket.s3.amazonaws.com/" + key + "?X-Amz-Signature=signature"
+		var archive []byte
+
+		mockRedisRepository.On("AcquireLock", mock.Anything, exportLock, ExportLockTTL).Return("lock", nil)
+		mockRedisRepository.On("ReleaseLock", mock.Anything, exportLock, "lock").Return(nil)
+		mockExportRepository.On("FindPending").Return(&[]model.DataExport{*export}, nil)
+		mockUserRepository.On("FindByID", mockUser.ID).Return(mockUser, nil)
+		mockFriendRepository.On("FriendsList", mockUser.ID).Return(&friends, nil)
+		mockGuildRepository.On("List", mockUser.ID).Return(&[]model.GuildResponse{guild.SerializeGuild("")}, nil)
+		mockMessageRepository.On("FindAuthorChannels", mockUser.ID).Return([]string{channelId, other.ChannelId}, nil)
+		mockMessageRepository.On("FindByAuthorInBatches", mockUser.ID, channelId, exportBatchSize, mock.Anything).
+			Run(func(args mock.Arguments) {
+				fn := args.Get(3).(func(*[]model.Message) error)
+				_ = fn(&[]model.Message{*message})
+				_ = fn(&[]model.Message{*upload})
+			}).
+			Return(nil)
+		mockMessageRepository.On("FindByAuthorInBatches", mockUser.ID, other.ChannelId, exportBatchSize, mock.Anything).
+			Run(func(args mock.Arguments) {
+				fn := args.Get(3).(func(*[]model.Message) error)
+				_ = fn(&[]model.Message{*other})
+			}).
+			Return(nil)
+		mockFileRepository.On("UploadExport", mock.Anything, mockUser.ID, export.ID+".zip").
+			Run(func(args mock.Arguments) {
+				archive, _ = io.ReadAll(args.Get(0).(io.Reader))
+			}).
+			Return(key, nil)
+		mockExportRepository.On("Save", mock.MatchedBy(func(e *model.DataExport) bool {
+			return e.ID == export.ID && e.Status == model.ExportReady && e.Key == key && e.ExpiresAt != nil
+		})).Return(nil)
+		mockFileRepository.On("GetSignedURL", key, model.ExportLifetime).Return(signed, nil)
+		mockMailRepository.On("SendExportReadyMail", mockUser.Email, signed).Return(nil)
+		mockExportRepository.On("FindExpired", mock.AnythingOfType("time.Time")).Return(&[]model.DataExport{}, nil)
+
+		err := es.ProcessExports()
+
+		assert.NoError(t, err)
+		mockExportRepository.AssertExpectations(t)
+		mockFileRepository.AssertExpectations(t)
+		mockMailRepository.AssertExpectations(t)
+
+		reader, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+		assert.NoError(t, err)
+
+		files := make(map[string][]byte)
+		for _, f := range reader.File {
+			rc, err := f.Open()
+			assert.NoError(t, err)
+			files[f.Name], _ = io.ReadAll(rc)
+			_ = rc.Close()
+		}
+
+		assert.Len(t, files, 6)
+		assert.Contains(t, files, "profile.json")
+		assert.Contains(t, files, "friends.json")
+		assert.Contains(t, files, "guilds.json")
+		assert.Contains(t, files, "messages/"+other.ChannelId+".json")
+
+		var profile map[string]interface{}
+		assert.NoError(t, json.Unmarshal(files["profile.json"], &profile))
+		assert.Equal(t, mockUser.Email, profile["email"])
+		assert.NotContains(t, profile, "password")
+
+		var messages []model.ExportedMessage
+		assert.NoError(t, json.Unmarshal(files["messages/"+channelId+".json"], &messages))
+		assert.Len(t, messages, 2)
+		assert.Equal(t, message.ID, messages[0].Id)
+
+		var attachments []model.ExportedAttachment
+		assert.NoError(t, json.Unmarshal(files["attachments.json"], &attachments))
+		assert.Equal(t, []model.ExportedAttachment{{
+			MessageId: upload.ID,
+			ChannelId: channelId,
+			Url:       upload.Attachment.Url,
+			FileType:  "image/png",
+			Filename:  "image.png",
+		}}, attachments)
+	})
+
+	t.Run("Marks the export as failed", func(t *testing.T) {
+		mockUser := fixture.GetMockUser()
+		export := getMockExport(mockUser.ID, model.ExportPending)
+
+		mockExportRepository := new(mocks.ExportRepository)
+		mockUserRepository := new(mocks.UserRepository)
+		mockFriendRepository := new(mocks.FriendRepository)
+		mockFileRepository := new(mocks.FileRepository)
+		mockMailRepository := new(mocks.MailRepository)
+		mockRedisRepository := new(mocks.RedisRepository)
+		es := NewExportService(&EXSConfig{
+			ExportRepository: mockExportRepository,
+			UserRepository:   mockUserRepository,
+			FriendRepository: mockFriendRepository,
+			FileRepository:   mockFileRepository,
+			MailRepository:   mockMailRepository,
+			RedisRepository:  mockRedisRepository,
+		})
+
+		mockRedisRepository.On("AcquireLock", mock.Anything, exportLock, ExportLockTTL).Return("lock", nil)
+		mockRedisRepository.On("ReleaseLock", mock.Anything, exportLock, "lock").Return(nil)
+		mockExportRepository.On("FindPending").Return(&[]model.DataExport{*export}, nil)
+		mockUserRepository.On("FindByID", mockUser.ID).Return(mockUser, nil)
+		mockFriendRepository.On("FriendsList", mockUser.ID).Return(nil, apperrors.NewInternal())
+		mockFileRepository.On("UploadExport", mock.Anything, mockUser.ID, export.ID+".zip").
+			Run(func(args mock.Arguments) {
+				_, _ = io.ReadAll(args.Get(0).(io.Reader))
+			}).
+			Return("", apperrors.NewInternal())
+		mockExportRepository.On("Save", mock.MatchedBy(func(e *model.DataExport) bool {
+			return e.ID == export.ID && e.Status == model.ExportFailed
+		})).Return(nil)
+		mockExportRepository.On("FindExpired", mock.AnythingOfType("time.Time")).Return(&[]model.DataExport{}, nil)
+
+		err := es.ProcessExports()
+
+		assert.NoError(t, err)
+		mockExportRepository.AssertExpectations(t)
+		mockMailRepository.AssertNotCalled(t, "SendExportReadyMail", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Removes expired archives", func(t *testing.T) {
+		export := getMockExport(fixture.RandID(), model.ExportReady)
+		export.Key = fmt.Sprintf("exports/%s/%s.zip", export.UserId, export.ID)
+		expiresAt := time.Now().Add(-time.Minute)
+		export.ExpiresAt = &expiresAt
+		key := export.Key
+
+		mockExportRepository := new(mocks.ExportRepository)
+		mockFileRepository := new(mocks.FileRepository)
+		mockRedisRepository := new(mocks.RedisRepository)
+		es := NewExportService(&EXSConfig{
+			ExportRepository: mockExportRepository,
+			FileRepository:   mockFileRepository,
+			RedisRepository:  mockRedisRepository,
+		})
+
+		mockRedisRepository.On("AcquireLock", mock.Anything, exportLock, ExportLockTTL).Return("lock", nil)
+		mockRedisRepository.On("ReleaseLock", mock.Anything, exportLock, "lock").Return(nil)
+		mockExportRepository.On("FindPending").Return(&[]model.DataExport{}, nil)
+		mockExportRepository.On("FindExpired", mock.AnythingOfType("time.Time")).Return(&[]model.DataExport{*export}, nil)
+		mockFileRepository.On("DeleteImage", key).Return(nil)
+		mockExportRepository.On("Save", mock.MatchedBy(func(e *model.DataExport) bool {
+			return e.ID == export.ID && e.Status == model.ExportExpired && e.Key == ""
+		})).Return(nil)
+
+		err := es.ProcessExports()
+
+		assert.NoError(t, err)
+		mockExportRepository.AssertExpectations(t)
+		mockFileRepository.AssertExpectations(t)
+	})
+
+	t.Run("Skips the run while another instance holds the lock", func(t *testing.T) {
+		mockExportRepository := new(mocks.ExportRepository)
+		mockRedisRepository := new(mocks.RedisRepository)
+		es := NewExportService(&EXSConfig{
+			ExportRepository: mockExportRepository,
+			RedisRepository:  mockRedisRepository,
+		})
+
+		mockRedisRepository.On("AcquireLock", mock.Anything, exportLock, ExportLockTTL).Return("", nil)
+
+		err := es.ProcessExports()
+
+		assert.NoError(t, err)
+		mockExportRepository.AssertNotCalled(t, "FindPending")
+		mockRedisRepository.AssertNotCalled(t, "ReleaseLock", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
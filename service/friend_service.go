@@ -50,3 +50,20 @@ func (f *friendService) RemoveFriend(memberId string, userId string) error {
 func (f *friendService) SaveRequests(user *model.User) error {
 	return f.FriendRepository.Save(user)
 }
+
+func (f *friendService) GetBlockedUsers(id string) (*[]model.BlockedUser, error) {
+	return f.FriendRepository.BlockList(id)
+}
+
+func (f *friendService) BlockUser(userId string, memberId string) error {
+	return f.FriendRepository.Block(userId, memberId)
+}
+
+func (f *friendService) UnblockUser(userId string, memberId string) error {
+	return f.FriendRepository.Unblock(userId, memberId)
+}
+
+// IsBlocked checks if one of the users blocked the other one
+func (f *friendService) IsBlocked(userId string, memberId string) (bool, error) {
+	return f.FriendRepository.IsBlocked(userId, memberId)
+}
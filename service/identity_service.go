@@ -0,0 +1,277 @@
+package service
+
+import (
+	"context"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// identityService acts as a struct for injecting an implementation of UserRepository
+// and IdentityRepository for use in service methods
+type identityService struct {
+	UserRepository     model.UserRepository
+	IdentityRepository model.IdentityRepository
+	Provider           *oidcProvider
+}
+
+// IDSConfig will hold repositories and the provider settings
+// that will eventually be injected into this service layer
+type IDSConfig struct {
+	UserRepository     model.UserRepository
+	IdentityRepository model.IdentityRepository
+	// Issuer of the OpenID Connect provider. The login is disabled if empty
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	// RedirectURL is the page the provider sends the user back to with the code
+	RedirectURL string
+	// Client used to talk to the provider. Defaults to a client with a 10 second timeout
+	Client *http.Client
+}
+
+// NewIdentityService is a factory function for
+// initializing an IdentityService with its repository layer dependencies
+func NewIdentityService(c *IDSConfig) model.IdentityService {
+	client := c.Client
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	return &identityService{
+		UserRepository:     c.UserRepository,
+		IdentityRepository: c.IdentityRepository,
+		Provider: &oidcProvider{
+			Issuer:       c.Issuer,
+			ClientID:     c.ClientID,
+			ClientSecret: c.ClientSecret,
+			RedirectURL:  c.RedirectURL,
+			Client:       client,
+		},
+	}
+}
+
+// Enabled reports if an identity provider has been configured
+func (s *identityService) Enabled() bool {
+	return s.Provider.Issuer != ""
+}
+
+// CreateAuthRequest starts a login at the provider
+func (s *identityService) CreateAuthRequest(ctx context.Context) (*model.OIDCAuthRequest, error) {
+	if !s.Enabled() {
+		return nil, apperrors.NewBadRequest(apperrors.OIDCNotConfigured)
+	}
+
+	values := make([]string, 3)
+	for i := range values {
+		value, err := generateOIDCValue()
+
+		if err != nil {
+			log.Printf("Failed to generate the login values: %v\n", err.Error())
+			return nil, apperrors.NewInternal()
+		}
+
+		values[i] = value
+	}
+
+	authURL, err := s.Provider.authURL(ctx, values[0], values[1], values[2])
+
+	if err != nil {
+		log.Printf("Failed to reach the identity provider: %v\n", err.Error())
+		return nil, apperrors.NewServiceUnavailable()
+	}
+
+	return &model.OIDCAuthRequest{
+		URL:      authURL,
+		State:    values[0],
+		Nonce:    values[1],
+		Verifier: values[2],
+	}, nil
+}
+
+// Login returns the user of the identity returned by the provider.
+// Unknown identities get linked to the account with the same email if both the provider
+// and the account verified it. Otherwise a new account without a password gets created.
+func (s *identityService) Login(ctx context.Context, code, verifier, nonce string) (*model.User, error) {
+	claims, err := s.exchange(ctx, code, verifier, nonce)
+
+	if err != nil {
+		return nil, err
+	}
+
+	identity, err := s.IdentityRepository.FindBySubject(claims.Issuer, claims.Subject)
+
+	if err == nil {
+		return s.UserRepository.FindByID(identity.UserId)
+	}
+
+	if apperrors.Status(err) != http.StatusNotFound {
+		return nil, err
+	}
+
+	email := strings.ToLower(strings.TrimSpace(claims.Email))
+
+	if email == "" {
+		return nil, apperrors.NewBadRequest(apperrors.OIDCEmailRequired)
+	}
+
+	user, err := s.UserRepository.FindByEmail(email)
+
+	switch {
+	case err == nil:
+		// Anyone could claim an unverified address, either at their provider
+		// or by registering it here without ever receiving a mail
+		if !bool(claims.EmailVerified) || !user.EmailVerified {
+			return nil, apperrors.NewBadRequest(apperrors.OIDCEmailNotVerified)
+		}
+
+		if user.IsBot {
+			return nil, apperrors.NewAuthorization(apperrors.InvalidOIDCLogin)
+		}
+	case apperrors.Status(err) == http.StatusNotFound:
+		user, err = s.createUser(claims, email)
+
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, err
+	}
+
+	if _, err := s.createIdentity(user.ID, claims); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// Link adds the identity returned by the provider to the given user
+func (s *identityService) Link(ctx context.Context, user *model.User, code, verifier, nonce string) (*model.UserIdentity, error) {
+	claims, err := s.exchange(ctx, code, verifier, nonce)
+
+	if err != nil {
+		return nil, err
+	}
+
+	identity, err := s.IdentityRepository.FindBySubject(claims.Issuer, claims.Subject)
+
+	if err == nil {
+		if identity.UserId != user.ID {
+			return nil, apperrors.NewBadRequest(apperrors.IdentityAlreadyLinked)
+		}
+		return identity, nil
+	}
+
+	if apperrors.Status(err) != http.StatusNotFound {
+		return nil, err
+	}
+
+	return s.createIdentity(user.ID, claims)
+}
+
+func (s *identityService) GetIdentities(userId string) (*[]model.UserIdentity, error) {
+	return s.IdentityRepository.FindByUser(userId)
+}
+
+// Unlink removes the identity from the user.
+// Users without a password must keep at least one identity to be able to log in.
+func (s *identityService) Unlink(user *model.User, identityId string) error {
+	identity, err := s.IdentityRepository.FindByID(identityId)
+
+	if err != nil || identity.UserId != user.ID {
+		return apperrors.NewNotFound("identity", identityId)
+	}
+
+	if user.Password == "" {
+		identities, err := s.IdentityRepository.FindByUser(user.ID)
+
+		if err != nil {
+			return err
+		}
+
+		if len(*identities) <= 1 {
+			return apperrors.NewBadRequest(apperrors.LastLoginMethod)
+		}
+	}
+
+	return s.IdentityRepository.Delete(identity)
+}
+
+// exchange redeems the code at the provider and returns the verified claims
+func (s *identityService) exchange(ctx context.Context, code, verifier, nonce string) (*oidcClaims, error) {
+	if !s.Enabled() {
+		return nil, apperrors.NewBadRequest(apperrors.OIDCNotConfigured)
+	}
+
+	claims, err := s.Provider.exchange(ctx, code, verifier, nonce)
+
+	if err != nil {
+		log.Printf("Failed to verify the login at the identity provider: %v\n", err.Error())
+		return nil, apperrors.NewAuthorization(apperrors.InvalidOIDCLogin)
+	}
+
+	return claims, nil
+}
+
+// createUser registers a user without a password for the identity
+func (s *identityService) createUser(claims *oidcClaims, email string) (*model.User, error) {
+	id, err := GenerateId()
+
+	if err != nil {
+		return nil, err
+	}
+
+	user := &model.User{
+		Username:      oidcUsername(claims, email),
+		Email:         email,
+		EmailVerified: bool(claims.EmailVerified),
+		Image:         generateAvatar(email),
+	}
+	user.ID = id
+
+	return s.UserRepository.Create(user)
+}
+
+func (s *identityService) createIdentity(userId string, claims *oidcClaims) (*model.UserIdentity, error) {
+	id, err := GenerateId()
+
+	if err != nil {
+		return nil, err
+	}
+
+	identity := &model.UserIdentity{
+		UserId:  userId,
+		Issuer:  claims.Issuer,
+		Subject: claims.Subject,
+		Email:   strings.ToLower(claims.Email),
+	}
+	identity.ID = id
+
+	if err := s.IdentityRepository.Create(identity); err != nil {
+		return nil, err
+	}
+
+	return identity, nil
+}
+
+// oidcUsername picks a username between 3 and 30 characters from the claims
+func oidcUsername(claims *oidcClaims, email string) string {
+	candidates := []string{claims.PreferredUsername, claims.Name, strings.Split(email, "@")[0]}
+
+	for _, candidate := range candidates {
+		username := []rune(strings.TrimSpace(candidate))
+
+		if len(username) > 30 {
+			username = username[:30]
+		}
+
+		if len(username) >= 3 {
+			return string(username)
+		}
+	}
+
+	return "user"
+}
@@ -0,0 +1,439 @@
+package service
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"github.com/sentrionic/valkyrie/mocks"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"github.com/sentrionic/valkyrie/model/fixture"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+const (
+	testClientID     = "valkyrie"
+	testClientSecret = "secret"
+	testRedirectURL  = "http://localhost:3000/oidc/callback"
+)
+
+// testOIDCProvider is a local stand-in for an OpenID Connect provider
+type testOIDCProvider struct {
+	*httptest.Server
+	key   *rsa.PrivateKey
+	codes map[string]testOIDCCode
+}
+
+type testOIDCCode struct {
+	challenge string
+	claims    map[string]interface{}
+	key       *rsa.PrivateKey
+}
+
+func newTestOIDCProvider(t *testing.T) *testOIDCProvider {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	p := &testOIDCProvider{key: key, codes: make(map[string]testOIDCCode)}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 p.URL,
+			"authorization_endpoint": p.URL + "/authorize",
+			"token_endpoint":         p.URL + "/token",
+			"jwks_uri":               p.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{{
+				"kid": "test",
+				"kty": "RSA",
+				"alg": "RS256",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		id, secret, ok := r.BasicAuth()
+		if !ok || id != testClientID || secret != testClientSecret {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		code, found := p.codes[r.PostFormValue("code")]
+		if !found || r.PostFormValue("redirect_uri") != testRedirectURL ||
+			pkceChallenge(r.PostFormValue("code_verifier")) != code.challenge {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"access_token": "access",
+			"token_type":   "Bearer",
+			"id_token":     p.sign(t, code.key, code.claims),
+		})
+	})
+
+	p.Server = httptest.NewServer(mux)
+	return p
+}
+
+// issue returns a code for the login of the given account
+func (p *testOIDCProvider) issue(verifier, nonce, subject, email string, verified bool) string {
+	code := fixture.RandStr(16)
+	p.codes[code] = testOIDCCode{
+		challenge: pkceChallenge(verifier),
+		key:       p.key,
+		claims: map[string]interface{}{
+			"iss":                p.URL,
+			"sub":                subject,
+			"aud":                testClientID,
+			"exp":                time.Now().Add(time.Minute).Unix(),
+			"iat":                time.Now().Unix(),
+			"nonce":              nonce,
+			"email":              email,
+			"email_verified":     verified,
+			"preferred_username": "oidcuser",
+		},
+	}
+	return code
+}
+
+func (p *testOIDCProvider) sign(t *testing.T, key *rsa.PrivateKey, claims map[string]interface{}) string {
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT", "kid": "test"})
+	assert.NoError(t, err)
+	payload, err := json.Marshal(claims)
+	assert.NoError(t, err)
+
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	hash := sha256.Sum256([]byte(signed))
+
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, hash[:])
+	assert.NoError(t, err)
+
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func newTestIdentityService(provider *testOIDCProvider, userRepository model.UserRepository, identityRepository model.IdentityRepository) model.IdentityService {
+	return NewIdentityService(&IDSConfig{
+		UserRepository:     userRepository,
+		IdentityRepository: identityRepository,
+		Issuer:             provider.URL,
+		ClientID:           testClientID,
+		ClientSecret:       testClientSecret,
+		RedirectURL:        testRedirectURL,
+	})
+}
+
+func TestIdentityService_CreateAuthRequest(t *testing.T) {
+	provider := newTestOIDCProvider(t)
+	defer provider.Close()
+
+	t.Run("Success", func(t *testing.T) {
+		is := newTestIdentityService(provider, nil, nil)
+
+		request, err := is.CreateAuthRequest(context.Background())
+		assert.NoError(t, err)
+
+		authURL, err := url.Parse(request.URL)
+		assert.NoError(t, err)
+
+		query := authURL.Query()
+		assert.Equal(t, provider.URL+"/authorize", authURL.Scheme+"://"+authURL.Host+authURL.Path)
+		assert.Equal(t, testClientID, query.Get("client_id"))
+		assert.Equal(t, testRedirectURL, query.Get("redirect_uri"))
+		assert.Equal(t, request.State, query.Get("state"))
+		assert.Equal(t, request.Nonce, query.Get("nonce"))
+		assert.Equal(t, pkceChallenge(request.Verifier), query.Get("code_challenge"))
+		assert.Equal(t, "S256", query.Get("code_challenge_method"))
+	})
+
+	t.Run("Not configured", func(t *testing.T) {
+		is := NewIdentityService(&IDSConfig{})
+
+		request, err := is.CreateAuthRequest(context.Background())
+
+		assert.Nil(t, request)
+		assert.Equal(t, apperrors.NewBadRequest(apperrors.OIDCNotConfigured), err)
+	})
+}
+
+func TestIdentityService_Login(t *testing.T) {
+	provider := newTestOIDCProvider(t)
+	defer provider.Close()
+
+	verifier, nonce := fixture.RandStr(43), fixture.RandStr(16)
+
+	t.Run("Existing identity", func(t *testing.T) {
+		user := fixture.GetMockUser()
+		subject := fixture.RandID()
+		identity := &model.UserIdentity{UserId: user.ID, Issuer: provider.URL, Subject: subject}
+
+		mockUserRepository := new(mocks.UserRepository)
+		mockIdentityRepository := new(mocks.IdentityRepository)
+		is := newTestIdentityService(provider, mockUserRepository, mockIdentityRepository)
+
+		mockIdentityRepository.On("FindBySubject", provider.URL, subject).Return(identity, nil)
+		mockUserRepository.On("FindByID", user.ID).Return(user, nil)
+
+		code := provider.issue(verifier, nonce, subject, "other@example.com", false)
+		result, err := is.Login(context.Background(), code, verifier, nonce)
+
+		assert.NoError(t, err)
+		assert.Equal(t, user, result)
+		mockIdentityRepository.AssertNotCalled(t, "Create", mock.Anything)
+	})
+
+	t.Run("Links the account with the verified email", func(t *testing.T) {
+		user := fixture.GetMockUser()
+		subject := fixture.RandID()
+
+		mockUserRepository := new(mocks.UserRepository)
+		mockIdentityRepository := new(mocks.IdentityRepository)
+		is := newTestIdentityService(provider, mockUserRepository, mockIdentityRepository)
+
+		mockIdentityRepository.On("FindBySubject", provider.URL, subject).Return(nil, apperrors.NewNotFound("identity", subject))
+		mockUserRepository.On("FindByEmail", user.Email).Return(user, nil)
+		mockIdentityRepository.On("Create", mock.MatchedBy(func(identity *model.UserIdentity) bool {
+			return identity.UserId == user.ID && identity.Subject == subject && identity.Issuer == provider.URL
+		})).Return(nil)
+
+		code := provider.issue(verifier, nonce, subject, user.Email, true)
+		result, err := is.Login(context.Background(), code, verifier, nonce)
+
+		assert.NoError(t, err)
+		assert.Equal(t, user, result)
+		mockIdentityRepository.AssertExpectations(t)
+		mockUserRepository.AssertNotCalled(t, "Create", mock.Anything)
+	})
+
+	t.Run("Unverified email of an existing account", func(t *testing.T) {
+		user := fixture.GetMockUser()
+		subject := fixture.RandID()
+
+		mockUserRepository := new(mocks.UserRepository)
+		mockIdentityRepository := new(mocks.IdentityRepository)
+		is := newTestIdentityService(provider, mockUserRepository, mockIdentityRepository)
+
+		mockIdentityRepository.On("FindBySubject", provider.URL, subject).Return(nil, apperrors.NewNotFound("identity", subject))
+		mockUserRepository.On("FindByEmail", user.Email).Return(user, nil)
+
+		code := provider.issue(verifier, nonce, subject, user.Email, false)
+		result, err := is.Login(context.Background(), code, verifier, nonce)
+
+		assert.Nil(t, result)
+		assert.Equal(t, apperrors.NewBadRequest(apperrors.OIDCEmailNotVerified), err)
+		mockIdentityRepository.AssertNotCalled(t, "Create", mock.Anything)
+	})
+
+	t.Run("Unverified email of the local account", func(t *testing.T) {
+		user := fixture.GetMockUser()
+		user.EmailVerified = false
+		subject := fixture.RandID()
+
+		mockUserRepository := new(mocks.UserRepository)
+		mockIdentityRepository := new(mocks.IdentityRepository)
+		is := newTestIdentityService(provider, mockUserRepository, mockIdentityRepository)
+
+		mockIdentityRepository.On("FindBySubject", provider.URL, subject).Return(nil, apperrors.NewNotFound("identity", subject))
+		mockUserRepository.On("FindByEmail", user.Email).Return(user, nil)
+
+		code := provider.issue(verifier, nonce, subject, user.Email, true)
+		result, err := is.Login(context.Background(), code, verifier, nonce)
+
+		assert.Nil(t, result)
+		assert.Equal(t, apperrors.NewBadRequest(apperrors.OIDCEmailNotVerified), err)
+		mockIdentityRepository.AssertNotCalled(t, "Create", mock.Anything)
+	})
+
+	t.Run("Creates a new account", func(t *testing.T) {
+		email := "new@example.com"
+		subject := fixture.RandID()
+
+		mockUserRepository := new(mocks.UserRepository)
+		mockIdentityRepository := new(mocks.IdentityRepository)
+		is := newTestIdentityService(provider, mockUserRepository, mockIdentityRepository)
+
+		mockIdentityRepository.On("FindBySubject", provider.URL, subject).Return(nil, apperrors.NewNotFound("identity", subject))
+		mockUserRepository.On("FindByEmail", email).Return(nil, apperrors.NewNotFound("email", email))
+		mockUserRepository.On("Create", mock.AnythingOfType("*model.User")).Return(func(user *model.User) *model.User {
+			return user
+		}, nil)
+		mockIdentityRepository.On("Create", mock.AnythingOfType("*model.UserIdentity")).Return(nil)
+
+		code := provider.issue(verifier, nonce, subject, email, true)
+		result, err := is.Login(context.Background(), code, verifier, nonce)
+
+		assert.NoError(t, err)
+		assert.NotEmpty(t, result.ID)
+		assert.Equal(t, email, result.Email)
+		assert.Equal(t, "oidcuser", result.Username)
+		assert.True(t, result.EmailVerified)
+		assert.Empty(t, result.Password)
+		mockIdentityRepository.AssertExpectations(t)
+	})
+
+	t.Run("Wrong nonce", func(t *testing.T) {
+		mockIdentityRepository := new(mocks.IdentityRepository)
+		is := newTestIdentityService(provider, nil, mockIdentityRepository)
+
+		code := provider.issue(verifier, "other", fixture.RandID(), "user@example.com", true)
+		result, err := is.Login(context.Background(), code, verifier, nonce)
+
+		assert.Nil(t, result)
+		assert.Equal(t, apperrors.NewAuthorization(apperrors.InvalidOIDCLogin), err)
+		mockIdentityRepository.AssertNotCalled(t, "FindBySubject", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Wrong verifier", func(t *testing.T) {
+		is := newTestIdentityService(provider, nil, nil)
+
+		code := provider.issue(verifier, nonce, fixture.RandID(), "user@example.com", true)
+		result, err := is.Login(context.Background(), code, fixture.RandStr(43), nonce)
+
+		assert.Nil(t, result)
+		assert.Equal(t, apperrors.NewAuthorization(apperrors.InvalidOIDCLogin), err)
+	})
+
+	t.Run("Invalid signature", func(t *testing.T) {
+		other, err := rsa.GenerateKey(rand.Reader, 2048)
+		assert.NoError(t, err)
+
+		is := newTestIdentityService(provider, nil, nil)
+
+		code := provider.issue(verifier, nonce, fixture.RandID(), "user@example.com", true)
+		issued := provider.codes[code]
+		issued.key = other
+		provider.codes[code] = issued
+
+		result, err := is.Login(context.Background(), code, verifier, nonce)
+
+		assert.Nil(t, result)
+		assert.Equal(t, apperrors.NewAuthorization(apperrors.InvalidOIDCLogin), err)
+	})
+
+	t.Run("Wrong audience", func(t *testing.T) {
+		is := newTestIdentityService(provider, nil, nil)
+
+		code := provider.issue(verifier, nonce, fixture.RandID(), "user@example.com", true)
+		provider.codes[code].claims["aud"] = []string{"another-client"}
+
+		result, err := is.Login(context.Background(), code, verifier, nonce)
+
+		assert.Nil(t, result)
+		assert.Equal(t, apperrors.NewAuthorization(apperrors.InvalidOIDCLogin), err)
+	})
+}
+
+func TestIdentityService_Link(t *testing.T) {
+	provider := newTestOIDCProvider(t)
+	defer provider.Close()
+
+	verifier, nonce := fixture.RandStr(43), fixture.RandStr(16)
+
+	t.Run("Success", func(t *testing.T) {
+		user := fixture.GetMockUser()
+		subject := fixture.RandID()
+
+		mockIdentityRepository := new(mocks.IdentityRepository)
+		is := newTestIdentityService(provider, nil, mockIdentityRepository)
+
+		mockIdentityRepository.On("FindBySubject", provider.URL, subject).Return(nil, apperrors.NewNotFound("identity", subject))
+		mockIdentityRepository.On("Create", mock.AnythingOfType("*model.UserIdentity")).Return(nil)
+
+		// Linking does not depend on the email of the identity
+		code := provider.issue(verifier, nonce, subject, "other@example.com", false)
+		identity, err := is.Link(context.Background(), user, code, verifier, nonce)
+
+		assert.NoError(t, err)
+		assert.NotEmpty(t, identity.ID)
+		assert.Equal(t, user.ID, identity.UserId)
+		assert.Equal(t, subject, identity.Subject)
+		mockIdentityRepository.AssertExpectations(t)
+	})
+
+	t.Run("Linked to another account", func(t *testing.T) {
+		user := fixture.GetMockUser()
+		subject := fixture.RandID()
+		identity := &model.UserIdentity{UserId: fixture.RandID(), Issuer: provider.URL, Subject: subject}
+
+		mockIdentityRepository := new(mocks.IdentityRepository)
+		is := newTestIdentityService(provider, nil, mockIdentityRepository)
+
+		mockIdentityRepository.On("FindBySubject", provider.URL, subject).Return(identity, nil)
+
+		code := provider.issue(verifier, nonce, subject, user.Email, true)
+		result, err := is.Link(context.Background(), user, code, verifier, nonce)
+
+		assert.Nil(t, result)
+		assert.Equal(t, apperrors.NewBadRequest(apperrors.IdentityAlreadyLinked), err)
+		mockIdentityRepository.AssertNotCalled(t, "Create", mock.Anything)
+	})
+}
+
+func TestIdentityService_Unlink(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		user := fixture.GetMockUser()
+		identity := &model.UserIdentity{UserId: user.ID}
+		identity.ID = fixture.RandID()
+
+		mockIdentityRepository := new(mocks.IdentityRepository)
+		is := NewIdentityService(&IDSConfig{IdentityRepository: mockIdentityRepository})
+
+		mockIdentityRepository.On("FindByID", identity.ID).Return(identity, nil)
+		mockIdentityRepository.On("Delete", identity).Return(nil)
+
+		err := is.Unlink(user, identity.ID)
+
+		assert.NoError(t, err)
+		mockIdentityRepository.AssertExpectations(t)
+	})
+
+	t.Run("Last identity without a password", func(t *testing.T) {
+		user := fixture.GetMockUser()
+		user.Password = ""
+		identity := &model.UserIdentity{UserId: user.ID}
+		identity.ID = fixture.RandID()
+
+		mockIdentityRepository := new(mocks.IdentityRepository)
+		is := NewIdentityService(&IDSConfig{IdentityRepository: mockIdentityRepository})
+
+		mockIdentityRepository.On("FindByID", identity.ID).Return(identity, nil)
+		mockIdentityRepository.On("FindByUser", user.ID).Return(&[]model.UserIdentity{*identity}, nil)
+
+		err := is.Unlink(user, identity.ID)
+
+		assert.Equal(t, apperrors.NewBadRequest(apperrors.LastLoginMethod), err)
+		mockIdentityRepository.AssertNotCalled(t, "Delete", mock.Anything)
+	})
+
+	t.Run("Identity of another user", func(t *testing.T) {
+		user := fixture.GetMockUser()
+		identity := &model.UserIdentity{UserId: fixture.RandID()}
+		identity.ID = fixture.RandID()
+
+		mockIdentityRepository := new(mocks.IdentityRepository)
+		is := NewIdentityService(&IDSConfig{IdentityRepository: mockIdentityRepository})
+
+		mockIdentityRepository.On("FindByID", identity.ID).Return(identity, nil)
+
+		err := is.Unlink(user, identity.ID)
+
+		assert.Equal(t, apperrors.NewNotFound("identity", identity.ID), err)
+		mockIdentityRepository.AssertNotCalled(t, "Delete", mock.Anything)
+	})
+}
@@ -46,6 +46,10 @@ func (m *messageService) CreateMessage(params *model.Message) (*model.Message, e
 	}
 	params.ID = id
 
+	if params.Type == "" {
+		params.Type = model.DefaultMessage
+	}
+
 	return m.MessageRepository.CreateMessage(params)
 }
 
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+// NewOutgoingClient returns a client for requests to user provided URLs like
+// event webhooks and command callbacks. Unless allowPrivate is set, the client
+// refuses to connect to loopback, private, link-local and unspecified addresses.
+// The check runs on every connection, so a host resolving to an internal
+// address after it got saved cannot be used to reach the internal network.
+func NewOutgoingClient(timeout time.Duration, allowPrivate bool) *http.Client {
+	dialer := &net.Dialer{
+		Timeout:   timeout,
+		KeepAlive: 30 * time.Second,
+	}
+
+	if !allowPrivate {
+		dialer.Control = denyPrivateAddress
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return dialer.DialContext(ctx, network, addr)
+	}
+
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+	}
+}
+
+// denyPrivateAddress gets called with the resolved address right before connecting
+func denyPrivateAddress(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+
+	if err != nil {
+		return err
+	}
+
+	ip := net.ParseIP(host)
+
+	if ip == nil || isPrivateIP(ip) {
+		return fmt.Errorf("connecting to %s is not allowed", host)
+	}
+
+	return nil
+}
+
+// isPrivateIP returns true if the ip is not reachable over the public internet
+func isPrivateIP(ip net.IP) bool {
+	return ip.IsLoopback() ||
+		ip.IsPrivate() ||
+		ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() ||
+		ip.IsUnspecified()
+}
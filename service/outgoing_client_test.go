@@ -0,0 +1,48 @@
+package service
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestNewOutgoingClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	t.Run("Refuses private addresses", func(t *testing.T) {
+		client := NewOutgoingClient(time.Second, false)
+
+		resp, err := client.Get(server.URL)
+
+		assert.Error(t, err)
+		assert.Nil(t, resp)
+	})
+
+	t.Run("Allows private addresses if enabled", func(t *testing.T) {
+		client := NewOutgoingClient(time.Second, true)
+
+		resp, err := client.Get(server.URL)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		_ = resp.Body.Close()
+	})
+}
+
+func TestIsPrivateIP(t *testing.T) {
+	private := []string{"127.0.0.1", "10.0.0.1", "172.16.0.1", "192.168.1.1", "169.254.169.254", "0.0.0.0", "::1", "fd00::1", "fe80::1"}
+	for _, ip := range private {
+		assert.True(t, isPrivateIP(net.ParseIP(ip)), ip)
+	}
+
+	public := []string{"1.1.1.1", "8.8.8.8", "2606:4700:4700::1111"}
+	for _, ip := range public {
+		assert.False(t, isPrivateIP(net.ParseIP(ip)), ip)
+	}
+}
@@ -9,16 +9,23 @@ import (
 
 type socketService struct {
 	Hub               ws.Hub
+	UserRepository    model.UserRepository
 	GuildRepository   model.GuildRepository
 	ChannelRepository model.ChannelRepository
+	FriendRepository  model.FriendRepository
+	EventService      model.EventService
 }
 
 // SSConfig will hold repositories that will eventually be injected into
 // this service layer
 type SSConfig struct {
 	Hub               ws.Hub
+	UserRepository    model.UserRepository
 	GuildRepository   model.GuildRepository
 	ChannelRepository model.ChannelRepository
+	FriendRepository  model.FriendRepository
+	// Optional. Forwards guild events to the event webhooks
+	EventService model.EventService
 }
 
 // NewSocketService is a factory function for
@@ -26,8 +33,11 @@ type SSConfig struct {
 func NewSocketService(c *SSConfig) model.SocketService {
 	return &socketService{
 		Hub:               c.Hub,
+		UserRepository:    c.UserRepository,
 		GuildRepository:   c.GuildRepository,
 		ChannelRepository: c.ChannelRepository,
+		FriendRepository:  c.FriendRepository,
+		EventService:      c.EventService,
 	}
 }
 
@@ -42,6 +52,8 @@ func (s *socketService) EmitNewMessage(room string, message *model.MessageRespon
 	}
 
 	s.Hub.BroadcastToRoom(data, room)
+
+	s.dispatchChannelEvent(room, model.MessageCreatedEvent, message)
 }
 
 func (s *socketService) EmitEditMessage(room string, message *model.MessageResponse) {
@@ -55,6 +67,8 @@ func (s *socketService) EmitEditMessage(room string, message *model.MessageRespo
 	}
 
 	s.Hub.BroadcastToRoom(data, room)
+
+	s.dispatchChannelEvent(room, model.MessageUpdatedEvent, message)
 }
 
 func (s *socketService) EmitDeleteMessage(room, messageId string) {
@@ -68,6 +82,8 @@ func (s *socketService) EmitDeleteMessage(room, messageId string) {
 	}
 
 	s.Hub.BroadcastToRoom(data, room)
+
+	s.dispatchChannelEvent(room, model.MessageDeletedEvent, map[string]string{"id": messageId, "channelId": room})
 }
 
 func (s *socketService) EmitNewChannel(room string, channel *model.ChannelResponse) {
@@ -81,6 +97,8 @@ func (s *socketService) EmitNewChannel(room string, channel *model.ChannelRespon
 	}
 
 	s.Hub.BroadcastToRoom(data, room)
+
+	s.dispatch(room, model.ChannelCreatedEvent, channel)
 }
 
 func (s *socketService) EmitNewPrivateChannel(members []string, channel *model.ChannelResponse) {
@@ -96,6 +114,8 @@ func (s *socketService) EmitNewPrivateChannel(members []string, channel *model.C
 	for _, id := range members {
 		s.Hub.BroadcastToRoom(data, id)
 	}
+
+	s.dispatchChannelEvent(channel.Id, model.ChannelCreatedEvent, channel)
 }
 
 func (s *socketService) EmitEditChannel(room string, channel *model.ChannelResponse) {
@@ -109,6 +129,8 @@ func (s *socketService) EmitEditChannel(room string, channel *model.ChannelRespo
 	}
 
 	s.Hub.BroadcastToRoom(data, room)
+
+	s.dispatch(room, model.ChannelUpdatedEvent, channel)
 }
 
 func (s *socketService) EmitDeleteChannel(channel *model.Channel) {
@@ -122,6 +144,8 @@ func (s *socketService) EmitDeleteChannel(channel *model.Channel) {
 	}
 
 	s.Hub.BroadcastToRoom(data, *channel.GuildID)
+
+	s.dispatch(*channel.GuildID, model.ChannelDeletedEvent, map[string]string{"id": channel.ID})
 }
 
 func (s *socketService) EmitEditGuild(guild *model.Guild) {
@@ -176,6 +200,20 @@ func (s *socketService) EmitRemoveFromGuild(memberId, guildId string) {
 	s.Hub.BroadcastToRoom(data, memberId)
 }
 
+func (s *socketService) EmitTransferGuild(transfer *model.GuildTransfer) {
+	data, err := json.Marshal(model.WebsocketMessage{
+		Action: ws.TransferGuildAction,
+		Data:   transfer,
+	})
+
+	if err != nil {
+		log.Printf("error marshalling response: %v\n", err)
+	}
+
+	s.Hub.BroadcastToRoom(data, transfer.OldOwnerId)
+	s.Hub.BroadcastToRoom(data, transfer.NewOwnerId)
+}
+
 func (s *socketService) EmitAddMember(room string, member *model.User) {
 
 	response := model.MemberResponse{
@@ -183,6 +221,7 @@ func (s *socketService) EmitAddMember(room string, member *model.User) {
 		Username:  member.Username,
 		Image:     member.Image,
 		IsOnline:  member.IsOnline,
+		Status:    member.GetPresence().Status,
 		CreatedAt: member.CreatedAt,
 		UpdatedAt: member.UpdatedAt,
 		IsFriend:  false,
@@ -198,6 +237,8 @@ func (s *socketService) EmitAddMember(room string, member *model.User) {
 	}
 
 	s.Hub.BroadcastToRoom(data, room)
+
+	s.dispatch(room, model.MemberJoinedEvent, response)
 }
 
 func (s *socketService) EmitRemoveMember(room, memberId string) {
@@ -211,30 +252,15 @@ func (s *socketService) EmitRemoveMember(room, memberId string) {
 	}
 
 	s.Hub.BroadcastToRoom(data, room)
+
+	s.dispatch(room, model.MemberLeftEvent, map[string]string{"id": memberId})
 }
 
+// EmitNewDMNotification emits the DM to all members except the author.
+// Each member receives the DM from their own perspective, so group DMs
+// list the other members. Members that blocked the author do not get notified.
+// Members with a pending message request receive the request instead.
 func (s *socketService) EmitNewDMNotification(channelId string, user *model.User) {
-
-	response := model.DirectMessage{
-		Id: channelId,
-		User: model.DMUser{
-			Id:       user.ID,
-			Username: user.Username,
-			Image:    user.Image,
-			IsOnline: user.IsOnline,
-			IsFriend: false,
-		},
-	}
-
-	notification, err := json.Marshal(model.WebsocketMessage{
-		Action: ws.NewDMNotificationAction,
-		Data:   response,
-	})
-
-	if err != nil {
-		log.Printf("error marshalling notification: %v\n", err)
-	}
-
 	pushToTop, err := json.Marshal(model.WebsocketMessage{
 		Action: ws.PushToTopAction,
 		Data:   channelId,
@@ -248,16 +274,114 @@ func (s *socketService) EmitNewDMNotification(channelId string, user *model.User
 
 	if err != nil {
 		log.Printf("error getting member ids: %v\n", err)
+		return
 	}
 
 	for _, id := range *members {
+		if blocked, _ := s.FriendRepository.HasBlocked(id, user.ID); blocked {
+			continue
+		}
+
 		if id != user.ID {
+			response, err := s.ChannelRepository.GetDirectMessage(channelId, id)
+
+			if err != nil {
+				log.Printf("error getting dm: %v\n", err)
+				continue
+			}
+
+			action := ws.NewDMNotificationAction
+			if response.IsRequest {
+				action = ws.NewDMRequestAction
+			}
+
+			notification, err := json.Marshal(model.WebsocketMessage{
+				Action: action,
+				Data:   response,
+			})
+
+			if err != nil {
+				log.Printf("error marshalling notification: %v\n", err)
+			}
+
 			s.Hub.BroadcastToRoom(notification, id)
+
+			// Requests are not part of the DM list
+			if response.IsRequest {
+				continue
+			}
 		}
 		s.Hub.BroadcastToRoom(pushToTop, id)
 	}
 }
 
+// EmitAddDM adds the DM to the DM list of the given users
+func (s *socketService) EmitAddDM(members []string, channelId string) {
+	for _, id := range members {
+		response, err := s.ChannelRepository.GetDirectMessage(channelId, id)
+
+		if err != nil {
+			log.Printf("error getting dm: %v\n", err)
+			continue
+		}
+
+		data, err := json.Marshal(model.WebsocketMessage{
+			Action: ws.AddDMAction,
+			Data:   response,
+		})
+
+		if err != nil {
+			log.Printf("error marshalling response: %v\n", err)
+		}
+
+		s.Hub.BroadcastToRoom(data, id)
+	}
+}
+
+// EmitEditDM emits the changed group DM to all of its members
+func (s *socketService) EmitEditDM(channelId string) {
+	members, err := s.ChannelRepository.GetDMMemberIds(channelId)
+
+	if err != nil {
+		log.Printf("error getting member ids: %v\n", err)
+		return
+	}
+
+	for _, id := range *members {
+		response, err := s.ChannelRepository.GetDirectMessage(channelId, id)
+
+		if err != nil {
+			log.Printf("error getting dm: %v\n", err)
+			continue
+		}
+
+		data, err := json.Marshal(model.WebsocketMessage{
+			Action: ws.EditDMAction,
+			Data:   response,
+		})
+
+		if err != nil {
+			log.Printf("error marshalling response: %v\n", err)
+		}
+
+		s.Hub.BroadcastToRoom(data, id)
+	}
+}
+
+// EmitRemoveDM removes the DM from the DM list of the given user
+func (s *socketService) EmitRemoveDM(userId, channelId string) {
+	data, err := json.Marshal(model.WebsocketMessage{
+		Action: ws.RemoveDMAction,
+		Data:   channelId,
+	})
+
+	if err != nil {
+		log.Printf("error marshalling response: %v\n", err)
+	}
+
+	s.Hub.BroadcastToRoom(data, userId)
+}
+
 func (s *socketService) EmitNewNotification(guildId, channelId string) {
 	data, err := json.Marshal(model.WebsocketMessage{
 		Action: ws.NewNotificationAction,
@@ -324,6 +448,7 @@ func (s *socketService) EmitAddFriend(user, member *model.User) {
 		Username: user.Username,
 		Image:    user.Image,
 		IsOnline: user.IsOnline,
+		Status:   user.GetPresence().Status,
 	}
 
 	data, err := json.Marshal(model.WebsocketMessage{
@@ -342,6 +467,7 @@ func (s *socketService) EmitAddFriend(user, member *model.User) {
 		Username: member.Username,
 		Image:    member.Image,
 		IsOnline: member.IsOnline,
+		Status:   member.GetPresence().Status,
 	}
 
 	data, err = json.Marshal(model.WebsocketMessage{
@@ -379,3 +505,50 @@ func (s *socketService) EmitRemoveFriend(userId, memberId string) {
 
 	s.Hub.BroadcastToRoom(data, memberId)
 }
+
+func (s *socketService) EmitPresenceUpdate(user *model.User) {
+	data, err := json.Marshal(model.WebsocketMessage{
+		Action: ws.PresenceUpdateEmission,
+		Data:   user.GetPresence(),
+	})
+
+	if err != nil {
+		log.Printf("error marshalling response: %v\n", err)
+	}
+
+	ids, err := s.UserRepository.GetFriendAndGuildIds(user.ID)
+
+	if err != nil {
+		log.Printf("error getting friend and guild ids: %v\n", err)
+		return
+	}
+
+	for _, id := range *ids {
+		s.Hub.BroadcastToRoom(data, id)
+	}
+}
+
+// EmitStartTyping emits the typing user to the channel.
+// The user gets removed after a timeout if they do not stop typing.
+func (s *socketService) EmitStartTyping(user *model.TypingUser) {
+	s.Hub.StartTyping(*user)
+}
+
+// EmitStopTyping removes the user from the typing users of the channel
+func (s *socketService) EmitStopTyping(channelId, userId string) {
+	s.Hub.StopTyping(channelId, userId)
+}
+
+// dispatch forwards the guild event to the event webhooks
+func (s *socketService) dispatch(guildId string, event string, data interface{}) {
+	if s.EventService != nil {
+		s.EventService.Dispatch(guildId, event, data)
+	}
+}
+
+// dispatchChannelEvent forwards the channel event to the event webhooks
+func (s *socketService) dispatchChannelEvent(channelId string, event string, data interface{}) {
+	if s.EventService != nil {
+		s.EventService.DispatchChannelEvent(channelId, event, data)
+	}
+}
@@ -0,0 +1,215 @@
+package service
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"log"
+	"strings"
+	"time"
+)
+
+// AccessTokenTouchInterval is the time after which the last use
+// of an access token gets written again
+const AccessTokenTouchInterval = time.Minute
+
+// tokenService acts as a struct for injecting an implementation of UserRepository
+// and TokenRepository for use in service methods
+type tokenService struct {
+	UserRepository  model.UserRepository
+	TokenRepository model.TokenRepository
+}
+
+// TSConfig will hold repositories that will eventually be injected into
+// this service layer
+type TSConfig struct {
+	UserRepository  model.UserRepository
+	TokenRepository model.TokenRepository
+}
+
+// NewTokenService is a factory function for
+// initializing a TokenService with its repository layer dependencies
+func NewTokenService(c *TSConfig) model.TokenService {
+	return &tokenService{
+		UserRepository:  c.UserRepository,
+		TokenRepository: c.TokenRepository,
+	}
+}
+
+// CreateBot creates a bot account owned by the given user.
+// Bots have no password and can only authenticate with access tokens.
+func (t *tokenService) CreateBot(owner *model.User, username string) (*model.User, error) {
+	bots, err := t.UserRepository.FindBots(owner.ID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(*bots) >= model.MaximumBots {
+		return nil, apperrors.NewBadRequest(apperrors.BotLimitReached)
+	}
+
+	id, err := GenerateId()
+
+	if err != nil {
+		return nil, err
+	}
+
+	email := fmt.Sprintf("%s@bot.invalid", id)
+
+	bot := &model.User{
+		Username:      username,
+		Email:         email,
+		EmailVerified: true,
+		IsBot:         true,
+		BotOwnerId:    &owner.ID,
+		Image:         generateAvatar(email),
+	}
+	bot.ID = id
+
+	return t.UserRepository.Create(bot)
+}
+
+func (t *tokenService) GetBots(ownerId string) (*[]model.User, error) {
+	return t.UserRepository.FindBots(ownerId)
+}
+
+// CreateToken creates an access token for the owner or one of their bots.
+// Returns the token together with its secret, which cannot be retrieved later.
+func (t *tokenService) CreateToken(
+	ownerId string,
+	userId string,
+	name string,
+	scopes []string,
+	expiresAt *time.Time,
+) (*model.AccessToken, string, error) {
+	if userId != ownerId {
+		bot, err := t.UserRepository.FindByID(userId)
+
+		if err != nil || !bot.IsBot || bot.BotOwnerId == nil || *bot.BotOwnerId != ownerId {
+			return nil, "", apperrors.NewNotFound("bot", userId)
+		}
+	}
+
+	tokens, err := t.TokenRepository.FindByOwner(ownerId)
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	if len(*tokens) >= model.MaximumTokens {
+		return nil, "", apperrors.NewBadRequest(apperrors.TokenLimitReached)
+	}
+
+	secret, err := generateAccessToken()
+
+	if err != nil {
+		log.Printf("Failed to generate an access token: %v\n", err.Error())
+		return nil, "", apperrors.NewInternal()
+	}
+
+	id, err := GenerateId()
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	token := &model.AccessToken{
+		Name:      name,
+		TokenHash: hashAccessToken(secret),
+		UserId:    userId,
+		OwnerId:   ownerId,
+		Scopes:    scopes,
+		ExpiresAt: expiresAt,
+	}
+	token.ID = id
+
+	if err = t.TokenRepository.Create(token); err != nil {
+		return nil, "", err
+	}
+
+	return token, secret, nil
+}
+
+func (t *tokenService) GetTokens(ownerId string) (*[]model.AccessToken, error) {
+	return t.TokenRepository.FindByOwner(ownerId)
+}
+
+// DeleteToken revokes the given access token of the owner
+func (t *tokenService) DeleteToken(ownerId string, tokenId string) error {
+	token, err := t.TokenRepository.FindByID(tokenId)
+
+	if err != nil {
+		return err
+	}
+
+	if token.OwnerId != ownerId {
+		return apperrors.NewNotFound("token", tokenId)
+	}
+
+	return t.TokenRepository.Delete(token)
+}
+
+// AuthenticateToken returns the access token for the given secret
+// if it did not expire and has the required scope.
+func (t *tokenService) AuthenticateToken(secret string, scope string) (*model.AccessToken, error) {
+	if !strings.HasPrefix(secret, model.AccessTokenPrefix) {
+		return nil, apperrors.NewAuthorization(apperrors.InvalidAccessToken)
+	}
+
+	token, err := t.TokenRepository.FindByHash(hashAccessToken(secret))
+
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	if token.ExpiresAt != nil && !now.Before(*token.ExpiresAt) {
+		return nil, apperrors.NewAuthorization(apperrors.InvalidAccessToken)
+	}
+
+	if !hasScope(token.Scopes, scope) {
+		return nil, apperrors.NewAuthorization(apperrors.MissingTokenScope)
+	}
+
+	// Avoid a write for every request
+	if token.LastUsedAt == nil || now.Sub(*token.LastUsedAt) >= AccessTokenTouchInterval {
+		if err = t.TokenRepository.UpdateLastUsed(token.ID, now); err != nil {
+			log.Printf("Failed to update the last use of token: %v\n%v", token.ID, err)
+		}
+		token.LastUsedAt = &now
+	}
+
+	return token, nil
+}
+
+// generateAccessToken returns a new random access token
+func generateAccessToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return model.AccessTokenPrefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashAccessToken returns the hash the access token gets stored as
+func hashAccessToken(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// hasScope checks if the scopes contain the given scope
+func hasScope(scopes []string, scope string) bool {
+	for _, s := range scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
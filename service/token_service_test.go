@@ -0,0 +1,299 @@
+package service
+
+import (
+	"github.com/sentrionic/valkyrie/mocks"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"github.com/sentrionic/valkyrie/model/fixture"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTokenService_CreateBot(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		owner := fixture.GetMockUser()
+		username := fixture.Username()
+
+		mockUserRepository := new(mocks.UserRepository)
+		ts := NewTokenService(&TSConfig{
+			UserRepository: mockUserRepository,
+		})
+
+		mockUserRepository.On("FindBots", owner.ID).Return(&[]model.User{}, nil)
+		mockUserRepository.
+			On("Create", mock.AnythingOfType("*model.User")).
+			Return(func(u *model.User) *model.User { return u }, nil)
+
+		bot, err := ts.CreateBot(owner, username)
+
+		assert.NoError(t, err)
+		assert.True(t, bot.IsBot)
+		assert.True(t, bot.EmailVerified)
+		assert.Equal(t, owner.ID, *bot.BotOwnerId)
+		assert.Equal(t, username, bot.Username)
+		assert.Equal(t, bot.ID+"@bot.invalid", bot.Email)
+		assert.Empty(t, bot.Password)
+		mockUserRepository.AssertExpectations(t)
+	})
+
+	t.Run("Bot limit reached", func(t *testing.T) {
+		owner := fixture.GetMockUser()
+
+		bots := make([]model.User, model.MaximumBots)
+
+		mockUserRepository := new(mocks.UserRepository)
+		ts := NewTokenService(&TSConfig{
+			UserRepository: mockUserRepository,
+		})
+
+		mockUserRepository.On("FindBots", owner.ID).Return(&bots, nil)
+
+		bot, err := ts.CreateBot(owner, fixture.Username())
+
+		assert.Nil(t, bot)
+		assert.Equal(t, apperrors.NewBadRequest(apperrors.BotLimitReached), err)
+		mockUserRepository.AssertNotCalled(t, "Create", mock.Anything)
+	})
+}
+
+func TestTokenService_CreateToken(t *testing.T) {
+	scopes := []string{model.ReadScope, model.WriteScope}
+
+	t.Run("Token for the owner", func(t *testing.T) {
+		owner := fixture.GetMockUser()
+
+		mockUserRepository := new(mocks.UserRepository)
+		mockTokenRepository := new(mocks.TokenRepository)
+		ts := NewTokenService(&TSConfig{
+			UserRepository:  mockUserRepository,
+			TokenRepository: mockTokenRepository,
+		})
+
+		mockTokenRepository.On("FindByOwner", owner.ID).Return(&[]model.AccessToken{}, nil)
+		mockTokenRepository.On("Create", mock.AnythingOfType("*model.AccessToken")).Return(nil)
+
+		token, secret, err := ts.CreateToken(owner.ID, owner.ID, "CI", scopes, nil)
+
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(secret, model.AccessTokenPrefix))
+		assert.Equal(t, hashAccessToken(secret), token.TokenHash)
+		assert.NotContains(t, token.TokenHash, secret)
+		assert.Equal(t, owner.ID, token.UserId)
+		assert.Equal(t, owner.ID, token.OwnerId)
+		assert.Equal(t, scopes, []string(token.Scopes))
+		mockUserRepository.AssertNotCalled(t, "FindByID", mock.Anything)
+		mockTokenRepository.AssertExpectations(t)
+	})
+
+	t.Run("Token for a bot", func(t *testing.T) {
+		owner := fixture.GetMockUser()
+		bot := fixture.GetMockUser()
+		bot.IsBot = true
+		bot.BotOwnerId = &owner.ID
+
+		mockUserRepository := new(mocks.UserRepository)
+		mockTokenRepository := new(mocks.TokenRepository)
+		ts := NewTokenService(&TSConfig{
+			UserRepository:  mockUserRepository,
+			TokenRepository: mockTokenRepository,
+		})
+
+		mockUserRepository.On("FindByID", bot.ID).Return(bot, nil)
+		mockTokenRepository.On("FindByOwner", owner.ID).Return(&[]model.AccessToken{}, nil)
+		mockTokenRepository.On("Create", mock.AnythingOfType("*model.AccessToken")).Return(nil)
+
+		token, _, err := ts.CreateToken(owner.ID, bot.ID, "Bot", scopes, nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, bot.ID, token.UserId)
+		assert.Equal(t, owner.ID, token.OwnerId)
+		mockUserRepository.AssertExpectations(t)
+		mockTokenRepository.AssertExpectations(t)
+	})
+
+	t.Run("Bot of another user", func(t *testing.T) {
+		owner := fixture.GetMockUser()
+		otherId := fixture.RandID()
+		bot := fixture.GetMockUser()
+		bot.IsBot = true
+		bot.BotOwnerId = &otherId
+
+		mockUserRepository := new(mocks.UserRepository)
+		mockTokenRepository := new(mocks.TokenRepository)
+		ts := NewTokenService(&TSConfig{
+			UserRepository:  mockUserRepository,
+			TokenRepository: mockTokenRepository,
+		})
+
+		mockUserRepository.On("FindByID", bot.ID).Return(bot, nil)
+
+		token, secret, err := ts.CreateToken(owner.ID, bot.ID, "Bot", scopes, nil)
+
+		assert.Nil(t, token)
+		assert.Empty(t, secret)
+		assert.Equal(t, apperrors.NewNotFound("bot", bot.ID), err)
+		mockTokenRepository.AssertNotCalled(t, "Create", mock.Anything)
+	})
+
+	t.Run("Token limit reached", func(t *testing.T) {
+		owner := fixture.GetMockUser()
+		tokens := make([]model.AccessToken, model.MaximumTokens)
+
+		mockTokenRepository := new(mocks.TokenRepository)
+		ts := NewTokenService(&TSConfig{
+			TokenRepository: mockTokenRepository,
+		})
+
+		mockTokenRepository.On("FindByOwner", owner.ID).Return(&tokens, nil)
+
+		token, _, err := ts.CreateToken(owner.ID, owner.ID, "CI", scopes, nil)
+
+		assert.Nil(t, token)
+		assert.Equal(t, apperrors.NewBadRequest(apperrors.TokenLimitReached), err)
+		mockTokenRepository.AssertNotCalled(t, "Create", mock.Anything)
+	})
+}
+
+func TestTokenService_DeleteToken(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		ownerId := fixture.RandID()
+		token := &model.AccessToken{OwnerId: ownerId}
+		token.ID = fixture.RandID()
+
+		mockTokenRepository := new(mocks.TokenRepository)
+		ts := NewTokenService(&TSConfig{
+			TokenRepository: mockTokenRepository,
+		})
+
+		mockTokenRepository.On("FindByID", token.ID).Return(token, nil)
+		mockTokenRepository.On("Delete", token).Return(nil)
+
+		err := ts.DeleteToken(ownerId, token.ID)
+
+		assert.NoError(t, err)
+		mockTokenRepository.AssertExpectations(t)
+	})
+
+	t.Run("Token of another user", func(t *testing.T) {
+		token := &model.AccessToken{OwnerId: fixture.RandID()}
+		token.ID = fixture.RandID()
+
+		mockTokenRepository := new(mocks.TokenRepository)
+		ts := NewTokenService(&TSConfig{
+			TokenRepository: mockTokenRepository,
+		})
+
+		mockTokenRepository.On("FindByID", token.ID).Return(token, nil)
+
+		err := ts.DeleteToken(fixture.RandID(), token.ID)
+
+		assert.Equal(t, apperrors.NewNotFound("token", token.ID), err)
+		mockTokenRepository.AssertNotCalled(t, "Delete", mock.Anything)
+	})
+}
+
+func TestTokenService_AuthenticateToken(t *testing.T) {
+	secret := model.AccessTokenPrefix + "secret"
+	hash := hashAccessToken(secret)
+
+	t.Run("Valid token", func(t *testing.T) {
+		token := &model.AccessToken{
+			UserId:    fixture.RandID(),
+			TokenHash: hash,
+			Scopes:    []string{model.ReadScope},
+		}
+		token.ID = fixture.RandID()
+
+		mockTokenRepository := new(mocks.TokenRepository)
+		ts := NewTokenService(&TSConfig{
+			TokenRepository: mockTokenRepository,
+		})
+
+		mockTokenRepository.On("FindByHash", hash).Return(token, nil)
+		mockTokenRepository.On("UpdateLastUsed", token.ID, mock.AnythingOfType("time.Time")).Return(nil)
+
+		result, err := ts.AuthenticateToken(secret, model.ReadScope)
+
+		assert.NoError(t, err)
+		assert.Equal(t, token.UserId, result.UserId)
+		assert.NotNil(t, result.LastUsedAt)
+		mockTokenRepository.AssertExpectations(t)
+	})
+
+	t.Run("Recently used token", func(t *testing.T) {
+		lastUsed := time.Now().Add(-10 * time.Second)
+		token := &model.AccessToken{
+			TokenHash:  hash,
+			Scopes:     []string{model.ReadScope},
+			LastUsedAt: &lastUsed,
+		}
+
+		mockTokenRepository := new(mocks.TokenRepository)
+		ts := NewTokenService(&TSConfig{
+			TokenRepository: mockTokenRepository,
+		})
+
+		mockTokenRepository.On("FindByHash", hash).Return(token, nil)
+
+		_, err := ts.AuthenticateToken(secret, model.ReadScope)
+
+		assert.NoError(t, err)
+		mockTokenRepository.AssertNotCalled(t, "UpdateLastUsed", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Missing scope", func(t *testing.T) {
+		token := &model.AccessToken{
+			TokenHash: hash,
+			Scopes:    []string{model.ReadScope},
+		}
+
+		mockTokenRepository := new(mocks.TokenRepository)
+		ts := NewTokenService(&TSConfig{
+			TokenRepository: mockTokenRepository,
+		})
+
+		mockTokenRepository.On("FindByHash", hash).Return(token, nil)
+
+		result, err := ts.AuthenticateToken(secret, model.WriteScope)
+
+		assert.Nil(t, result)
+		assert.Equal(t, apperrors.NewAuthorization(apperrors.MissingTokenScope), err)
+	})
+
+	t.Run("Expired token", func(t *testing.T) {
+		expiresAt := time.Now().Add(-time.Minute)
+		token := &model.AccessToken{
+			TokenHash: hash,
+			Scopes:    []string{model.ReadScope},
+			ExpiresAt: &expiresAt,
+		}
+
+		mockTokenRepository := new(mocks.TokenRepository)
+		ts := NewTokenService(&TSConfig{
+			TokenRepository: mockTokenRepository,
+		})
+
+		mockTokenRepository.On("FindByHash", hash).Return(token, nil)
+
+		result, err := ts.AuthenticateToken(secret, model.ReadScope)
+
+		assert.Nil(t, result)
+		assert.Equal(t, apperrors.NewAuthorization(apperrors.InvalidAccessToken), err)
+	})
+
+	t.Run("Invalid prefix", func(t *testing.T) {
+		mockTokenRepository := new(mocks.TokenRepository)
+		ts := NewTokenService(&TSConfig{
+			TokenRepository: mockTokenRepository,
+		})
+
+		result, err := ts.AuthenticateToken("secret", model.ReadScope)
+
+		assert.Nil(t, result)
+		assert.Equal(t, apperrors.NewAuthorization(apperrors.InvalidAccessToken), err)
+		mockTokenRepository.AssertNotCalled(t, "FindByHash", mock.Anything)
+	})
+}
@@ -0,0 +1,159 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// totpIssuer is the name authenticator apps show for the account
+	totpIssuer = "Valkyrie"
+	// totpPeriod is the time a code stays valid
+	totpPeriod = 30
+	// totpDigits is the length of a code
+	totpDigits = 6
+	// totpSkew is the number of periods before and after the current
+	// one that still get accepted to account for clock drift
+	totpSkew = 1
+	// totpWindow is the time a code gets accepted for. Used codes are
+	// remembered for that long, so they cannot be used a second time.
+	totpWindow = (2*totpSkew + 1) * totpPeriod * time.Second
+	// recoveryCodeCount is the number of recovery codes a user gets
+	recoveryCodeCount = 10
+	// recoveryCodeSeparator separates the hashed recovery codes in the DB
+	recoveryCodeSeparator = ","
+)
+
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// generateTOTPSecret returns a random base32 encoded secret as defined in RFC 4226
+func generateTOTPSecret() (string, error) {
+	secret := make([]byte, 20)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+
+	return totpEncoding.EncodeToString(secret), nil
+}
+
+// totpURI returns the otpauth URI authenticator apps use to add the account
+func totpURI(account, secret string) string {
+	label := url.PathEscape(fmt.Sprintf("%s:%s", totpIssuer, account))
+
+	params := url.Values{}
+	params.Set("secret", secret)
+	params.Set("issuer", totpIssuer)
+	params.Set("algorithm", "SHA1")
+	params.Set("digits", fmt.Sprint(totpDigits))
+	params.Set("period", fmt.Sprint(totpPeriod))
+
+	return fmt.Sprintf("otpauth://totp/%s?%s", label, params.Encode())
+}
+
+// totpCode returns the code for the given secret and time step as defined in RFC 6238
+func totpCode(secret string, counter uint64) (string, error) {
+	key, err := totpEncoding.DecodeString(strings.ToUpper(secret))
+	if err != nil {
+		return "", err
+	}
+
+	msg := make([]byte, 8)
+	binary.BigEndian.PutUint64(msg, counter)
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg)
+	sum := mac.Sum(nil)
+
+	// dynamic truncation
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+	for i := 0; i < totpDigits; i++ {
+		mod *= 10
+	}
+
+	return fmt.Sprintf("%0*d", totpDigits, value%mod), nil
+}
+
+// validateTOTP checks if the code is valid for the secret at the given time
+// and returns the time step the code belongs to
+func validateTOTP(secret, code string, now time.Time) (int64, bool) {
+	code = strings.TrimSpace(code)
+	if len(code) != totpDigits {
+		return 0, false
+	}
+
+	counter := now.Unix() / totpPeriod
+	for i := -totpSkew; i <= totpSkew; i++ {
+		step := counter + int64(i)
+		expected, err := totpCode(secret, uint64(step))
+		if err != nil {
+			return 0, false
+		}
+
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+
+	return 0, false
+}
+
+// generateRecoveryCodes returns new recovery codes and their hashes
+// in the format they get stored in
+func generateRecoveryCodes() ([]string, string, error) {
+	codes := make([]string, recoveryCodeCount)
+	hashes := make([]string, recoveryCodeCount)
+
+	for i := range codes {
+		raw := make([]byte, 5)
+		if _, err := rand.Read(raw); err != nil {
+			return nil, "", err
+		}
+
+		code := strings.ToLower(totpEncoding.EncodeToString(raw))
+		codes[i] = fmt.Sprintf("%s-%s", code[:4], code[4:])
+		hashes[i] = hashRecoveryCode(codes[i])
+	}
+
+	return codes, strings.Join(hashes, recoveryCodeSeparator), nil
+}
+
+// useRecoveryCode checks if the code is one of the stored recovery codes.
+// Returns the stored codes without the used one.
+func useRecoveryCode(stored, code string) (string, bool) {
+	if stored == "" {
+		return stored, false
+	}
+
+	hash := hashRecoveryCode(code)
+	hashes := strings.Split(stored, recoveryCodeSeparator)
+
+	for i, h := range hashes {
+		if subtle.ConstantTimeCompare([]byte(h), []byte(hash)) == 1 {
+			remaining := append(hashes[:i:i], hashes[i+1:]...)
+			return strings.Join(remaining, recoveryCodeSeparator), true
+		}
+	}
+
+	return stored, false
+}
+
+// hashRecoveryCode hashes the normalized code. The codes are random,
+// so a fast hash suffices unlike for passwords.
+func hashRecoveryCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
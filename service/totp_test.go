@@ -0,0 +1,65 @@
+package service
+
+import (
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTOTP(t *testing.T) {
+	// Test vector from RFC 6238 truncated to six digits
+	secret := base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+	code, err := totpCode(secret, 59/totpPeriod)
+	assert.NoError(t, err)
+	assert.Equal(t, "287082", code)
+
+	now := time.Unix(59, 0)
+	step, ok := validateTOTP(secret, "287082", now)
+	assert.True(t, ok)
+	assert.Equal(t, int64(1), step)
+
+	// Codes of the previous step still get accepted and keep their step
+	step, ok = validateTOTP(secret, "287082", now.Add(totpPeriod*time.Second))
+	assert.True(t, ok)
+	assert.Equal(t, int64(1), step)
+
+	_, ok = validateTOTP(secret, "287082", now.Add(3*totpPeriod*time.Second))
+	assert.False(t, ok)
+	_, ok = validateTOTP(secret, "123456", now)
+	assert.False(t, ok)
+	_, ok = validateTOTP(secret, "", now)
+	assert.False(t, ok)
+
+	generated, err := generateTOTPSecret()
+	assert.NoError(t, err)
+	assert.Len(t, generated, 32)
+
+	uri := totpURI("test@example.com", generated)
+	assert.True(t, strings.HasPrefix(uri, "otpauth://totp/Valkyrie:test@example.com?"))
+	assert.Contains(t, uri, "secret="+generated)
+}
+
+func TestRecoveryCodes(t *testing.T) {
+	codes, stored, err := generateRecoveryCodes()
+	assert.NoError(t, err)
+	assert.Len(t, codes, recoveryCodeCount)
+	assert.NotContains(t, stored, codes[0])
+
+	remaining, ok := useRecoveryCode(stored, strings.ToUpper(codes[3]))
+	assert.True(t, ok)
+	assert.Len(t, strings.Split(remaining, recoveryCodeSeparator), recoveryCodeCount-1)
+
+	// Codes can only be used once
+	_, ok = useRecoveryCode(remaining, codes[3])
+	assert.False(t, ok)
+
+	_, ok = useRecoveryCode(remaining, "invalid")
+	assert.False(t, ok)
+
+	_, ok = useRecoveryCode("", codes[0])
+	assert.False(t, ok)
+}
@@ -9,13 +9,49 @@ import (
 	"github.com/sentrionic/valkyrie/model/apperrors"
 	"log"
 	"mime/multipart"
+	"net/http"
+	"sort"
 	"strings"
+	"time"
 )
 
+// SessionTTL is the time after which a websocket session counts as disconnected
+// if it did not send a heartbeat
+const SessionTTL = 2 * time.Minute
+
+// Login brute-force protection
+const (
+	// FreeLoginAttempts is the number of failed logins before further attempts get delayed
+	FreeLoginAttempts = 3
+	// AccountLockoutAttempts is the number of failed logins after which an account gets locked
+	AccountLockoutAttempts = 10
+	// IPLockoutAttempts is the number of failed logins after which an ip gets locked
+	IPLockoutAttempts = 50
+	// LoginAttemptWindow is the time failed logins get counted for
+	LoginAttemptWindow = time.Hour
+	// LoginLockoutDuration is the time a lockout lasts
+	LoginLockoutDuration = 15 * time.Minute
+	// TwoFactorTicketAttempts is the number of wrong codes after which
+	// a login ticket gets invalidated
+	TwoFactorTicketAttempts = 5
+)
+
+// LoginSessionTouchInterval is the time after which the last use
+// of a login session gets updated
+const LoginSessionTouchInterval = time.Minute
+
+// AccountDeletionInterval is the time between the runs deleting
+// the accounts whose grace period is over
+const AccountDeletionInterval = time.Hour
+
+// accountDeletionLock is the name of the lock held while deleting accounts
+const accountDeletionLock = "account-deletion"
+
 // UserService acts as a struct for injecting an implementation of UserRepository
 // for use in service methods
 type userService struct {
 	UserRepository  model.UserRepository
+	GuildRepository model.GuildRepository
 	FileRepository  model.FileRepository
 	RedisRepository model.RedisRepository
 	MailRepository  model.MailRepository
@@ -25,6 +61,7 @@ type userService struct {
 // this service layer
 type USConfig struct {
 	UserRepository  model.UserRepository
+	GuildRepository model.GuildRepository
 	FileRepository  model.FileRepository
 	RedisRepository model.RedisRepository
 	MailRepository  model.MailRepository
@@ -35,6 +72,7 @@ type USConfig struct {
 func NewUserService(c *USConfig) model.UserService {
 	return &userService{
 		UserRepository:  c.UserRepository,
+		GuildRepository: c.GuildRepository,
 		FileRepository:  c.FileRepository,
 		RedisRepository: c.RedisRepository,
 		MailRepository:  c.MailRepository,
@@ -81,12 +119,28 @@ func (s *userService) Register(user *model.User) (*model.User, error) {
 // Login reaches out to the UserRepository check if the user exists
 // and then compares the supplied password with the provided password
 // if a valid email/password combo is provided, u will hold all
-// available user fields
-func (s *userService) Login(email, password string) (*model.User, error) {
+// available user fields.
+// Failed attempts slow down further attempts for the account and
+// the ip and eventually lock both out for a while.
+func (s *userService) Login(ctx context.Context, email, password, ip string) (*model.User, error) {
+	accountKey, ipKey := loginKeys(email, ip)
+
+	if err := s.checkLoginLockout(ctx, accountKey, ipKey); err != nil {
+		return nil, err
+	}
+
 	user, err := s.UserRepository.FindByEmail(email)
 
 	// Will return NotAuthorized to client to omit details of why
 	if err != nil {
+		s.recordFailedLogin(ctx, nil, accountKey, ipKey)
+		return nil, apperrors.NewAuthorization(apperrors.InvalidCredentials)
+	}
+
+	// Bots can only authenticate with access tokens and accounts
+	// created by an identity provider have no password
+	if user.IsBot || user.Password == "" {
+		s.recordFailedLogin(ctx, user, accountKey, ipKey)
 		return nil, apperrors.NewAuthorization(apperrors.InvalidCredentials)
 	}
 
@@ -98,12 +152,107 @@ func (s *userService) Login(email, password string) (*model.User, error) {
 	}
 
 	if !match {
+		s.recordFailedLogin(ctx, user, accountKey, ipKey)
 		return nil, apperrors.NewAuthorization(apperrors.InvalidCredentials)
 	}
 
+	// The failures of users with two-factor authentication get reset once they provided their code
+	if !user.TwoFactorEnabled {
+		s.resetFailedLogins(ctx, user, accountKey)
+	}
+
 	return user, nil
 }
 
+// loginKeys returns the keys the failed logins of the account and the ip get counted under
+func loginKeys(email, ip string) (string, string) {
+	return fmt.Sprintf("account:%s", email), fmt.Sprintf("ip:%s", ip)
+}
+
+// checkLoginLockout returns an error containing the remaining wait
+// if any of the given keys is locked out
+func (s *userService) checkLoginLockout(ctx context.Context, keys ...string) error {
+	for _, key := range keys {
+		wait, err := s.RedisRepository.GetLoginLockout(ctx, key)
+
+		if err != nil {
+			return err
+		}
+
+		if wait > 0 {
+			return apperrors.NewTooManyRequests(apperrors.LoginLocked, wait)
+		}
+	}
+
+	return nil
+}
+
+// resetFailedLogins clears the failed logins of the account after a successful login
+func (s *userService) resetFailedLogins(ctx context.Context, user *model.User, accountKey string) {
+	if err := s.RedisRepository.ResetFailedLogins(ctx, accountKey); err != nil {
+		log.Printf("Failed to reset the failed logins of user: %v\n%v", user.ID, err)
+	}
+}
+
+// recordFailedLogin counts the failed login for the account and the ip and
+// locks them out for the resulting backoff. Notifies the owner of the
+// account once it got locked. Errors only get logged, so they do not
+// reveal anything about the account.
+func (s *userService) recordFailedLogin(ctx context.Context, user *model.User, accountKey, ipKey string) {
+	limits := map[string]int64{
+		accountKey: AccountLockoutAttempts,
+		ipKey:      IPLockoutAttempts,
+	}
+
+	for key, lockoutAt := range limits {
+		failures, err := s.RedisRepository.AddFailedLogin(ctx, key, LoginAttemptWindow)
+
+		if err != nil {
+			log.Printf("Failed to count the failed login for %s: %v\n", key, err)
+			continue
+		}
+
+		backoff := loginBackoff(failures, lockoutAt)
+		if backoff == 0 {
+			continue
+		}
+
+		if err = s.RedisRepository.SetLoginLockout(ctx, key, backoff); err != nil {
+			log.Printf("Failed to lock out %s: %v\n", key, err)
+			continue
+		}
+
+		if key == accountKey && user != nil && failures == lockoutAt {
+			if err = s.MailRepository.SendLockoutMail(user.Email); err != nil {
+				log.Printf("Failed to send lockout mail to user: %v\n%v", user.ID, err)
+			}
+		}
+	}
+}
+
+// loginBackoff returns the time further logins have to wait after
+// the given number of failures. Doubles with each failure after the
+// free attempts and turns into a lockout once lockoutAt is reached.
+func loginBackoff(failures, lockoutAt int64) time.Duration {
+	if failures >= lockoutAt {
+		return LoginLockoutDuration
+	}
+
+	if failures <= FreeLoginAttempts {
+		return 0
+	}
+
+	// Stop doubling before the duration could overflow
+	for backoff := time.Second; backoff < LoginLockoutDuration; backoff *= 2 {
+		if failures == FreeLoginAttempts+1 {
+			return backoff
+		}
+		failures--
+	}
+
+	return LoginLockoutDuration
+}
+
 func (s *userService) UpdateAccount(u *model.User) error {
 	return s.UserRepository.Update(u)
 }
@@ -111,8 +260,9 @@ func (s *userService) UpdateAccount(u *model.User) error {
 func (s *userService) IsEmailAlreadyInUse(email string) bool {
 	user, err := s.UserRepository.FindByEmail(email)
 
+	// Only a missing user means that the email is free
 	if err != nil {
-		return true
+		return apperrors.Status(err) != http.StatusNotFound
 	}
 
 	return user.ID != ""
@@ -126,7 +276,9 @@ func (s *userService) DeleteImage(key string) error {
 	return s.FileRepository.DeleteImage(key)
 }
 
-func (s *userService) ChangePassword(currentPassword, newPassword string, user *model.User) error {
+// ChangePassword sets the new password if the current password and the second factor are valid.
+// The code only gets checked after the password, so wrong passwords cannot use up recovery codes.
+func (s *userService) ChangePassword(ctx context.Context, currentPassword, newPassword, code string, user *model.User) error {
 	// verify
 	match, err := comparePasswords(user.Password, currentPassword)
 
@@ -138,6 +290,10 @@ func (s *userService) ChangePassword(currentPassword, newPassword string, user *
 		return apperrors.NewAuthorization(apperrors.InvalidOldPassword)
 	}
 
+	if err = s.VerifyTwoFactor(ctx, user, code); err != nil {
+		return err
+	}
+
 	hashedPassword, err := hashPassword(newPassword)
 
 	if err != nil {
@@ -189,6 +345,614 @@ func (s *userService) ResetPassword(ctx context.Context, password string, token
 	return user, nil
 }
 
+func (s *userService) SendVerificationMail(ctx context.Context, user *model.User) error {
+	if user.EmailVerified {
+		return apperrors.NewBadRequest(apperrors.EmailAlreadyVerified)
+	}
+
+	token, err := s.RedisRepository.SetVerificationToken(ctx, user.ID)
+
+	if err != nil {
+		return err
+	}
+
+	return s.MailRepository.SendVerificationMail(user.Email, token)
+}
+
+func (s *userService) VerifyEmail(ctx context.Context, token string) (*model.User, error) {
+	id, err := s.RedisRepository.GetIdFromVerificationToken(ctx, token)
+
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.UserRepository.FindByID(id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	user.EmailVerified = true
+
+	if err = s.UserRepository.Update(user); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (s *userService) RequestEmailChange(ctx context.Context, user *model.User, email string) error {
+	token, err := s.RedisRepository.SetEmailChangeToken(ctx, &model.EmailChange{
+		UserId: user.ID,
+		Email:  email,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return s.MailRepository.SendEmailChangeMail(email, token)
+}
+
+// ConfirmEmailChange changes the user's email to the confirmed address and
+// sends the previous address a link to revert the change
+func (s *userService) ConfirmEmailChange(ctx context.Context, token string) (*model.User, error) {
+	change, err := s.RedisRepository.GetEmailChange(ctx, token)
+
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.UserRepository.FindByID(change.UserId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Someone else might have taken the email in the meantime
+	if s.IsEmailAlreadyInUse(change.Email) {
+		return nil, apperrors.NewBadRequest(apperrors.DuplicateEmail)
+	}
+
+	previous := user.Email
+	revertToken, err := s.RedisRepository.SetEmailRevertToken(ctx, &model.EmailChange{
+		UserId: user.ID,
+		Email:  previous,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	user.Email = change.Email
+	user.EmailVerified = true
+
+	if err = s.UserRepository.Update(user); err != nil {
+		return nil, err
+	}
+
+	if err = s.MailRepository.SendEmailChangedMail(previous, user.Email, revertToken); err != nil {
+		log.Printf("Failed to send email change notification to user: %v\n%v", user.ID, err)
+	}
+
+	return user, nil
+}
+
+// RevertEmailChange restores the email the user had before the change.
+// Whoever changed the email might have had access to the account, so all
+// sessions get logged out and the user has to reset their password.
+func (s *userService) RevertEmailChange(ctx context.Context, token string) (*model.User, error) {
+	change, err := s.RedisRepository.GetEmailRevert(ctx, token)
+
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.UserRepository.FindByID(change.UserId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Email != change.Email && s.IsEmailAlreadyInUse(change.Email) {
+		return nil, apperrors.NewBadRequest(apperrors.DuplicateEmail)
+	}
+
+	hadPassword := user.Password != ""
+	user.Email = change.Email
+	user.EmailVerified = true
+	user.Password = ""
+
+	if err = s.UserRepository.Update(user); err != nil {
+		return nil, err
+	}
+
+	if err = s.RedisRepository.DeleteOtherLoginSessions(ctx, user.ID, ""); err != nil {
+		return nil, err
+	}
+
+	if err = s.RedisRepository.DeleteEmailChange(ctx, user.ID); err != nil {
+		return nil, err
+	}
+
+	if hadPassword {
+		if err = s.ForgotPassword(ctx, user); err != nil {
+			log.Printf("Failed to send password reset to user: %v\n%v", user.ID, err)
+		}
+	}
+
+	return user, nil
+}
+
+// SetupTwoFactor generates a new secret for the user. Two-factor authentication
+// only gets enabled once the user confirmed the secret with a valid code.
+func (s *userService) SetupTwoFactor(user *model.User) (*model.TwoFactorSetup, error) {
+	if user.TwoFactorEnabled {
+		return nil, apperrors.NewBadRequest(apperrors.TwoFactorAlreadyEnabled)
+	}
+
+	secret, err := generateTOTPSecret()
+
+	if err != nil {
+		log.Printf("Unable to generate two-factor secret for user: %v\n", user.ID)
+		return nil, apperrors.NewInternal()
+	}
+
+	user.TwoFactorSecret = secret
+
+	if err = s.UserRepository.Update(user); err != nil {
+		return nil, err
+	}
+
+	return &model.TwoFactorSetup{
+		Secret: secret,
+		URI:    totpURI(user.Email, secret),
+	}, nil
+}
+
+// EnableTwoFactor enables two-factor authentication if the code matches
+// the secret from the setup and returns the user's recovery codes
+func (s *userService) EnableTwoFactor(ctx context.Context, user *model.User, code string) ([]string, error) {
+	if user.TwoFactorEnabled {
+		return nil, apperrors.NewBadRequest(apperrors.TwoFactorAlreadyEnabled)
+	}
+
+	if user.TwoFactorSecret == "" {
+		return nil, apperrors.NewBadRequest(apperrors.TwoFactorNotSetup)
+	}
+
+	valid, err := s.useTOTP(ctx, user, code)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !valid {
+		return nil, apperrors.NewAuthorization(apperrors.InvalidTwoFactorCode)
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+
+	if err != nil {
+		log.Printf("Unable to generate recovery codes for user: %v\n", user.ID)
+		return nil, apperrors.NewInternal()
+	}
+
+	user.TwoFactorEnabled = true
+	user.RecoveryCodes = hashes
+
+	if err = s.UserRepository.Update(user); err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+// DisableTwoFactor disables two-factor authentication after checking
+// the user's password and second factor
+func (s *userService) DisableTwoFactor(ctx context.Context, user *model.User, password, code string) error {
+	if !user.TwoFactorEnabled {
+		return apperrors.NewBadRequest(apperrors.TwoFactorNotEnabled)
+	}
+
+	if err := s.VerifyPassword(user, password); err != nil {
+		return err
+	}
+
+	if err := s.VerifyTwoFactor(ctx, user, code); err != nil {
+		return err
+	}
+
+	user.TwoFactorEnabled = false
+	user.TwoFactorSecret = ""
+	user.RecoveryCodes = ""
+
+	return s.UserRepository.Update(user)
+}
+
+// ScheduleDeletion schedules the deletion of the account after checking the user's
+// password and second factor. All login sessions get revoked, so the deletion only
+// gets cancelled if the user logs in again during the grace period.
+func (s *userService) ScheduleDeletion(ctx context.Context, user *model.User, password, code string) error {
+	if err := s.VerifyPassword(user, password); err != nil {
+		return err
+	}
+
+	if err := s.VerifyTwoFactor(ctx, user, code); err != nil {
+		return err
+	}
+
+	scheduledAt := time.Now().Add(model.AccountDeletionGracePeriod)
+	user.DeletionScheduledAt = &scheduledAt
+
+	if err := s.UserRepository.Update(user); err != nil {
+		return err
+	}
+
+	return s.RedisRepository.DeleteOtherLoginSessions(ctx, user.ID, "")
+}
+
+// CancelDeletion cancels the scheduled deletion of the account
+func (s *userService) CancelDeletion(user *model.User) error {
+	if user.DeletionScheduledAt == nil {
+		return nil
+	}
+
+	user.DeletionScheduledAt = nil
+
+	return s.UserRepository.Update(user)
+}
+
+// DeleteScheduledAccounts deletes all accounts whose grace period is over.
+// A failed account does not stop the others and gets retried on the next run.
+func (s *userService) DeleteScheduledAccounts(ctx context.Context) error {
+	// Only one instance deletes the accounts at a time
+	lockId, err := s.RedisRepository.AcquireLock(ctx, accountDeletionLock, AccountDeletionInterval)
+
+	if err != nil || lockId == "" {
+		return err
+	}
+	defer s.RedisRepository.ReleaseLock(ctx, accountDeletionLock, lockId)
+
+	users, err := s.UserRepository.FindScheduledForDeletion(time.Now())
+
+	if err != nil {
+		return err
+	}
+
+	for i := range *users {
+		user := &(*users)[i]
+
+		if err = s.deleteAccount(ctx, user); err != nil {
+			log.Printf("Failed to delete the account of user %v: %v\n", user.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// deleteAccount deletes the bots of the user, passes on or deletes their guilds
+// and replaces the user with an anonymous "Deleted User" that keeps their messages
+func (s *userService) deleteAccount(ctx context.Context, user *model.User) error {
+	bots, err := s.UserRepository.FindBots(user.ID)
+
+	if err != nil {
+		return err
+	}
+
+	for i := range *bots {
+		if err = s.deleteAccount(ctx, &(*bots)[i]); err != nil {
+			return err
+		}
+	}
+
+	guilds, err := s.GuildRepository.List(user.ID)
+
+	if err != nil {
+		return err
+	}
+
+	for _, guild := range *guilds {
+		if guild.OwnerId != user.ID {
+			continue
+		}
+
+		if err = s.passOnGuild(guild.Id, user.ID); err != nil {
+			return err
+		}
+	}
+
+	image := user.Image
+	email := fmt.Sprintf("%s@deleted.invalid", user.ID)
+
+	user.Username = model.DeletedUsername
+	user.Email = email
+	user.EmailVerified = false
+	user.Password = ""
+	user.TwoFactorEnabled = false
+	user.TwoFactorSecret = ""
+	user.RecoveryCodes = ""
+	user.BotOwnerId = nil
+	user.Image = generateAvatar(email)
+	user.IsOnline = false
+	user.Status = model.OfflineStatus
+	user.CustomStatus = nil
+	user.CustomStatusEmoji = nil
+	user.CustomStatusExpiresAt = nil
+	user.DMPrivacy = model.DMPrivacyFriends
+	user.DeletionScheduledAt = nil
+
+	if err = s.UserRepository.Anonymize(user); err != nil {
+		return err
+	}
+
+	if err = s.RedisRepository.DeleteOtherLoginSessions(ctx, user.ID, ""); err != nil {
+		log.Printf("Failed to revoke the sessions of deleted user %v: %v\n", user.ID, err)
+	}
+
+	// Generated avatars are not stored in the bucket
+	if !strings.HasPrefix(image, "https://gravatar.com/") {
+		if err = s.FileRepository.DeleteImage(image); err != nil {
+			log.Printf("Failed to delete the avatar of deleted user %v: %v\n", user.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// passOnGuild makes the longest standing member the owner of the guild
+// or deletes the guild if the owner is the only person left
+func (s *userService) passOnGuild(guildId string, ownerId string) error {
+	next, err := s.GuildRepository.FindNextOwner(guildId, ownerId)
+
+	if err != nil {
+		if apperrors.Status(err) == http.StatusNotFound {
+			return s.GuildRepository.Delete(guildId)
+		}
+		return err
+	}
+
+	guild, err := s.GuildRepository.FindByID(guildId)
+
+	if err != nil {
+		return err
+	}
+
+	guild.OwnerId = next.ID
+
+	return s.GuildRepository.Save(guild)
+}
+
+// RegenerateRecoveryCodes replaces the user's recovery codes with new ones
+func (s *userService) RegenerateRecoveryCodes(ctx context.Context, user *model.User, code string) ([]string, error) {
+	if !user.TwoFactorEnabled {
+		return nil, apperrors.NewBadRequest(apperrors.TwoFactorNotEnabled)
+	}
+
+	if err := s.VerifyTwoFactor(ctx, user, code); err != nil {
+		return nil, err
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+
+	if err != nil {
+		log.Printf("Unable to generate recovery codes for user: %v\n", user.ID)
+		return nil, apperrors.NewInternal()
+	}
+
+	user.RecoveryCodes = hashes
+
+	if err = s.UserRepository.Update(user); err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+// VerifyPassword confirms a sensitive action with the user's password.
+// Accounts without a password have to set one first.
+func (s *userService) VerifyPassword(user *model.User, password string) error {
+	if user.Password == "" {
+		return apperrors.NewBadRequest(apperrors.PasswordNotSet)
+	}
+
+	match, err := comparePasswords(user.Password, password)
+
+	if err != nil {
+		return apperrors.NewInternal()
+	}
+
+	if !match {
+		return apperrors.NewAuthorization(apperrors.InvalidPassword)
+	}
+
+	return nil
+}
+
+// VerifyTwoFactor checks the code against the user's authenticator secret
+// and their recovery codes. A used recovery code becomes invalid.
+// Always succeeds if the user did not enable two-factor authentication.
+func (s *userService) VerifyTwoFactor(ctx context.Context, user *model.User, code string) error {
+	if !user.TwoFactorEnabled {
+		return nil
+	}
+
+	if code == "" {
+		return apperrors.NewAuthorization(apperrors.TwoFactorRequired)
+	}
+
+	valid, err := s.useTOTP(ctx, user, code)
+
+	if err != nil {
+		return err
+	}
+
+	if valid {
+		return nil
+	}
+
+	remaining, ok := useRecoveryCode(user.RecoveryCodes, code)
+
+	if !ok {
+		return apperrors.NewAuthorization(apperrors.InvalidTwoFactorCode)
+	}
+
+	user.RecoveryCodes = remaining
+
+	return s.UserRepository.Update(user)
+}
+
+// useTOTP checks the code against the user's authenticator secret.
+// Each code only works once, so a code that got observed cannot be reused.
+func (s *userService) useTOTP(ctx context.Context, user *model.User, code string) (bool, error) {
+	step, valid := validateTOTP(user.TwoFactorSecret, code, time.Now())
+
+	if !valid {
+		return false, nil
+	}
+
+	return s.RedisRepository.UseTwoFactorStep(ctx, user.ID, step, totpWindow)
+}
+
+// CreateTwoFactorTicket returns a short-lived ticket that identifies
+// a login which still awaits the second factor
+func (s *userService) CreateTwoFactorTicket(ctx context.Context, userId string) (string, error) {
+	return s.RedisRepository.SetTwoFactorTicket(ctx, userId)
+}
+
+// VerifyTwoFactorLogin completes the login for the given ticket
+// if the code is valid
+func (s *userService) VerifyTwoFactorLogin(ctx context.Context, ticket string, code string, ip string) (*model.User, error) {
+	id, err := s.RedisRepository.GetIdFromTwoFactorTicket(ctx, ticket)
+
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.UserRepository.FindByID(id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	accountKey, ipKey := loginKeys(user.Email, ip)
+
+	if err = s.checkLoginLockout(ctx, accountKey, ipKey); err != nil {
+		return nil, err
+	}
+
+	if err = s.VerifyTwoFactor(ctx, user, code); err != nil {
+		// Wrong codes count towards the lockout, so logging in again
+		// for a new ticket does not allow guessing further codes
+		s.recordFailedLogin(ctx, user, accountKey, ipKey)
+
+		attempts, rErr := s.RedisRepository.AddFailedTwoFactorAttempt(ctx, ticket)
+
+		if rErr != nil {
+			return nil, rErr
+		}
+
+		// The user has to log in with their password again
+		if attempts >= TwoFactorTicketAttempts {
+			if rErr = s.RedisRepository.DeleteTwoFactorTicket(ctx, ticket); rErr != nil {
+				return nil, rErr
+			}
+			return nil, apperrors.NewAuthorization(apperrors.InvalidTwoFactorTicket)
+		}
+
+		return nil, err
+	}
+
+	if err = s.RedisRepository.DeleteTwoFactorTicket(ctx, ticket); err != nil {
+		return nil, err
+	}
+
+	s.resetFailedLogins(ctx, user, accountKey)
+
+	return user, nil
+}
+
+// CreateLoginSession stores the metadata of a new login session
+// of the user and returns its id
+func (s *userService) CreateLoginSession(ctx context.Context, userId string, userAgent string, ip string) (string, error) {
+	id, err := GenerateId()
+
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	err = s.RedisRepository.SaveLoginSession(ctx, userId, &model.LoginSession{
+		ID:         id,
+		UserAgent:  userAgent,
+		IP:         ip,
+		CreatedAt:  now,
+		LastUsedAt: now,
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+// ValidateLoginSession checks that the login session did not get revoked
+// and updates when it got used last
+func (s *userService) ValidateLoginSession(ctx context.Context, userId string, sessionId string) error {
+	session, err := s.RedisRepository.GetLoginSession(ctx, userId, sessionId)
+
+	if err != nil {
+		if apperrors.Status(err) == http.StatusNotFound {
+			return apperrors.NewAuthorization(apperrors.InvalidSession)
+		}
+		return err
+	}
+
+	// Only write once in a while instead of on every request
+	if time.Since(session.LastUsedAt) < LoginSessionTouchInterval {
+		return nil
+	}
+
+	session.LastUsedAt = time.Now()
+
+	return s.RedisRepository.SaveLoginSession(ctx, userId, session)
+}
+
+// GetLoginSessions returns the login sessions of the user with
+// the most recently used one first and marks the current one
+func (s *userService) GetLoginSessions(ctx context.Context, userId string, currentId string) (*[]model.LoginSession, error) {
+	sessions, err := s.RedisRepository.GetLoginSessions(ctx, userId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range *sessions {
+		(*sessions)[i].Current = (*sessions)[i].ID == currentId
+	}
+
+	sort.Slice(*sessions, func(i, j int) bool {
+		return (*sessions)[i].LastUsedAt.After((*sessions)[j].LastUsedAt)
+	})
+
+	return sessions, nil
+}
+
+// RevokeLoginSession logs the given session of the user out
+func (s *userService) RevokeLoginSession(ctx context.Context, userId string, sessionId string) error {
+	if _, err := s.RedisRepository.GetLoginSession(ctx, userId, sessionId); err != nil {
+		return err
+	}
+
+	return s.RedisRepository.DeleteLoginSession(ctx, userId, sessionId)
+}
+
+// RevokeOtherLoginSessions logs all sessions of the user except the current one out
+func (s *userService) RevokeOtherLoginSessions(ctx context.Context, userId string, currentId string) error {
+	return s.RedisRepository.DeleteOtherLoginSessions(ctx, userId, currentId)
+}
+
 func (s *userService) GetFriendAndGuildIds(userId string) (*[]string, error) {
 	return s.UserRepository.GetFriendAndGuildIds(userId)
 }
@@ -197,6 +961,105 @@ func (s *userService) GetRequestCount(userId string) (*int64, error) {
 	return s.UserRepository.GetRequestCount(userId)
 }
 
+// CreateWSTicket returns a short-lived ticket the user can
+// authenticate the websocket connection with
+func (s *userService) CreateWSTicket(ctx context.Context, userId string) (string, error) {
+	return s.RedisRepository.SetWSTicket(ctx, userId)
+}
+
+// RedeemWSTicket returns the id of the user the ticket belongs to.
+// The ticket is invalid afterwards.
+func (s *userService) RedeemWSTicket(ctx context.Context, ticket string) (string, error) {
+	return s.RedisRepository.GetIdFromWSTicket(ctx, ticket)
+}
+
+// UpdatePresence saves the chosen presence of the user. The user only
+// appears online if they have a live session and are not invisible.
+func (s *userService) UpdatePresence(ctx context.Context, user *model.User) error {
+	isConnected, err := s.RedisRepository.HasLiveSession(ctx, user.ID)
+
+	if err != nil {
+		return err
+	}
+
+	user.IsOnline = isConnected && user.Status != model.InvisibleStatus
+
+	return s.UserRepository.Update(user)
+}
+
+// ConnectSession stores or refreshes the given session of the user.
+// Returns the user if it is their only live session, so their presence
+// can be emitted, or nil if their presence did not change.
+func (s *userService) ConnectSession(ctx context.Context, userId string, sessionId string) (*model.User, error) {
+	isFirst, err := s.RedisRepository.AddSession(ctx, userId, sessionId, SessionTTL)
+
+	if err != nil || !isFirst {
+		return nil, err
+	}
+
+	return s.setOnline(userId, true)
+}
+
+// DisconnectSession removes the given session of the user.
+// Returns the user if it was their last live session, so their presence
+// can be emitted, or nil if their presence did not change.
+func (s *userService) DisconnectSession(ctx context.Context, userId string, sessionId string) (*model.User, error) {
+	isLast, err := s.RedisRepository.RemoveSession(ctx, userId, sessionId)
+
+	if err != nil || !isLast {
+		return nil, err
+	}
+
+	return s.setOnline(userId, false)
+}
+
+// ExpireSessions marks all users whose last session expired without
+// disconnecting, e.g. because the server crashed, as offline
+func (s *userService) ExpireSessions(ctx context.Context) ([]*model.User, error) {
+	ids, err := s.RedisRepository.ExpireSessions(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	users := make([]*model.User, 0)
+	for _, id := range ids {
+		user, err := s.setOnline(id, false)
+
+		if err != nil {
+			log.Printf("Unable to set user %s offline: %v\n", id, err)
+			continue
+		}
+
+		users = append(users, user)
+	}
+
+	return users, nil
+}
+
+// setOnline stores if the user appears online. Only writes
+// to the DB if the value changed.
+func (s *userService) setOnline(userId string, isConnected bool) (*model.User, error) {
+	user, err := s.UserRepository.FindByID(userId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	isOnline := isConnected && user.Status != model.InvisibleStatus
+	if user.IsOnline == isOnline {
+		return user, nil
+	}
+
+	user.IsOnline = isOnline
+
+	if err = s.UserRepository.Update(user); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
 // generateAvatar returns a gravatar using the md5 hash of the email
 func generateAvatar(email string) string {
 	hash := md5.Sum([]byte(email))
@@ -9,7 +9,9 @@ import (
 	"github.com/sentrionic/valkyrie/model/fixture"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"net/http"
 	"testing"
+	"time"
 )
 
 func TestGet(t *testing.T) {
@@ -155,24 +157,54 @@ func TestLogin(t *testing.T) {
 	validPW := "howdyhoneighbor!"
 	hashedValidPW, _ := hashPassword(validPW)
 	invalidPW := "howdyhodufus!"
-
-	mockUserRepository := new(mocks.UserRepository)
-	us := NewUserService(&USConfig{
-		UserRepository: mockUserRepository,
-	})
+	ip := "127.0.0.1"
+	ipKey := fmt.Sprintf("ip:%s", ip)
 
 	t.Run("Success", func(t *testing.T) {
 		mockUser := fixture.GetMockUser()
 		mockUser.Password = hashedValidPW
+		accountKey := fmt.Sprintf("account:%s", mockUser.Email)
+
+		mockUserRepository := new(mocks.UserRepository)
+		mockRedisRepository := new(mocks.RedisRepository)
+		us := NewUserService(&USConfig{
+			UserRepository:  mockUserRepository,
+			RedisRepository: mockRedisRepository,
+		})
 
+		mockRedisRepository.On("GetLoginLockout", mock.Anything, mock.Anything).Return(time.Duration(0), nil)
+		mockRedisRepository.On("ResetFailedLogins", mock.Anything, accountKey).Return(nil)
 		mockUserRepository.
 			On("FindByEmail", mockUser.Email).Return(mockUser, nil)
 
-		user, err := us.Login(mockUser.Email, validPW)
+		user, err := us.Login(context.TODO(), mockUser.Email, validPW, ip)
 
 		assert.NoError(t, err)
 		assert.Equal(t, user, mockUser)
 		mockUserRepository.AssertCalled(t, "FindByEmail", mockUser.Email)
+		mockRedisRepository.AssertExpectations(t)
+	})
+
+	t.Run("Two-factor users keep their failed logins", func(t *testing.T) {
+		mockUser := fixture.GetMockUser()
+		mockUser.Password = hashedValidPW
+		mockUser.TwoFactorEnabled = true
+
+		mockUserRepository := new(mocks.UserRepository)
+		mockRedisRepository := new(mocks.RedisRepository)
+		us := NewUserService(&USConfig{
+			UserRepository:  mockUserRepository,
+			RedisRepository: mockRedisRepository,
+		})
+
+		mockRedisRepository.On("GetLoginLockout", mock.Anything, mock.Anything).Return(time.Duration(0), nil)
+		mockUserRepository.On("FindByEmail", mockUser.Email).Return(mockUser, nil)
+
+		user, err := us.Login(context.TODO(), mockUser.Email, validPW, ip)
+
+		assert.NoError(t, err)
+		assert.Equal(t, user, mockUser)
+		mockRedisRepository.AssertNotCalled(t, "ResetFailedLogins", mock.Anything, mock.Anything)
 	})
 
 	t.Run("Invalid email/password combination", func(t *testing.T) {
@@ -181,25 +213,173 @@ func TestLogin(t *testing.T) {
 		mockUserResp := fixture.GetMockUser()
 		mockUserResp.ID = uid
 		mockUserResp.Password = hashedValidPW
+		accountKey := fmt.Sprintf("account:%s", mockUserResp.Email)
 
 		mockArgs := mock.Arguments{
 			mockUserResp.Email,
 		}
 
+		mockUserRepository := new(mocks.UserRepository)
+		mockRedisRepository := new(mocks.RedisRepository)
+		us := NewUserService(&USConfig{
+			UserRepository:  mockUserRepository,
+			RedisRepository: mockRedisRepository,
+		})
+
+		mockRedisRepository.On("GetLoginLockout", mock.Anything, mock.Anything).Return(time.Duration(0), nil)
+		mockRedisRepository.On("AddFailedLogin", mock.Anything, accountKey, LoginAttemptWindow).Return(int64(1), nil)
+		mockRedisRepository.On("AddFailedLogin", mock.Anything, ipKey, LoginAttemptWindow).Return(int64(1), nil)
+
 		// We can use Run method to modify the user when the Create method is called.
 		//  We can then chain on a Return method to return no error
 		mockUserRepository.
 			On("FindByEmail", mockArgs...).Return(mockUserResp, nil)
 
-		user, err := us.Login(mockUserResp.Email, invalidPW)
+		user, err := us.Login(context.TODO(), mockUserResp.Email, invalidPW, ip)
 
 		assert.Error(t, err)
 		assert.EqualError(t, err, apperrors.InvalidCredentials)
 		assert.Nil(t, user)
 		mockUserRepository.AssertCalled(t, "FindByEmail", mockArgs...)
+		mockRedisRepository.AssertExpectations(t)
+		mockRedisRepository.AssertNotCalled(t, "SetLoginLockout", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Unknown email counts as failure", func(t *testing.T) {
+		email := fixture.Email()
+		accountKey := fmt.Sprintf("account:%s", email)
+
+		mockUserRepository := new(mocks.UserRepository)
+		mockRedisRepository := new(mocks.RedisRepository)
+		us := NewUserService(&USConfig{
+			UserRepository:  mockUserRepository,
+			RedisRepository: mockRedisRepository,
+		})
+
+		mockRedisRepository.On("GetLoginLockout", mock.Anything, mock.Anything).Return(time.Duration(0), nil)
+		mockRedisRepository.On("AddFailedLogin", mock.Anything, accountKey, LoginAttemptWindow).Return(int64(1), nil)
+		mockRedisRepository.On("AddFailedLogin", mock.Anything, ipKey, LoginAttemptWindow).Return(int64(1), nil)
+		mockUserRepository.On("FindByEmail", email).Return(nil, apperrors.NewNotFound("email", email))
+
+		user, err := us.Login(context.TODO(), email, invalidPW, ip)
+
+		assert.EqualError(t, err, apperrors.InvalidCredentials)
+		assert.Nil(t, user)
+		mockRedisRepository.AssertExpectations(t)
+	})
+
+	t.Run("Bots cannot log in", func(t *testing.T) {
+		mockUser := fixture.GetMockUser()
+		mockUser.IsBot = true
+		mockUser.Password = ""
+		accountKey := fmt.Sprintf("account:%s", mockUser.Email)
+
+		mockUserRepository := new(mocks.UserRepository)
+		mockRedisRepository := new(mocks.RedisRepository)
+		us := NewUserService(&USConfig{
+			UserRepository:  mockUserRepository,
+			RedisRepository: mockRedisRepository,
+		})
+
+		mockRedisRepository.On("GetLoginLockout", mock.Anything, mock.Anything).Return(time.Duration(0), nil)
+		mockRedisRepository.On("AddFailedLogin", mock.Anything, accountKey, LoginAttemptWindow).Return(int64(1), nil)
+		mockRedisRepository.On("AddFailedLogin", mock.Anything, ipKey, LoginAttemptWindow).Return(int64(1), nil)
+		mockUserRepository.On("FindByEmail", mockUser.Email).Return(mockUser, nil)
+
+		user, err := us.Login(context.TODO(), mockUser.Email, "", ip)
+
+		assert.EqualError(t, err, apperrors.InvalidCredentials)
+		assert.Nil(t, user)
+		mockRedisRepository.AssertExpectations(t)
+	})
+
+	t.Run("Accounts without a password cannot log in", func(t *testing.T) {
+		mockUser := fixture.GetMockUser()
+		mockUser.Password = ""
+		accountKey := fmt.Sprintf("account:%s", mockUser.Email)
+
+		mockUserRepository := new(mocks.UserRepository)
+		mockRedisRepository := new(mocks.RedisRepository)
+		us := NewUserService(&USConfig{
+			UserRepository:  mockUserRepository,
+			RedisRepository: mockRedisRepository,
+		})
+
+		mockRedisRepository.On("GetLoginLockout", mock.Anything, mock.Anything).Return(time.Duration(0), nil)
+		mockRedisRepository.On("AddFailedLogin", mock.Anything, accountKey, LoginAttemptWindow).Return(int64(1), nil)
+		mockRedisRepository.On("AddFailedLogin", mock.Anything, ipKey, LoginAttemptWindow).Return(int64(1), nil)
+		mockUserRepository.On("FindByEmail", mockUser.Email).Return(mockUser, nil)
+
+		user, err := us.Login(context.TODO(), mockUser.Email, "", ip)
+
+		assert.EqualError(t, err, apperrors.InvalidCredentials)
+		assert.Nil(t, user)
+		mockRedisRepository.AssertExpectations(t)
+	})
+
+	t.Run("Locks the account and sends a mail", func(t *testing.T) {
+		mockUser := fixture.GetMockUser()
+		mockUser.Password = hashedValidPW
+		accountKey := fmt.Sprintf("account:%s", mockUser.Email)
+
+		mockUserRepository := new(mocks.UserRepository)
+		mockRedisRepository := new(mocks.RedisRepository)
+		mockMailRepository := new(mocks.MailRepository)
+		us := NewUserService(&USConfig{
+			UserRepository:  mockUserRepository,
+			RedisRepository: mockRedisRepository,
+			MailRepository:  mockMailRepository,
+		})
+
+		mockRedisRepository.On("GetLoginLockout", mock.Anything, mock.Anything).Return(time.Duration(0), nil)
+		mockRedisRepository.
+			On("AddFailedLogin", mock.Anything, accountKey, LoginAttemptWindow).
+			Return(int64(AccountLockoutAttempts), nil)
+		mockRedisRepository.On("AddFailedLogin", mock.Anything, ipKey, LoginAttemptWindow).Return(int64(1), nil)
+		mockRedisRepository.On("SetLoginLockout", mock.Anything, accountKey, LoginLockoutDuration).Return(nil)
+		mockUserRepository.On("FindByEmail", mockUser.Email).Return(mockUser, nil)
+		mockMailRepository.On("SendLockoutMail", mockUser.Email).Return(nil)
+
+		user, err := us.Login(context.TODO(), mockUser.Email, invalidPW, ip)
+
+		assert.EqualError(t, err, apperrors.InvalidCredentials)
+		assert.Nil(t, user)
+		mockRedisRepository.AssertExpectations(t)
+		mockMailRepository.AssertExpectations(t)
+	})
+
+	t.Run("Locked out", func(t *testing.T) {
+		mockUser := fixture.GetMockUser()
+		mockUser.Password = hashedValidPW
+		accountKey := fmt.Sprintf("account:%s", mockUser.Email)
+
+		mockUserRepository := new(mocks.UserRepository)
+		mockRedisRepository := new(mocks.RedisRepository)
+		us := NewUserService(&USConfig{
+			UserRepository:  mockUserRepository,
+			RedisRepository: mockRedisRepository,
+		})
+
+		wait := 90 * time.Second
+		mockRedisRepository.On("GetLoginLockout", mock.Anything, accountKey).Return(wait, nil)
+
+		user, err := us.Login(context.TODO(), mockUser.Email, validPW, ip)
+
+		assert.Nil(t, user)
+		assert.Equal(t, apperrors.NewTooManyRequests(apperrors.LoginLocked, wait), err)
+		assert.Equal(t, http.StatusTooManyRequests, apperrors.Status(err))
+		mockUserRepository.AssertNotCalled(t, "FindByEmail", mock.Anything)
 	})
 }
 
+func TestLoginBackoff(t *testing.T) {
+	assert.Equal(t, time.Duration(0), loginBackoff(FreeLoginAttempts, AccountLockoutAttempts))
+	assert.Equal(t, time.Second, loginBackoff(FreeLoginAttempts+1, AccountLockoutAttempts))
+	assert.Equal(t, 4*time.Second, loginBackoff(FreeLoginAttempts+3, AccountLockoutAttempts))
+	assert.Equal(t, LoginLockoutDuration, loginBackoff(AccountLockoutAttempts, AccountLockoutAttempts))
+	assert.Equal(t, LoginLockoutDuration, loginBackoff(IPLockoutAttempts-1, IPLockoutAttempts))
+}
+
 func TestUpdateDetails(t *testing.T) {
 	mockUserRepository := new(mocks.UserRepository)
 	us := NewUserService(&USConfig{
@@ -294,10 +474,11 @@ func TestUserService_ChangeAvatar(t *testing.T) {
 				CreatedAt: mockUser.CreatedAt,
 				UpdatedAt: mockUser.UpdatedAt,
 			},
-			Email:    mockUser.Email,
-			Username: mockUser.Username,
-			Image:    imageURL,
-			Password: mockUser.Password,
+			Email:         mockUser.Email,
+			EmailVerified: mockUser.EmailVerified,
+			Username:      mockUser.Username,
+			Image:         imageURL,
+			Password:      mockUser.Password,
 		}
 
 		mockUserRepository.
@@ -476,7 +657,7 @@ func TestUserService_ChangePassword(t *testing.T) {
 
 		mockUserRepository.On("Update", mockUser).Return(nil)
 
-		err = us.ChangePassword(currentPassword, newPassword, mockUser)
+		err = us.ChangePassword(context.TODO(), currentPassword, newPassword, "", mockUser)
 		assert.NoError(t, err)
 
 		assert.NotEqual(t, mockUser.Password, newPassword)
@@ -493,7 +674,7 @@ func TestUserService_ChangePassword(t *testing.T) {
 		mockUserRepository := new(mocks.UserRepository)
 		us := NewUserService(&USConfig{UserRepository: mockUserRepository})
 
-		err := us.ChangePassword(currentPassword, newPassword, mockUser)
+		err := us.ChangePassword(context.TODO(), currentPassword, newPassword, "", mockUser)
 		assert.Error(t, err)
 
 		assert.Equal(t, err, apperrors.NewInternal())
@@ -513,7 +694,7 @@ func TestUserService_ChangePassword(t *testing.T) {
 		mockUserRepository := new(mocks.UserRepository)
 		us := NewUserService(&USConfig{UserRepository: mockUserRepository})
 
-		err = us.ChangePassword(currentPassword, newPassword, mockUser)
+		err = us.ChangePassword(context.TODO(), currentPassword, newPassword, "", mockUser)
 		assert.Error(t, err)
 
 		assert.Equal(t, err, apperrors.NewAuthorization(apperrors.InvalidOldPassword))
@@ -536,11 +717,51 @@ func TestUserService_ChangePassword(t *testing.T) {
 		mockError := apperrors.NewInternal()
 		mockUserRepository.On("Update", mockUser).Return(mockError)
 
-		err = us.ChangePassword(currentPassword, newPassword, mockUser)
+		err = us.ChangePassword(context.TODO(), currentPassword, newPassword, "", mockUser)
 		assert.Error(t, err)
 
 		mockUserRepository.AssertExpectations(t)
 	})
+
+	t.Run("Wrong password does not check the code", func(t *testing.T) {
+		mockUser := fixture.GetMockUser()
+		hashedPassword, err := hashPassword(mockUser.Password)
+		assert.NoError(t, err)
+		mockUser.Password = hashedPassword
+		codes, hashes, err := generateRecoveryCodes()
+		assert.NoError(t, err)
+		mockUser.TwoFactorEnabled = true
+		mockUser.TwoFactorSecret, _ = generateTOTPSecret()
+		mockUser.RecoveryCodes = hashes
+
+		mockUserRepository := new(mocks.UserRepository)
+		us := NewUserService(&USConfig{UserRepository: mockUserRepository})
+
+		err = us.ChangePassword(context.TODO(), fixture.RandStringRunes(10), fixture.RandStringRunes(10), codes[0], mockUser)
+
+		assert.Equal(t, apperrors.NewAuthorization(apperrors.InvalidOldPassword), err)
+		assert.Equal(t, hashes, mockUser.RecoveryCodes)
+		mockUserRepository.AssertNotCalled(t, "Update", mock.Anything)
+	})
+
+	t.Run("Invalid two-factor code", func(t *testing.T) {
+		mockUser := fixture.GetMockUser()
+		currentPassword := mockUser.Password
+		hashedPassword, err := hashPassword(currentPassword)
+		assert.NoError(t, err)
+		mockUser.Password = hashedPassword
+		mockUser.TwoFactorEnabled = true
+		mockUser.TwoFactorSecret, _ = generateTOTPSecret()
+
+		mockUserRepository := new(mocks.UserRepository)
+		us := NewUserService(&USConfig{UserRepository: mockUserRepository})
+
+		err = us.ChangePassword(context.TODO(), currentPassword, fixture.RandStringRunes(10), "invalid", mockUser)
+
+		assert.Equal(t, apperrors.NewAuthorization(apperrors.InvalidTwoFactorCode), err)
+		assert.Equal(t, hashedPassword, mockUser.Password)
+		mockUserRepository.AssertNotCalled(t, "Update", mock.Anything)
+	})
 }
 
 func TestUserService_ForgotPassword(t *testing.T) {
@@ -678,3 +899,1292 @@ func TestUserService_ResetPassword(t *testing.T) {
 		mockRedisRepository.AssertExpectations(t)
 	})
 }
+
+func TestUserService_SendVerificationMail(t *testing.T) {
+	token := fixture.RandStringRunes(10)
+
+	t.Run("Success", func(t *testing.T) {
+		mockUser := fixture.GetMockUser()
+		mockUser.EmailVerified = false
+		mockRedisRepository := new(mocks.RedisRepository)
+		mockMailRepository := new(mocks.MailRepository)
+
+		us := NewUserService(&USConfig{
+			RedisRepository: mockRedisRepository,
+			MailRepository:  mockMailRepository,
+		})
+
+		mockRedisRepository.On("SetVerificationToken", mock.Anything, mockUser.ID).Return(token, nil)
+		mockMailRepository.On("SendVerificationMail", mockUser.Email, token).Return(nil)
+
+		err := us.SendVerificationMail(context.TODO(), mockUser)
+		assert.NoError(t, err)
+
+		mockRedisRepository.AssertExpectations(t)
+		mockMailRepository.AssertExpectations(t)
+	})
+
+	t.Run("Already verified", func(t *testing.T) {
+		mockUser := fixture.GetMockUser()
+		mockRedisRepository := new(mocks.RedisRepository)
+		mockMailRepository := new(mocks.MailRepository)
+
+		us := NewUserService(&USConfig{
+			RedisRepository: mockRedisRepository,
+			MailRepository:  mockMailRepository,
+		})
+
+		err := us.SendVerificationMail(context.TODO(), mockUser)
+		assert.EqualError(t, err, apperrors.NewBadRequest(apperrors.EmailAlreadyVerified).Error())
+
+		mockRedisRepository.AssertNotCalled(t, "SetVerificationToken", mock.Anything, mock.Anything)
+		mockMailRepository.AssertNotCalled(t, "SendVerificationMail", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		mockUser := fixture.GetMockUser()
+		mockUser.EmailVerified = false
+		mockRedisRepository := new(mocks.RedisRepository)
+		mockMailRepository := new(mocks.MailRepository)
+
+		us := NewUserService(&USConfig{
+			RedisRepository: mockRedisRepository,
+			MailRepository:  mockMailRepository,
+		})
+
+		mockError := apperrors.NewInternal()
+		mockRedisRepository.On("SetVerificationToken", mock.Anything, mockUser.ID).Return("", mockError)
+
+		err := us.SendVerificationMail(context.TODO(), mockUser)
+		assert.Error(t, err)
+
+		mockRedisRepository.AssertExpectations(t)
+		mockMailRepository.AssertNotCalled(t, "SendVerificationMail", mock.Anything, mock.Anything)
+	})
+}
+
+func TestUserService_VerifyEmail(t *testing.T) {
+	token := fixture.RandStr(10)
+
+	t.Run("Success", func(t *testing.T) {
+		mockUser := fixture.GetMockUser()
+		mockUser.EmailVerified = false
+		mockUserRepository := new(mocks.UserRepository)
+		mockRedisRepository := new(mocks.RedisRepository)
+
+		us := NewUserService(&USConfig{
+			UserRepository:  mockUserRepository,
+			RedisRepository: mockRedisRepository,
+		})
+
+		mockRedisRepository.On("GetIdFromVerificationToken", mock.Anything, token).Return(mockUser.ID, nil)
+		mockUserRepository.On("FindByID", mockUser.ID).Return(mockUser, nil)
+		mockUserRepository.On("Update", mockUser).Return(nil)
+
+		user, err := us.VerifyEmail(context.TODO(), token)
+		assert.NoError(t, err)
+		assert.True(t, user.EmailVerified)
+
+		mockUserRepository.AssertExpectations(t)
+		mockRedisRepository.AssertExpectations(t)
+	})
+
+	t.Run("Invalid token", func(t *testing.T) {
+		mockUserRepository := new(mocks.UserRepository)
+		mockRedisRepository := new(mocks.RedisRepository)
+
+		us := NewUserService(&USConfig{
+			UserRepository:  mockUserRepository,
+			RedisRepository: mockRedisRepository,
+		})
+
+		mockError := apperrors.NewBadRequest(apperrors.InvalidVerificationToken)
+		mockRedisRepository.On("GetIdFromVerificationToken", mock.Anything, token).Return("", mockError)
+
+		user, err := us.VerifyEmail(context.TODO(), token)
+		assert.EqualError(t, err, mockError.Error())
+		assert.Nil(t, user)
+
+		mockUserRepository.AssertNotCalled(t, "FindByID", mock.Anything)
+		mockUserRepository.AssertNotCalled(t, "Update", mock.Anything)
+	})
+}
+
+func TestUserService_ConfirmEmailChange(t *testing.T) {
+	token := fixture.RandStr(10)
+
+	t.Run("Success", func(t *testing.T) {
+		mockUser := fixture.GetMockUser()
+		oldEmail := mockUser.Email
+		change := &model.EmailChange{UserId: mockUser.ID, Email: fixture.Email()}
+		revertToken := fixture.RandStr(10)
+
+		mockUserRepository := new(mocks.UserRepository)
+		mockRedisRepository := new(mocks.RedisRepository)
+		mockMailRepository := new(mocks.MailRepository)
+
+		us := NewUserService(&USConfig{
+			UserRepository:  mockUserRepository,
+			RedisRepository: mockRedisRepository,
+			MailRepository:  mockMailRepository,
+		})
+
+		mockRedisRepository.On("GetEmailChange", mock.Anything, token).Return(change, nil)
+		mockUserRepository.On("FindByID", mockUser.ID).Return(mockUser, nil)
+		mockUserRepository.On("FindByEmail", change.Email).Return(nil, apperrors.NewNotFound("email", change.Email))
+		mockRedisRepository.
+			On("SetEmailRevertToken", mock.Anything, &model.EmailChange{UserId: mockUser.ID, Email: oldEmail}).
+			Return(revertToken, nil)
+		mockUserRepository.On("Update", mockUser).Return(nil)
+		mockMailRepository.On("SendEmailChangedMail", oldEmail, change.Email, revertToken).Return(nil)
+
+		user, err := us.ConfirmEmailChange(context.TODO(), token)
+		assert.NoError(t, err)
+		assert.Equal(t, change.Email, user.Email)
+		assert.True(t, user.EmailVerified)
+
+		mockUserRepository.AssertExpectations(t)
+		mockRedisRepository.AssertExpectations(t)
+		mockMailRepository.AssertExpectations(t)
+	})
+
+	t.Run("Email got taken", func(t *testing.T) {
+		mockUser := fixture.GetMockUser()
+		oldEmail := mockUser.Email
+		taken := fixture.GetMockUser()
+		change := &model.EmailChange{UserId: mockUser.ID, Email: taken.Email}
+
+		mockUserRepository := new(mocks.UserRepository)
+		mockRedisRepository := new(mocks.RedisRepository)
+
+		us := NewUserService(&USConfig{
+			UserRepository:  mockUserRepository,
+			RedisRepository: mockRedisRepository,
+		})
+
+		mockRedisRepository.On("GetEmailChange", mock.Anything, token).Return(change, nil)
+		mockUserRepository.On("FindByID", mockUser.ID).Return(mockUser, nil)
+		mockUserRepository.On("FindByEmail", change.Email).Return(taken, nil)
+
+		user, err := us.ConfirmEmailChange(context.TODO(), token)
+		assert.EqualError(t, err, apperrors.NewBadRequest(apperrors.DuplicateEmail).Error())
+		assert.Nil(t, user)
+		assert.Equal(t, oldEmail, mockUser.Email)
+
+		mockUserRepository.AssertNotCalled(t, "Update", mock.Anything)
+		mockRedisRepository.AssertNotCalled(t, "SetEmailRevertToken", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Invalid token", func(t *testing.T) {
+		mockUserRepository := new(mocks.UserRepository)
+		mockRedisRepository := new(mocks.RedisRepository)
+
+		us := NewUserService(&USConfig{
+			UserRepository:  mockUserRepository,
+			RedisRepository: mockRedisRepository,
+		})
+
+		mockError := apperrors.NewBadRequest(apperrors.InvalidEmailChangeToken)
+		mockRedisRepository.On("GetEmailChange", mock.Anything, token).Return(nil, mockError)
+
+		user, err := us.ConfirmEmailChange(context.TODO(), token)
+		assert.EqualError(t, err, mockError.Error())
+		assert.Nil(t, user)
+
+		mockUserRepository.AssertNotCalled(t, "FindByID", mock.Anything)
+		mockUserRepository.AssertNotCalled(t, "Update", mock.Anything)
+	})
+}
+
+func TestUserService_RevertEmailChange(t *testing.T) {
+	token := fixture.RandStr(10)
+
+	t.Run("Success", func(t *testing.T) {
+		mockUser := fixture.GetMockUser()
+		change := &model.EmailChange{UserId: mockUser.ID, Email: fixture.Email()}
+
+		resetToken := fixture.RandStr(10)
+
+		mockUserRepository := new(mocks.UserRepository)
+		mockRedisRepository := new(mocks.RedisRepository)
+		mockMailRepository := new(mocks.MailRepository)
+
+		us := NewUserService(&USConfig{
+			UserRepository:  mockUserRepository,
+			RedisRepository: mockRedisRepository,
+			MailRepository:  mockMailRepository,
+		})
+
+		mockRedisRepository.On("GetEmailRevert", mock.Anything, token).Return(change, nil)
+		mockUserRepository.On("FindByID", mockUser.ID).Return(mockUser, nil)
+		mockUserRepository.On("FindByEmail", change.Email).Return(nil, apperrors.NewNotFound("email", change.Email))
+		mockUserRepository.On("Update", mockUser).Return(nil)
+		mockRedisRepository.On("DeleteOtherLoginSessions", mock.Anything, mockUser.ID, "").Return(nil)
+		mockRedisRepository.On("DeleteEmailChange", mock.Anything, mockUser.ID).Return(nil)
+		mockRedisRepository.On("SetResetToken", mock.Anything, mockUser.ID).Return(resetToken, nil)
+		mockMailRepository.On("SendResetMail", change.Email, resetToken).Return(nil)
+
+		user, err := us.RevertEmailChange(context.TODO(), token)
+		assert.NoError(t, err)
+		assert.Equal(t, change.Email, user.Email)
+		assert.Empty(t, user.Password)
+
+		mockRedisRepository.AssertCalled(t, "DeleteOtherLoginSessions", mock.Anything, mockUser.ID, "")
+		mockUserRepository.AssertExpectations(t)
+		mockRedisRepository.AssertExpectations(t)
+		mockMailRepository.AssertExpectations(t)
+	})
+
+	t.Run("Sessions cannot be revoked", func(t *testing.T) {
+		mockUser := fixture.GetMockUser()
+		mockUser.Password = ""
+		change := &model.EmailChange{UserId: mockUser.ID, Email: fixture.Email()}
+
+		mockUserRepository := new(mocks.UserRepository)
+		mockRedisRepository := new(mocks.RedisRepository)
+
+		us := NewUserService(&USConfig{
+			UserRepository:  mockUserRepository,
+			RedisRepository: mockRedisRepository,
+		})
+
+		mockError := apperrors.NewInternal()
+		mockRedisRepository.On("GetEmailRevert", mock.Anything, token).Return(change, nil)
+		mockUserRepository.On("FindByID", mockUser.ID).Return(mockUser, nil)
+		mockUserRepository.On("FindByEmail", change.Email).Return(nil, apperrors.NewNotFound("email", change.Email))
+		mockUserRepository.On("Update", mockUser).Return(nil)
+		mockRedisRepository.On("DeleteOtherLoginSessions", mock.Anything, mockUser.ID, "").Return(mockError)
+
+		user, err := us.RevertEmailChange(context.TODO(), token)
+		assert.EqualError(t, err, mockError.Error())
+		assert.Nil(t, user)
+
+		mockRedisRepository.AssertCalled(t, "DeleteOtherLoginSessions", mock.Anything, mockUser.ID, "")
+		mockRedisRepository.AssertNotCalled(t, "SetResetToken", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Invalid token", func(t *testing.T) {
+		mockUserRepository := new(mocks.UserRepository)
+		mockRedisRepository := new(mocks.RedisRepository)
+
+		us := NewUserService(&USConfig{
+			UserRepository:  mockUserRepository,
+			RedisRepository: mockRedisRepository,
+		})
+
+		mockError := apperrors.NewBadRequest(apperrors.InvalidEmailChangeToken)
+		mockRedisRepository.On("GetEmailRevert", mock.Anything, token).Return(nil, mockError)
+
+		user, err := us.RevertEmailChange(context.TODO(), token)
+		assert.EqualError(t, err, mockError.Error())
+		assert.Nil(t, user)
+
+		mockUserRepository.AssertNotCalled(t, "Update", mock.Anything)
+	})
+}
+
+func TestUserService_SetupTwoFactor(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockUser := fixture.GetMockUser()
+		mockUserRepository := new(mocks.UserRepository)
+
+		us := NewUserService(&USConfig{
+			UserRepository: mockUserRepository,
+		})
+
+		mockUserRepository.On("Update", mockUser).Return(nil)
+
+		setup, err := us.SetupTwoFactor(mockUser)
+		assert.NoError(t, err)
+		assert.Equal(t, mockUser.TwoFactorSecret, setup.Secret)
+		assert.Equal(t, totpURI(mockUser.Email, setup.Secret), setup.URI)
+		assert.False(t, mockUser.TwoFactorEnabled)
+
+		mockUserRepository.AssertExpectations(t)
+	})
+
+	t.Run("Already enabled", func(t *testing.T) {
+		mockUser := fixture.GetMockUser()
+		mockUser.TwoFactorEnabled = true
+		mockUserRepository := new(mocks.UserRepository)
+
+		us := NewUserService(&USConfig{
+			UserRepository: mockUserRepository,
+		})
+
+		setup, err := us.SetupTwoFactor(mockUser)
+		assert.EqualError(t, err, apperrors.NewBadRequest(apperrors.TwoFactorAlreadyEnabled).Error())
+		assert.Nil(t, setup)
+
+		mockUserRepository.AssertNotCalled(t, "Update", mock.Anything)
+	})
+}
+
+func TestUserService_EnableTwoFactor(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockUser := fixture.GetMockUser()
+		secret, err := generateTOTPSecret()
+		assert.NoError(t, err)
+		mockUser.TwoFactorSecret = secret
+
+		mockUserRepository := new(mocks.UserRepository)
+		mockRedisRepository := new(mocks.RedisRepository)
+
+		us := NewUserService(&USConfig{
+			UserRepository:  mockUserRepository,
+			RedisRepository: mockRedisRepository,
+		})
+
+		step := time.Now().Unix() / totpPeriod
+		mockUserRepository.On("Update", mockUser).Return(nil)
+		mockRedisRepository.On("UseTwoFactorStep", mock.Anything, mockUser.ID, step, totpWindow).Return(true, nil)
+
+		code, err := totpCode(secret, uint64(step))
+		assert.NoError(t, err)
+
+		codes, err := us.EnableTwoFactor(context.TODO(), mockUser, code)
+		assert.NoError(t, err)
+		assert.Len(t, codes, recoveryCodeCount)
+		assert.True(t, mockUser.TwoFactorEnabled)
+		assert.NotEmpty(t, mockUser.RecoveryCodes)
+
+		mockUserRepository.AssertExpectations(t)
+		mockRedisRepository.AssertExpectations(t)
+	})
+
+	t.Run("Invalid code", func(t *testing.T) {
+		mockUser := fixture.GetMockUser()
+		secret, err := generateTOTPSecret()
+		assert.NoError(t, err)
+		mockUser.TwoFactorSecret = secret
+
+		mockUserRepository := new(mocks.UserRepository)
+
+		us := NewUserService(&USConfig{
+			UserRepository: mockUserRepository,
+		})
+
+		codes, err := us.EnableTwoFactor(context.TODO(), mockUser, "abcdef")
+		assert.EqualError(t, err, apperrors.NewAuthorization(apperrors.InvalidTwoFactorCode).Error())
+		assert.Nil(t, codes)
+		assert.False(t, mockUser.TwoFactorEnabled)
+
+		mockUserRepository.AssertNotCalled(t, "Update", mock.Anything)
+	})
+
+	t.Run("Not set up", func(t *testing.T) {
+		mockUser := fixture.GetMockUser()
+		mockUserRepository := new(mocks.UserRepository)
+
+		us := NewUserService(&USConfig{
+			UserRepository: mockUserRepository,
+		})
+
+		codes, err := us.EnableTwoFactor(context.TODO(), mockUser, "123456")
+		assert.EqualError(t, err, apperrors.NewBadRequest(apperrors.TwoFactorNotSetup).Error())
+		assert.Nil(t, codes)
+
+		mockUserRepository.AssertNotCalled(t, "Update", mock.Anything)
+	})
+}
+
+func TestUserService_VerifyTwoFactor(t *testing.T) {
+	getTwoFactorUser := func() (*model.User, []string) {
+		mockUser := fixture.GetMockUser()
+		secret, _ := generateTOTPSecret()
+		codes, hashes, _ := generateRecoveryCodes()
+		mockUser.TwoFactorEnabled = true
+		mockUser.TwoFactorSecret = secret
+		mockUser.RecoveryCodes = hashes
+		return mockUser, codes
+	}
+
+	t.Run("Valid authenticator code", func(t *testing.T) {
+		mockUser, _ := getTwoFactorUser()
+		mockUserRepository := new(mocks.UserRepository)
+		mockRedisRepository := new(mocks.RedisRepository)
+
+		us := NewUserService(&USConfig{
+			UserRepository:  mockUserRepository,
+			RedisRepository: mockRedisRepository,
+		})
+
+		step := time.Now().Unix() / totpPeriod
+		mockRedisRepository.On("UseTwoFactorStep", mock.Anything, mockUser.ID, step, totpWindow).Return(true, nil)
+
+		code, err := totpCode(mockUser.TwoFactorSecret, uint64(step))
+		assert.NoError(t, err)
+
+		err = us.VerifyTwoFactor(context.TODO(), mockUser, code)
+		assert.NoError(t, err)
+
+		mockRedisRepository.AssertExpectations(t)
+		mockUserRepository.AssertNotCalled(t, "Update", mock.Anything)
+	})
+
+	t.Run("Authenticator code cannot be reused", func(t *testing.T) {
+		mockUser, _ := getTwoFactorUser()
+		mockUserRepository := new(mocks.UserRepository)
+		mockRedisRepository := new(mocks.RedisRepository)
+
+		us := NewUserService(&USConfig{
+			UserRepository:  mockUserRepository,
+			RedisRepository: mockRedisRepository,
+		})
+
+		step := time.Now().Unix() / totpPeriod
+		mockRedisRepository.On("UseTwoFactorStep", mock.Anything, mockUser.ID, step, totpWindow).Return(true, nil).Once()
+		mockRedisRepository.On("UseTwoFactorStep", mock.Anything, mockUser.ID, step, totpWindow).Return(false, nil)
+
+		code, err := totpCode(mockUser.TwoFactorSecret, uint64(step))
+		assert.NoError(t, err)
+
+		err = us.VerifyTwoFactor(context.TODO(), mockUser, code)
+		assert.NoError(t, err)
+
+		err = us.VerifyTwoFactor(context.TODO(), mockUser, code)
+		assert.EqualError(t, err, apperrors.NewAuthorization(apperrors.InvalidTwoFactorCode).Error())
+
+		mockRedisRepository.AssertNumberOfCalls(t, "UseTwoFactorStep", 2)
+		mockUserRepository.AssertNotCalled(t, "Update", mock.Anything)
+	})
+
+	t.Run("Recovery code gets used up", func(t *testing.T) {
+		mockUser, codes := getTwoFactorUser()
+		mockUserRepository := new(mocks.UserRepository)
+
+		us := NewUserService(&USConfig{
+			UserRepository: mockUserRepository,
+		})
+
+		mockUserRepository.On("Update", mockUser).Return(nil)
+
+		err := us.VerifyTwoFactor(context.TODO(), mockUser, codes[0])
+		assert.NoError(t, err)
+
+		err = us.VerifyTwoFactor(context.TODO(), mockUser, codes[0])
+		assert.EqualError(t, err, apperrors.NewAuthorization(apperrors.InvalidTwoFactorCode).Error())
+
+		mockUserRepository.AssertNumberOfCalls(t, "Update", 1)
+	})
+
+	t.Run("Missing code", func(t *testing.T) {
+		mockUser, _ := getTwoFactorUser()
+
+		us := NewUserService(&USConfig{})
+
+		err := us.VerifyTwoFactor(context.TODO(), mockUser, "")
+		assert.EqualError(t, err, apperrors.NewAuthorization(apperrors.TwoFactorRequired).Error())
+	})
+
+	t.Run("Not enabled", func(t *testing.T) {
+		mockUser := fixture.GetMockUser()
+
+		us := NewUserService(&USConfig{})
+
+		err := us.VerifyTwoFactor(context.TODO(), mockUser, "")
+		assert.NoError(t, err)
+	})
+}
+
+func TestUserService_VerifyTwoFactorLogin(t *testing.T) {
+	ticket := fixture.RandStr(32)
+	ip := "127.0.0.1"
+	ipKey := fmt.Sprintf("ip:%s", ip)
+
+	t.Run("Success", func(t *testing.T) {
+		mockUser := fixture.GetMockUser()
+		secret, err := generateTOTPSecret()
+		assert.NoError(t, err)
+		mockUser.TwoFactorEnabled = true
+		mockUser.TwoFactorSecret = secret
+
+		mockUserRepository := new(mocks.UserRepository)
+		mockRedisRepository := new(mocks.RedisRepository)
+
+		us := NewUserService(&USConfig{
+			UserRepository:  mockUserRepository,
+			RedisRepository: mockRedisRepository,
+		})
+
+		mockRedisRepository.On("GetIdFromTwoFactorTicket", mock.Anything, ticket).Return(mockUser.ID, nil)
+		mockRedisRepository.On("DeleteTwoFactorTicket", mock.Anything, ticket).Return(nil)
+		mockRedisRepository.On("GetLoginLockout", mock.Anything, mock.Anything).Return(time.Duration(0), nil)
+		mockRedisRepository.On("ResetFailedLogins", mock.Anything, fmt.Sprintf("account:%s", mockUser.Email)).Return(nil)
+		mockRedisRepository.On("UseTwoFactorStep", mock.Anything, mockUser.ID, mock.Anything, totpWindow).Return(true, nil)
+		mockUserRepository.On("FindByID", mockUser.ID).Return(mockUser, nil)
+
+		code, err := totpCode(secret, uint64(time.Now().Unix()/totpPeriod))
+		assert.NoError(t, err)
+
+		user, err := us.VerifyTwoFactorLogin(context.TODO(), ticket, code, ip)
+		assert.NoError(t, err)
+		assert.Equal(t, mockUser, user)
+
+		mockUserRepository.AssertExpectations(t)
+		mockRedisRepository.AssertExpectations(t)
+	})
+
+	t.Run("Invalid code keeps the ticket", func(t *testing.T) {
+		mockUser := fixture.GetMockUser()
+		secret, err := generateTOTPSecret()
+		assert.NoError(t, err)
+		mockUser.TwoFactorEnabled = true
+		mockUser.TwoFactorSecret = secret
+
+		mockUserRepository := new(mocks.UserRepository)
+		mockRedisRepository := new(mocks.RedisRepository)
+
+		us := NewUserService(&USConfig{
+			UserRepository:  mockUserRepository,
+			RedisRepository: mockRedisRepository,
+		})
+
+		mockRedisRepository.On("GetIdFromTwoFactorTicket", mock.Anything, ticket).Return(mockUser.ID, nil)
+		mockRedisRepository.On("AddFailedTwoFactorAttempt", mock.Anything, ticket).Return(int64(1), nil)
+		mockRedisRepository.On("GetLoginLockout", mock.Anything, mock.Anything).Return(time.Duration(0), nil)
+		mockRedisRepository.On("AddFailedLogin", mock.Anything, fmt.Sprintf("account:%s", mockUser.Email), LoginAttemptWindow).Return(int64(1), nil)
+		mockRedisRepository.On("AddFailedLogin", mock.Anything, ipKey, LoginAttemptWindow).Return(int64(1), nil)
+		mockUserRepository.On("FindByID", mockUser.ID).Return(mockUser, nil)
+
+		user, err := us.VerifyTwoFactorLogin(context.TODO(), ticket, "invalid", ip)
+		assert.EqualError(t, err, apperrors.NewAuthorization(apperrors.InvalidTwoFactorCode).Error())
+		assert.Nil(t, user)
+
+		mockRedisRepository.AssertExpectations(t)
+		mockRedisRepository.AssertNotCalled(t, "DeleteTwoFactorTicket", mock.Anything, mock.Anything)
+		mockRedisRepository.AssertNotCalled(t, "ResetFailedLogins", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Too many invalid codes delete the ticket", func(t *testing.T) {
+		mockUser := fixture.GetMockUser()
+		secret, err := generateTOTPSecret()
+		assert.NoError(t, err)
+		mockUser.TwoFactorEnabled = true
+		mockUser.TwoFactorSecret = secret
+
+		mockUserRepository := new(mocks.UserRepository)
+		mockRedisRepository := new(mocks.RedisRepository)
+
+		us := NewUserService(&USConfig{
+			UserRepository:  mockUserRepository,
+			RedisRepository: mockRedisRepository,
+		})
+
+		mockRedisRepository.On("GetIdFromTwoFactorTicket", mock.Anything, ticket).Return(mockUser.ID, nil)
+		mockRedisRepository.On("AddFailedTwoFactorAttempt", mock.Anything, ticket).Return(int64(TwoFactorTicketAttempts), nil)
+		mockRedisRepository.On("GetLoginLockout", mock.Anything, mock.Anything).Return(time.Duration(0), nil)
+		mockRedisRepository.On("AddFailedLogin", mock.Anything, fmt.Sprintf("account:%s", mockUser.Email), LoginAttemptWindow).Return(int64(1), nil)
+		mockRedisRepository.On("AddFailedLogin", mock.Anything, ipKey, LoginAttemptWindow).Return(int64(1), nil)
+		mockRedisRepository.On("DeleteTwoFactorTicket", mock.Anything, ticket).Return(nil)
+		mockUserRepository.On("FindByID", mockUser.ID).Return(mockUser, nil)
+
+		user, err := us.VerifyTwoFactorLogin(context.TODO(), ticket, "invalid", ip)
+		assert.EqualError(t, err, apperrors.NewAuthorization(apperrors.InvalidTwoFactorTicket).Error())
+		assert.Nil(t, user)
+
+		mockRedisRepository.AssertExpectations(t)
+	})
+
+	t.Run("New tickets do not reset the lockout", func(t *testing.T) {
+		mockUser := fixture.GetMockUser()
+		password := "password123"
+		mockUser.Password, _ = hashPassword(password)
+		secret, err := generateTOTPSecret()
+		assert.NoError(t, err)
+		mockUser.TwoFactorEnabled = true
+		mockUser.TwoFactorSecret = secret
+		accountKey := fmt.Sprintf("account:%s", mockUser.Email)
+
+		mockUserRepository := new(mocks.UserRepository)
+		mockRedisRepository := new(mocks.RedisRepository)
+		mockMailRepository := new(mocks.MailRepository)
+
+		us := NewUserService(&USConfig{
+			UserRepository:  mockUserRepository,
+			RedisRepository: mockRedisRepository,
+			MailRepository:  mockMailRepository,
+		})
+
+		// Emulates the counters and the lockout stored in redis
+		var failures int64
+		var lockedOut bool
+		mockRedisRepository.On("GetLoginLockout", mock.Anything, accountKey).Return(
+			func(ctx context.Context, key string) time.Duration {
+				if lockedOut {
+					return LoginLockoutDuration
+				}
+				return 0
+			},
+			nil,
+		)
+		mockRedisRepository.On("GetLoginLockout", mock.Anything, ipKey).Return(time.Duration(0), nil)
+		mockRedisRepository.On("AddFailedLogin", mock.Anything, accountKey, LoginAttemptWindow).Return(
+			func(ctx context.Context, key string, window time.Duration) int64 {
+				failures++
+				return failures
+			},
+			nil,
+		)
+		mockRedisRepository.On("AddFailedLogin", mock.Anything, ipKey, LoginAttemptWindow).Return(int64(1), nil)
+		mockRedisRepository.On("SetLoginLockout", mock.Anything, accountKey, mock.AnythingOfType("time.Duration")).
+			Return(nil).
+			Run(func(args mock.Arguments) {
+				lockedOut = args.Get(2).(time.Duration) == LoginLockoutDuration
+			})
+		mockRedisRepository.On("GetIdFromTwoFactorTicket", mock.Anything, mock.Anything).Return(mockUser.ID, nil)
+		mockRedisRepository.On("AddFailedTwoFactorAttempt", mock.Anything, mock.Anything).Return(int64(1), nil)
+		mockUserRepository.On("FindByEmail", mockUser.Email).Return(mockUser, nil)
+		mockUserRepository.On("FindByID", mockUser.ID).Return(mockUser, nil)
+		mockMailRepository.On("SendLockoutMail", mockUser.Email).Return(nil)
+
+		// Each login with the password hands out a new ticket
+		for i := 0; i < AccountLockoutAttempts; i++ {
+			user, err := us.Login(context.TODO(), mockUser.Email, password, ip)
+			if lockedOut {
+				assert.Error(t, err)
+				break
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, mockUser, user)
+
+			_, err = us.VerifyTwoFactorLogin(context.TODO(), fixture.RandStr(32), "invalid", ip)
+			assert.Error(t, err)
+		}
+
+		assert.True(t, lockedOut)
+
+		_, err = us.Login(context.TODO(), mockUser.Email, password, ip)
+		assert.Equal(t, apperrors.NewTooManyRequests(apperrors.LoginLocked, LoginLockoutDuration), err)
+
+		_, err = us.VerifyTwoFactorLogin(context.TODO(), ticket, "invalid", ip)
+		assert.Equal(t, apperrors.NewTooManyRequests(apperrors.LoginLocked, LoginLockoutDuration), err)
+
+		mockRedisRepository.AssertNotCalled(t, "ResetFailedLogins", mock.Anything, mock.Anything)
+		mockMailRepository.AssertExpectations(t)
+	})
+
+	t.Run("Invalid ticket", func(t *testing.T) {
+		mockUserRepository := new(mocks.UserRepository)
+		mockRedisRepository := new(mocks.RedisRepository)
+
+		us := NewUserService(&USConfig{
+			UserRepository:  mockUserRepository,
+			RedisRepository: mockRedisRepository,
+		})
+
+		mockError := apperrors.NewAuthorization(apperrors.InvalidTwoFactorTicket)
+		mockRedisRepository.On("GetIdFromTwoFactorTicket", mock.Anything, ticket).Return("", mockError)
+
+		user, err := us.VerifyTwoFactorLogin(context.TODO(), ticket, "123456", ip)
+		assert.EqualError(t, err, mockError.Error())
+		assert.Nil(t, user)
+
+		mockUserRepository.AssertNotCalled(t, "FindByID", mock.Anything)
+	})
+}
+
+func TestUserService_DisableTwoFactor(t *testing.T) {
+	password := "password123"
+	hashedPassword, _ := hashPassword(password)
+
+	t.Run("Success", func(t *testing.T) {
+		mockUser := fixture.GetMockUser()
+		mockUser.Password = hashedPassword
+		codes, hashes, err := generateRecoveryCodes()
+		assert.NoError(t, err)
+		mockUser.TwoFactorEnabled = true
+		mockUser.TwoFactorSecret, _ = generateTOTPSecret()
+		mockUser.RecoveryCodes = hashes
+
+		mockUserRepository := new(mocks.UserRepository)
+
+		us := NewUserService(&USConfig{
+			UserRepository: mockUserRepository,
+		})
+
+		mockUserRepository.On("Update", mockUser).Return(nil)
+
+		err = us.DisableTwoFactor(context.TODO(), mockUser, password, codes[0])
+		assert.NoError(t, err)
+		assert.False(t, mockUser.TwoFactorEnabled)
+		assert.Empty(t, mockUser.TwoFactorSecret)
+		assert.Empty(t, mockUser.RecoveryCodes)
+	})
+
+	t.Run("Wrong password", func(t *testing.T) {
+		mockUser := fixture.GetMockUser()
+		mockUser.Password = hashedPassword
+		mockUser.TwoFactorEnabled = true
+
+		mockUserRepository := new(mocks.UserRepository)
+
+		us := NewUserService(&USConfig{
+			UserRepository: mockUserRepository,
+		})
+
+		err := us.DisableTwoFactor(context.TODO(), mockUser, "wrongpassword", "123456")
+		assert.EqualError(t, err, apperrors.NewAuthorization(apperrors.InvalidPassword).Error())
+		assert.True(t, mockUser.TwoFactorEnabled)
+
+		mockUserRepository.AssertNotCalled(t, "Update", mock.Anything)
+	})
+
+	t.Run("Password not set", func(t *testing.T) {
+		mockUser := fixture.GetMockUser()
+		mockUser.Password = ""
+		mockUser.TwoFactorEnabled = true
+
+		mockUserRepository := new(mocks.UserRepository)
+
+		us := NewUserService(&USConfig{
+			UserRepository: mockUserRepository,
+		})
+
+		err := us.DisableTwoFactor(context.TODO(), mockUser, password, "123456")
+		assert.EqualError(t, err, apperrors.NewBadRequest(apperrors.PasswordNotSet).Error())
+		assert.True(t, mockUser.TwoFactorEnabled)
+
+		mockUserRepository.AssertNotCalled(t, "Update", mock.Anything)
+	})
+}
+
+func TestUserService_ScheduleDeletion(t *testing.T) {
+	password := "password123"
+	hashedPassword, _ := hashPassword(password)
+
+	t.Run("Success", func(t *testing.T) {
+		mockUser := fixture.GetMockUser()
+		mockUser.Password = hashedPassword
+
+		mockUserRepository := new(mocks.UserRepository)
+		mockRedisRepository := new(mocks.RedisRepository)
+		us := NewUserService(&USConfig{
+			UserRepository:  mockUserRepository,
+			RedisRepository: mockRedisRepository,
+		})
+
+		mockUserRepository.On("Update", mockUser).Return(nil)
+		mockRedisRepository.On("DeleteOtherLoginSessions", mock.Anything, mockUser.ID, "").Return(nil)
+
+		err := us.ScheduleDeletion(context.TODO(), mockUser, password, "")
+
+		assert.NoError(t, err)
+		assert.NotNil(t, mockUser.DeletionScheduledAt)
+		assert.WithinDuration(t, time.Now().Add(model.AccountDeletionGracePeriod), *mockUser.DeletionScheduledAt, time.Minute)
+		mockUserRepository.AssertExpectations(t)
+		mockRedisRepository.AssertExpectations(t)
+	})
+
+	t.Run("Wrong password", func(t *testing.T) {
+		mockUser := fixture.GetMockUser()
+		mockUser.Password = hashedPassword
+
+		mockUserRepository := new(mocks.UserRepository)
+		us := NewUserService(&USConfig{
+			UserRepository: mockUserRepository,
+		})
+
+		err := us.ScheduleDeletion(context.TODO(), mockUser, "wrongpassword", "")
+
+		assert.Equal(t, apperrors.NewAuthorization(apperrors.InvalidPassword), err)
+		assert.Nil(t, mockUser.DeletionScheduledAt)
+		mockUserRepository.AssertNotCalled(t, "Update", mock.Anything)
+	})
+
+	t.Run("Account without a password", func(t *testing.T) {
+		mockUser := fixture.GetMockUser()
+		mockUser.Password = ""
+
+		mockUserRepository := new(mocks.UserRepository)
+		us := NewUserService(&USConfig{
+			UserRepository: mockUserRepository,
+		})
+
+		err := us.ScheduleDeletion(context.TODO(), mockUser, password, "")
+
+		assert.Equal(t, apperrors.NewBadRequest(apperrors.PasswordNotSet), err)
+		mockUserRepository.AssertNotCalled(t, "Update", mock.Anything)
+	})
+
+	t.Run("Second factor required", func(t *testing.T) {
+		mockUser := fixture.GetMockUser()
+		mockUser.Password = hashedPassword
+		mockUser.TwoFactorEnabled = true
+		mockUser.TwoFactorSecret, _ = generateTOTPSecret()
+
+		mockUserRepository := new(mocks.UserRepository)
+		us := NewUserService(&USConfig{
+			UserRepository: mockUserRepository,
+		})
+
+		err := us.ScheduleDeletion(context.TODO(), mockUser, password, "")
+
+		assert.Equal(t, apperrors.NewAuthorization(apperrors.TwoFactorRequired), err)
+		mockUserRepository.AssertNotCalled(t, "Update", mock.Anything)
+	})
+}
+
+func TestUserService_CancelDeletion(t *testing.T) {
+	mockUser := fixture.GetMockUser()
+	scheduledAt := time.Now().Add(time.Hour)
+	mockUser.DeletionScheduledAt = &scheduledAt
+
+	mockUserRepository := new(mocks.UserRepository)
+	us := NewUserService(&USConfig{
+		UserRepository: mockUserRepository,
+	})
+
+	mockUserRepository.On("Update", mockUser).Return(nil)
+
+	err := us.CancelDeletion(mockUser)
+
+	assert.NoError(t, err)
+	assert.Nil(t, mockUser.DeletionScheduledAt)
+	mockUserRepository.AssertExpectations(t)
+}
+
+func TestUserService_DeleteScheduledAccounts(t *testing.T) {
+	t.Run("Anonymizes the user and passes on their guilds", func(t *testing.T) {
+		mockUser := fixture.GetMockUser()
+		mockUser.Image = "https://bucket.s3.amazonaws.com/files/users/avatar.jpeg"
+		scheduledAt := time.Now().Add(-time.Hour)
+		mockUser.DeletionScheduledAt = &scheduledAt
+		userId := mockUser.ID
+
+		kept := fixture.GetMockGuild(userId)
+		deleted := fixture.GetMockGuild(userId)
+		joined := fixture.GetMockGuild(fixture.RandID())
+		successor := fixture.GetMockUser()
+
+		mockUserRepository := new(mocks.UserRepository)
+		mockGuildRepository := new(mocks.GuildRepository)
+		mockRedisRepository := new(mocks.RedisRepository)
+		mockFileRepository := new(mocks.FileRepository)
+		us := NewUserService(&USConfig{
+			UserRepository:  mockUserRepository,
+			GuildRepository: mockGuildRepository,
+			RedisRepository: mockRedisRepository,
+			FileRepository:  mockFileRepository,
+		})
+
+		mockRedisRepository.On("AcquireLock", mock.Anything, accountDeletionLock, AccountDeletionInterval).Return("lock", nil)
+		mockRedisRepository.On("ReleaseLock", mock.Anything, accountDeletionLock, "lock").Return(nil)
+		mockUserRepository.On("FindScheduledForDeletion", mock.AnythingOfType("time.Time")).Return(&[]model.User{*mockUser}, nil)
+		mockUserRepository.On("FindBots", userId).Return(&[]model.User{}, nil)
+		mockGuildRepository.On("List", userId).Return(&[]model.GuildResponse{
+			kept.SerializeGuild(""),
+			deleted.SerializeGuild(""),
+			joined.SerializeGuild(""),
+		}, nil)
+		mockGuildRepository.On("FindNextOwner", kept.ID, userId).Return(successor, nil)
+		mockGuildRepository.On("FindByID", kept.ID).Return(kept, nil)
+		mockGuildRepository.On("Save", mock.MatchedBy(func(g *model.Guild) bool {
+			return g.ID == kept.ID && g.OwnerId == successor.ID
+		})).Return(nil)
+		mockGuildRepository.On("FindNextOwner", deleted.ID, userId).Return(nil, apperrors.NewNotFound("member", deleted.ID))
+		mockGuildRepository.On("Delete", deleted.ID).Return(nil)
+		mockUserRepository.On("Anonymize", mock.MatchedBy(func(u *model.User) bool {
+			return u.ID == userId &&
+				u.Username == model.DeletedUsername &&
+				u.Email == userId+"@deleted.invalid" &&
+				u.Password == "" &&
+				u.DeletionScheduledAt == nil
+		})).Return(nil)
+		mockRedisRepository.On("DeleteOtherLoginSessions", mock.Anything, userId, "").Return(nil)
+		mockFileRepository.On("DeleteImage", "https://bucket.s3.amazonaws.com/files/users/avatar.jpeg").Return(nil)
+
+		err := us.DeleteScheduledAccounts(context.TODO())
+
+		assert.NoError(t, err)
+		mockUserRepository.AssertExpectations(t)
+		mockGuildRepository.AssertExpectations(t)
+		mockFileRepository.AssertExpectations(t)
+		mockGuildRepository.AssertNotCalled(t, "FindNextOwner", joined.ID, mock.Anything)
+	})
+
+	t.Run("Deletes the bots of the user", func(t *testing.T) {
+		mockUser := fixture.GetMockUser()
+		bot := fixture.GetMockUser()
+		bot.IsBot = true
+		bot.BotOwnerId = &mockUser.ID
+
+		mockUserRepository := new(mocks.UserRepository)
+		mockGuildRepository := new(mocks.GuildRepository)
+		mockRedisRepository := new(mocks.RedisRepository)
+		mockFileRepository := new(mocks.FileRepository)
+		us := NewUserService(&USConfig{
+			UserRepository:  mockUserRepository,
+			GuildRepository: mockGuildRepository,
+			RedisRepository: mockRedisRepository,
+			FileRepository:  mockFileRepository,
+		})
+
+		mockRedisRepository.On("AcquireLock", mock.Anything, accountDeletionLock, AccountDeletionInterval).Return("lock", nil)
+		mockRedisRepository.On("ReleaseLock", mock.Anything, accountDeletionLock, "lock").Return(nil)
+		mockUserRepository.On("FindScheduledForDeletion", mock.AnythingOfType("time.Time")).Return(&[]model.User{*mockUser}, nil)
+		mockUserRepository.On("FindBots", mockUser.ID).Return(&[]model.User{*bot}, nil)
+		mockUserRepository.On("FindBots", bot.ID).Return(&[]model.User{}, nil)
+		mockGuildRepository.On("List", mock.Anything).Return(&[]model.GuildResponse{}, nil)
+		mockUserRepository.On("Anonymize", mock.AnythingOfType("*model.User")).Return(nil)
+		mockRedisRepository.On("DeleteOtherLoginSessions", mock.Anything, mock.Anything, "").Return(nil)
+
+		err := us.DeleteScheduledAccounts(context.TODO())
+
+		assert.NoError(t, err)
+		mockUserRepository.AssertCalled(t, "Anonymize", mock.MatchedBy(func(u *model.User) bool {
+			return u.ID == bot.ID && u.BotOwnerId == nil
+		}))
+		mockUserRepository.AssertCalled(t, "Anonymize", mock.MatchedBy(func(u *model.User) bool {
+			return u.ID == mockUser.ID
+		}))
+		// Generated avatars are not stored in the bucket
+		mockFileRepository.AssertNotCalled(t, "DeleteImage", mock.Anything)
+	})
+
+	t.Run("Skips the run while another instance holds the lock", func(t *testing.T) {
+		mockUserRepository := new(mocks.UserRepository)
+		mockRedisRepository := new(mocks.RedisRepository)
+		us := NewUserService(&USConfig{
+			UserRepository:  mockUserRepository,
+			RedisRepository: mockRedisRepository,
+		})
+
+		mockRedisRepository.On("AcquireLock", mock.Anything, accountDeletionLock, AccountDeletionInterval).Return("", nil)
+
+		err := us.DeleteScheduledAccounts(context.TODO())
+
+		assert.NoError(t, err)
+		mockUserRepository.AssertNotCalled(t, "FindScheduledForDeletion", mock.Anything)
+		mockRedisRepository.AssertNotCalled(t, "ReleaseLock", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestUserService_ValidateLoginSession(t *testing.T) {
+	userId := fixture.RandID()
+	sessionId := fixture.RandID()
+
+	t.Run("Recently used session", func(t *testing.T) {
+		mockRedisRepository := new(mocks.RedisRepository)
+
+		us := NewUserService(&USConfig{
+			RedisRepository: mockRedisRepository,
+		})
+
+		session := &model.LoginSession{ID: sessionId, LastUsedAt: time.Now()}
+		mockRedisRepository.On("GetLoginSession", mock.Anything, userId, sessionId).Return(session, nil)
+
+		err := us.ValidateLoginSession(context.TODO(), userId, sessionId)
+		assert.NoError(t, err)
+
+		mockRedisRepository.AssertNotCalled(t, "SaveLoginSession", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Updates the last use", func(t *testing.T) {
+		mockRedisRepository := new(mocks.RedisRepository)
+
+		us := NewUserService(&USConfig{
+			RedisRepository: mockRedisRepository,
+		})
+
+		lastUsed := time.Now().Add(-time.Hour)
+		session := &model.LoginSession{ID: sessionId, LastUsedAt: lastUsed}
+		mockRedisRepository.On("GetLoginSession", mock.Anything, userId, sessionId).Return(session, nil)
+		mockRedisRepository.On("SaveLoginSession", mock.Anything, userId, session).Return(nil)
+
+		err := us.ValidateLoginSession(context.TODO(), userId, sessionId)
+		assert.NoError(t, err)
+		assert.True(t, session.LastUsedAt.After(lastUsed))
+
+		mockRedisRepository.AssertExpectations(t)
+	})
+
+	t.Run("Revoked session", func(t *testing.T) {
+		mockRedisRepository := new(mocks.RedisRepository)
+
+		us := NewUserService(&USConfig{
+			RedisRepository: mockRedisRepository,
+		})
+
+		mockRedisRepository.
+			On("GetLoginSession", mock.Anything, userId, sessionId).
+			Return(nil, apperrors.NewNotFound("session", sessionId))
+
+		err := us.ValidateLoginSession(context.TODO(), userId, sessionId)
+		assert.EqualError(t, err, apperrors.NewAuthorization(apperrors.InvalidSession).Error())
+	})
+}
+
+func TestUserService_GetLoginSessions(t *testing.T) {
+	userId := fixture.RandID()
+	now := time.Now()
+
+	mockRedisRepository := new(mocks.RedisRepository)
+
+	us := NewUserService(&USConfig{
+		RedisRepository: mockRedisRepository,
+	})
+
+	sessions := []model.LoginSession{
+		{ID: "old", LastUsedAt: now.Add(-time.Hour)},
+		{ID: "current", LastUsedAt: now},
+	}
+	mockRedisRepository.On("GetLoginSessions", mock.Anything, userId).Return(&sessions, nil)
+
+	result, err := us.GetLoginSessions(context.TODO(), userId, "current")
+	assert.NoError(t, err)
+	assert.Equal(t, "current", (*result)[0].ID)
+	assert.True(t, (*result)[0].Current)
+	assert.False(t, (*result)[1].Current)
+}
+
+func TestUserService_RevokeLoginSession(t *testing.T) {
+	userId := fixture.RandID()
+	sessionId := fixture.RandID()
+
+	t.Run("Success", func(t *testing.T) {
+		mockRedisRepository := new(mocks.RedisRepository)
+
+		us := NewUserService(&USConfig{
+			RedisRepository: mockRedisRepository,
+		})
+
+		mockRedisRepository.
+			On("GetLoginSession", mock.Anything, userId, sessionId).
+			Return(&model.LoginSession{ID: sessionId}, nil)
+		mockRedisRepository.On("DeleteLoginSession", mock.Anything, userId, sessionId).Return(nil)
+
+		err := us.RevokeLoginSession(context.TODO(), userId, sessionId)
+		assert.NoError(t, err)
+
+		mockRedisRepository.AssertExpectations(t)
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		mockRedisRepository := new(mocks.RedisRepository)
+
+		us := NewUserService(&USConfig{
+			RedisRepository: mockRedisRepository,
+		})
+
+		mockError := apperrors.NewNotFound("session", sessionId)
+		mockRedisRepository.On("GetLoginSession", mock.Anything, userId, sessionId).Return(nil, mockError)
+
+		err := us.RevokeLoginSession(context.TODO(), userId, sessionId)
+		assert.EqualError(t, err, mockError.Error())
+
+		mockRedisRepository.AssertNotCalled(t, "DeleteLoginSession", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestUserService_ConnectSession(t *testing.T) {
+	sessionId := fixture.RandStr(10)
+
+	t.Run("First session sets the user online", func(t *testing.T) {
+		mockUser := fixture.GetMockUser()
+		mockUser.IsOnline = false
+		mockUser.Status = model.OnlineStatus
+
+		mockUserRepository := new(mocks.UserRepository)
+		mockRedisRepository := new(mocks.RedisRepository)
+
+		us := NewUserService(&USConfig{
+			UserRepository:  mockUserRepository,
+			RedisRepository: mockRedisRepository,
+		})
+
+		mockRedisRepository.On("AddSession", mock.Anything, mockUser.ID, sessionId, SessionTTL).Return(true, nil)
+		mockUserRepository.On("FindByID", mockUser.ID).Return(mockUser, nil)
+		mockUserRepository.On("Update", mockUser).Return(nil)
+
+		user, err := us.ConnectSession(context.TODO(), mockUser.ID, sessionId)
+		assert.NoError(t, err)
+		assert.Equal(t, mockUser, user)
+		assert.True(t, user.IsOnline)
+
+		mockUserRepository.AssertExpectations(t)
+		mockRedisRepository.AssertExpectations(t)
+	})
+
+	t.Run("Invisible user stays offline", func(t *testing.T) {
+		mockUser := fixture.GetMockUser()
+		mockUser.IsOnline = false
+		mockUser.Status = model.InvisibleStatus
+
+		mockUserRepository := new(mocks.UserRepository)
+		mockRedisRepository := new(mocks.RedisRepository)
+
+		us := NewUserService(&USConfig{
+			UserRepository:  mockUserRepository,
+			RedisRepository: mockRedisRepository,
+		})
+
+		mockRedisRepository.On("AddSession", mock.Anything, mockUser.ID, sessionId, SessionTTL).Return(true, nil)
+		mockUserRepository.On("FindByID", mockUser.ID).Return(mockUser, nil)
+
+		user, err := us.ConnectSession(context.TODO(), mockUser.ID, sessionId)
+		assert.NoError(t, err)
+		assert.False(t, user.IsOnline)
+
+		mockUserRepository.AssertNotCalled(t, "Update", mock.Anything)
+	})
+
+	t.Run("Other live session does not write", func(t *testing.T) {
+		uid := fixture.RandID()
+
+		mockUserRepository := new(mocks.UserRepository)
+		mockRedisRepository := new(mocks.RedisRepository)
+
+		us := NewUserService(&USConfig{
+			UserRepository:  mockUserRepository,
+			RedisRepository: mockRedisRepository,
+		})
+
+		mockRedisRepository.On("AddSession", mock.Anything, uid, sessionId, SessionTTL).Return(false, nil)
+
+		user, err := us.ConnectSession(context.TODO(), uid, sessionId)
+		assert.NoError(t, err)
+		assert.Nil(t, user)
+
+		mockUserRepository.AssertNotCalled(t, "FindByID", mock.Anything)
+		mockUserRepository.AssertNotCalled(t, "Update", mock.Anything)
+	})
+
+	t.Run("Redis error", func(t *testing.T) {
+		uid := fixture.RandID()
+
+		mockUserRepository := new(mocks.UserRepository)
+		mockRedisRepository := new(mocks.RedisRepository)
+
+		us := NewUserService(&USConfig{
+			UserRepository:  mockUserRepository,
+			RedisRepository: mockRedisRepository,
+		})
+
+		mockError := apperrors.NewInternal()
+		mockRedisRepository.On("AddSession", mock.Anything, uid, sessionId, SessionTTL).Return(false, mockError)
+
+		user, err := us.ConnectSession(context.TODO(), uid, sessionId)
+		assert.ErrorIs(t, err, mockError)
+		assert.Nil(t, user)
+
+		mockUserRepository.AssertNotCalled(t, "FindByID", mock.Anything)
+	})
+}
+
+func TestUserService_DisconnectSession(t *testing.T) {
+	sessionId := fixture.RandStr(10)
+
+	t.Run("Last session sets the user offline", func(t *testing.T) {
+		mockUser := fixture.GetMockUser()
+		mockUser.IsOnline = true
+		mockUser.Status = model.OnlineStatus
+
+		mockUserRepository := new(mocks.UserRepository)
+		mockRedisRepository := new(mocks.RedisRepository)
+
+		us := NewUserService(&USConfig{
+			UserRepository:  mockUserRepository,
+			RedisRepository: mockRedisRepository,
+		})
+
+		mockRedisRepository.On("RemoveSession", mock.Anything, mockUser.ID, sessionId).Return(true, nil)
+		mockUserRepository.On("FindByID", mockUser.ID).Return(mockUser, nil)
+		mockUserRepository.On("Update", mockUser).Return(nil)
+
+		user, err := us.DisconnectSession(context.TODO(), mockUser.ID, sessionId)
+		assert.NoError(t, err)
+		assert.False(t, user.IsOnline)
+		assert.Equal(t, model.OfflineStatus, user.GetPresence().Status)
+
+		mockUserRepository.AssertExpectations(t)
+		mockRedisRepository.AssertExpectations(t)
+	})
+
+	t.Run("Other live session keeps the user online", func(t *testing.T) {
+		uid := fixture.RandID()
+
+		mockUserRepository := new(mocks.UserRepository)
+		mockRedisRepository := new(mocks.RedisRepository)
+
+		us := NewUserService(&USConfig{
+			UserRepository:  mockUserRepository,
+			RedisRepository: mockRedisRepository,
+		})
+
+		mockRedisRepository.On("RemoveSession", mock.Anything, uid, sessionId).Return(false, nil)
+
+		user, err := us.DisconnectSession(context.TODO(), uid, sessionId)
+		assert.NoError(t, err)
+		assert.Nil(t, user)
+
+		mockUserRepository.AssertNotCalled(t, "Update", mock.Anything)
+	})
+}
+
+func TestUserService_ExpireSessions(t *testing.T) {
+	mockUser := fixture.GetMockUser()
+	mockUser.IsOnline = true
+
+	mockUserRepository := new(mocks.UserRepository)
+	mockRedisRepository := new(mocks.RedisRepository)
+
+	us := NewUserService(&USConfig{
+		UserRepository:  mockUserRepository,
+		RedisRepository: mockRedisRepository,
+	})
+
+	missingId := fixture.RandID()
+
+	mockRedisRepository.On("ExpireSessions", mock.Anything).Return([]string{mockUser.ID, missingId}, nil)
+	mockUserRepository.On("FindByID", mockUser.ID).Return(mockUser, nil)
+	mockUserRepository.On("FindByID", missingId).Return(nil, apperrors.NewNotFound("user", missingId))
+	mockUserRepository.On("Update", mockUser).Return(nil)
+
+	users, err := us.ExpireSessions(context.TODO())
+	assert.NoError(t, err)
+	assert.Equal(t, []*model.User{mockUser}, users)
+	assert.False(t, mockUser.IsOnline)
+
+	mockUserRepository.AssertExpectations(t)
+	mockRedisRepository.AssertExpectations(t)
+}
+
+func TestUserService_UpdatePresence(t *testing.T) {
+	testCases := []struct {
+		name        string
+		status      string
+		isConnected bool
+		isOnline    bool
+	}{
+		{name: "Connected", status: model.DoNotDisturbStatus, isConnected: true, isOnline: true},
+		{name: "Connected and invisible", status: model.InvisibleStatus, isConnected: true, isOnline: false},
+		{name: "Not connected", status: model.OnlineStatus, isConnected: false, isOnline: false},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			mockUser := fixture.GetMockUser()
+			mockUser.Status = tc.status
+
+			mockUserRepository := new(mocks.UserRepository)
+			mockRedisRepository := new(mocks.RedisRepository)
+
+			us := NewUserService(&USConfig{
+				UserRepository:  mockUserRepository,
+				RedisRepository: mockRedisRepository,
+			})
+
+			mockRedisRepository.On("HasLiveSession", mock.Anything, mockUser.ID).Return(tc.isConnected, nil)
+			mockUserRepository.On("Update", mockUser).Return(nil)
+
+			err := us.UpdatePresence(context.TODO(), mockUser)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.isOnline, mockUser.IsOnline)
+
+			mockUserRepository.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package service
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"log"
+)
+
+// webhookService acts as a struct for injecting an implementation of WebhookRepository
+// for use in service methods
+type webhookService struct {
+	WebhookRepository model.WebhookRepository
+}
+
+// WHSConfig will hold repositories that will eventually be injected into
+// this service layer
+type WHSConfig struct {
+	WebhookRepository model.WebhookRepository
+}
+
+// NewWebhookService is a factory function for
+// initializing a WebhookService with its repository layer dependencies
+func NewWebhookService(c *WHSConfig) model.WebhookService {
+	return &webhookService{
+		WebhookRepository: c.WebhookRepository,
+	}
+}
+
+func (w *webhookService) Get(id string) (*model.Webhook, error) {
+	return w.WebhookRepository.FindByID(id)
+}
+
+func (w *webhookService) GetChannelWebhooks(channelId string) (*[]model.Webhook, error) {
+	return w.WebhookRepository.FindByChannel(channelId)
+}
+
+// CreateWebhook creates the webhook with a new token.
+// Returns the webhook together with its token, which cannot be retrieved later.
+func (w *webhookService) CreateWebhook(webhook *model.Webhook) (*model.Webhook, string, error) {
+	webhooks, err := w.WebhookRepository.FindByChannel(webhook.ChannelId)
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	if len(*webhooks) >= model.MaximumWebhooks {
+		return nil, "", apperrors.NewBadRequest(apperrors.WebhookLimitReached)
+	}
+
+	id, err := GenerateId()
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	token, err := generateWebhookToken()
+
+	if err != nil {
+		log.Printf("Failed to generate a webhook token: %v\n", err.Error())
+		return nil, "", apperrors.NewInternal()
+	}
+
+	webhook.ID = id
+	webhook.TokenHash = hashWebhookToken(token)
+
+	if webhook.Avatar == "" {
+		webhook.Avatar = generateAvatar(id)
+	}
+
+	webhook, err = w.WebhookRepository.Create(webhook)
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	return webhook, token, nil
+}
+
+func (w *webhookService) UpdateWebhook(webhook *model.Webhook) error {
+	return w.WebhookRepository.Update(webhook)
+}
+
+func (w *webhookService) DeleteWebhook(webhook *model.Webhook) error {
+	return w.WebhookRepository.Delete(webhook)
+}
+
+// AuthenticateWebhook returns the webhook for the given id if the token matches
+func (w *webhookService) AuthenticateWebhook(id string, token string) (*model.Webhook, error) {
+	webhook, err := w.WebhookRepository.FindByID(id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	hash := hashWebhookToken(token)
+	if subtle.ConstantTimeCompare([]byte(hash), []byte(webhook.TokenHash)) != 1 {
+		return nil, apperrors.NewAuthorization(apperrors.InvalidWebhookToken)
+	}
+
+	return webhook, nil
+}
+
+// generateWebhookToken returns a new random webhook token
+func generateWebhookToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashWebhookToken returns the hash the webhook token gets stored as
+func hashWebhookToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
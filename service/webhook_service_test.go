@@ -0,0 +1,111 @@
+package service
+
+import (
+	"github.com/sentrionic/valkyrie/mocks"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"github.com/sentrionic/valkyrie/model/fixture"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+)
+
+func TestWebhookService_CreateWebhook(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		params := &model.Webhook{
+			Name:      "CI",
+			ChannelId: fixture.RandID(),
+			GuildId:   fixture.RandID(),
+			UserId:    fixture.RandID(),
+		}
+
+		mockWebhookRepository := new(mocks.WebhookRepository)
+		ws := NewWebhookService(&WHSConfig{
+			WebhookRepository: mockWebhookRepository,
+		})
+
+		mockWebhookRepository.On("FindByChannel", params.ChannelId).Return(&[]model.Webhook{}, nil)
+		mockWebhookRepository.On("Create", params).Return(params, nil)
+
+		webhook, token, err := ws.CreateWebhook(params)
+
+		assert.NoError(t, err)
+		assert.NotEmpty(t, webhook.ID)
+		assert.NotEmpty(t, webhook.Avatar)
+		assert.NotEmpty(t, token)
+		assert.Equal(t, hashWebhookToken(token), webhook.TokenHash)
+		mockWebhookRepository.AssertExpectations(t)
+	})
+
+	t.Run("Webhook limit reached", func(t *testing.T) {
+		params := &model.Webhook{
+			Name:      "CI",
+			ChannelId: fixture.RandID(),
+		}
+		webhooks := make([]model.Webhook, model.MaximumWebhooks)
+
+		mockWebhookRepository := new(mocks.WebhookRepository)
+		ws := NewWebhookService(&WHSConfig{
+			WebhookRepository: mockWebhookRepository,
+		})
+
+		mockWebhookRepository.On("FindByChannel", params.ChannelId).Return(&webhooks, nil)
+
+		webhook, _, err := ws.CreateWebhook(params)
+
+		assert.Nil(t, webhook)
+		assert.Equal(t, apperrors.NewBadRequest(apperrors.WebhookLimitReached), err)
+		mockWebhookRepository.AssertNotCalled(t, "Create", mock.Anything)
+	})
+}
+
+func TestWebhookService_AuthenticateWebhook(t *testing.T) {
+	token := fixture.RandStr(43)
+	webhook := &model.Webhook{TokenHash: hashWebhookToken(token)}
+	webhook.ID = fixture.RandID()
+
+	t.Run("Valid token", func(t *testing.T) {
+		mockWebhookRepository := new(mocks.WebhookRepository)
+		ws := NewWebhookService(&WHSConfig{
+			WebhookRepository: mockWebhookRepository,
+		})
+
+		mockWebhookRepository.On("FindByID", webhook.ID).Return(webhook, nil)
+
+		result, err := ws.AuthenticateWebhook(webhook.ID, token)
+
+		assert.NoError(t, err)
+		assert.Equal(t, webhook, result)
+	})
+
+	t.Run("Invalid token", func(t *testing.T) {
+		mockWebhookRepository := new(mocks.WebhookRepository)
+		ws := NewWebhookService(&WHSConfig{
+			WebhookRepository: mockWebhookRepository,
+		})
+
+		mockWebhookRepository.On("FindByID", webhook.ID).Return(webhook, nil)
+
+		result, err := ws.AuthenticateWebhook(webhook.ID, "invalid")
+
+		assert.Nil(t, result)
+		assert.Equal(t, apperrors.NewAuthorization(apperrors.InvalidWebhookToken), err)
+	})
+
+	t.Run("Unknown webhook", func(t *testing.T) {
+		id := fixture.RandID()
+		mockError := apperrors.NewNotFound("webhook", id)
+
+		mockWebhookRepository := new(mocks.WebhookRepository)
+		ws := NewWebhookService(&WHSConfig{
+			WebhookRepository: mockWebhookRepository,
+		})
+
+		mockWebhookRepository.On("FindByID", id).Return(nil, mockError)
+
+		result, err := ws.AuthenticateWebhook(id, token)
+
+		assert.Nil(t, result)
+		assert.Equal(t, mockError, err)
+	})
+}
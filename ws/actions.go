@@ -12,6 +12,13 @@ const (
 	ToggleOnlineAction    = "toggleOnline"
 	ToggleOfflineAction   = "toggleOffline"
 	GetRequestCountAction = "getRequestCount"
+	IdentifyAction        = "identify"
+	JoinVoiceAction       = "joinVoice"
+	LeaveVoiceAction      = "leaveVoice"
+	UpdateVoiceAction     = "updateVoiceState"
+	VoiceOfferAction      = "voiceOffer"
+	VoiceAnswerAction     = "voiceAnswer"
+	VoiceCandidateAction  = "voiceCandidate"
 )
 
 // Emitted Messages
@@ -26,12 +33,16 @@ const (
 	EditGuildAction         = "edit_guild"
 	DeleteGuildAction       = "delete_guild"
 	RemoveFromGuildAction   = "remove_from_guild"
+	TransferGuildAction     = "transfer_guild"
 	AddMemberAction         = "add_member"
 	RemoveMemberAction      = "remove_member"
 	NewDMNotificationAction = "new_dm_notification"
+	NewDMRequestAction      = "new_dm_request"
+	AddDMAction             = "add_dm"
+	EditDMAction            = "edit_dm"
+	RemoveDMAction          = "remove_dm"
 	NewNotificationAction   = "new_notification"
-	ToggleOnlineEmission    = "toggle_online"
-	ToggleOfflineEmission   = "toggle_offline"
+	PresenceUpdateEmission  = "presence_update"
 	AddToTypingAction       = "addToTyping"
 	RemoveFromTypingAction  = "removeFromTyping"
 	SendRequestAction       = "send_request"
@@ -40,4 +51,10 @@ const (
 	RemoveFriendAction      = "remove_friend"
 	PushToTopAction         = "push_to_top"
 	RequestCountEmission    = "requestCount"
+	ReadyEmission           = "ready"
+	ErrorEmission           = "error"
+	VoiceStateEmission      = "voice_state"
+	VoiceOfferEmission      = "voice_offer"
+	VoiceAnswerEmission     = "voice_answer"
+	VoiceCandidateEmission  = "voice_candidate"
 )
@@ -1,11 +1,13 @@
 package ws
 
 import (
-	"encoding/json"
 	"github.com/gin-gonic/gin"
+	gonanoid "github.com/matoous/go-nanoid/v2"
 	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
 	"log"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/gorilla/websocket"
@@ -23,35 +25,84 @@ const (
 
 	// Maximum message size allowed from peer.
 	maxMessageSize = 10000
+
+	// Time without any action after which the client is considered idle
+	idleTimeout = 10 * time.Minute
+
+	// Interval in which expired sessions of crashed clients or servers get removed
+	sessionSweepPeriod = 30 * time.Second
 )
 
 var newline = []byte{'\n'}
 
-var upgrader = websocket.Upgrader{
-	ReadBufferSize:  4096,
-	WriteBufferSize: 4096,
-	CheckOrigin: func(r *http.Request) bool {
-		return true
-	},
+// newUpgrader creates the upgrader that only accepts the given origins.
+// Requests without an Origin header do not come from a browser and are accepted.
+func newUpgrader(allowedOrigins []string) websocket.Upgrader {
+	return websocket.Upgrader{
+		ReadBufferSize:  4096,
+		WriteBufferSize: 4096,
+		// Negotiate permessage-deflate with clients that support it
+		EnableCompression: true,
+		CheckOrigin: func(r *http.Request) bool {
+			origin := r.Header.Get("Origin")
+			if origin == "" {
+				return true
+			}
+
+			for _, allowed := range allowedOrigins {
+				if origin == allowed {
+					return true
+				}
+			}
+			return false
+		},
+	}
 }
 
 // Client represents the websockets client at the server
 type Client struct {
 	// The actual websockets connection.
-	ID    string
-	conn  *websocket.Conn
-	hub   *Hub
-	send  chan []byte
-	rooms map[*Room]bool
+	ID   string
+	conn *websocket.Conn
+	hub  *Hub
+	send chan []byte
+	// The unique id of this connection, used to track the users live sessions
+	sessionId string
+	// The encoding the client requested, either json or msgpack
+	encoding string
+	rooms    map[*Room]bool
+	// The joined channels with the user's display name in each of them
+	channels map[string]string
+	// The voice channel this connection joined. Empty if it is not in one
+	voiceChannel string
+	// The rate limits per action and for invalid messages.
+	// Only used by the read pump
+	limits     map[string]*tokenBucket
+	violations *tokenBucket
+	// The event categories the client subscribed to.
+	// Nil until identify got called and all events get forwarded
+	intents map[string]bool
+	// Time of the last received action and whether the client went idle
+	lastActivity time.Time
+	idle         bool
+	// Whether the client toggled online and keeps its session alive
+	connected bool
+	mu        sync.RWMutex
 }
 
-func newClient(conn *websocket.Conn, hub *Hub, id string) *Client {
+func newClient(conn *websocket.Conn, hub *Hub, id string, encoding string) *Client {
 	return &Client{
-		ID:    id,
-		conn:  conn,
-		hub:   hub,
-		send:  make(chan []byte, 256),
-		rooms: make(map[*Room]bool),
+		ID:           id,
+		sessionId:    gonanoid.Must(),
+		conn:         conn,
+		hub:          hub,
+		send:         make(chan []byte, 256),
+		encoding:     encoding,
+		rooms:        make(map[*Room]bool),
+		channels:     make(map[string]string),
+		limits:       make(map[string]*tokenBucket),
+		violations:   newTokenBucket(violationLimit),
+		lastActivity: time.Now(),
 	}
 }
 
@@ -71,11 +122,11 @@ func (client *Client) readPump() {
 
 	// Start endless read loop, waiting for messages from client
 	for {
-		_, jsonMessage, err := client.conn.ReadMessage()
+		_, rawMessage, err := client.conn.ReadMessage()
 		if err != nil {
 			break
 		}
-		client.handleNewMessage(jsonMessage)
+		client.handleNewMessage(rawMessage)
 	}
 
 }
@@ -96,6 +147,15 @@ func (client *Client) writePump() {
 				return
 			}
 
+			// Binary messages cannot be joined by a newline,
+			// so every message gets its own frame
+			if client.encoding == MsgpackEncoding {
+				if err := client.conn.WriteMessage(websocket.BinaryMessage, message); err != nil {
+					return
+				}
+				continue
+			}
+
 			w, err := client.conn.NextWriter(websocket.TextMessage)
 			if err != nil {
 				return
@@ -117,30 +177,52 @@ func (client *Client) writePump() {
 			if err := client.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
 				return
 			}
+			client.heartbeat()
+			client.checkIdle()
 		}
 	}
 }
 
 func (client *Client) disconnect() {
+	client.leave()
+	_ = client.conn.Close()
+}
+
+// leave ends the session of the client and removes it from the hub and all rooms
+func (client *Client) leave() {
+	client.stopAllTyping()
+	client.leaveVoice()
+	client.toggleOnlineStatus(false)
 	client.hub.unregister <- client
 	for room := range client.rooms {
 		room.unregister <- client
 	}
 	close(client.send)
-	_ = client.conn.Close()
 }
 
 // ServeWs handles websockets requests from clients requests.
+// The encoding query parameter specifies if the client wants
+// to receive json or msgpack messages. Defaults to json.
 func ServeWs(hub *Hub, ctx *gin.Context) {
 
 	userId := ctx.MustGet("userId").(string)
-	conn, err := upgrader.Upgrade(ctx.Writer, ctx.Request, nil)
+
+	encoding := ctx.DefaultQuery("encoding", JSONEncoding)
+	if !isValidEncoding(encoding) {
+		e := apperrors.NewBadRequest(apperrors.InvalidEncoding)
+		ctx.JSON(e.Status(), gin.H{
+			"error": e,
+		})
+		return
+	}
+
+	conn, err := hub.upgrader.Upgrade(ctx.Writer, ctx.Request, nil)
 	if err != nil {
 		log.Println(err)
 		return
 	}
 
-	client := newClient(conn, hub, userId)
+	client := newClient(conn, hub, userId, encoding)
 
 	go client.writePump()
 	go client.readPump()
@@ -148,11 +230,29 @@ func ServeWs(hub *Hub, ctx *gin.Context) {
 	hub.register <- client
 }
 
-func (client *Client) handleNewMessage(jsonMessage []byte) {
+func (client *Client) handleNewMessage(rawMessage []byte) {
 
 	var message model.ReceivedMessage
-	if err := json.Unmarshal(jsonMessage, &message); err != nil {
-		log.Printf("Error on unmarshal JSON message %s", err)
+	if err := decodeMessage(rawMessage, client.encoding, &message); err != nil {
+		client.reject(&model.WebsocketError{
+			Code:    InvalidMessageError,
+			Message: apperrors.InvalidWSMessage,
+		}, CloseInvalidMessage)
+		return
+	}
+
+	if wsErr := client.validateMessage(message); wsErr != nil {
+		client.reject(wsErr, CloseInvalidMessage)
+		return
+	}
+
+	if wsErr := client.checkRateLimit(message.Action); wsErr != nil {
+		client.reject(wsErr, CloseRateLimited)
+		return
+	}
+
+	if message.Action != ToggleOfflineAction {
+		client.registerActivity()
 	}
 
 	switch message.Action {
@@ -172,9 +272,19 @@ func (client *Client) handleNewMessage(jsonMessage []byte) {
 
 	// Chat Typing Actions
 	case StartTypingAction:
-		client.handleTypingEvent(message, AddToTypingAction)
+		client.handleStartTyping(message)
 	case StopTypingAction:
-		client.handleTypingEvent(message, RemoveFromTypingAction)
+		client.handleStopTyping(message)
+
+	// Voice Actions
+	case JoinVoiceAction:
+		client.handleJoinVoice(message)
+	case LeaveVoiceAction:
+		client.leaveVoice()
+	case UpdateVoiceAction:
+		client.handleUpdateVoiceState(message)
+	case VoiceOfferAction, VoiceAnswerAction, VoiceCandidateAction:
+		client.handleVoiceSignal(message)
 
 	// Online Status Actions
 	case ToggleOnlineAction:
@@ -185,54 +295,118 @@ func (client *Client) handleNewMessage(jsonMessage []byte) {
 	// Other
 	case GetRequestCountAction:
 		client.handleGetRequestCount()
+	case IdentifyAction:
+		client.handleIdentify(message)
 	}
 }
 
+// handleIdentify sets the event categories the client wants to receive
+// and emits the accepted intents back to the client
+func (client *Client) handleIdentify(message model.ReceivedMessage) {
+	intents := make(map[string]bool)
+	accepted := make([]string, 0)
+
+	for _, intent := range message.Intents {
+		if isValidIntent(intent) && !intents[intent] {
+			intents[intent] = true
+			accepted = append(accepted, intent)
+		}
+	}
+
+	client.mu.Lock()
+	client.intents = intents
+	client.mu.Unlock()
+
+	client.sendMessage(&model.WebsocketMessage{
+		Action: ReadyEmission,
+		Data:   accepted,
+	})
+}
+
+// sendMessage sends the message only to this client
+func (client *Client) sendMessage(msg *model.WebsocketMessage) {
+	client.send <- newOutgoingMessage(msg.Encode()).encode(client.encoding)
+}
+
+// wantsEvent checks if the client subscribed to the category of the given action
+func (client *Client) wantsEvent(action string) bool {
+	intent, ok := eventIntents[action]
+	if !ok {
+		return true
+	}
+
+	client.mu.RLock()
+	defer client.mu.RUnlock()
+
+	return client.intents == nil || client.intents[intent]
+}
+
 // handleJoinChannelMessage joins the given room if the user is a member in it
 func (client *Client) handleJoinChannelMessage(message model.ReceivedMessage) {
-	roomName := message.Room
+	_ = client.joinChannel(message.Room)
+}
 
+// handleJoinGuildMessage joins the given guild if the user is member in it
+func (client *Client) handleJoinGuildMessage(message model.ReceivedMessage) {
+	_ = client.joinGuild(message.Room)
+}
+
+// handleJoinRoomMessage joins the given room
+func (client *Client) handleJoinRoomMessage(message model.ReceivedMessage) {
+	client.joinRoom(message.Room)
+}
+
+// joinChannel joins the room of the given channel if the user is a member in it
+func (client *Client) joinChannel(channelId string) error {
 	cs := client.hub.channelService
-	channel, err := cs.Get(roomName)
+	channel, err := cs.Get(channelId)
 
 	if err != nil {
-		return
+		return apperrors.NewNotFound("channel", channelId)
 	}
 
 	// Check if the user has access to the given channel
 	if err = cs.IsChannelMember(channel, client.ID); err != nil {
-		return
+		return err
 	}
 
-	client.handleJoinRoomMessage(message)
-}
+	// Resolve the name shown in typing events once per join
+	displayName, err := client.getDisplayName(channel)
 
-// handleJoinGuildMessage joins the given guild if the user is member in it
-func (client *Client) handleJoinGuildMessage(message model.ReceivedMessage) {
-	roomName := message.Room
+	if err != nil {
+		return err
+	}
 
-	gs := client.hub.guildService
-	guild, err := gs.GetGuild(roomName)
+	client.mu.Lock()
+	client.channels[channel.ID] = displayName
+	client.mu.Unlock()
+
+	client.joinRoom(channel.ID)
+	return nil
+}
+
+// joinGuild joins the room of the given guild if the user is a member in it
+func (client *Client) joinGuild(guildId string) error {
+	guild, err := client.hub.guildService.GetGuild(guildId)
 
 	if err != nil {
-		return
+		return apperrors.NewNotFound("guild", guildId)
 	}
 
 	// Check if the user is member of the given guild
 	if !isMember(guild, client.ID) {
-		return
+		return apperrors.NewAuthorization(apperrors.NotAMember)
 	}
 
-	client.handleJoinRoomMessage(message)
+	client.joinRoom(guild.ID)
+	return nil
 }
 
-// handleJoinRoomMessage joins the given room
-func (client *Client) handleJoinRoomMessage(message model.ReceivedMessage) {
-	roomName := message.Room
-
-	room := client.hub.findRoomById(roomName)
+// joinRoom joins the given room
+func (client *Client) joinRoom(roomId string) {
+	room := client.hub.findRoomById(roomId)
 	if room == nil {
-		room = client.hub.createRoom(roomName)
+		room = client.hub.createRoom(roomId)
 	}
 
 	client.rooms[room] = true
@@ -248,6 +422,12 @@ func (client *Client) handleLeaveGuildMessage(message model.ReceivedMessage) {
 
 // handleLeaveRoomMessage leaves the room
 func (client *Client) handleLeaveRoomMessage(message model.ReceivedMessage) {
+	client.hub.StopTyping(message.Room, client.ID)
+
+	client.mu.Lock()
+	delete(client.channels, message.Room)
+	client.mu.Unlock()
+
 	room := client.hub.findRoomById(message.Room)
 	delete(client.rooms, room)
 
@@ -273,59 +453,111 @@ func (client *Client) handleGetRequestCount() {
 	}
 }
 
-// handleTypingEvent emits the username of the currently typing user to the room
-func (client *Client) handleTypingEvent(message model.ReceivedMessage, action string) {
-	roomID := message.Room
-	if room := client.hub.findRoomById(roomID); room != nil {
-		msg := model.WebsocketMessage{
-			Action: action,
-			Data:   message.Message,
-		}
-		room.broadcast <- &msg
+// toggleOnlineStatus adds or removes the session of the client and emits the
+// users presence to all guilds the user is a member of and all of their friends
+// if it was their first or last live session.
+// Invisible users stay offline for everyone else.
+func (client *Client) toggleOnlineStatus(isOnline bool) {
+	client.mu.Lock()
+	wasConnected := client.connected
+	client.connected = isOnline
+	client.mu.Unlock()
+
+	if !isOnline && !wasConnected {
+		return
 	}
-}
 
-// toggleOnlineStatus updates the users online status and emits it to all
-// guilds the user is a member of and all of their friends
-func (client *Client) toggleOnlineStatus(isOnline bool) {
-	uid := client.ID
 	us := client.hub.userService
 
-	user, err := us.Get(uid)
+	var user *model.User
+	var err error
+	if isOnline {
+		user, err = us.ConnectSession(ctx, client.ID, client.sessionId)
+	} else {
+		user, err = us.DisconnectSession(ctx, client.ID, client.sessionId)
+	}
 
 	if err != nil {
-		log.Printf("could not find user: %v", err)
+		log.Printf("could not update session: %v", err)
 		return
 	}
 
-	user.IsOnline = isOnline
+	if user != nil {
+		client.emitPresence(user)
+	}
+}
 
-	if err := us.UpdateAccount(user); err != nil {
-		log.Printf("could not update user: %v", err)
-		return
+// heartbeat refreshes the session and the voice state of the client so they do not expire
+func (client *Client) heartbeat() {
+	client.mu.RLock()
+	connected := client.connected
+	voiceChannel := client.voiceChannel
+	client.mu.RUnlock()
+
+	if connected {
+		client.toggleOnlineStatus(true)
+	}
+
+	if voiceChannel != "" {
+		if err := client.hub.channelService.RefreshVoiceState(ctx, client.ID); err != nil {
+			log.Printf("could not refresh voice state: %v", err)
+		}
+	}
+}
+
+// registerActivity updates the time of the last action
+// and emits the users presence if they were idle
+func (client *Client) registerActivity() {
+	client.mu.Lock()
+	wasIdle := client.idle
+	client.idle = false
+	client.lastActivity = time.Now()
+	client.mu.Unlock()
+
+	if wasIdle {
+		client.refreshPresence()
+	}
+}
+
+// checkIdle marks the client as idle if it did not send
+// any action within the idleTimeout and emits the users presence
+func (client *Client) checkIdle() {
+	client.mu.Lock()
+	becameIdle := !client.idle && time.Since(client.lastActivity) > idleTimeout
+	if becameIdle {
+		client.idle = true
 	}
+	client.mu.Unlock()
 
-	ids, err := us.GetFriendAndGuildIds(uid)
+	if becameIdle {
+		client.refreshPresence()
+	}
+}
+
+// refreshPresence fetches the user and emits their current presence
+func (client *Client) refreshPresence() {
+	user, err := client.hub.userService.Get(client.ID)
 
 	if err != nil {
-		log.Printf("could not find ids: %v", err)
+		log.Printf("could not find user: %v", err)
 		return
 	}
 
-	action := ToggleOfflineEmission
-	if isOnline {
-		action = ToggleOnlineEmission
-	}
+	client.emitPresence(user)
+}
 
-	for _, id := range *ids {
-		if room := client.hub.findRoomById(id); room != nil {
-			msg := model.WebsocketMessage{
-				Action: action,
-				Data:   uid,
-			}
-			room.broadcast <- &msg
-		}
+// emitPresence emits the presence_update of the given user to all guilds
+// the user is a member of and all of their friends
+func (client *Client) emitPresence(user *model.User) {
+	presence := user.GetPresence()
+
+	client.mu.RLock()
+	if client.idle && presence.Status == model.OnlineStatus {
+		presence.Status = model.IdleStatus
 	}
+	client.mu.RUnlock()
+
+	client.hub.emitPresence(presence)
 }
 
 // isMember checks if the user is member of the given guild
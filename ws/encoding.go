@@ -0,0 +1,89 @@
+package ws
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/vmihailenco/msgpack/v5"
+	"log"
+)
+
+// Supported Encodings
+const (
+	JSONEncoding    = "json"
+	MsgpackEncoding = "msgpack"
+)
+
+// isValidEncoding checks if the given encoding is supported
+func isValidEncoding(encoding string) bool {
+	return encoding == JSONEncoding || encoding == MsgpackEncoding
+}
+
+// outgoingMessage holds a published message and caches it for
+// every requested encoding, so each format only gets encoded
+// once per broadcast instead of once per client
+type outgoingMessage struct {
+	payload []byte
+	encoded map[string][]byte
+	action  *string
+}
+
+// newOutgoingMessage wraps the given JSON payload
+func newOutgoingMessage(payload []byte) *outgoingMessage {
+	return &outgoingMessage{
+		payload: payload,
+		encoded: map[string][]byte{JSONEncoding: payload},
+	}
+}
+
+// encode returns the message in the given encoding
+func (m *outgoingMessage) encode(encoding string) []byte {
+	if encoded, ok := m.encoded[encoding]; ok {
+		return encoded
+	}
+
+	var message interface{}
+	if err := json.Unmarshal(m.payload, &message); err != nil {
+		log.Printf("Error on unmarshal JSON message %s", err)
+		return nil
+	}
+
+	var buf bytes.Buffer
+	enc := msgpack.NewEncoder(&buf)
+	enc.SetCustomStructTag("json")
+
+	if err := enc.Encode(message); err != nil {
+		log.Printf("Error on encoding msgpack message %s", err)
+		return nil
+	}
+
+	m.encoded[encoding] = buf.Bytes()
+	return m.encoded[encoding]
+}
+
+// getAction returns the action of the message
+func (m *outgoingMessage) getAction() string {
+	if m.action == nil {
+		var message struct {
+			Action string `json:"action"`
+		}
+		if err := json.Unmarshal(m.payload, &message); err != nil {
+			log.Printf("Error on unmarshal JSON message %s", err)
+		}
+		m.action = &message.Action
+	}
+
+	return *m.action
+}
+
+// decodeMessage turns the received websocket message into a ReceivedMessage
+// using the given encoding
+func decodeMessage(data []byte, encoding string, message *model.ReceivedMessage) error {
+	if encoding == MsgpackEncoding {
+		dec := msgpack.NewDecoder(bytes.NewReader(data))
+		dec.SetCustomStructTag("json")
+		return dec.Decode(message)
+	}
+
+	return json.Unmarshal(data, message)
+}
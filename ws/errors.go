@@ -0,0 +1,121 @@
+package ws
+
+import (
+	"github.com/gorilla/websocket"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"time"
+)
+
+// Error codes of the emitted error events
+const (
+	InvalidMessageError = "invalid_message"
+	UnknownActionError  = "unknown_action"
+	ForbiddenError      = "forbidden"
+	RateLimitedError    = "rate_limited"
+)
+
+// Close codes used when the client keeps sending invalid or too many actions
+const (
+	CloseInvalidMessage = 4002
+	CloseRateLimited    = 4008
+)
+
+// roomActions are the actions that require a room
+var roomActions = map[string]bool{
+	JoinUserAction:    true,
+	JoinGuildAction:   true,
+	JoinChannelAction: true,
+	LeaveGuildAction:  true,
+	LeaveRoomAction:   true,
+	StartTypingAction: true,
+	StopTypingAction:  true,
+	JoinVoiceAction:   true,
+}
+
+// signalActions are the WebRTC signaling actions that require a target user
+var signalActions = map[string]string{
+	VoiceOfferAction:     VoiceOfferEmission,
+	VoiceAnswerAction:    VoiceAnswerEmission,
+	VoiceCandidateAction: VoiceCandidateEmission,
+}
+
+// validateMessage checks if the action exists and contains all required
+// fields. Returns the error to emit to the client if it is invalid.
+func (client *Client) validateMessage(message model.ReceivedMessage) *model.WebsocketError {
+	if _, ok := actionLimits[message.Action]; !ok {
+		return &model.WebsocketError{
+			Code:    UnknownActionError,
+			Message: apperrors.UnknownAction,
+			Action:  message.Action,
+		}
+	}
+
+	if roomActions[message.Action] && message.Room == "" {
+		return &model.WebsocketError{
+			Code:    InvalidMessageError,
+			Message: apperrors.MissingRoom,
+			Action:  message.Action,
+		}
+	}
+
+	if _, ok := signalActions[message.Action]; ok && (message.Target == "" || message.Signal == nil) {
+		return &model.WebsocketError{
+			Code:    InvalidMessageError,
+			Message: apperrors.MissingSignal,
+			Action:  message.Action,
+		}
+	}
+
+	if message.Action == JoinUserAction && message.Room != client.ID {
+		return &model.WebsocketError{
+			Code:    ForbiddenError,
+			Message: apperrors.ForeignUserRoom,
+			Action:  message.Action,
+		}
+	}
+
+	return nil
+}
+
+// checkRateLimit takes a token from the bucket of the given action.
+// Returns the error to emit to the client if it is rate limited.
+func (client *Client) checkRateLimit(action string) *model.WebsocketError {
+	bucket, ok := client.limits[action]
+	if !ok {
+		bucket = newTokenBucket(actionLimits[action])
+		client.limits[action] = bucket
+	}
+
+	if bucket.allow() {
+		return nil
+	}
+
+	return &model.WebsocketError{
+		Code:       RateLimitedError,
+		Message:    apperrors.RateLimited,
+		Action:     action,
+		RetryAfter: bucket.retryAfter().Milliseconds(),
+	}
+}
+
+// reject emits the error to the client and closes the connection
+// with the given close code if it exceeded the violation limit
+func (client *Client) reject(wsErr *model.WebsocketError, closeCode int) {
+	client.sendMessage(&model.WebsocketMessage{
+		Action: ErrorEmission,
+		Data:   wsErr,
+	})
+
+	if !client.violations.allow() {
+		client.closeWithCode(closeCode, wsErr.Message)
+	}
+}
+
+// closeWithCode sends a close frame with the given code and reason
+// and closes the connection, which stops the read pump
+func (client *Client) closeWithCode(code int, reason string) {
+	message := websocket.FormatCloseMessage(code, reason)
+	_ = client.conn.WriteControl(websocket.CloseMessage, message, time.Now().Add(writeWait))
+	_ = client.conn.Close()
+}
@@ -2,7 +2,10 @@ package ws
 
 import (
 	"github.com/go-redis/redis/v8"
+	"github.com/gorilla/websocket"
 	"github.com/sentrionic/valkyrie/model"
+	"log"
+	"time"
 )
 
 // Hub contains all rooms and clients
@@ -16,6 +19,8 @@ type Hub struct {
 	guildService   model.GuildService
 	userService    model.UserService
 	redisClient    *redis.Client
+	upgrader       websocket.Upgrader
+	typing         *typingTracker
 }
 
 // Config will hold services that will eventually be injected into this
@@ -25,6 +30,8 @@ type Config struct {
 	GuildService   model.GuildService
 	ChannelService model.ChannelService
 	Redis          *redis.Client
+	// The origins browsers are allowed to connect from
+	AllowedOrigins []string
 }
 
 // NewWebsocketHub creates a new Hub
@@ -39,14 +46,23 @@ func NewWebsocketHub(c *Config) *Hub {
 		guildService:   c.GuildService,
 		userService:    c.UserService,
 		redisClient:    c.Redis,
+		upgrader:       newUpgrader(c.AllowedOrigins),
+		typing:         newTypingTracker(),
 	}
 }
 
 // Run our websocket server, accepting various requests
 func (hub *Hub) Run() {
+	sweeper := time.NewTicker(sessionSweepPeriod)
+	defer sweeper.Stop()
+
 	for {
 		select {
 
+		case <-sweeper.C:
+			go hub.expireSessions()
+			go hub.expireVoiceStates()
+
 		case client := <-hub.register:
 			hub.registerClient(client)
 
@@ -68,8 +84,9 @@ func (hub *Hub) unregisterClient(client *Client) {
 }
 
 func (hub *Hub) broadcastToClients(message []byte) {
+	out := newOutgoingMessage(message)
 	for client := range hub.clients {
-		client.send <- message
+		client.send <- out.encode(client.encoding)
 	}
 }
 
@@ -80,6 +97,14 @@ func (hub *Hub) BroadcastToRoom(message []byte, roomId string) {
 	}
 }
 
+// publishToRoom publishes the message to the given room on all server instances,
+// even if no client of this instance joined it
+func (hub *Hub) publishToRoom(roomId string, message *model.WebsocketMessage) {
+	if err := hub.redisClient.Publish(ctx, roomId, message.Encode()).Err(); err != nil {
+		log.Println(err)
+	}
+}
+
 func (hub *Hub) findRoomById(id string) *Room {
 	var foundRoom *Room
 	for room := range hub.rooms {
@@ -99,3 +124,58 @@ func (hub *Hub) createRoom(id string) *Room {
 
 	return room
 }
+
+// expireSessions sets all users whose last session expired offline
+// and emits their presence
+func (hub *Hub) expireSessions() {
+	users, err := hub.userService.ExpireSessions(ctx)
+
+	if err != nil {
+		log.Printf("could not expire sessions: %v", err)
+		return
+	}
+
+	for _, user := range users {
+		hub.emitPresence(user.GetPresence())
+	}
+}
+
+// expireVoiceStates disconnects all users whose client stopped
+// sending heartbeats from their voice channel
+func (hub *Hub) expireVoiceStates() {
+	states, err := hub.channelService.ExpireVoiceStates(ctx)
+
+	if err != nil {
+		log.Printf("could not expire voice states: %v", err)
+		return
+	}
+
+	emitted := make(map[string]bool)
+	for _, state := range *states {
+		if !emitted[state.ChannelId] {
+			emitted[state.ChannelId] = true
+			hub.emitVoiceState(ctx, state.GuildId, state.ChannelId)
+		}
+	}
+}
+
+// emitPresence emits the presence_update to all guilds
+// the user is a member of and all of their friends
+func (hub *Hub) emitPresence(presence model.Presence) {
+	ids, err := hub.userService.GetFriendAndGuildIds(presence.UserId)
+
+	if err != nil {
+		log.Printf("could not find ids: %v", err)
+		return
+	}
+
+	msg := model.WebsocketMessage{
+		Action: PresenceUpdateEmission,
+		Data:   presence,
+	}
+
+	// The members and friends might be connected to other instances
+	for _, id := range *ids {
+		hub.publishToRoom(id, &msg)
+	}
+}
@@ -0,0 +1,47 @@
+package ws
+
+// Intents a client can subscribe to with the identify action
+const (
+	MessagesIntent = "messages"
+	TypingIntent   = "typing"
+	PresenceIntent = "presence"
+	MembersIntent  = "members"
+	FriendsIntent  = "friends"
+	VoiceIntent    = "voice"
+)
+
+// eventIntents maps the emitted actions to the intent a client
+// needs to receive them. Actions that are not listed, like guild
+// and channel changes, are always forwarded.
+var eventIntents = map[string]string{
+	NewMessageAction:        MessagesIntent,
+	EditMessageAction:       MessagesIntent,
+	DeleteMessageAction:     MessagesIntent,
+	NewDMNotificationAction: MessagesIntent,
+	NewDMRequestAction:      MessagesIntent,
+	NewNotificationAction:   MessagesIntent,
+	PushToTopAction:         MessagesIntent,
+	AddToTypingAction:       TypingIntent,
+	RemoveFromTypingAction:  TypingIntent,
+	PresenceUpdateEmission:  PresenceIntent,
+	AddMemberAction:         MembersIntent,
+	RemoveMemberAction:      MembersIntent,
+	SendRequestAction:       FriendsIntent,
+	AddRequestAction:        FriendsIntent,
+	AddFriendAction:         FriendsIntent,
+	RemoveFriendAction:      FriendsIntent,
+	RequestCountEmission:    FriendsIntent,
+	VoiceStateEmission:      VoiceIntent,
+	VoiceOfferEmission:      VoiceIntent,
+	VoiceAnswerEmission:     VoiceIntent,
+	VoiceCandidateEmission:  VoiceIntent,
+}
+
+// isValidIntent checks if the given intent exists
+func isValidIntent(intent string) bool {
+	switch intent {
+	case MessagesIntent, TypingIntent, PresenceIntent, MembersIntent, FriendsIntent, VoiceIntent:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,78 @@
+package ws
+
+import (
+	"math"
+	"time"
+)
+
+// rateLimit specifies how many actions a client can send in a burst
+// and how many tokens per second get refilled afterwards
+type rateLimit struct {
+	burst float64
+	rate  float64
+}
+
+// actionLimits holds the limits per action. Every connection
+// gets its own bucket per action.
+var actionLimits = map[string]rateLimit{
+	JoinUserAction:        {burst: 5, rate: 0.5},
+	JoinChannelAction:     {burst: 20, rate: 2},
+	JoinGuildAction:       {burst: 20, rate: 2},
+	LeaveRoomAction:       {burst: 20, rate: 2},
+	LeaveGuildAction:      {burst: 20, rate: 2},
+	StartTypingAction:     {burst: 10, rate: 1},
+	StopTypingAction:      {burst: 10, rate: 1},
+	ToggleOnlineAction:    {burst: 5, rate: 0.2},
+	ToggleOfflineAction:   {burst: 5, rate: 0.2},
+	GetRequestCountAction: {burst: 5, rate: 0.5},
+	IdentifyAction:        {burst: 3, rate: 0.1},
+	JoinVoiceAction:       {burst: 5, rate: 0.5},
+	LeaveVoiceAction:      {burst: 5, rate: 0.5},
+	UpdateVoiceAction:     {burst: 10, rate: 1},
+	VoiceOfferAction:      {burst: 20, rate: 2},
+	VoiceAnswerAction:     {burst: 20, rate: 2},
+	VoiceCandidateAction:  {burst: 100, rate: 20},
+}
+
+// violationLimit specifies how many rate limited or invalid messages a client
+// can send before the connection gets closed
+var violationLimit = rateLimit{burst: 10, rate: 0.1}
+
+// tokenBucket is a token bucket rate limiter.
+// It is not safe for concurrent use.
+type tokenBucket struct {
+	limit  rateLimit
+	tokens float64
+	last   time.Time
+}
+
+// newTokenBucket creates a full bucket for the given limit
+func newTokenBucket(limit rateLimit) *tokenBucket {
+	return &tokenBucket{
+		limit:  limit,
+		tokens: limit.burst,
+		last:   time.Now(),
+	}
+}
+
+// allow takes a token from the bucket and returns false if it is empty
+func (b *tokenBucket) allow() bool {
+	now := time.Now()
+	b.tokens = math.Min(b.limit.burst, b.tokens+now.Sub(b.last).Seconds()*b.limit.rate)
+	b.last = now
+
+	if b.tokens < 1 {
+		return false
+	}
+
+	b.tokens--
+	return true
+}
+
+// retryAfter returns the time until the next token is available
+func (b *tokenBucket) retryAfter() time.Duration {
+	if b.tokens >= 1 {
+		return 0
+	}
+	return time.Duration((1 - b.tokens) / b.limit.rate * float64(time.Second))
+}
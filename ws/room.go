@@ -62,9 +62,14 @@ func (room *Room) unregisterClientInRoom(client *Client) {
 }
 
 // broadcastToClientsInRoom sends the given message to all members in the room
+// that subscribed to it using the encoding each client requested
 func (room *Room) broadcastToClientsInRoom(message []byte) {
+	out := newOutgoingMessage(message)
 	for client := range room.clients {
-		client.send <- message
+		if !client.wantsEvent(out.getAction()) {
+			continue
+		}
+		client.send <- out.encode(client.encoding)
 	}
 }
 
@@ -0,0 +1,80 @@
+package ws
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+	"net/http"
+	"time"
+)
+
+// ServeSSE streams the events of the user's room and the requested guild
+// and channel rooms as Server-Sent Events. It is the fallback for clients
+// that cannot use websockets. Rooms get requested with the guild and channel
+// query parameters.
+// Actions like typing go through the REST endpoints.
+func ServeSSE(hub *Hub, ctx *gin.Context) {
+	userId := ctx.MustGet("userId").(string)
+
+	client := newClient(nil, hub, userId, JSONEncoding)
+
+	hub.register <- client
+	client.joinRoom(userId)
+
+	// The rooms get validated like the join actions
+	for _, guildId := range ctx.QueryArray("guild") {
+		if err := client.joinGuild(guildId); err != nil {
+			client.leave()
+			ctx.JSON(apperrors.Status(err), gin.H{
+				"error": err,
+			})
+			return
+		}
+	}
+
+	for _, channelId := range ctx.QueryArray("channel") {
+		if err := client.joinChannel(channelId); err != nil {
+			client.leave()
+			ctx.JSON(apperrors.Status(err), gin.H{
+				"error": err,
+			})
+			return
+		}
+	}
+
+	ctx.Header("Content-Type", "text/event-stream")
+	ctx.Header("Cache-Control", "no-cache")
+	ctx.Header("Connection", "keep-alive")
+	// Disable proxy buffering so events get delivered immediately
+	ctx.Header("X-Accel-Buffering", "no")
+	ctx.Status(http.StatusOK)
+	ctx.Writer.Flush()
+
+	// The stream counts as a live session as long as it is open
+	client.toggleOnlineStatus(true)
+
+	ticker := time.NewTicker(pingPeriod)
+	defer func() {
+		ticker.Stop()
+		client.leave()
+	}()
+
+	for {
+		select {
+		case message := <-client.send:
+			if _, err := fmt.Fprintf(ctx.Writer, "data: %s\n\n", message); err != nil {
+				return
+			}
+			ctx.Writer.Flush()
+		case <-ticker.C:
+			// Comments keep proxies from closing the idle connection
+			if _, err := fmt.Fprint(ctx.Writer, ": ping\n\n"); err != nil {
+				return
+			}
+			ctx.Writer.Flush()
+			client.heartbeat()
+		case <-ctx.Request.Context().Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package ws
+
+import (
+	"github.com/sentrionic/valkyrie/model"
+	"sync"
+	"time"
+)
+
+const (
+	// Time after which a typing user gets removed if they did not send stopTyping
+	typingTimeout = 10 * time.Second
+
+	// Min time between two emitted addToTyping events of a user in a channel
+	typingThrottle = 3 * time.Second
+)
+
+// typingState holds the typing user, the time of their last emitted addToTyping
+// event and the timer that removes them after the timeout
+type typingState struct {
+	user     model.TypingUser
+	lastEmit time.Time
+	timer    *time.Timer
+}
+
+// typingTracker holds the currently typing users of all channels.
+// Typing can be started by websocket clients and the REST endpoints,
+// so the state is kept in the hub.
+type typingTracker struct {
+	mu    sync.Mutex
+	users map[string]*typingState
+}
+
+// newTypingTracker creates an empty typingTracker
+func newTypingTracker() *typingTracker {
+	return &typingTracker{
+		users: make(map[string]*typingState),
+	}
+}
+
+// typingKey returns the key of the user in the given channel
+func typingKey(channelId, userId string) string {
+	return channelId + ":" + userId
+}
+
+// StartTyping emits that the user started typing in their channel.
+// Repeated calls within the typingThrottle only extend the timeout.
+func (hub *Hub) StartTyping(user model.TypingUser) {
+	key := typingKey(user.ChannelId, user.UserId)
+
+	hub.typing.mu.Lock()
+	state, ok := hub.typing.users[key]
+	if !ok {
+		state = &typingState{user: user}
+		state.timer = time.AfterFunc(typingTimeout, func() {
+			hub.StopTyping(user.ChannelId, user.UserId)
+		})
+		hub.typing.users[key] = state
+	} else {
+		state.timer.Reset(typingTimeout)
+	}
+
+	shouldEmit := time.Since(state.lastEmit) > typingThrottle
+	if shouldEmit {
+		state.lastEmit = time.Now()
+	}
+	hub.typing.mu.Unlock()
+
+	if shouldEmit {
+		hub.publishToRoom(user.ChannelId, &model.WebsocketMessage{
+			Action: AddToTypingAction,
+			Data:   user,
+		})
+	}
+}
+
+// StopTyping removes the user from the typing users of the channel
+// if they are currently typing in it
+func (hub *Hub) StopTyping(channelId, userId string) {
+	key := typingKey(channelId, userId)
+
+	hub.typing.mu.Lock()
+	state, ok := hub.typing.users[key]
+	if ok {
+		state.timer.Stop()
+		delete(hub.typing.users, key)
+	}
+	hub.typing.mu.Unlock()
+
+	if ok {
+		hub.publishToRoom(channelId, &model.WebsocketMessage{
+			Action: RemoveFromTypingAction,
+			Data:   state.user,
+		})
+	}
+}
+
+// handleStartTyping emits that the user started typing in the given channel.
+// The channel must have been joined first.
+func (client *Client) handleStartTyping(message model.ReceivedMessage) {
+	channelId := message.Room
+
+	client.mu.RLock()
+	displayName, ok := client.channels[channelId]
+	client.mu.RUnlock()
+
+	if !ok {
+		return
+	}
+
+	client.hub.StartTyping(model.TypingUser{
+		ChannelId: channelId,
+		UserId:    client.ID,
+		Username:  displayName,
+	})
+}
+
+// handleStopTyping emits that the user stopped typing in the given channel
+func (client *Client) handleStopTyping(message model.ReceivedMessage) {
+	client.hub.StopTyping(message.Room, client.ID)
+}
+
+// stopAllTyping removes the user from the typing users of all joined channels
+func (client *Client) stopAllTyping() {
+	client.mu.RLock()
+	channelIds := make([]string, 0, len(client.channels))
+	for channelId := range client.channels {
+		channelIds = append(channelIds, channelId)
+	}
+	client.mu.RUnlock()
+
+	for _, channelId := range channelIds {
+		client.hub.StopTyping(channelId, client.ID)
+	}
+}
+
+// getDisplayName returns the nickname of the user in the guild
+// of the given channel or their username if they have none
+func (client *Client) getDisplayName(channel *model.Channel) (string, error) {
+	user, err := client.hub.userService.Get(client.ID)
+
+	if err != nil {
+		return "", err
+	}
+
+	if channel.GuildID != nil {
+		settings, err := client.hub.guildService.GetMemberSettings(client.ID, *channel.GuildID)
+
+		if err == nil && settings.Nickname != nil {
+			return *settings.Nickname, nil
+		}
+	}
+
+	return user.Username, nil
+}
@@ -0,0 +1,154 @@
+package ws
+
+import (
+	"context"
+	"github.com/sentrionic/valkyrie/model"
+	"github.com/sentrionic/valkyrie/model/apperrors"
+)
+
+// handleJoinVoice connects the user to the given voice channel.
+// The user must have access to the channel and gets disconnected
+// from their previous voice channel.
+func (client *Client) handleJoinVoice(message model.ReceivedMessage) {
+	cs := client.hub.channelService
+	channel, err := cs.Get(message.Room)
+
+	if err != nil || channel.GuildID == nil {
+		client.sendVoiceError(message.Action, apperrors.NewNotFound("channel", message.Room).Message)
+		return
+	}
+
+	if channel.Type != model.VoiceChannel {
+		client.sendVoiceError(message.Action, apperrors.NotAVoiceChannel)
+		return
+	}
+
+	// Check if the user has access to the given channel
+	if err = cs.IsChannelMember(channel, client.ID); err != nil {
+		client.sendVoiceError(message.Action, err.Error())
+		return
+	}
+
+	ctx := context.Background()
+	previous, err := cs.JoinVoiceChannel(ctx, &model.VoiceState{
+		UserId:    client.ID,
+		ChannelId: channel.ID,
+		GuildId:   *channel.GuildID,
+	})
+
+	if err != nil {
+		return
+	}
+
+	client.mu.Lock()
+	client.voiceChannel = channel.ID
+	client.mu.Unlock()
+
+	if previous != nil {
+		client.hub.emitVoiceState(ctx, previous.GuildId, previous.ChannelId)
+	}
+	client.hub.emitVoiceState(ctx, *channel.GuildID, channel.ID)
+}
+
+// leaveVoice disconnects the user from the voice channel
+// if this connection joined it
+func (client *Client) leaveVoice() {
+	client.mu.Lock()
+	channelId := client.voiceChannel
+	client.voiceChannel = ""
+	client.mu.Unlock()
+
+	if channelId == "" {
+		return
+	}
+
+	ctx := context.Background()
+	cs := client.hub.channelService
+
+	// The user might have joined another channel with a different connection
+	current, err := cs.GetVoiceState(ctx, client.ID)
+
+	if err != nil || current == nil || current.ChannelId != channelId {
+		return
+	}
+
+	state, err := cs.LeaveVoiceChannel(ctx, client.ID)
+
+	if err != nil || state == nil {
+		return
+	}
+
+	client.hub.emitVoiceState(ctx, state.GuildId, state.ChannelId)
+}
+
+// handleUpdateVoiceState changes the mute and deafen state of the user
+func (client *Client) handleUpdateVoiceState(message model.ReceivedMessage) {
+	ctx := context.Background()
+	state, err := client.hub.channelService.UpdateVoiceState(ctx, client.ID, message.Mute, message.Deaf)
+
+	if err != nil {
+		client.sendVoiceError(message.Action, err.Error())
+		return
+	}
+
+	client.hub.emitVoiceState(ctx, state.GuildId, state.ChannelId)
+}
+
+// handleVoiceSignal relays the WebRTC signal to the target user.
+// Both users must be connected to the same voice channel.
+func (client *Client) handleVoiceSignal(message model.ReceivedMessage) {
+	ctx := context.Background()
+	cs := client.hub.channelService
+
+	sender, err := cs.GetVoiceState(ctx, client.ID)
+
+	if err != nil || sender == nil {
+		client.sendVoiceError(message.Action, apperrors.NotInVoiceChannel)
+		return
+	}
+
+	target, err := cs.GetVoiceState(ctx, message.Target)
+
+	if err != nil || target == nil || target.ChannelId != sender.ChannelId {
+		client.sendVoiceError(message.Action, apperrors.NotInSameVoiceChannel)
+		return
+	}
+
+	client.hub.publishToRoom(message.Target, &model.WebsocketMessage{
+		Action: signalActions[message.Action],
+		Data: model.VoiceSignal{
+			ChannelId: sender.ChannelId,
+			UserId:    client.ID,
+			Signal:    message.Signal,
+		},
+	})
+}
+
+// sendVoiceError emits that the voice action could not be completed
+func (client *Client) sendVoiceError(action, message string) {
+	client.sendMessage(&model.WebsocketMessage{
+		Action: ErrorEmission,
+		Data: model.WebsocketError{
+			Code:    ForbiddenError,
+			Message: message,
+			Action:  action,
+		},
+	})
+}
+
+// emitVoiceState emits the participants of the voice channel to its guild
+func (hub *Hub) emitVoiceState(ctx context.Context, guildId, channelId string) {
+	participants, err := hub.channelService.GetVoiceParticipants(ctx, channelId)
+
+	if err != nil {
+		return
+	}
+
+	hub.publishToRoom(guildId, &model.WebsocketMessage{
+		Action: VoiceStateEmission,
+		Data: model.VoiceChannelState{
+			ChannelId:    channelId,
+			Participants: *participants,
+		},
+	})
+}